@@ -18,6 +18,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/types/module"
 	"github.com/cosmos/cosmos-sdk/version"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/vesting"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/crisis"
 	distr "github.com/cosmos/cosmos-sdk/x/distribution"
@@ -36,7 +37,9 @@ import (
 
 	"github.com/stratosnet/stratos-chain/helpers"
 	"github.com/stratosnet/stratos-chain/x/pot"
+	potclient "github.com/stratosnet/stratos-chain/x/pot/client"
 	"github.com/stratosnet/stratos-chain/x/register"
+	registerclient "github.com/stratosnet/stratos-chain/x/register/client"
 	"github.com/stratosnet/stratos-chain/x/sds"
 	// this line is used by starport scaffolding # 1
 )
@@ -57,7 +60,8 @@ var (
 		mint.AppModuleBasic{},
 		distr.AppModuleBasic{},
 		gov.NewAppModuleBasic(
-			paramsclient.ProposalHandler, distrclient.ProposalHandler, upgradeclient.ProposalHandler),
+			paramsclient.ProposalHandler, distrclient.ProposalHandler, upgradeclient.ProposalHandler,
+			registerclient.ProposalHandler, potclient.ProposalHandler),
 		params.AppModuleBasic{},
 		crisis.AppModuleBasic{},
 		slashing.AppModuleBasic{},
@@ -71,13 +75,19 @@ var (
 	)
 
 	maccPerms = map[string][]string{
-		auth.FeeCollectorName:     nil,
-		distr.ModuleName:          nil,
-		mint.ModuleName:           {supply.Minter},
-		staking.BondedPoolName:    {supply.Burner, supply.Staking},
-		staking.NotBondedPoolName: {supply.Burner, supply.Staking},
-		gov.ModuleName:            {supply.Burner},
-		pot.FoundationAccount:     nil,
+		auth.FeeCollectorName:                  nil,
+		distr.ModuleName:                       nil,
+		mint.ModuleName:                        {supply.Minter},
+		staking.BondedPoolName:                 {supply.Burner, supply.Staking},
+		staking.NotBondedPoolName:              {supply.Burner, supply.Staking},
+		gov.ModuleName:                         {supply.Burner},
+		pot.FoundationAccount:                  nil,
+		pot.TotalRewardPoolName:                nil,
+		pot.TotalUnissuedPrepayPoolName:        nil,
+		register.ResourceNodeBondedPoolName:    nil,
+		register.ResourceNodeNotBondedPoolName: {supply.Burner},
+		register.IndexingNodeBondedPoolName:    nil,
+		register.IndexingNodeNotBondedPoolName: {supply.Burner},
 	}
 	// module accounts that are allowed to receive tokens
 	//allowedReceivingModAcc = map[string]bool{
@@ -89,6 +99,7 @@ func MakeCodec() *codec.Codec {
 	var cdc = codec.New()
 
 	ModuleBasics.RegisterCodec(cdc)
+	vesting.RegisterCodec(cdc)
 	sdk.RegisterCodec(cdc)
 	codec.RegisterCrypto(cdc)
 
@@ -179,6 +190,7 @@ func NewInitApp(
 	app.subspaces[gov.ModuleName] = app.paramsKeeper.Subspace(gov.DefaultParamspace).WithKeyTable(gov.ParamKeyTable())
 	app.subspaces[pot.ModuleName] = app.paramsKeeper.Subspace(pot.DefaultParamSpace)
 	app.subspaces[register.ModuleName] = app.paramsKeeper.Subspace(register.DefaultParamSpace)
+	app.subspaces[sds.ModuleName] = app.paramsKeeper.Subspace(sds.DefaultParamSpace)
 	// this line is used by starport scaffolding # 5.1
 
 	app.accountKeeper = auth.NewAccountKeeper(app.cdc, keys[auth.StoreKey], app.subspaces[auth.ModuleName], auth.ProtoBaseAccount)
@@ -201,23 +213,13 @@ func NewInitApp(
 	)
 	// this line is used by starport scaffolding # 4
 
-	// register the proposal types
-	govRouter := gov.NewRouter()
-	govRouter.AddRoute(gov.RouterKey, gov.ProposalHandler).
-		AddRoute(params.RouterKey, params.NewParamChangeProposalHandler(app.paramsKeeper)).
-		AddRoute(distr.RouterKey, distr.NewCommunityPoolSpendProposalHandler(app.distrKeeper)).
-		AddRoute(upgrade.RouterKey, upgrade.NewSoftwareUpgradeProposalHandler(app.upgradeKeeper))
-	app.govKeeper = gov.NewKeeper(
-		app.cdc, keys[gov.StoreKey], app.subspaces[gov.ModuleName], app.supplyKeeper,
-		&stakingKeeper, govRouter,
-	)
-
 	app.registerKeeper = register.NewKeeper(
 		app.cdc,
 		keys[register.StoreKey],
 		app.subspaces[register.ModuleName],
 		app.accountKeeper,
 		app.bankKeeper,
+		app.supplyKeeper,
 	)
 
 	app.potKeeper = pot.NewKeeper(
@@ -235,11 +237,29 @@ func NewInitApp(
 	app.sdsKeeper = sds.NewKeeper(
 		app.cdc,
 		keys[sds.StoreKey],
+		app.subspaces[sds.ModuleName],
 		app.bankKeeper,
+		app.supplyKeeper,
 		app.registerKeeper,
 		app.potKeeper,
 	)
 
+	// NOTE: potKeeper above is passed by value, sds does not need to trigger its own hooks
+	app.potKeeper = *app.potKeeper.SetHooks(app.sdsKeeper.Hooks())
+
+	// register the proposal types
+	govRouter := gov.NewRouter()
+	govRouter.AddRoute(gov.RouterKey, gov.ProposalHandler).
+		AddRoute(params.RouterKey, params.NewParamChangeProposalHandler(app.paramsKeeper)).
+		AddRoute(distr.RouterKey, distr.NewCommunityPoolSpendProposalHandler(app.distrKeeper)).
+		AddRoute(upgrade.RouterKey, upgrade.NewSoftwareUpgradeProposalHandler(app.upgradeKeeper)).
+		AddRoute(register.RouterKey, register.NewUpdateParamsProposalHandler(app.registerKeeper)).
+		AddRoute(pot.RouterKey, pot.NewUpdateParamsProposalHandler(app.potKeeper))
+	app.govKeeper = gov.NewKeeper(
+		app.cdc, keys[gov.StoreKey], app.subspaces[gov.ModuleName], app.supplyKeeper,
+		&stakingKeeper, govRouter,
+	)
+
 	app.mm = module.NewManager(
 		genutil.NewAppModule(app.accountKeeper, app.stakingKeeper, app.BaseApp.DeliverTx),
 		auth.NewAppModule(app.accountKeeper),
@@ -254,13 +274,13 @@ func NewInitApp(
 		upgrade.NewAppModule(app.upgradeKeeper),
 
 		register.NewAppModule(app.registerKeeper, app.accountKeeper, app.bankKeeper),
-		pot.NewAppModule(app.potKeeper, app.bankKeeper, app.supplyKeeper, app.accountKeeper, app.stakingKeeper, app.registerKeeper),
-		sds.NewAppModule(app.sdsKeeper, app.bankKeeper, app.registerKeeper),
+		pot.NewAppModule(app.potKeeper, app.bankKeeper, app.supplyKeeper, app.accountKeeper, app.stakingKeeper, app.registerKeeper, app.sdsKeeper),
+		sds.NewAppModule(app.sdsKeeper, app.bankKeeper, app.accountKeeper, app.registerKeeper),
 		// this line is used by starport scaffolding # 6
 	)
 
 	app.mm.SetOrderEndBlockers(
-		crisis.ModuleName, gov.ModuleName, staking.ModuleName, register.ModuleName,
+		crisis.ModuleName, gov.ModuleName, staking.ModuleName, register.ModuleName, pot.ModuleName, sds.ModuleName,
 		// this line is used by starport scaffolding # 6.1
 	)
 
@@ -273,25 +293,73 @@ func NewInitApp(
 		slashing.ModuleName,
 		gov.ModuleName,
 		mint.ModuleName,
-		crisis.ModuleName,
 		genutil.ModuleName,
 		register.ModuleName,
 		pot.ModuleName,
 		sds.ModuleName,
 		upgrade.ModuleName,
 		supply.ModuleName,
+		// the invariants are asserted once every other module is initialized
+		crisis.ModuleName,
 
 		// this line is used by starport scaffolding # 7
 	)
 
+	app.mm.RegisterInvariants(&app.crisisKeeper)
 	app.mm.RegisterRoutes(app.Router(), app.QueryRouter())
 
+	// create the simulation manager and define the order of the modules for deterministic simulations
+	app.sm = module.NewSimulationManager(
+		auth.NewAppModule(app.accountKeeper),
+		bank.NewAppModule(app.bankKeeper, app.accountKeeper),
+		supply.NewAppModule(app.supplyKeeper, app.accountKeeper),
+		gov.NewAppModule(app.govKeeper, app.accountKeeper, app.supplyKeeper),
+		mint.NewAppModule(app.mintKeeper),
+		staking.NewAppModule(app.stakingKeeper, app.accountKeeper, app.supplyKeeper),
+		distr.NewAppModule(app.distrKeeper, app.accountKeeper, app.supplyKeeper, app.stakingKeeper),
+		slashing.NewAppModule(app.slashingKeeper, app.accountKeeper, app.stakingKeeper),
+		params.NewAppModule(), // NOTE: only used for simulation to generate randomized param change proposals
+		register.NewAppModule(app.registerKeeper, app.accountKeeper, app.bankKeeper),
+		pot.NewAppModule(app.potKeeper, app.bankKeeper, app.supplyKeeper, app.accountKeeper, app.stakingKeeper, app.registerKeeper, app.sdsKeeper),
+		sds.NewAppModule(app.sdsKeeper, app.bankKeeper, app.accountKeeper, app.registerKeeper),
+	)
+
+	app.sm.RegisterStoreDecoders()
+
 	app.SetInitChainer(app.InitChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
 	app.SetEndBlocker(app.EndBlocker)
 
 	app.upgradeKeeper.SetUpgradeHandler(version.Version, func(ctx sdk.Context, plan upgrade.Plan) {
 		logger.Info("Upgrade Handler working")
+		// set the register params added since the chain started
+		app.registerKeeper.MigrateParams(ctx)
+		// move the node stakes counted in the register store into the token pool module accounts
+		app.registerKeeper.MigrateTokenPools(ctx)
+		// cap the volume credited to the resource nodes registered without a capacity
+		app.registerKeeper.MigrateResourceNodeCapacities(ctx)
+		// set the pot params added since the chain started
+		app.potKeeper.MigrateParams(ctx)
+		// rewrite the pot individual rewards under the big endian epoch key layout
+		app.potKeeper.MigrateIndividualRewards(ctx)
+		// record the rewards withdrawn before the withdrawn totals were tracked
+		app.potKeeper.MigrateWithdrawnTotalRewards(ctx)
+		// hold the unwithdrawn rewards and the unissued prepay in the pot token pools, then recompute the total supply
+		app.potKeeper.MigrateTokenPools(ctx)
+		// queue the immature rewards by mature epoch and mature the rewards of the nodes that earned nothing since
+		app.potKeeper.MigrateMaturityQueue(ctx)
+		// store the reward address pool under one key per node
+		app.potKeeper.MigrateRewardAddressPool(ctx)
+		// record the owner of the rewards distributed so far, so they stay withdrawable once their node is removed
+		app.potKeeper.MigrateRewardOwners(ctx)
+		// key the volume reports waiting for votes by epoch and report reference, so the reports of an epoch can compete
+		app.potKeeper.MigrateVolumeReportProposals(ctx)
+		// set the sds params added since the chain started
+		app.sdsKeeper.MigrateParams(ctx)
+		// store the prepay balances per denom
+		app.sdsKeeper.MigratePrepayBalances(ctx)
+		// every prepay balance recorded so far was moved out of bank
+		app.sdsKeeper.SetTotalPrepay(ctx, app.sdsKeeper.SumPrepayBalances(ctx))
 	})
 	app.SetStoreLoader(bam.StoreLoaderWithUpgrade(&store.StoreUpgrades{
 		Renamed: []store.StoreRename{{
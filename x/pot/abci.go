@@ -15,5 +15,8 @@ func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k keeper.Keeper)
 
 // EndBlocker called every block, process inflation, update validator set.
 func EndBlocker(ctx sdk.Context, k keeper.Keeper) {
-	// 	TODO: fill out if your application requires endblock, if not you can delete this function
+	// drop the volume reports that did not reach the quorum in time
+	k.RemoveExpiredVolumeReports(ctx)
+	// mature the rewards of every node reaching their mature epoch, including the nodes that earned nothing since
+	k.MatureRewards(ctx)
 }
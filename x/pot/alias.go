@@ -6,11 +6,13 @@ import (
 )
 
 const (
-	DefaultParamSpace = types.DefaultParamSpace
-	ModuleName        = types.ModuleName
-	StoreKey          = types.StoreKey
-	RouterKey         = types.RouterKey
-	FoundationAccount = types.FoundationAccount
+	DefaultParamSpace           = types.DefaultParamSpace
+	ModuleName                  = types.ModuleName
+	StoreKey                    = types.StoreKey
+	RouterKey                   = types.RouterKey
+	FoundationAccount           = types.FoundationAccount
+	TotalRewardPoolName         = types.TotalRewardPoolName
+	TotalUnissuedPrepayPoolName = types.TotalUnissuedPrepayPoolName
 )
 
 var (
@@ -19,8 +21,13 @@ var (
 	ParamKeyTable           = types.ParamKeyTable
 	NewGenesisState         = types.NewGenesisState
 	NewMsgFoundationDeposit = types.NewMsgFoundationDeposit
+	NewMsgVolumeReportVote  = types.NewMsgVolumeReportVote
+	NewSingleUserVolume     = types.NewSingleUserVolume
 )
 
 type (
-	Keeper = keeper.Keeper
+	Keeper               = keeper.Keeper
+	PotHooks             = types.PotHooks
+	SingleUserVolume     = types.SingleUserVolume
+	VolumeReportProposal = types.VolumeReportProposal
 )
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
@@ -40,7 +41,8 @@ func setupMsgVolumeReport(newEpoch int64) types.MsgVolumeReport {
 	reportReference := "report for epoch " + epoch.String()
 	reporterOwner := idxOwner1
 
-	volumeReportMsg := types.NewMsgVolumeReport(nodesVolume, reporter, epoch, reportReference, reporterOwner)
+	userVolume := types.NewSingleUserVolume(idxOwner1, resourceNodeVolume1.Add(resourceNodeVolume2).Add(resourceNodeVolume3))
+	volumeReportMsg := types.NewMsgVolumeReport(nodesVolume, reporter, epoch, reportReference, reporterOwner, []types.SingleUserVolume{userVolume})
 
 	return volumeReportMsg
 }
@@ -191,6 +193,30 @@ func TestPotVolumeReportMsgs(t *testing.T) {
 
 		SignCheckDeliver(t, mApp.Cdc, mApp.BaseApp, header, []sdk.Msg{volumeReportMsg}, []uint64{ownerAccNum}, []uint64{ownerAccSeq}, true, true, idxOwnerPrivKey1)
 
+		/********************* vote for the report until the quorum is reached *********************/
+		header = abci.Header{Height: mApp.LastBlockHeight() + 1}
+		ctx = mApp.BaseApp.NewContext(true, header)
+		_, found := k.GetVolumeReportProposal(ctx, volumeReportMsg.Epoch, volumeReportMsg.ReportReference)
+		require.True(t, found)
+
+		voters := []struct {
+			nodeAddr  sdk.AccAddress
+			ownerAddr sdk.AccAddress
+			privKey   crypto.PrivKey
+		}{
+			{idxNodeAddr2, idxOwner2, idxOwnerPrivKey2},
+			{idxNodeAddr3, idxOwner3, idxOwnerPrivKey3},
+		}
+		for _, voter := range voters {
+			voteMsg := types.NewMsgVolumeReportVote(volumeReportMsg.Epoch, volumeReportMsg.ReportReference, true, voter.nodeAddr, voter.ownerAddr)
+			voterOwnerAcc := mApp.AccountKeeper.GetAccount(ctx, voter.ownerAddr)
+			SignCheckDeliver(t, mApp.Cdc, mApp.BaseApp, header, []sdk.Msg{voteMsg}, []uint64{voterOwnerAcc.GetAccountNumber()}, []uint64{voterOwnerAcc.GetSequence()}, true, true, voter.privKey)
+			header = abci.Header{Height: mApp.LastBlockHeight() + 1}
+			ctx = mApp.BaseApp.NewContext(true, header)
+		}
+		_, found = k.GetVolumeReportProposal(ctx, volumeReportMsg.Epoch, volumeReportMsg.ReportReference)
+		require.False(t, found)
+
 		/********************* commit & check result *********************/
 		header = abci.Header{Height: mApp.LastBlockHeight() + 1}
 		ctx = mApp.BaseApp.NewContext(true, header)
@@ -271,14 +297,20 @@ func getMockApp(t *testing.T) (*mock.App, Keeper, staking.Keeper, bank.Keeper, s
 
 	bankKeeper := bank.NewBaseKeeper(mApp.AccountKeeper, mApp.ParamsKeeper.Subspace(bank.DefaultParamspace), blacklistedAddrs)
 	maccPerms := map[string][]string{
-		auth.FeeCollectorName:     nil,
-		staking.NotBondedPoolName: {supply.Burner, supply.Staking},
-		staking.BondedPoolName:    {supply.Burner, supply.Staking},
-		types.FoundationAccount:   nil,
+		auth.FeeCollectorName:                  nil,
+		staking.NotBondedPoolName:              {supply.Burner, supply.Staking},
+		staking.BondedPoolName:                 {supply.Burner, supply.Staking},
+		types.FoundationAccount:                nil,
+		types.TotalRewardPoolName:              nil,
+		types.TotalUnissuedPrepayPoolName:      nil,
+		register.ResourceNodeBondedPoolName:    nil,
+		register.ResourceNodeNotBondedPoolName: {supply.Burner},
+		register.IndexingNodeBondedPoolName:    nil,
+		register.IndexingNodeNotBondedPoolName: {supply.Burner},
 	}
 	supplyKeeper := supply.NewKeeper(mApp.Cdc, keySupply, mApp.AccountKeeper, bankKeeper, maccPerms)
 	stakingKeeper := staking.NewKeeper(mApp.Cdc, keyStaking, supplyKeeper, mApp.ParamsKeeper.Subspace(staking.DefaultParamspace))
-	registerKeeper := register.NewKeeper(mApp.Cdc, keyRegister, mApp.ParamsKeeper.Subspace(register.DefaultParamSpace), mApp.AccountKeeper, bankKeeper)
+	registerKeeper := register.NewKeeper(mApp.Cdc, keyRegister, mApp.ParamsKeeper.Subspace(register.DefaultParamSpace), mApp.AccountKeeper, bankKeeper, supplyKeeper)
 
 	keeper := NewKeeper(mApp.Cdc, keyPot, mApp.ParamsKeeper.Subspace(DefaultParamSpace), auth.FeeCollectorName, bankKeeper, supplyKeeper, mApp.AccountKeeper, stakingKeeper, registerKeeper)
 
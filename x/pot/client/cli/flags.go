@@ -9,8 +9,15 @@ const (
 	FlagEpoch           = "epoch"
 	FlagReportReference = "reference"
 	FlagNodesVolume     = "nodes-volume"
+	FlagUsersVolume     = "users-volume"
 	FlagAmount          = "amount"
 	FlagNodeAddress     = "node-address"
+	FlagNodeAddresses   = "node-addresses"
+	FlagWithdrawAddress = "withdraw-address"
+	FlagVoter           = "voter-addr"
+	FlagOpinion         = "opinion"
+	FlagStartEpoch      = "start-epoch"
+	FlagEndEpoch        = "end-epoch"
 )
 
 var (
@@ -18,8 +25,14 @@ var (
 	FsEpoch           = flag.NewFlagSet("", flag.ContinueOnError)
 	FsReportReference = flag.NewFlagSet("", flag.ContinueOnError)
 	FsNodesVolume     = flag.NewFlagSet("", flag.ContinueOnError)
+	FsUsersVolume     = flag.NewFlagSet("", flag.ContinueOnError)
 	FsAmount          = flag.NewFlagSet("", flag.ContinueOnError)
 	FsNodeAddress     = flag.NewFlagSet("", flag.ContinueOnError)
+	FsNodeAddresses   = flag.NewFlagSet("", flag.ContinueOnError)
+	FsWithdrawAddress = flag.NewFlagSet("", flag.ContinueOnError)
+	FsVoter           = flag.NewFlagSet("", flag.ContinueOnError)
+	FsOpinion         = flag.NewFlagSet("", flag.ContinueOnError)
+	FsEpochRange      = flag.NewFlagSet("", flag.ContinueOnError)
 )
 
 func init() {
@@ -27,6 +40,13 @@ func init() {
 	FsEpoch.String(FlagEpoch, "", "the epoch when this PoT message reported.")
 	FsReportReference.String(FlagReportReference, "", " the hash used as a reference to this PoT report")
 	FsNodesVolume.String(FlagNodesVolume, "", "a string of KEY-VALUE pairs. The KEY is 'node_address' and the VALUE is the proof of traffic of this node")
+	FsUsersVolume.String(FlagUsersVolume, "", "a string of KEY-VALUE pairs. The KEY is 'user_address' and the VALUE is the ozone consumed by this user")
 	FsAmount.String(FlagAmount, "", "Amount of coins to withdraw")
 	FsNodeAddress.String(FlagNodeAddress, "", "The address of the node to withdraw")
+	FsNodeAddresses.String(FlagNodeAddresses, "", "Comma separated addresses of the nodes to withdraw all mature rewards from")
+	FsWithdrawAddress.String(FlagWithdrawAddress, "", "The address credited by the withdrawals of the node, the owner address to reset it")
+	FsVoter.String(FlagVoter, "", "the node address of voter")
+	FsOpinion.Bool(FlagOpinion, true, "approve (true) or reject (false) the volume report")
+	FsEpochRange.String(FlagStartEpoch, "", "the first mature epoch of the range, from the first epoch if empty")
+	FsEpochRange.String(FlagEndEpoch, "", "the last mature epoch of the range, to the last epoch if empty")
 }
@@ -10,6 +10,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/stratosnet/stratos-chain/x/pot/keeper"
 	"github.com/stratosnet/stratos-chain/x/pot/types"
 	"strconv"
 	"strings"
@@ -28,13 +29,37 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 
 	potQueryCmd.AddCommand(
 		flags.GetCommands(
+			GetCmdQueryParams(queryRoute, cdc),
 			GetCmdQueryVolumeReport(queryRoute, cdc),
+			GetCmdQueryRewardHistory(queryRoute, cdc),
+			GetCmdQueryRewardAddressPool(queryRoute, cdc),
 		)...,
 	)
 
 	return potQueryCmd
 }
 
+// GetCmdQueryParams implements the query params command.
+func GetCmdQueryParams(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "params",
+		Args:  cobra.NoArgs,
+		Short: "Query the current pot parameters",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryParams)
+			resp, _, err := cliCtx.QueryWithData(route, nil)
+			if err != nil {
+				return err
+			}
+			var params types.Params
+			cdc.MustUnmarshalJSON(resp, &params)
+			return cliCtx.PrintOutput(params)
+		},
+	}
+}
+
 // GetCmdQueryVolumeReport implements the query volume report command.
 func GetCmdQueryVolumeReport(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -77,6 +102,86 @@ func QueryVolumeReport(cliCtx context.CLIContext, queryRoute string, epoch sdk.I
 	return reportRes, height, nil
 }
 
+// GetCmdQueryRewardHistory implements the query reward history command.
+func GetCmdQueryRewardHistory(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reward-history [node_addr]",
+		Short: "Query the rewards of a node by mature epoch",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the mining/traffic split and maturity status of the rewards of a node, by mature epoch.`),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			nodeAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			startEpoch, endEpoch := sdk.NewInt(-1), sdk.NewInt(-1)
+			if epochStr := viper.GetString(FlagStartEpoch); epochStr != "" {
+				if startEpoch, err = checkFlagEpoch(epochStr); err != nil {
+					return err
+				}
+			}
+			if epochStr := viper.GetString(FlagEndEpoch); epochStr != "" {
+				if endEpoch, err = checkFlagEpoch(epochStr); err != nil {
+					return err
+				}
+			}
+
+			params := keeper.NewQueryRewardHistoryParams(
+				viper.GetInt(flags.FlagPage), viper.GetInt(flags.FlagLimit), nodeAddr, startEpoch, endEpoch)
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryRewardHistory)
+			resp, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+			var history []keeper.RewardHistoryRecord
+			cdc.MustUnmarshalJSON(resp, &history)
+			return cliCtx.PrintOutput(history)
+		},
+	}
+	cmd.Flags().AddFlagSet(FsEpochRange)
+	cmd.Flags().Int(flags.FlagPage, 1, "pagination page of rewards to query for")
+	cmd.Flags().Int(flags.FlagLimit, keeper.QueryDefaultLimit, "pagination limit of rewards to query for")
+
+	return cmd
+}
+
+// GetCmdQueryRewardAddressPool implements the query reward address pool command.
+func GetCmdQueryRewardAddressPool(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reward-address-pool",
+		Short: "Query the nodes that ever received a reward",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			params := keeper.NewQueryRewardAddressPoolParams(viper.GetInt(flags.FlagPage), viper.GetInt(flags.FlagLimit))
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryRewardAddressPool)
+			resp, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+			var addrs []sdk.AccAddress
+			cdc.MustUnmarshalJSON(resp, &addrs)
+			return cliCtx.PrintOutput(addrs)
+		},
+	}
+	cmd.Flags().Int(flags.FlagPage, 1, "pagination page of nodes to query for")
+	cmd.Flags().Int(flags.FlagLimit, keeper.QueryDefaultLimit, "pagination limit of nodes to query for")
+
+	return cmd
+}
+
 func checkFlagEpoch(epochStr string) (sdk.Int, error) {
 	epochInt64, err := strconv.ParseInt(epochStr, 10, 64)
 	if err != nil {
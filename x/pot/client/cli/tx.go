@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"strconv"
+	"strings"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -22,6 +23,11 @@ type singleNodeVolumeStr struct {
 	Volume      string `json:"node_volume"`
 }
 
+type singleUserVolumeStr struct {
+	UserAddress string `json:"user_address"`
+	Volume      string `json:"user_volume"`
+}
+
 // GetTxCmd returns the transaction commands for this module
 func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 	potTxCmd := &cobra.Command{
@@ -34,7 +40,10 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 
 	potTxCmd.AddCommand(flags.PostCommands(
 		VolumeReportCmd(cdc),
+		VolumeReportVoteCmd(cdc),
 		WithdrawCmd(cdc),
+		WithdrawAllCmd(cdc),
+		SetWithdrawAddressCmd(cdc),
 		FoundationDepositCmd(cdc),
 	)...)
 	return potTxCmd
@@ -87,6 +96,96 @@ func buildWithdrawMsg(cliCtx context.CLIContext, txBldr auth.TxBuilder) (auth.Tx
 	return txBldr, msg, nil
 }
 
+// WithdrawAllCmd will withdraw all mature rewards of the given nodes in a single tx.
+func WithdrawAllCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "withdraw-all",
+		Short: "withdraw all mature POT rewards of one or many nodes",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			txBldr, msg, err := buildWithdrawAllMsg(cliCtx, txBldr)
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(FsNodeAddresses)
+
+	_ = cmd.MarkFlagRequired(FlagNodeAddresses)
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+
+	return cmd
+}
+
+// makes a new MsgWithdrawAll.
+func buildWithdrawAllMsg(cliCtx context.CLIContext, txBldr auth.TxBuilder) (auth.TxBuilder, sdk.Msg, error) {
+	var nodeAddresses []sdk.AccAddress
+	for _, nodeAddressStr := range strings.Split(viper.GetString(FlagNodeAddresses), ",") {
+		nodeAddress, err := sdk.AccAddressFromBech32(strings.TrimSpace(nodeAddressStr))
+		if err != nil {
+			return txBldr, nil, err
+		}
+		nodeAddresses = append(nodeAddresses, nodeAddress)
+	}
+	ownerAddress := cliCtx.GetFromAddress()
+
+	msg := types.NewMsgWithdrawAll(nodeAddresses, ownerAddress)
+
+	return txBldr, msg, nil
+}
+
+// SetWithdrawAddressCmd will register the address credited by the withdrawals of a node.
+func SetWithdrawAddressCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-withdraw-address",
+		Short: "set the address credited by the POT reward withdrawals of a node",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			txBldr, msg, err := buildSetWithdrawAddressMsg(cliCtx, txBldr)
+			if err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(FsNodeAddress)
+	cmd.Flags().AddFlagSet(FsWithdrawAddress)
+
+	_ = cmd.MarkFlagRequired(FlagNodeAddress)
+	_ = cmd.MarkFlagRequired(FlagWithdrawAddress)
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+
+	return cmd
+}
+
+// makes a new MsgSetWithdrawAddress.
+func buildSetWithdrawAddressMsg(cliCtx context.CLIContext, txBldr auth.TxBuilder) (auth.TxBuilder, sdk.Msg, error) {
+	nodeAddress, err := sdk.AccAddressFromBech32(viper.GetString(FlagNodeAddress))
+	if err != nil {
+		return txBldr, nil, err
+	}
+	withdrawAddress, err := sdk.AccAddressFromBech32(viper.GetString(FlagWithdrawAddress))
+	if err != nil {
+		return txBldr, nil, err
+	}
+	ownerAddress := cliCtx.GetFromAddress()
+
+	msg := types.NewMsgSetWithdrawAddress(nodeAddress, ownerAddress, withdrawAddress)
+
+	return txBldr, msg, nil
+}
+
 // VolumeReportCmd will report nodes volume and sign it with the given key.
 func VolumeReportCmd(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -107,6 +206,7 @@ func VolumeReportCmd(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().AddFlagSet(FsEpoch)
 	cmd.Flags().AddFlagSet(FsReportReference)
 	cmd.Flags().AddFlagSet(FsNodesVolume)
+	cmd.Flags().AddFlagSet(FsUsersVolume)
 
 	_ = cmd.MarkFlagRequired(FlagReporter)
 	_ = cmd.MarkFlagRequired(FlagEpoch)
@@ -150,6 +250,26 @@ func createVolumeReportMsg(cliCtx context.CLIContext, txBldr auth.TxBuilder) (au
 		nodesVolume = append(nodesVolume, types.NewSingleNodeVolume(nodeAcc, nodeVolume))
 	}
 
+	var usersVolume = make([]types.SingleUserVolume, 0)
+	if usersVolumeJSON := viper.GetString(FlagUsersVolume); len(usersVolumeJSON) > 0 {
+		var usersVolumeStr = make([]singleUserVolumeStr, 0)
+		err = cliCtx.Codec.UnmarshalJSON([]byte(usersVolumeJSON), &usersVolumeStr)
+		if err != nil {
+			return txBldr, nil, err
+		}
+		for _, u := range usersVolumeStr {
+			userAcc, err := sdk.AccAddressFromBech32(u.UserAddress)
+			if err != nil {
+				return txBldr, nil, err
+			}
+			volumeInt64, err := strconv.ParseInt(u.Volume, 10, 64)
+			if err != nil {
+				return txBldr, nil, err
+			}
+			usersVolume = append(usersVolume, types.NewSingleUserVolume(userAcc, sdk.NewInt(volumeInt64)))
+		}
+	}
+
 	reporterOwner := cliCtx.GetFromAddress()
 
 	msg := types.NewMsgVolumeReport(
@@ -158,10 +278,59 @@ func createVolumeReportMsg(cliCtx context.CLIContext, txBldr auth.TxBuilder) (au
 		epoch,
 		reportReference,
 		reporterOwner,
+		usersVolume,
 	)
 	return txBldr, msg, nil
 }
 
+// VolumeReportVoteCmd will vote for a pending volume report and sign it with the given key.
+func VolumeReportVoteCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "volume-report-vote [flags]",
+		Short: "Approve or reject a pending volume report",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+			txBldr, msg, err := createVolumeReportVoteMsg(cliCtx, txBldr)
+			if err != nil {
+				return err
+			}
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().AddFlagSet(FsVoter)
+	cmd.Flags().AddFlagSet(FsEpoch)
+	cmd.Flags().AddFlagSet(FsReportReference)
+	cmd.Flags().AddFlagSet(FsOpinion)
+
+	_ = cmd.MarkFlagRequired(FlagVoter)
+	_ = cmd.MarkFlagRequired(FlagEpoch)
+	_ = cmd.MarkFlagRequired(FlagReportReference)
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+
+	return cmd
+}
+
+func createVolumeReportVoteMsg(cliCtx context.CLIContext, txBldr auth.TxBuilder) (auth.TxBuilder, sdk.Msg, error) {
+	voter, err := sdk.AccAddressFromBech32(viper.GetString(FlagVoter))
+	if err != nil {
+		return txBldr, nil, err
+	}
+
+	value, err := strconv.ParseInt(viper.GetString(FlagEpoch), 10, 64)
+	if err != nil {
+		return txBldr, nil, err
+	}
+	epoch := sdk.NewInt(value)
+	reportReference := viper.GetString(FlagReportReference)
+	opinion := viper.GetBool(FlagOpinion)
+	voterOwner := cliCtx.GetFromAddress()
+
+	msg := types.NewMsgVolumeReportVote(epoch, reportReference, opinion, voter, voterOwner)
+	return txBldr, msg, nil
+}
+
 func FoundationDepositCmd(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "foundation-deposit",
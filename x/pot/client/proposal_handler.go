@@ -0,0 +1,12 @@
+package client
+
+import (
+	govclient "github.com/cosmos/cosmos-sdk/x/gov/client"
+	"github.com/stratosnet/stratos-chain/x/pot/client/cli"
+	"github.com/stratosnet/stratos-chain/x/pot/client/rest"
+)
+
+// ProposalHandler is the pot params update proposal handler
+var (
+	ProposalHandler = govclient.NewProposalHandler(cli.GetCmdSubmitUpdateParamsProposal, rest.ProposalRESTHandler)
+)
@@ -10,6 +10,8 @@ const (
 	RestOwnerAddress = "owner"
 	RestHeight       = "height"
 	RestEpoch        = "epoch"
+	RestStartEpoch   = "start_epoch"
+	RestEndEpoch     = "end_epoch"
 )
 
 // RegisterRoutes registers pot-related REST handlers to a router
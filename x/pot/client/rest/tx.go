@@ -1,7 +1,6 @@
 package rest
 
 import (
-	"fmt"
 	"github.com/cosmos/cosmos-sdk/client/context"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/rest"
@@ -14,7 +13,10 @@ import (
 //registerTxRoutes registers pot-related REST Tx handlers to a router
 func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/pot/volume/report", volumeReportRequestHandlerFn(cliCtx)).Methods("POST")
+	r.HandleFunc("/pot/volume/vote", volumeReportVoteRequestHandlerFn(cliCtx)).Methods("POST")
 	r.HandleFunc("/pot/address/{nodeAddr}/rewards", withdrawPotRewardsHandlerFn(cliCtx)).Methods("POST")
+	r.HandleFunc("/pot/address/{nodeAddr}/withdraw_address", setWithdrawAddressHandlerFn(cliCtx)).Methods("POST")
+	r.HandleFunc("/pot/rewards/withdraw_all", withdrawAllPotRewardsHandlerFn(cliCtx)).Methods("POST")
 	r.HandleFunc("/pot/foundation_deposit", foundationDepositHandlerFn(cliCtx)).Methods("POST")
 }
 
@@ -27,7 +29,17 @@ type (
 	withdrawRewardsReq struct {
 		BaseReq    rest.BaseReq `json:"base_req" yaml:"base_req"`
 		Amount     string       `json:"amount" yaml:"amount"`
-		TargetAddr string       `json:"target_addr" yaml:"target_addr"`
+		TargetAddr string       `json:"target_addr" yaml:"target_addr"` // optional, registered as the withdraw address of the node
+	}
+
+	withdrawAllRewardsReq struct {
+		BaseReq       rest.BaseReq `json:"base_req" yaml:"base_req"`
+		NodeAddresses []string     `json:"node_addresses" yaml:"node_addresses"`
+	}
+
+	setWithdrawAddressReq struct {
+		BaseReq         rest.BaseReq `json:"base_req" yaml:"base_req"`
+		WithdrawAddress string       `json:"withdraw_address" yaml:"withdraw_address"`
 	}
 
 	volumeReportReq struct {
@@ -36,6 +48,15 @@ type (
 		Reporter        string                   `json:"reporter" yaml:"reporter"`                 // volume reporter
 		Epoch           int64                    `json:"report_epoch" yaml:"report_epoch"`         // volume report epoch
 		ReportReference string                   `json:"report_reference" yaml:"report_reference"` // volume report reference
+		UsersVolume     []types.SingleUserVolume `json:"users_volume" yaml:"users_volume"`         // ozone consumed by each user
+	}
+
+	volumeReportVoteReq struct {
+		BaseReq         rest.BaseReq `json:"base_req" yaml:"base_req"`
+		Voter           string       `json:"voter" yaml:"voter"`                       // voting indexing node
+		Epoch           int64        `json:"report_epoch" yaml:"report_epoch"`         // volume report epoch
+		ReportReference string       `json:"report_reference" yaml:"report_reference"` // volume report reference
+		Opinion         bool         `json:"opinion" yaml:"opinion"`                   // approve or reject
 	}
 )
 
@@ -75,7 +96,43 @@ func volumeReportRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		msg := types.NewMsgVolumeReport(nodesVolume, reporter, epoch, reportReference, reporterOwner)
+		msg := types.NewMsgVolumeReport(nodesVolume, reporter, epoch, reportReference, reporterOwner, req.UsersVolume)
+		err = msg.ValidateBasic()
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+// volumeReportVoteRequestHandlerFn rest API handler to create a volume report vote tx.
+func volumeReportVoteRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req volumeReportVoteReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		voter, err := sdk.AccAddressFromBech32(req.Voter)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		voterOwner, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgVolumeReportVote(sdk.NewInt(req.Epoch), req.ReportReference, req.Opinion, voter, voterOwner)
 		err = msg.ValidateBasic()
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -112,22 +169,100 @@ func withdrawPotRewardsHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		targetAddrStr := req.TargetAddr
-		targetAddr, ok := checkAccountAddressVar(w, r, targetAddrStr)
+		ownerAddrStr := req.BaseReq.From
+		ownerAddr, ok := checkAccountAddressVar(w, r, ownerAddrStr)
 		if !ok {
 			return
 		}
 
-		//TODO: Add targetAddr after NewMsgWithdraw updates
-		fmt.Println("targetAddr", targetAddr)
+		var msgs []sdk.Msg
+		if len(req.TargetAddr) > 0 {
+			targetAddr, ok := checkAccountAddressVar(w, r, req.TargetAddr)
+			if !ok {
+				return
+			}
+			msgs = append(msgs, types.NewMsgSetWithdrawAddress(nodeAddr, ownerAddr, targetAddr))
+		}
+		msgs = append(msgs, types.NewMsgWithdraw(sdk.NewCoin(types.DefaultBondDenom, amount), nodeAddr, ownerAddr))
+		for _, msg := range msgs {
+			if err := msg.ValidateBasic(); err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
 
-		ownerAddrStr := req.BaseReq.From
-		ownerAddr, ok := checkAccountAddressVar(w, r, ownerAddrStr)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, msgs)
+	}
+}
+
+// rest API handler to withdraw all mature pot rewards of the given nodes
+func withdrawAllPotRewardsHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req withdrawAllRewardsReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		var nodeAddrs []sdk.AccAddress
+		for _, nodeAddrStr := range req.NodeAddresses {
+			nodeAddr, ok := checkAccountAddressVar(w, r, nodeAddrStr)
+			if !ok {
+				return
+			}
+			nodeAddrs = append(nodeAddrs, nodeAddr)
+		}
+
+		ownerAddr, ok := checkAccountAddressVar(w, r, req.BaseReq.From)
+		if !ok {
+			return
+		}
+
+		msg := types.NewMsgWithdrawAll(nodeAddrs, ownerAddr)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+// rest API handler to set the address credited by the withdrawals of a node
+func setWithdrawAddressHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req setWithdrawAddressReq
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, "failed to parse request")
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		nodeAddr, ok := checkAccountAddressVar(w, r, mux.Vars(r)["nodeAddr"])
+		if !ok {
+			return
+		}
+		withdrawAddr, ok := checkAccountAddressVar(w, r, req.WithdrawAddress)
+		if !ok {
+			return
+		}
+		ownerAddr, ok := checkAccountAddressVar(w, r, req.BaseReq.From)
 		if !ok {
 			return
 		}
 
-		msg := types.NewMsgWithdraw(sdk.NewCoin(types.DefaultBondDenom, amount), nodeAddr, ownerAddr)
+		msg := types.NewMsgSetWithdrawAddress(nodeAddr, ownerAddr, withdrawAddr)
 		if err := msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -2,6 +2,7 @@ package pot
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/stratosnet/stratos-chain/x/pot/types"
 )
 
@@ -10,6 +11,77 @@ import (
 func InitGenesis(ctx sdk.Context, keeper Keeper, data types.GenesisState) {
 	keeper.SetParams(ctx, data.Params)
 	keeper.SetInitialUOzonePrice(ctx, data.InitialUozPrice)
+
+	if !data.TotalMinedTokens.IsNil() {
+		keeper.SetTotalMinedTokens(ctx, data.TotalMinedTokens)
+	}
+	for _, record := range data.MinedTokens {
+		keeper.SetMinedTokens(ctx, record.Epoch, record.MinedTokens)
+	}
+	if !data.TotalUnissuedPrepay.IsNil() {
+		keeper.SetTotalUnissuedPrepay(ctx, data.TotalUnissuedPrepay)
+	}
+	for _, acc := range data.RewardAddressPool {
+		keeper.SetRewardAddress(ctx, acc)
+	}
+	if !data.LastReportedEpoch.IsNil() {
+		keeper.SetLastReportedEpoch(ctx, data.LastReportedEpoch)
+	}
+
+	lastReportedEpoch := keeper.GetLastReportedEpoch(ctx)
+	for _, record := range data.IndividualRewards {
+		keeper.SetIndividualReward(ctx, record.NodeAddress, record.MatureEpoch, record.Reward)
+		// the rewards maturing after the last reported epoch are still immature
+		if record.MatureEpoch.GT(lastReportedEpoch) {
+			keeper.InsertMaturityQueue(ctx, record.MatureEpoch, record.NodeAddress)
+		}
+	}
+	for _, total := range data.MatureTotalRewards {
+		keeper.SetMatureTotalReward(ctx, total.NodeAddress, total.Total)
+	}
+	for _, total := range data.ImmatureTotalRewards {
+		keeper.SetImmatureTotalReward(ctx, total.NodeAddress, total.Total)
+	}
+	for _, total := range data.WithdrawnTotalRewards {
+		keeper.SetWithdrawnTotalReward(ctx, total.NodeAddress, total.Total)
+	}
+	for _, node := range data.RewardsWithheld {
+		keeper.SetRewardWithheld(ctx, node)
+	}
+	for _, record := range data.WithdrawAddresses {
+		keeper.SetWithdrawAddress(ctx, record.NodeAddress, record.WithdrawAddress)
+	}
+	for _, record := range data.RewardOwners {
+		keeper.SetRewardOwner(ctx, record.NodeAddress, record.OwnerAddress)
+	}
+
+	for _, report := range data.VolumeReports {
+		keeper.SetVolumeReport(ctx, report.Epoch, report.Record)
+	}
+	for _, proposal := range data.VolumeReportProposals {
+		keeper.SetVolumeReportProposal(ctx, proposal)
+	}
+
+	initTokenPool(ctx, keeper, types.TotalRewardPoolName, keeper.SumUnwithdrawnRewards(ctx))
+	initTokenPool(ctx, keeper, types.TotalUnissuedPrepayPoolName, keeper.GetTotalUnissuedPrepay(ctx))
+}
+
+// initTokenPool sets the balance of a token pool from the pot records when the pool is not part of the genesis
+// accounts, otherwise the balance it was exported with must match them
+func initTokenPool(ctx sdk.Context, keeper Keeper, poolName string, amount sdk.Int) {
+	bondDenom := keeper.BondDenom(ctx)
+	pool := keeper.GetTokenPool(ctx, poolName)
+	if pool.GetCoins().IsZero() {
+		if err := pool.SetCoins(sdk.NewCoins(sdk.NewCoin(bondDenom, amount))); err != nil {
+			panic(err)
+		}
+		keeper.SetTokenPool(ctx, pool)
+		return
+	}
+	if !pool.GetCoins().AmountOf(bondDenom).Equal(amount) {
+		panic(sdkerrors.Wrapf(types.ErrInvalidGenesisPool, "%s holds %s, the pot records add up to %s%s",
+			poolName, pool.GetCoins(), amount, bondDenom))
+	}
 }
 
 // ExportGenesis writes the current store values
@@ -19,5 +91,76 @@ func ExportGenesis(ctx sdk.Context, keeper Keeper) (data types.GenesisState) {
 	params := keeper.GetParams(ctx)
 	initialUOzonePrice := keeper.GetInitialUOzonePrice(ctx)
 
-	return types.NewGenesisState(params, initialUOzonePrice)
+	var minedTokens []types.MinedTokensRecord
+	keeper.IterateMinedTokens(ctx, func(epoch sdk.Int, minedToken sdk.Int) (stop bool) {
+		minedTokens = append(minedTokens, types.MinedTokensRecord{Epoch: epoch, MinedTokens: minedToken})
+		return false
+	})
+
+	var individualRewards []types.IndividualRewardRecord
+	keeper.IterateAllIndividualRewards(ctx, func(acc sdk.AccAddress, epoch sdk.Int, reward types.IndividualReward) (stop bool) {
+		individualRewards = append(individualRewards, types.IndividualRewardRecord{NodeAddress: acc, MatureEpoch: epoch, Reward: reward})
+		return false
+	})
+
+	var matureTotalRewards []types.NodeRewardTotal
+	keeper.IterateMatureTotalRewards(ctx, func(acc sdk.AccAddress, total sdk.Int) (stop bool) {
+		matureTotalRewards = append(matureTotalRewards, types.NodeRewardTotal{NodeAddress: acc, Total: total})
+		return false
+	})
+
+	var immatureTotalRewards []types.NodeRewardTotal
+	keeper.IterateImmatureTotalRewards(ctx, func(acc sdk.AccAddress, total sdk.Int) (stop bool) {
+		immatureTotalRewards = append(immatureTotalRewards, types.NodeRewardTotal{NodeAddress: acc, Total: total})
+		return false
+	})
+
+	var withdrawnTotalRewards []types.NodeRewardTotal
+	keeper.IterateWithdrawnTotalRewards(ctx, func(acc sdk.AccAddress, total sdk.Int) (stop bool) {
+		withdrawnTotalRewards = append(withdrawnTotalRewards, types.NodeRewardTotal{NodeAddress: acc, Total: total})
+		return false
+	})
+
+	var withdrawAddresses []types.NodeWithdrawAddress
+	keeper.IterateWithdrawAddresses(ctx, func(acc sdk.AccAddress, withdrawAddr sdk.AccAddress) (stop bool) {
+		withdrawAddresses = append(withdrawAddresses, types.NodeWithdrawAddress{NodeAddress: acc, WithdrawAddress: withdrawAddr})
+		return false
+	})
+
+	var rewardOwners []types.NodeRewardOwner
+	keeper.IterateRewardOwners(ctx, func(acc sdk.AccAddress, owner sdk.AccAddress) (stop bool) {
+		rewardOwners = append(rewardOwners, types.NodeRewardOwner{NodeAddress: acc, OwnerAddress: owner})
+		return false
+	})
+
+	var volumeReports []types.EpochVolumeReport
+	keeper.IterateVolumeReports(ctx, func(epoch sdk.Int, reportRecord types.VolumeReportRecord) (stop bool) {
+		volumeReports = append(volumeReports, types.EpochVolumeReport{Epoch: epoch, Record: reportRecord})
+		return false
+	})
+
+	var volumeReportProposals []types.VolumeReportProposal
+	keeper.IterateVolumeReportProposals(ctx, func(proposal types.VolumeReportProposal) (stop bool) {
+		volumeReportProposals = append(volumeReportProposals, proposal)
+		return false
+	})
+
+	return types.GenesisState{
+		Params:                params,
+		InitialUozPrice:       initialUOzonePrice,
+		TotalMinedTokens:      keeper.GetTotalMinedTokens(ctx),
+		MinedTokens:           minedTokens,
+		TotalUnissuedPrepay:   keeper.GetTotalUnissuedPrepay(ctx),
+		RewardAddressPool:     keeper.GetRewardAddressPool(ctx),
+		LastReportedEpoch:     keeper.GetLastReportedEpoch(ctx),
+		IndividualRewards:     individualRewards,
+		MatureTotalRewards:    matureTotalRewards,
+		ImmatureTotalRewards:  immatureTotalRewards,
+		WithdrawnTotalRewards: withdrawnTotalRewards,
+		RewardsWithheld:       keeper.GetRewardsWithheld(ctx),
+		WithdrawAddresses:     withdrawAddresses,
+		RewardOwners:          rewardOwners,
+		VolumeReports:         volumeReports,
+		VolumeReportProposals: volumeReportProposals,
+	}
 }
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	"github.com/stratosnet/stratos-chain/x/pot/keeper"
 	"github.com/stratosnet/stratos-chain/x/pot/types"
 	"github.com/tendermint/tendermint/crypto/tmhash"
+	"strconv"
 )
 
 // NewHandler ...
@@ -19,8 +21,14 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 			return handleMsgReportVolume(ctx, k, msg)
 		case types.MsgWithdraw:
 			return handleMsgWithdraw(ctx, k, msg)
+		case types.MsgWithdrawAll:
+			return handleMsgWithdrawAll(ctx, k, msg)
+		case types.MsgSetWithdrawAddress:
+			return handleMsgSetWithdrawAddress(ctx, k, msg)
 		case types.MsgFoundationDeposit:
 			return handleMsgFoundationDeposit(ctx, k, msg)
+		case types.MsgVolumeReportVote:
+			return handleMsgVolumeReportVote(ctx, k, msg)
 		default:
 			errMsg := fmt.Sprintf("unrecognized %s message type: %T", types.ModuleName, msg)
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, errMsg)
@@ -30,25 +38,11 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 
 // Handle handleMsgReportVolume.
 func handleMsgReportVolume(ctx sdk.Context, k keeper.Keeper, msg types.MsgVolumeReport) (*sdk.Result, error) {
-	if !(k.IsSPNode(ctx, msg.Reporter)) {
-		errMsg := fmt.Sprint("Volume report is not sent by a superior peer")
-		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, errMsg)
-	}
-
-	// ensure epoch increment
-	lastEpoch := k.GetLastReportedEpoch(ctx)
-	if msg.Epoch.LTE(lastEpoch) {
-		e := sdkerrors.Wrapf(types.ErrMatureEpoch, "expected epoch should be greater than %s, got %s",
-			lastEpoch.String(), msg.Epoch.String())
-		return nil, e
-	}
-
 	txBytes := ctx.TxBytes()
 	txhash := fmt.Sprintf("%X", tmhash.Sum(txBytes))
 
-	reportRecord := types.NewReportRecord(msg.Reporter, msg.ReportReference, txhash)
-	k.SetVolumeReport(ctx, msg.Epoch, reportRecord)
-	totalConsumedOzone, err := k.DistributePotReward(ctx, msg.NodesVolume, msg.Epoch)
+	status, totalConsumedOzone, err := k.SubmitVolumeReport(ctx, msg.NodesVolume, msg.UsersVolume, msg.Reporter, msg.ReporterOwner,
+		msg.Epoch, msg.ReportReference, txhash)
 	if err != nil {
 		return nil, err
 	}
@@ -56,20 +50,67 @@ func handleMsgReportVolume(ctx sdk.Context, k keeper.Keeper, msg types.MsgVolume
 	ctx.EventManager().EmitEvents(sdk.Events{
 		sdk.NewEvent(
 			types.EventTypeVolumeReport,
-			sdk.NewAttribute(types.AttributeKeyTotalConsumedOzone, totalConsumedOzone.String()),
+			sdk.NewAttribute(types.AttributeKeyReporter, msg.Reporter.String()),
 			sdk.NewAttribute(types.AttributeKeyReportReference, hex.EncodeToString([]byte(msg.ReportReference))),
 			sdk.NewAttribute(types.AttributeKeyEpoch, msg.Epoch.String()),
 		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
 			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
-			sdk.NewAttribute(sdk.AttributeKeySender, msg.Reporter.String()),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.ReporterOwner.String()),
+		),
+	})
+	emitVolumeReportResultEvent(ctx, msg.Epoch, status, totalConsumedOzone)
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgVolumeReportVote(ctx sdk.Context, k keeper.Keeper, msg types.MsgVolumeReportVote) (*sdk.Result, error) {
+	status, totalConsumedOzone, err := k.VoteVolumeReport(ctx, msg.Epoch, msg.ReportReference, msg.Opinion,
+		msg.VoterNetworkAddress, msg.VoterOwnerAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeVolumeReportVote,
+			sdk.NewAttribute(types.AttributeKeyVoter, msg.VoterNetworkAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyOpinion, strconv.FormatBool(msg.Opinion)),
+			sdk.NewAttribute(types.AttributeKeyEpoch, msg.Epoch.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.VoterOwnerAddress.String()),
 		),
 	})
+	emitVolumeReportResultEvent(ctx, msg.Epoch, status, totalConsumedOzone)
 
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
 
+// emitVolumeReportResultEvent emits an event once a volume report proposal is settled or dropped
+func emitVolumeReportResultEvent(ctx sdk.Context, epoch sdk.Int, status types.VolumeReportProposalStatus, totalConsumedOzone sdk.Dec) {
+	switch status {
+	case types.VolumeReportProposalAccepted:
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeVolumeReportAccepted,
+				sdk.NewAttribute(types.AttributeKeyTotalConsumedOzone, totalConsumedOzone.String()),
+				sdk.NewAttribute(types.AttributeKeyEpoch, epoch.String()),
+			),
+		)
+	case types.VolumeReportProposalRejected:
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeVolumeReportRejected,
+				sdk.NewAttribute(types.AttributeKeyEpoch, epoch.String()),
+			),
+		)
+	}
+}
+
 func handleMsgWithdraw(ctx sdk.Context, k keeper.Keeper, msg types.MsgWithdraw) (*sdk.Result, error) {
 	err := k.Withdraw(ctx, msg.Amount, msg.NodeAddress, msg.OwnerAddress)
 	if err != nil {
@@ -82,6 +123,49 @@ func handleMsgWithdraw(ctx sdk.Context, k keeper.Keeper, msg types.MsgWithdraw)
 			sdk.NewAttribute(types.AttributeKeyAmount, msg.Amount.String()),
 			sdk.NewAttribute(types.AttributeKeyNodeAddress, msg.NodeAddress.String()),
 			sdk.NewAttribute(types.AttributeKeyOwnerAddress, msg.OwnerAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyWithdrawAddress, k.GetWithdrawTarget(ctx, msg.NodeAddress, msg.OwnerAddress).String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.OwnerAddress.String()),
+		),
+	})
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgWithdrawAll(ctx sdk.Context, k keeper.Keeper, msg types.MsgWithdrawAll) (*sdk.Result, error) {
+	total, err := k.WithdrawAll(ctx, msg.NodeAddresses, msg.OwnerAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeWithdrawAll,
+			sdk.NewAttribute(types.AttributeKeyAmount, total.String()),
+			sdk.NewAttribute(types.AttributeKeyOwnerAddress, msg.OwnerAddress.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.OwnerAddress.String()),
+		),
+	})
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgSetWithdrawAddress(ctx sdk.Context, k keeper.Keeper, msg types.MsgSetWithdrawAddress) (*sdk.Result, error) {
+	err := k.UpdateWithdrawAddress(ctx, msg.NodeAddress, msg.OwnerAddress, msg.WithdrawAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeSetWithdrawAddress,
+			sdk.NewAttribute(types.AttributeKeyNodeAddress, msg.NodeAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyWithdrawAddress, msg.WithdrawAddress.String()),
 		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
@@ -111,3 +195,16 @@ func handleMsgFoundationDeposit(ctx sdk.Context, k keeper.Keeper, msg types.MsgF
 	})
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
+
+// NewUpdateParamsProposalHandler handles the pot params update proposals
+func NewUpdateParamsProposalHandler(k keeper.Keeper) govtypes.Handler {
+	return func(ctx sdk.Context, content govtypes.Content) error {
+		switch c := content.(type) {
+		case types.UpdateParamsProposal:
+			return keeper.HandleUpdateParamsProposal(ctx, k, c)
+
+		default:
+			return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized pot proposal content type: %T", c)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package keeper
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stratosnet/stratos-chain/x/pot/types"
+	"github.com/stratosnet/stratos-chain/x/register"
 )
 
 func (k Keeper) DistributePotReward(ctx sdk.Context, trafficList []types.SingleNodeVolume, epoch sdk.Int) (totalConsumedOzone sdk.Dec, err error) {
@@ -91,7 +92,7 @@ func (k Keeper) deductRewardFromRewardProviderAccount(ctx sdk.Context, goal type
 		ctx.Logger().Info("balance of foundation account is 0")
 		return types.ErrInsufficientFoundationAccBalance
 	}
-	_, err = k.BankKeeper.SubtractCoins(ctx, foundationAccountAddr, amountToDeduct)
+	err = k.SupplyKeeper.SendCoinsFromModuleToModule(ctx, types.FoundationAccount, types.TotalRewardPoolName, amountToDeduct)
 	if err != nil {
 		return err
 	}
@@ -99,8 +100,8 @@ func (k Keeper) deductRewardFromRewardProviderAccount(ctx sdk.Context, goal type
 	// update mined token record by adding mining reward
 	oldTotalMinedToken := k.GetTotalMinedTokens(ctx)
 	newTotalMinedToken := oldTotalMinedToken.Add(totalRewardFromMiningPool)
-	k.setTotalMinedTokens(ctx, newTotalMinedToken)
-	k.setMinedTokens(ctx, epoch, totalRewardFromMiningPool)
+	k.SetTotalMinedTokens(ctx, newTotalMinedToken)
+	k.SetMinedTokens(ctx, epoch, totalRewardFromMiningPool)
 
 	// deduct traffic reward from prepay pool
 	totalUnIssuedPrepay := k.GetTotalUnissuedPrepay(ctx)
@@ -108,6 +109,11 @@ func (k Keeper) deductRewardFromRewardProviderAccount(ctx sdk.Context, goal type
 	if newTotalUnIssuedPrePay.IsNegative() {
 		return types.ErrInsufficientUnissuedPrePayBalance
 	}
+	err = k.SupplyKeeper.SendCoinsFromModuleToModule(ctx, types.TotalUnissuedPrepayPoolName, types.TotalRewardPoolName,
+		sdk.NewCoins(sdk.NewCoin(k.BondDenom(ctx), totalRewardFromTrafficPool)))
+	if err != nil {
+		return err
+	}
 	k.SetTotalUnissuedPrepay(ctx, newTotalUnIssuedPrePay)
 
 	return nil
@@ -130,7 +136,7 @@ func (k Keeper) returnBalance(ctx sdk.Context, goal types.DistributeGoal, epoch
 		return types.ErrUnknownAccountAddress
 	}
 	amountToAdd := sdk.NewCoins(sdk.NewCoin(k.BondDenom(ctx), balanceOfMiningPool))
-	_, err = k.BankKeeper.AddCoins(ctx, foundationAccountAddr, amountToAdd)
+	err = k.SupplyKeeper.SendCoinsFromModuleToModule(ctx, types.TotalRewardPoolName, types.FoundationAccount, amountToAdd)
 	if err != nil {
 		return err
 	}
@@ -140,10 +146,15 @@ func (k Keeper) returnBalance(ctx sdk.Context, goal types.DistributeGoal, epoch
 	newTotalMinedToken := oldTotalMinedToken.Sub(balanceOfMiningPool)
 	oldMinedToken := k.GetMinedTokens(ctx, epoch)
 	newMinedToken := oldMinedToken.Sub(balanceOfMiningPool)
-	k.setTotalMinedTokens(ctx, newTotalMinedToken)
-	k.setMinedTokens(ctx, epoch, newMinedToken)
+	k.SetTotalMinedTokens(ctx, newTotalMinedToken)
+	k.SetMinedTokens(ctx, epoch, newMinedToken)
 
 	// return balance to prepay pool
+	err = k.SupplyKeeper.SendCoinsFromModuleToModule(ctx, types.TotalRewardPoolName, types.TotalUnissuedPrepayPoolName,
+		sdk.NewCoins(sdk.NewCoin(k.BondDenom(ctx), balanceOfTrafficPool)))
+	if err != nil {
+		return err
+	}
 	totalUnIssuedPrepay := k.GetTotalUnissuedPrepay(ctx)
 	newTotalUnIssuedPrePay := totalUnIssuedPrepay.Add(balanceOfTrafficPool)
 	k.SetTotalUnissuedPrepay(ctx, newTotalUnIssuedPrePay)
@@ -244,50 +255,64 @@ func (k Keeper) distributeRewardToSdsNodes(ctx sdk.Context, rewardDetailList []t
 
 	for _, reward := range rewardDetailList {
 		nodeAddr := reward.NodeAddress
-		totalReward := reward.RewardFromMiningPool.Add(reward.RewardFromTrafficPool)
-		k.addNewRewardAndReCalcTotal(ctx, nodeAddr, currentEpoch, matureEpoch, totalReward)
+		newReward := types.NewIndividualReward(reward.RewardFromMiningPool, reward.RewardFromTrafficPool)
+		k.addNewRewardAndReCalcTotal(ctx, nodeAddr, matureEpoch, newReward)
+		k.SetRewardOwner(ctx, nodeAddr, k.getRewardOwnerToRecord(ctx, nodeAddr))
 	}
-	k.setLastReportedEpoch(ctx, currentEpoch)
+	k.SetLastReportedEpoch(ctx, currentEpoch)
 	return nil
 }
 
-func (k Keeper) addNewRewardAndReCalcTotal(ctx sdk.Context, account sdk.AccAddress, currentEpoch sdk.Int, matureEpoch sdk.Int, newReward sdk.Int) NodeRewardsRecord {
-	oldMatureTotal := k.GetMatureTotalReward(ctx, account)
-	oldImmatureTotal := k.GetImmatureTotalReward(ctx, account)
-	matureStartEpoch := k.GetLastReportedEpoch(ctx).Int64() + 1
-	matureEndEpoch := currentEpoch.Int64()
-
-	immatureToMature := sdk.ZeroInt()
-	for i := matureStartEpoch; i <= matureEndEpoch; i++ {
-		reward := k.GetIndividualReward(ctx, account, sdk.NewInt(i))
-		immatureToMature = immatureToMature.Add(reward)
+// getRewardOwnerToRecord returns the owner of a registered node, or the account itself for the rewards of a delegator
+func (k Keeper) getRewardOwnerToRecord(ctx sdk.Context, acc sdk.AccAddress) sdk.AccAddress {
+	if resourceNode, found := k.RegisterKeeper.GetResourceNode(ctx, acc); found {
+		return resourceNode.OwnerAddress
 	}
+	if indexingNode, found := k.RegisterKeeper.GetIndexingNode(ctx, acc); found {
+		return indexingNode.OwnerAddress
+	}
+	return acc
+}
 
-	matureTotal := oldMatureTotal.Add(immatureToMature)
-	immatureTotal := oldImmatureTotal.Sub(immatureToMature).Add(newReward)
+func (k Keeper) addNewRewardAndReCalcTotal(ctx sdk.Context, account sdk.AccAddress, matureEpoch sdk.Int, newReward types.IndividualReward) NodeRewardsRecord {
+	matureTotal := k.GetMatureTotalReward(ctx, account)
+	immatureTotal := k.GetImmatureTotalReward(ctx, account).Add(newReward.Total())
 
-	rewardAddressPool := k.GetRewardAddressPool(ctx)
-	addrExist := false
-	for i := 0; i < len(rewardAddressPool); i++ {
-		if rewardAddressPool[i].Equals(account) {
-			addrExist = true
-			break
-		}
-	}
-	if addrExist == false {
-		rewardAddressPool = append(rewardAddressPool, account)
-		k.setRewardAddressPool(ctx, rewardAddressPool)
+	if !k.HasRewardAddress(ctx, account) {
+		k.SetRewardAddress(ctx, account)
 	}
 
 	distributionRecord := NewNodeRewardsInfo(account, matureTotal, immatureTotal)
 	potRewardsRecordVal := NewNodeRewardsRecord(distributionRecord)
 
-	k.setMatureTotalReward(ctx, account, matureTotal)
-	k.setImmatureTotalReward(ctx, account, immatureTotal)
-	k.setIndividualReward(ctx, account, matureEpoch, newReward)
+	k.SetImmatureTotalReward(ctx, account, immatureTotal)
+	k.SetIndividualReward(ctx, account, matureEpoch, newReward)
+	k.InsertMaturityQueue(ctx, matureEpoch, account)
 	return potRewardsRecordVal
 }
 
+// MatureRewards moves the queued individual rewards maturing up to the last reported epoch from the immature
+// to the mature totals of their nodes, whether or not the nodes earned a reward since
+func (k Keeper) MatureRewards(ctx sdk.Context) (matured int) {
+	type queueEntry struct {
+		epoch sdk.Int
+		acc   sdk.AccAddress
+	}
+	var entries []queueEntry
+	k.IterateMaturityQueue(ctx, k.GetLastReportedEpoch(ctx), func(epoch sdk.Int, acc sdk.AccAddress) (stop bool) {
+		entries = append(entries, queueEntry{epoch, acc})
+		return false
+	})
+
+	for _, entry := range entries {
+		reward := k.GetIndividualReward(ctx, entry.acc, entry.epoch)
+		k.SetMatureTotalReward(ctx, entry.acc, k.GetMatureTotalReward(ctx, entry.acc).Add(reward))
+		k.SetImmatureTotalReward(ctx, entry.acc, k.GetImmatureTotalReward(ctx, entry.acc).Sub(reward))
+		k.RemoveFromMaturityQueue(ctx, entry.epoch, entry.acc)
+	}
+	return len(entries)
+}
+
 // reward will mature 14 days since distribution. Each epoch interval is about 10 minutes.
 func (k Keeper) getMatureEpochByCurrentEpoch(ctx sdk.Context, currentEpoch sdk.Int) (matureEpoch sdk.Int) {
 	// 14 days = 20160 minutes = 2016 epochs
@@ -309,7 +334,8 @@ func (k Keeper) distributeValidatorRewardToFeePool(ctx sdk.Context, distributeGo
 		return distributeGoal, types.ErrUnknownAccountAddress
 	}
 
-	_, err := k.BankKeeper.AddCoins(ctx, feePoolAccAddr, sdk.NewCoins(sdk.NewCoin(k.BondDenom(ctx), totalRewardSendToFeePool)))
+	err := k.SupplyKeeper.SendCoinsFromModuleToModule(ctx, types.TotalRewardPoolName, k.feeCollectorName,
+		sdk.NewCoins(sdk.NewCoin(k.BondDenom(ctx), totalRewardSendToFeePool)))
 	if err != nil {
 		return distributeGoal, err
 	}
@@ -335,14 +361,28 @@ func (k Keeper) CalcRewardForResourceNode(ctx sdk.Context, trafficList []types.S
 	resourceNodeList := k.RegisterKeeper.GetAllResourceNodes(ctx)
 	for _, node := range resourceNodeList {
 		nodeAddr := node.GetNetworkAddr()
+		// only the nodes whose tokens are in the bonded pool share the stake reward
+		if !node.GetStatus().Equal(sdk.Bonded) {
+			continue
+		}
+		// the share of a node whose rewards are withheld stays in distributeGoal and is returned to the pools
+		if k.IsRewardWithheld(ctx, nodeAddr) {
+			continue
+		}
 
-		shareOfToken := node.GetTokens().ToDec().Quo(totalStakeOfResourceNodes.ToDec())
+		// the tokens scheduled to be returned by an unbonding entry have left the bonded pool
+		stake := k.RegisterKeeper.GetAvailableStake(ctx, nodeAddr, node.GetTokens())
+		shareOfToken := stake.ToDec().Quo(totalStakeOfResourceNodes.ToDec())
 		stakeRewardFromMiningPool := distributeGoal.BlockChainRewardToResourceNodeFromMiningPool.ToDec().Mul(shareOfToken).TruncateInt()
 		stakeRewardFromTrafficPool := distributeGoal.BlockChainRewardToResourceNodeFromTrafficPool.ToDec().Mul(shareOfToken).TruncateInt()
 
 		totalUsedFromMiningPool = totalUsedFromMiningPool.Add(stakeRewardFromMiningPool)
 		totalUsedFromTrafficPool = totalUsedFromTrafficPool.Add(stakeRewardFromTrafficPool)
 
+		// the delegators of the node take their share of the stake reward, minus commission
+		stakeRewardFromMiningPool, stakeRewardFromTrafficPool = k.splitStakeRewardWithDelegators(ctx, nodeAddr,
+			node.GetTokens(), node.GetCommissionRate(), stakeRewardFromMiningPool, stakeRewardFromTrafficPool, rewardDetailMap)
+
 		if _, ok := rewardDetailMap[nodeAddr.String()]; !ok {
 			reward := types.NewDefaultReward(nodeAddr)
 			rewardDetailMap[nodeAddr.String()] = reward
@@ -369,6 +409,9 @@ func (k Keeper) CalcRewardForResourceNode(ctx sdk.Context, trafficList []types.S
 	for _, nodeTraffic := range trafficList {
 		nodeAddr := nodeTraffic.NodeAddress
 		nodeTraffic := nodeTraffic.Volume
+		if k.IsRewardWithheld(ctx, nodeAddr) {
+			continue
+		}
 
 		shareOfTraffic := nodeTraffic.ToDec().Quo(totalConsumedOzone.ToDec())
 		trafficRewardFromMiningPool :=
@@ -407,13 +450,20 @@ func (k Keeper) CalcRewardForIndexingNode(ctx sdk.Context, distributeGoal types.
 	totalUsedIndexingRewardFromTrafficPool := sdk.ZeroInt()
 
 	totalStakeOfIndexingNodes := k.RegisterKeeper.GetIndexingNodeBondedToken(ctx).Amount
-	indexingNodeList := k.RegisterKeeper.GetAllIndexingNodes(ctx)
+	// only the nodes whose tokens are in the bonded pool share the rewards of the indexing nodes
+	var indexingNodeList []register.IndexingNode
+	for _, node := range k.RegisterKeeper.GetAllIndexingNodes(ctx) {
+		if node.GetStatus().Equal(sdk.Bonded) {
+			indexingNodeList = append(indexingNodeList, node)
+		}
+	}
 	indexingNodeCnt := sdk.NewInt(int64(len(indexingNodeList)))
 	for _, node := range indexingNodeList {
 		nodeAddr := node.GetNetworkAddr()
 
 		// 1, calc stake reward
-		shareOfToken := node.GetTokens().ToDec().Quo(totalStakeOfIndexingNodes.ToDec())
+		stake := k.RegisterKeeper.GetAvailableStake(ctx, nodeAddr, node.GetTokens())
+		shareOfToken := stake.ToDec().Quo(totalStakeOfIndexingNodes.ToDec())
 		stakeRewardFromMiningPool :=
 			distributeGoal.BlockChainRewardToIndexingNodeFromMiningPool.ToDec().Mul(shareOfToken).TruncateInt()
 		stakeRewardFromTrafficPool :=
@@ -422,6 +472,10 @@ func (k Keeper) CalcRewardForIndexingNode(ctx sdk.Context, distributeGoal types.
 		totalUsedStakeRewardFromMiningPool = totalUsedStakeRewardFromMiningPool.Add(stakeRewardFromMiningPool)
 		totalUsedStakeRewardFromTrafficPool = totalUsedStakeRewardFromTrafficPool.Add(stakeRewardFromTrafficPool)
 
+		// the delegators of the node take their share of the stake reward, minus commission
+		stakeRewardFromMiningPool, stakeRewardFromTrafficPool = k.splitStakeRewardWithDelegators(ctx, nodeAddr,
+			node.GetTokens(), node.GetCommissionRate(), stakeRewardFromMiningPool, stakeRewardFromTrafficPool, rewardDetailMap)
+
 		// 2, calc indexing reward
 		indexingRewardFromMiningPool :=
 			distributeGoal.MetaNodeRewardToIndexingNodeFromMiningPool.ToDec().Quo(indexingNodeCnt.ToDec()).TruncateInt()
@@ -454,6 +508,41 @@ func (k Keeper) CalcRewardForIndexingNode(ctx sdk.Context, distributeGoal types.
 	return rewardDetailMap, distributeGoal
 }
 
+// splitStakeRewardWithDelegators credits every delegator of a node with its pro-rata share of the node's stake reward,
+// net of the node's commission, and returns the part of the stake reward that is left to the node itself
+func (k Keeper) splitStakeRewardWithDelegators(ctx sdk.Context, nodeAddr sdk.AccAddress, nodeTokens sdk.Int,
+	commissionRate sdk.Dec, rewardFromMiningPool sdk.Int, rewardFromTrafficPool sdk.Int, rewardDetailMap map[string]types.Reward,
+) (nodeRewardFromMiningPool sdk.Int, nodeRewardFromTrafficPool sdk.Int) {
+
+	nodeRewardFromMiningPool = rewardFromMiningPool
+	nodeRewardFromTrafficPool = rewardFromTrafficPool
+	if !nodeTokens.IsPositive() {
+		return
+	}
+
+	for _, delegation := range k.RegisterKeeper.GetNodeDelegations(ctx, nodeAddr) {
+		shareOfDelegation := delegation.Amount.ToDec().Quo(nodeTokens.ToDec())
+		netShare := shareOfDelegation.Mul(sdk.OneDec().Sub(commissionRate))
+		delegatorRewardFromMiningPool := rewardFromMiningPool.ToDec().Mul(netShare).TruncateInt()
+		delegatorRewardFromTrafficPool := rewardFromTrafficPool.ToDec().Mul(netShare).TruncateInt()
+
+		nodeRewardFromMiningPool = nodeRewardFromMiningPool.Sub(delegatorRewardFromMiningPool)
+		nodeRewardFromTrafficPool = nodeRewardFromTrafficPool.Sub(delegatorRewardFromTrafficPool)
+
+		delegatorAddr := delegation.DelegatorAddress
+		if _, ok := rewardDetailMap[delegatorAddr.String()]; !ok {
+			reward := types.NewDefaultReward(delegatorAddr)
+			rewardDetailMap[delegatorAddr.String()] = reward
+		}
+
+		newReward := rewardDetailMap[delegatorAddr.String()]
+		newReward = newReward.AddRewardFromMiningPool(delegatorRewardFromMiningPool)
+		newReward = newReward.AddRewardFromTrafficPool(delegatorRewardFromTrafficPool)
+		rewardDetailMap[delegatorAddr.String()] = newReward
+	}
+	return
+}
+
 func (k Keeper) GetTotalConsumedOzone(trafficList []types.SingleNodeVolume) sdk.Int {
 	totalTraffic := sdk.ZeroInt()
 	for _, vol := range trafficList {
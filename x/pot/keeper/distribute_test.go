@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"errors"
 	"fmt"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
@@ -10,7 +11,9 @@ import (
 	"github.com/stratosnet/stratos-chain/x/register"
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"math"
 	"testing"
+	"time"
 )
 
 const (
@@ -103,14 +106,15 @@ func Test(t *testing.T) {
 	registerKeeper.SetInitialGenesisStakeTotal(ctx, initialGenesisStakeTotal.Amount)
 
 	//PrePay
-	k.SetTotalUnissuedPrepay(ctx, totalUnissuedPrePay)
+	setTotalUnissuedPrepay(t, ctx, k, totalUnissuedPrePay)
 	//remaining ozone limit
 	registerKeeper.SetRemainingOzoneLimit(ctx, remainingOzoneLimit)
 
 	//pot genesis data load
-	foundationAccountAddr := supplyKeeper.GetModuleAddress(types.FoundationAccount)
-	err = bankKeeper.SetCoins(ctx, foundationAccountAddr, foundationDeposit)
+	foundationAccount := supplyKeeper.GetModuleAccount(ctx, types.FoundationAccount)
+	err = foundationAccount.SetCoins(foundationDeposit)
 	require.NoError(t, err)
+	supplyKeeper.SetModuleAccount(ctx, foundationAccount)
 
 	//initialize owner accounts
 	createAccount(t, ctx, accountKeeper, bankKeeper, resOwner1, sdk.NewCoins(initialStakeRes1))
@@ -122,11 +126,11 @@ func Test(t *testing.T) {
 	createAccount(t, ctx, accountKeeper, bankKeeper, idxOwner2, sdk.NewCoins(initialStakeIdx2))
 	createAccount(t, ctx, accountKeeper, bankKeeper, idxOwner3, sdk.NewCoins(initialStakeIdx3))
 	//initialize sds node register msg
-	msgRes1 := register.NewMsgCreateResourceNode("sds://resourceNode1", pubKeyRes1, initialStakeRes1, resOwner1, register.NewDescription("sds://resourceNode1", "", "", "", ""), "4")
-	msgRes2 := register.NewMsgCreateResourceNode("sds://resourceNode2", pubKeyRes2, initialStakeRes2, resOwner2, register.NewDescription("sds://resourceNode2", "", "", "", ""), "4")
-	msgRes3 := register.NewMsgCreateResourceNode("sds://resourceNode3", pubKeyRes3, initialStakeRes3, resOwner3, register.NewDescription("sds://resourceNode3", "", "", "", ""), "4")
-	msgRes4 := register.NewMsgCreateResourceNode("sds://resourceNode4", pubKeyRes4, initialStakeRes4, resOwner4, register.NewDescription("sds://resourceNode4", "", "", "", ""), "4")
-	msgRes5 := register.NewMsgCreateResourceNode("sds://resourceNode5", pubKeyRes5, initialStakeRes5, resOwner5, register.NewDescription("sds://resourceNode5", "", "", "", ""), "4")
+	msgRes1 := register.NewMsgCreateResourceNode("sds://resourceNode1", pubKeyRes1, initialStakeRes1, resOwner1, register.NewDescription("sds://resourceNode1", "", "", "", ""), "4", sdk.ZeroInt())
+	msgRes2 := register.NewMsgCreateResourceNode("sds://resourceNode2", pubKeyRes2, initialStakeRes2, resOwner2, register.NewDescription("sds://resourceNode2", "", "", "", ""), "4", sdk.ZeroInt())
+	msgRes3 := register.NewMsgCreateResourceNode("sds://resourceNode3", pubKeyRes3, initialStakeRes3, resOwner3, register.NewDescription("sds://resourceNode3", "", "", "", ""), "4", sdk.ZeroInt())
+	msgRes4 := register.NewMsgCreateResourceNode("sds://resourceNode4", pubKeyRes4, initialStakeRes4, resOwner4, register.NewDescription("sds://resourceNode4", "", "", "", ""), "4", sdk.ZeroInt())
+	msgRes5 := register.NewMsgCreateResourceNode("sds://resourceNode5", pubKeyRes5, initialStakeRes5, resOwner5, register.NewDescription("sds://resourceNode5", "", "", "", ""), "4", sdk.ZeroInt())
 	msgIdx1 := register.NewMsgCreateIndexingNode("sds://indexingNode1", pubKeyIdx1, initialStakeIdx1, idxOwner1, register.NewDescription("sds://indexingNode1", "", "", "", ""))
 	msgIdx2 := register.NewMsgCreateIndexingNode("sds://indexingNode2", pubKeyIdx2, initialStakeIdx2, idxOwner2, register.NewDescription("sds://indexingNode2", "", "", "", ""))
 	msgIdx3 := register.NewMsgCreateIndexingNode("sds://indexingNode3", pubKeyIdx3, initialStakeIdx3, idxOwner3, register.NewDescription("sds://indexingNode3", "", "", "", ""))
@@ -160,8 +164,9 @@ func Test(t *testing.T) {
 
 	// set the status of indexing nodes to bonded
 	idxUnBondedPool := k.RegisterKeeper.GetIndexingNodeNotBondedToken(ctx)
-	k.RegisterKeeper.SetIndexingNodeBondedToken(ctx, idxUnBondedPool)
-	k.RegisterKeeper.SetIndexingNodeNotBondedToken(ctx, sdk.NewCoin(k.BondDenom(ctx), sdk.ZeroInt()))
+	err = k.SupplyKeeper.SendCoinsFromModuleToModule(ctx, register.IndexingNodeNotBondedPoolName, register.IndexingNodeBondedPoolName,
+		sdk.NewCoins(idxUnBondedPool))
+	require.NoError(t, err)
 	idxNode1, _ := k.RegisterKeeper.GetIndexingNode(ctx, addrIdx1)
 	idxNode2, _ := k.RegisterKeeper.GetIndexingNode(ctx, addrIdx2)
 	idxNode3, _ := k.RegisterKeeper.GetIndexingNode(ctx, addrIdx3)
@@ -200,10 +205,308 @@ func Test(t *testing.T) {
 	testMetaNodeRewardFromMiningPool(t, ctx, k, bankKeeper, trafficList)
 	testTrafficRewardFromMiningPool(t, ctx, k, bankKeeper, trafficList)
 
+	testWithheldRewards(t, ctx, k, trafficList)
+
 	testFullDistributeProcessAtEpoch1(t, ctx, k, trafficList)
 	testFullDistributeProcessAtEpoch2017(t, ctx, k, trafficList)
+	testMatureRewardsWithoutNewReward(t, ctx, k)
+	testVolumeReportEpochJump(t, ctx, k, trafficList)
+	testVolumeReportNodesVolume(t, ctx, k, trafficList)
+	testVolumeReportNodeStatus(t, ctx, k, trafficList)
+	testVolumeReportCompetingProposals(t, ctx, k, trafficList)
+	testVolumeReportUsersVolume(t, trafficList)
 	testWithdraw(t, ctx, k, bankKeeper)
+	testWithdrawAll(t, ctx, k, bankKeeper)
+	testWithdrawRemovedNode(t, ctx, k, bankKeeper)
+	testMigrateIndividualRewards(t, ctx, k)
+	testMigrateWithdrawnTotalRewards(t, ctx, k)
+	testMigrateTokenPools(t, ctx, k)
+	testMigrateMaturityQueue(t, ctx, k)
+	testMigrateRewardAddressPool(t, ctx, k)
+	testMigrateRewardOwners(t, ctx, k)
+	testMigrateVolumeReportProposals(t, ctx, k, trafficList)
+
+}
+
+func testWithheldRewards(t *testing.T, ctx sdk.Context, k Keeper, trafficList []types.SingleNodeVolume) {
+	// keep the withholding out of the state checked by the following tests
+	ctx, _ = ctx.CacheContext()
+	_, distributeGoal, err := k.CalcTrafficRewardInTotal(ctx, trafficList, types.InitDistributeGoal())
+	require.NoError(t, err)
 
+	k.SetRewardWithheld(ctx, addrRes1)
+	rewardDetailMap, distributeGoalBalance := k.CalcRewardForResourceNode(ctx, trafficList, distributeGoal, make(map[string]types.Reward))
+	_, found := rewardDetailMap[addrRes1.String()]
+	require.False(t, found)
+	_, found = rewardDetailMap[addrRes2.String()]
+	require.True(t, found)
+	// the share of the withheld node is left to be returned to the pools
+	require.True(t, distributeGoalBalance.TrafficRewardToResourceNodeFromTrafficPool.IsPositive())
+
+	k.RemoveRewardWithheld(ctx, addrRes1)
+	require.False(t, k.IsRewardWithheld(ctx, addrRes1))
+}
+
+func testMatureRewardsWithoutNewReward(t *testing.T, ctx sdk.Context, k Keeper) {
+	// keep the new reward out of the state checked by the following tests
+	ctx, _ = ctx.CacheContext()
+	idleNode := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	lastReportedEpoch := k.GetLastReportedEpoch(ctx)
+	matureEpoch := lastReportedEpoch.AddRaw(1)
+
+	reward := types.NewIndividualReward(sdk.NewInt(100), sdk.NewInt(50))
+	k.addNewRewardAndReCalcTotal(ctx, idleNode, matureEpoch, reward)
+	require.Equal(t, sdk.NewInt(150), k.GetImmatureTotalReward(ctx, idleNode))
+
+	// nothing matures until an epoch reaching the mature epoch is reported
+	require.Equal(t, 0, k.MatureRewards(ctx))
+	require.Equal(t, sdk.ZeroInt(), k.GetMatureTotalReward(ctx, idleNode))
+
+	// the node earns nothing at the next epoch, its reward matures all the same
+	k.SetLastReportedEpoch(ctx, matureEpoch)
+	require.Equal(t, 1, k.MatureRewards(ctx))
+	require.Equal(t, sdk.NewInt(150), k.GetMatureTotalReward(ctx, idleNode))
+	require.Equal(t, sdk.ZeroInt(), k.GetImmatureTotalReward(ctx, idleNode))
+	require.Equal(t, 0, k.MatureRewards(ctx))
+
+	msg, broken := MaturityQueueInvariant(k)(ctx)
+	require.False(t, broken, msg)
+}
+
+func testVolumeReportEpochJump(t *testing.T, ctx sdk.Context, k Keeper, trafficList []types.SingleNodeVolume) {
+	// keep the volume reports out of the state checked by the following tests
+	ctx, _ = ctx.CacheContext()
+	maxEpoch := k.GetLastReportedEpoch(ctx).AddRaw(k.MaxEpochJump(ctx))
+
+	_, _, err := k.SubmitVolumeReport(ctx, trafficList, nil, addrIdx1, idxOwner1, maxEpoch.AddRaw(1), "ref", "hash")
+	require.True(t, types.ErrEpochJumpTooLarge.Is(err))
+
+	_, _, err = k.SubmitVolumeReport(ctx, trafficList, nil, addrIdx1, idxOwner1, maxEpoch, "ref", "hash")
+	require.NoError(t, err)
+
+	// the first report is bounded from the genesis epoch
+	k.SetLastReportedEpoch(ctx, sdk.ZeroInt())
+	firstMaxEpoch := sdk.NewInt(k.MaxEpochJump(ctx))
+	_, _, err = k.SubmitVolumeReport(ctx, trafficList, nil, addrIdx1, idxOwner1, firstMaxEpoch.AddRaw(1), "ref", "hash")
+	require.True(t, types.ErrEpochJumpTooLarge.Is(err))
+	_, _, err = k.SubmitVolumeReport(ctx, trafficList, nil, addrIdx1, idxOwner1, firstMaxEpoch, "ref", "hash")
+	require.NoError(t, err)
+}
+
+func testVolumeReportNodesVolume(t *testing.T, ctx sdk.Context, k Keeper, trafficList []types.SingleNodeVolume) {
+	// keep the volume reports out of the state checked by the following tests
+	ctx, _ = ctx.CacheContext()
+	epoch := k.GetLastReportedEpoch(ctx).AddRaw(1)
+
+	resNode3, _ := k.RegisterKeeper.GetResourceNode(ctx, addrRes3)
+	resNode3.Capacity = sdk.NewInt(resourceNodeVolume3 - 1)
+	k.RegisterKeeper.SetResourceNode(ctx, resNode3)
+	resNode4, _ := k.RegisterKeeper.GetResourceNode(ctx, addrRes4)
+	resNode4.Suspend = true
+	k.RegisterKeeper.SetResourceNode(ctx, resNode4)
+
+	unknownNode := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	nodesVolume := append([]types.SingleNodeVolume{}, trafficList...)
+	nodesVolume = append(nodesVolume,
+		types.NewSingleNodeVolume(unknownNode, sdk.NewInt(1)),
+		types.NewSingleNodeVolume(addrRes1, sdk.NewInt(1)),
+		types.NewSingleNodeVolume(addrRes4, sdk.ZeroInt()),
+	)
+
+	// the stateless checks reject the duplicate and the zero volume
+	msg := types.NewMsgVolumeReport(nodesVolume, addrIdx1, epoch, "ref", idxOwner1, nil)
+	err := msg.ValidateBasic()
+	require.True(t, types.ErrInvalidNodesVolume.Is(err))
+	require.True(t, types.ErrDuplicateNodeAddress.Is(err))
+	require.True(t, types.ErrVolumeNotPositive.Is(err))
+
+	// the report lists every rejected entry in order
+	_, _, err = k.SubmitVolumeReport(ctx, nodesVolume, nil, addrIdx1, idxOwner1, epoch, "ref", "hash")
+	var nodeErrs types.NodeVolumeErrors
+	require.True(t, errors.As(err, &nodeErrs))
+	require.Len(t, nodeErrs, 5)
+	require.Equal(t, 2, nodeErrs[0].Index)
+	require.True(t, types.ErrVolumeExceedsCapacity.Is(nodeErrs[0].Err))
+	require.Equal(t, 3, nodeErrs[1].Index)
+	require.True(t, types.ErrNotValidResourceNode.Is(nodeErrs[1].Err))
+	require.Equal(t, 4, nodeErrs[2].Index)
+	require.True(t, types.ErrDuplicateNodeAddress.Is(nodeErrs[2].Err))
+	require.Equal(t, 5, nodeErrs[3].Index)
+	require.True(t, types.ErrVolumeNotPositive.Is(nodeErrs[3].Err))
+	require.Equal(t, 5, nodeErrs[4].Index)
+	require.True(t, types.ErrNotValidResourceNode.Is(nodeErrs[4].Err))
+	_, found := k.GetVolumeReportProposal(ctx, epoch, "ref")
+	require.False(t, found)
+
+	// a volume up to the capacity is accepted
+	resNode3.Capacity = sdk.NewInt(resourceNodeVolume3)
+	k.RegisterKeeper.SetResourceNode(ctx, resNode3)
+	status, _, err := k.SubmitVolumeReport(ctx, trafficList, nil, addrIdx1, idxOwner1, epoch, "ref", "hash")
+	require.NoError(t, err)
+	require.Equal(t, types.VolumeReportProposalPending, status)
+
+	// the entries are checked again when the report settles
+	_, _, err = k.VoteVolumeReport(ctx, epoch, "ref", true, addrIdx2, idxOwner2)
+	require.NoError(t, err)
+	resNode1, _ := k.RegisterKeeper.GetResourceNode(ctx, addrRes1)
+	resNode1.Suspend = true
+	k.RegisterKeeper.SetResourceNode(ctx, resNode1)
+	_, _, err = k.VoteVolumeReport(ctx, epoch, "ref", true, addrIdx3, idxOwner3)
+	require.True(t, types.ErrNotValidResourceNode.Is(err))
+}
+
+func testVolumeReportNodeStatus(t *testing.T, ctx sdk.Context, k Keeper, trafficList []types.SingleNodeVolume) {
+	// keep the volume reports out of the state checked by the following tests
+	ctx, _ = ctx.CacheContext()
+	epoch := k.GetLastReportedEpoch(ctx).AddRaw(1)
+
+	// the reporter must be a registered indexing node owned by the reporter owner
+	unknownNode := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	_, _, err := k.SubmitVolumeReport(ctx, trafficList, nil, unknownNode, idxOwner1, epoch, "ref", "hash")
+	require.Equal(t, types.ErrNotValidIndexingNode, err)
+	_, _, err = k.SubmitVolumeReport(ctx, trafficList, nil, addrIdx1, idxOwner2, epoch, "ref", "hash")
+	require.Equal(t, types.ErrNotTheOwner, err)
+
+	// a suspended or unbonded indexing node can neither report nor vote
+	idxNode1, _ := k.RegisterKeeper.GetIndexingNode(ctx, addrIdx1)
+	suspended := idxNode1
+	suspended.Suspend = true
+	k.RegisterKeeper.SetIndexingNode(ctx, suspended)
+	_, _, err = k.SubmitVolumeReport(ctx, trafficList, nil, addrIdx1, idxOwner1, epoch, "ref", "hash")
+	require.Equal(t, types.ErrNotValidIndexingNode, err)
+	unbonded := idxNode1
+	unbonded.Status = sdk.Unbonded
+	k.RegisterKeeper.SetIndexingNode(ctx, unbonded)
+	_, _, err = k.SubmitVolumeReport(ctx, trafficList, nil, addrIdx1, idxOwner1, epoch, "ref", "hash")
+	require.Equal(t, types.ErrNotValidIndexingNode, err)
+
+	_, _, err = k.SubmitVolumeReport(ctx, trafficList, nil, addrIdx2, idxOwner2, epoch, "ref", "hash")
+	require.NoError(t, err)
+	_, _, err = k.VoteVolumeReport(ctx, epoch, "ref", true, addrIdx1, idxOwner1)
+	require.Equal(t, types.ErrNotValidIndexingNode, err)
+	k.RegisterKeeper.SetIndexingNode(ctx, idxNode1)
+
+	// every listed resource node must be bonded
+	resNode1, _ := k.RegisterKeeper.GetResourceNode(ctx, addrRes1)
+	resNode1.Status = sdk.Unbonding
+	k.RegisterKeeper.SetResourceNode(ctx, resNode1)
+	_, _, err = k.SubmitVolumeReport(ctx, trafficList, nil, addrIdx1, idxOwner1, epoch.AddRaw(1), "ref", "hash")
+	var nodeErrs types.NodeVolumeErrors
+	require.True(t, errors.As(err, &nodeErrs))
+	require.Len(t, nodeErrs, 1)
+	require.True(t, nodeErrs[0].NodeAddress.Equals(addrRes1))
+	require.True(t, types.ErrNotValidResourceNode.Is(nodeErrs[0].Err))
+}
+
+func testVolumeReportCompetingProposals(t *testing.T, ctx sdk.Context, k Keeper, trafficList []types.SingleNodeVolume) {
+	// keep the volume reports out of the state checked by the following tests
+	ctx, _ = ctx.CacheContext()
+	epoch := k.GetLastReportedEpoch(ctx).AddRaw(1)
+	params := k.GetParams(ctx)
+	params.VolumeReportVotingPeriod = 10 * time.Minute
+	k.SetParams(ctx, params)
+
+	status, _, err := k.SubmitVolumeReport(ctx, trafficList, nil, addrIdx1, idxOwner1, epoch, "refA", "hash")
+	require.NoError(t, err)
+	require.Equal(t, types.VolumeReportProposalPending, status)
+	proposalA, found := k.GetVolumeReportProposal(ctx, epoch, "refA")
+	require.True(t, found)
+	require.Equal(t, ctx.BlockHeader().Time.Add(10*time.Minute), proposalA.ExpireTime)
+
+	// a reference is reported once per epoch, and a reporter approves a single report of the epoch
+	_, _, err = k.SubmitVolumeReport(ctx, trafficList, nil, addrIdx2, idxOwner2, epoch, "refA", "hash")
+	require.Equal(t, types.ErrVolumeReportProposalExists, err)
+	_, _, err = k.SubmitVolumeReport(ctx, trafficList[:2], nil, addrIdx1, idxOwner1, epoch, "refB", "hash")
+	require.Equal(t, types.ErrVolumeReportAlreadyApproved, err)
+
+	// a competing report of the same epoch is tallied on its own
+	status, _, err = k.SubmitVolumeReport(ctx, trafficList[:2], nil, addrIdx2, idxOwner2, epoch, "refB", "hash")
+	require.NoError(t, err)
+	require.Equal(t, types.VolumeReportProposalPending, status)
+	_, _, err = k.VoteVolumeReport(ctx, epoch, "refC", true, addrIdx3, idxOwner3)
+	require.Equal(t, types.ErrReportReferenceMismatch, err)
+	_, _, err = k.VoteVolumeReport(ctx, epoch.AddRaw(1), "refA", true, addrIdx3, idxOwner3)
+	require.Equal(t, types.ErrNoVolumeReportProposalFound, err)
+	_, _, err = k.VoteVolumeReport(ctx, epoch, "refA", true, addrIdx2, idxOwner2)
+	require.Equal(t, types.ErrVolumeReportAlreadyApproved, err)
+
+	status, _, err = k.VoteVolumeReport(ctx, epoch, "refB", true, addrIdx3, idxOwner3)
+	require.NoError(t, err)
+	require.Equal(t, types.VolumeReportProposalPending, status)
+	status, _, err = k.VoteVolumeReport(ctx, epoch, "refB", false, addrIdx1, idxOwner1)
+	require.NoError(t, err)
+	require.Equal(t, types.VolumeReportProposalRejected, status)
+	_, found = k.GetVolumeReportProposal(ctx, epoch, "refB")
+	require.False(t, found)
+
+	// the approvals of the rejected report no longer bind its voters
+	status, _, err = k.VoteVolumeReport(ctx, epoch, "refA", true, addrIdx2, idxOwner2)
+	require.NoError(t, err)
+	require.Equal(t, types.VolumeReportProposalPending, status)
+
+	// a late report of the epoch competes with the pending one, and is dropped once the epoch is settled
+	_, _, err = k.SubmitVolumeReport(ctx, trafficList[:1], nil, addrIdx3, idxOwner3, epoch, "refD", "hash")
+	require.NoError(t, err)
+	status, _, err = k.VoteVolumeReport(ctx, epoch, "refD", false, addrIdx1, idxOwner1)
+	require.NoError(t, err)
+	require.Equal(t, types.VolumeReportProposalRejected, status)
+
+	status, _, err = k.VoteVolumeReport(ctx, epoch, "refA", true, addrIdx3, idxOwner3)
+	require.NoError(t, err)
+	require.Equal(t, types.VolumeReportProposalAccepted, status)
+	require.Equal(t, epoch, k.GetLastReportedEpoch(ctx))
+	require.Equal(t, "refA", k.GetVolumeReport(ctx, epoch).ReportReference)
+	k.IterateEpochVolumeReportProposals(ctx, epoch, func(proposal types.VolumeReportProposal) (stop bool) {
+		t.Fatalf("unexpected pending volume report %s", proposal.ReportReference)
+		return true
+	})
+
+	// the reports expire after the voting period of the params
+	nextEpoch := epoch.AddRaw(1)
+	_, _, err = k.SubmitVolumeReport(ctx, trafficList, nil, addrIdx1, idxOwner1, nextEpoch, "refA", "hash")
+	require.NoError(t, err)
+	ctx = ctx.WithBlockTime(ctx.BlockHeader().Time.Add(10*time.Minute + time.Second))
+	_, _, err = k.VoteVolumeReport(ctx, nextEpoch, "refA", true, addrIdx2, idxOwner2)
+	require.Equal(t, types.ErrVolumeReportVoteExpired, err)
+	require.False(t, k.HasApprovedVolumeReport(ctx, nextEpoch, addrIdx1))
+	_, _, err = k.SubmitVolumeReport(ctx, trafficList, nil, addrIdx1, idxOwner1, nextEpoch, "refB", "hash")
+	require.NoError(t, err)
+}
+
+func testVolumeReportUsersVolume(t *testing.T, trafficList []types.SingleNodeVolume) {
+	epoch := sdk.OneInt()
+	totalVolume := sdk.ZeroInt()
+	for _, nodeVolume := range trafficList {
+		totalVolume = totalVolume.Add(nodeVolume.Volume)
+	}
+	half := totalVolume.QuoRaw(2)
+
+	usersVolume := []types.SingleUserVolume{
+		types.NewSingleUserVolume(resOwner1, half),
+		types.NewSingleUserVolume(resOwner2, totalVolume.Sub(half)),
+	}
+	require.NoError(t, types.NewMsgVolumeReport(trafficList, addrIdx1, epoch, "ref", idxOwner1, usersVolume).ValidateBasic())
+
+	// the users must pay for the whole traffic of the nodes
+	err := types.NewMsgVolumeReport(trafficList, addrIdx1, epoch, "ref", idxOwner1, usersVolume[:1]).ValidateBasic()
+	require.True(t, types.ErrVolumeSumMismatch.Is(err))
+	err = types.NewMsgVolumeReport(trafficList, addrIdx1, epoch, "ref", idxOwner1, nil).ValidateBasic()
+	require.True(t, types.ErrVolumeSumMismatch.Is(err))
+
+	// a user is listed once
+	duplicated := []types.SingleUserVolume{
+		types.NewSingleUserVolume(resOwner1, half),
+		types.NewSingleUserVolume(resOwner1, totalVolume.Sub(half)),
+	}
+	err = types.NewMsgVolumeReport(trafficList, addrIdx1, epoch, "ref", idxOwner1, duplicated).ValidateBasic()
+	require.True(t, types.ErrDuplicateUserAddress.Is(err))
+
+	// the epoch must fit in the 64 bits of the store keys
+	tooLarge := sdk.NewIntFromUint64(math.MaxUint64).AddRaw(1)
+	err = types.NewMsgVolumeReport(trafficList, addrIdx1, tooLarge, "ref", idxOwner1, usersVolume).ValidateBasic()
+	require.True(t, types.ErrEpochTooLarge.Is(err))
+	err = types.NewMsgVolumeReportVote(tooLarge, "ref", true, addrIdx1, idxOwner1).ValidateBasic()
+	require.True(t, types.ErrEpochTooLarge.Is(err))
 }
 
 func testWithdraw(t *testing.T, ctx sdk.Context, k Keeper, bankKeeper bank.Keeper) {
@@ -212,6 +515,12 @@ func testWithdraw(t *testing.T, ctx sdk.Context, k Keeper, bankKeeper bank.Keepe
 	err := k.Withdraw(ctx, sdk.NewCoin("ustos", sdk.NewInt(139380831257)), addrRes1, resOwner2)
 	require.Error(t, err, types.ErrNotTheOwner)
 
+	// the network key of a node is not its owner
+	err = k.Withdraw(ctx, sdk.NewCoin("ustos", sdk.NewInt(139380831257)), addrRes1, addrRes1)
+	require.True(t, types.ErrNotTheOwner.Is(err))
+	err = k.UpdateWithdrawAddress(ctx, addrRes1, addrRes1, addrRes1)
+	require.True(t, types.ErrNotTheOwner.Is(err))
+
 	err = k.Withdraw(ctx, sdk.NewCoin("ustos", sdk.NewInt(139380831258)), addrRes1, resOwner1)
 	require.Error(t, err, types.ErrInsufficientMatureTotal)
 
@@ -223,11 +532,89 @@ func testWithdraw(t *testing.T, ctx sdk.Context, k Keeper, bankKeeper bank.Keepe
 
 	matureTotalResNode1 := k.GetMatureTotalReward(ctx, addrRes1)
 	require.Equal(t, matureTotalResNode1, sdk.ZeroInt())
+	require.Equal(t, sdk.NewInt(139380831257), k.GetWithdrawnTotalReward(ctx, addrRes1))
+
+	msg, broken := AllInvariants(k)(ctx)
+	require.False(t, broken, msg)
+}
+
+func testWithdrawAll(t *testing.T, ctx sdk.Context, k Keeper, bankKeeper bank.Keeper) {
+	// keep the withdrawals out of the state checked by the following tests
+	ctx, _ = ctx.CacheContext()
+	coldWallet := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+
+	err := k.UpdateWithdrawAddress(ctx, addrRes2, resOwner1, coldWallet)
+	require.Equal(t, types.ErrNotTheOwner, err)
+	err = k.UpdateWithdrawAddress(ctx, addrRes2, resOwner2, coldWallet)
+	require.NoError(t, err)
+	require.Equal(t, coldWallet, k.GetWithdrawTarget(ctx, addrRes2, resOwner2))
+
+	_, err = k.WithdrawAll(ctx, []sdk.AccAddress{addrRes2, addrRes3}, resOwner2)
+	require.Equal(t, types.ErrNotTheOwner, err)
+
+	ownerBalanceBefore := bankKeeper.GetCoins(ctx, resOwner2)
+	total, err := k.WithdrawAll(ctx, []sdk.AccAddress{addrRes2}, resOwner2)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt(86000938434), total.Amount)
+	require.Equal(t, sdk.NewInt(86000938434), bankKeeper.GetCoins(ctx, coldWallet).AmountOf("ustos"))
+	require.Equal(t, ownerBalanceBefore, bankKeeper.GetCoins(ctx, resOwner2))
+	require.Equal(t, sdk.ZeroInt(), k.GetMatureTotalReward(ctx, addrRes2))
+	require.Equal(t, sdk.NewInt(86000938434), k.GetWithdrawnTotalReward(ctx, addrRes2))
+
+	_, err = k.WithdrawAll(ctx, []sdk.AccAddress{addrRes2}, resOwner2)
+	require.Equal(t, types.ErrNoMatureReward, err)
+
+	// setting the owner address back credits the owner again
+	err = k.UpdateWithdrawAddress(ctx, addrRes2, resOwner2, resOwner2)
+	require.NoError(t, err)
+	_, found := k.GetWithdrawAddress(ctx, addrRes2)
+	require.False(t, found)
+
+	msg, broken := AllInvariants(k)(ctx)
+	require.False(t, broken, msg)
+}
+
+func testWithdrawRemovedNode(t *testing.T, ctx sdk.Context, k Keeper, bankKeeper bank.Keeper) {
+	// keep the removal of the node out of the state checked by the following tests
+	ctx, _ = ctx.CacheContext()
+	owner, found := k.GetRewardOwner(ctx, addrRes2)
+	require.True(t, found)
+	require.Equal(t, resOwner2, owner)
+
+	// unbond the whole stake of the node and remove it once the unbonding is mature
+	node, found := k.RegisterKeeper.GetResourceNode(ctx, addrRes2)
+	require.True(t, found)
+	_, matureTime, err := k.RegisterKeeper.UnbondResourceNode(ctx, node, node.Tokens)
+	require.NoError(t, err)
+	ctx = ctx.WithBlockTime(matureTime)
+	k.RegisterKeeper.BlockRegisteredNodesUpdates(ctx)
+	_, found = k.RegisterKeeper.GetResourceNode(ctx, addrRes2)
+	require.False(t, found)
+
+	// the network key of the removed node is still not its owner
+	amount := sdk.NewCoin("ustos", k.GetMatureTotalReward(ctx, addrRes2))
+	require.True(t, amount.IsPositive())
+	err = k.Withdraw(ctx, amount, addrRes2, addrRes2)
+	require.True(t, types.ErrNotTheOwner.Is(err))
+	err = k.Withdraw(ctx, amount, addrRes2, resOwner1)
+	require.True(t, types.ErrNotTheOwner.Is(err))
+
+	ownerBalanceBefore := bankKeeper.GetCoins(ctx, resOwner2)
+	err = k.Withdraw(ctx, amount, addrRes2, resOwner2)
+	require.NoError(t, err)
+	require.Equal(t, amount.Amount, bankKeeper.GetCoins(ctx, resOwner2).Sub(ownerBalanceBefore).AmountOf("ustos"))
+
+	// the rewards of an address without a recorded owner cannot be withdrawn
+	unknownNode := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	err = k.UpdateWithdrawAddress(ctx, unknownNode, unknownNode, unknownNode)
+	require.True(t, types.ErrNotTheOwner.Is(err))
 }
 
 func testFullDistributeProcessAtEpoch2017(t *testing.T, ctx sdk.Context, k Keeper, trafficList []types.SingleNodeVolume) {
 	_, err := k.DistributePotReward(ctx, trafficList, epoch2017)
 	require.NoError(t, err)
+	// the rewards distributed at epoch1 mature at the end of the block
+	require.Equal(t, len(k.GetRewardAddressPool(ctx)), k.MatureRewards(ctx))
 	fmt.Println("Distribution result at Epoch2017: ")
 	rewardAddrList := k.GetRewardAddressPool(ctx)
 	fmt.Println("address pool: ")
@@ -304,7 +691,7 @@ func testFullDistributeProcessAtEpoch2017(t *testing.T, ctx sdk.Context, k Keepe
 
 func testFullDistributeProcessAtEpoch1(t *testing.T, ctx sdk.Context, k Keeper, trafficList []types.SingleNodeVolume) {
 	//PrePay
-	k.SetTotalUnissuedPrepay(ctx, totalUnissuedPrePay)
+	setTotalUnissuedPrepay(t, ctx, k, totalUnissuedPrePay)
 
 	_, err := k.DistributePotReward(ctx, trafficList, epoch1)
 	require.NoError(t, err)
@@ -419,6 +806,9 @@ func testBlockChainRewardFromTrafficPool(t *testing.T, ctx sdk.Context, k Keeper
 	//6, distribute skate reward to fee pool for validators
 	distributeGoal, err = k.distributeValidatorRewardToFeePool(ctx, distributeGoal)
 	require.NoError(t, err)
+	//8, return balance to traffic pool & mining pool
+	err = k.returnBalance(ctx, distributeGoal, epoch1)
+	require.NoError(t, err)
 
 	feePoolAfter := getFeePoolBalance(t, ctx, k, bankKeeper)
 
@@ -482,6 +872,9 @@ func testMetaNodeRewardFromTrafficPool(t *testing.T, ctx sdk.Context, k Keeper,
 	//6, distribute skate reward to fee pool for validators
 	distributeGoal, err = k.distributeValidatorRewardToFeePool(ctx, distributeGoal)
 	require.NoError(t, err)
+	//8, return balance to traffic pool & mining pool
+	err = k.returnBalance(ctx, distributeGoal, epoch1)
+	require.NoError(t, err)
 
 	feePoolAfter := getFeePoolBalance(t, ctx, k, bankKeeper)
 
@@ -552,6 +945,9 @@ func testTrafficRewardFromTrafficPool(t *testing.T, ctx sdk.Context, k Keeper, b
 	distributeGoal, err = k.distributeValidatorRewardToFeePool(ctx, distributeGoal)
 	require.NoError(t, err)
 	feePoolAfter := getFeePoolBalance(t, ctx, k, bankKeeper)
+	//8, return balance to traffic pool & mining pool
+	err = k.returnBalance(ctx, distributeGoal, epoch1)
+	require.NoError(t, err)
 
 	require.Equal(t, feePoolBefore, feePoolAfter)
 	require.Equal(t, exceptedResNode1Rwd, rewardDetailMap[addrRes1.String()].RewardFromTrafficPool)
@@ -618,6 +1014,9 @@ func testBlockChainRewardFromMiningPool(t *testing.T, ctx sdk.Context, k Keeper,
 	//6, distribute skate reward to fee pool for validators
 	distributeGoal, err = k.distributeValidatorRewardToFeePool(ctx, distributeGoal)
 	require.NoError(t, err)
+	//8, return balance to traffic pool & mining pool
+	err = k.returnBalance(ctx, distributeGoal, epoch1)
+	require.NoError(t, err)
 
 	feePoolAfter := getFeePoolBalance(t, ctx, k, bankKeeper)
 
@@ -681,6 +1080,9 @@ func testMetaNodeRewardFromMiningPool(t *testing.T, ctx sdk.Context, k Keeper, b
 	//6, distribute skate reward to fee pool for validators
 	distributeGoal, err = k.distributeValidatorRewardToFeePool(ctx, distributeGoal)
 	require.NoError(t, err)
+	//8, return balance to traffic pool & mining pool
+	err = k.returnBalance(ctx, distributeGoal, epoch1)
+	require.NoError(t, err)
 
 	feePoolAfter := getFeePoolBalance(t, ctx, k, bankKeeper)
 
@@ -751,6 +1153,9 @@ func testTrafficRewardFromMiningPool(t *testing.T, ctx sdk.Context, k Keeper, ba
 	distributeGoal, err = k.distributeValidatorRewardToFeePool(ctx, distributeGoal)
 	require.NoError(t, err)
 	feePoolAfter := getFeePoolBalance(t, ctx, k, bankKeeper)
+	//8, return balance to traffic pool & mining pool
+	err = k.returnBalance(ctx, distributeGoal, epoch1)
+	require.NoError(t, err)
 
 	require.Equal(t, feePoolBefore, feePoolAfter)
 	require.Equal(t, exceptedResNode1Rwd, rewardDetailMap[addrRes1.String()].RewardFromMiningPool)
@@ -784,9 +1189,42 @@ func createAccount(t *testing.T, ctx sdk.Context, accountKeeper auth.AccountKeep
 	require.NoError(t, err)
 }
 
+// setTotalUnissuedPrepay sets the unissued prepay along with the balance of the pool holding it
+func setTotalUnissuedPrepay(t *testing.T, ctx sdk.Context, k Keeper, amount sdk.Int) {
+	k.SetTotalUnissuedPrepay(ctx, amount)
+	pool := k.GetTokenPool(ctx, types.TotalUnissuedPrepayPoolName)
+	require.NoError(t, pool.SetCoins(sdk.NewCoins(sdk.NewCoin(k.BondDenom(ctx), amount))))
+	k.SetTokenPool(ctx, pool)
+}
+
 func getFeePoolBalance(t *testing.T, ctx sdk.Context, k Keeper, bankKeeper bank.Keeper) sdk.Coins {
 	feePoolAccAddr := k.SupplyKeeper.GetModuleAddress(k.feeCollectorName)
 	require.NotNil(t, feePoolAccAddr)
 	coins := bankKeeper.GetCoins(ctx, feePoolAccAddr)
 	return coins
 }
+
+// BenchmarkDistributeRewardToSdsNodes reports the gas of recording the rewards of 10k nodes that are already
+// in the reward address pool, as every volume report of a network of that size does.
+func BenchmarkDistributeRewardToSdsNodes(b *testing.B) {
+	const numNodes = 10000
+	ctx, _, _, k, _, _, _, _ := CreateTestInput(b, false)
+
+	rewards := make([]types.Reward, numNodes)
+	for i := range rewards {
+		nodeAddr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+		rewards[i] = types.NewReward(nodeAddr, sdk.NewInt(int64(i+1)), sdk.NewInt(int64(i+1)))
+	}
+	require.NoError(b, k.distributeRewardToSdsNodes(ctx, rewards, epoch1))
+	require.Len(b, k.GetRewardAddressPool(ctx), numNodes)
+
+	var gasUsed uint64
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cacheCtx, _ := ctx.WithGasMeter(sdk.NewInfiniteGasMeter()).CacheContext()
+		require.NoError(b, k.distributeRewardToSdsNodes(cacheCtx, rewards, epoch2017))
+		gasUsed = cacheCtx.GasMeter().GasConsumed()
+	}
+	b.ReportMetric(float64(gasUsed), "gas/op")
+	b.ReportMetric(float64(gasUsed)/numNodes, "gas/node")
+}
@@ -0,0 +1,144 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stratosnet/stratos-chain/x/pot/types"
+)
+
+// RegisterInvariants registers all pot invariants
+func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
+	ir.RegisterRoute(types.ModuleName, "reward-totals", RewardTotalsInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "token-pools", TokenPoolsInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "maturity-queue", MaturityQueueInvariant(k))
+}
+
+// AllInvariants runs all invariants of the pot module.
+func AllInvariants(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		res, stop := RewardTotalsInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+		res, stop = TokenPoolsInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+		return MaturityQueueInvariant(k)(ctx)
+	}
+}
+
+// RewardTotalsInvariant checks that the mature, immature and withdrawn reward totals
+// of every node add up to the sum of its individual rewards
+func RewardTotalsInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		individual := make(map[string]sdk.Int)
+		totals := make(map[string]sdk.Int)
+		var nodes []string // in store order, to keep the message deterministic
+		addTo := func(sums map[string]sdk.Int, acc sdk.AccAddress, amount sdk.Int) {
+			_, inIndividual := individual[acc.String()]
+			_, inTotals := totals[acc.String()]
+			if !inIndividual && !inTotals {
+				nodes = append(nodes, acc.String())
+			}
+			if sum, ok := sums[acc.String()]; ok {
+				amount = amount.Add(sum)
+			}
+			sums[acc.String()] = amount
+		}
+
+		k.IterateAllIndividualRewards(ctx, func(acc sdk.AccAddress, _ sdk.Int, reward types.IndividualReward) (stop bool) {
+			addTo(individual, acc, reward.Total())
+			return false
+		})
+		sumTotals := func(acc sdk.AccAddress, total sdk.Int) (stop bool) {
+			addTo(totals, acc, total)
+			return false
+		}
+		k.IterateMatureTotalRewards(ctx, sumTotals)
+		k.IterateImmatureTotalRewards(ctx, sumTotals)
+		k.IterateWithdrawnTotalRewards(ctx, sumTotals)
+
+		var msg string
+		broken := false
+		for _, acc := range nodes {
+			sum, ok := individual[acc]
+			if !ok {
+				sum = sdk.ZeroInt()
+			}
+			total, ok := totals[acc]
+			if !ok {
+				total = sdk.ZeroInt()
+			}
+			if !total.Equal(sum) {
+				broken = true
+				msg += fmt.Sprintf("\tnode %s has individual rewards %v, reward totals %v\n", acc, sum, total)
+			}
+		}
+
+		return sdk.FormatInvariant(types.ModuleName, "reward totals", msg), broken
+	}
+}
+
+// TokenPoolsInvariant checks that the total reward pool holds the rewards not withdrawn yet
+// and the total unissued prepay pool holds the unissued prepay
+func TokenPoolsInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		unwithdrawn := k.SumUnwithdrawnRewards(ctx)
+		rewardPool := k.GetTotalRewardPoolToken(ctx).Amount
+		unissuedPrepay := k.GetTotalUnissuedPrepay(ctx)
+		unissuedPrepayPool := k.GetTotalUnissuedPrepayPoolToken(ctx).Amount
+
+		broken := !rewardPool.Equal(unwithdrawn) || !unissuedPrepayPool.Equal(unissuedPrepay)
+
+		return sdk.FormatInvariant(types.ModuleName, "token pools", fmt.Sprintf(
+			"\ttotal reward pool balance: %v\n"+
+				"\tsum of mature and immature reward totals: %v\n"+
+				"\ttotal unissued prepay pool balance: %v\n"+
+				"\ttotal unissued prepay: %v\n",
+			rewardPool, unwithdrawn, unissuedPrepayPool, unissuedPrepay)), broken
+	}
+}
+
+// MaturityQueueInvariant checks that the immature reward total of every node is the sum of its individual rewards
+// waiting in the maturity queue
+func MaturityQueueInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		queued := make(map[string]sdk.Int)
+		k.IterateAllMaturityQueue(ctx, func(epoch sdk.Int, acc sdk.AccAddress) (stop bool) {
+			sum, ok := queued[acc.String()]
+			if !ok {
+				sum = sdk.ZeroInt()
+			}
+			queued[acc.String()] = sum.Add(k.GetIndividualReward(ctx, acc, epoch))
+			return false
+		})
+
+		var msg string
+		broken := false
+		checked := make(map[string]bool)
+		k.IterateImmatureTotalRewards(ctx, func(acc sdk.AccAddress, total sdk.Int) (stop bool) {
+			checked[acc.String()] = true
+			sum, ok := queued[acc.String()]
+			if !ok {
+				sum = sdk.ZeroInt()
+			}
+			if !total.Equal(sum) {
+				broken = true
+				msg += fmt.Sprintf("\tnode %s has immature total %v, queued rewards %v\n", acc, total, sum)
+			}
+			return false
+		})
+		k.IterateAllMaturityQueue(ctx, func(epoch sdk.Int, acc sdk.AccAddress) (stop bool) {
+			if !checked[acc.String()] {
+				broken = true
+				msg += fmt.Sprintf("\tnode %s has a reward queued at epoch %v but no immature total\n", acc, epoch)
+				checked[acc.String()] = true
+			}
+			return false
+		})
+
+		return sdk.FormatInvariant(types.ModuleName, "maturity queue", msg), broken
+	}
+}
@@ -26,6 +26,7 @@ type Keeper struct {
 	AccountKeeper    auth.AccountKeeper
 	StakingKeeper    staking.Keeper
 	RegisterKeeper   register.Keeper
+	hooks            types.PotHooks
 }
 
 // NewKeeper creates a pot keeper
@@ -47,6 +48,15 @@ func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, paramSpace params.Subspace, f
 	return keeper
 }
 
+// Set the pot hooks
+func (k *Keeper) SetHooks(ph types.PotHooks) *Keeper {
+	if k.hooks != nil {
+		panic("cannot set pot hooks twice")
+	}
+	k.hooks = ph
+	return k
+}
+
 // Logger returns a module-specific logger.
 func (k Keeper) Logger(ctx sdk.Context) log.Logger {
 	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
@@ -74,6 +84,24 @@ func (k Keeper) DeleteVolumeReport(ctx sdk.Context, key []byte) {
 	store.Delete(key)
 }
 
+// IterateVolumeReports iterates over the volume reports settled for each epoch
+func (k Keeper) IterateVolumeReports(ctx sdk.Context, handler func(epoch sdk.Int, reportRecord types.VolumeReportRecord) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.VolumeReportStoreKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		epoch, ok := sdk.NewIntFromString(string(iter.Key()[len(types.VolumeReportStoreKeyPrefix):]))
+		if !ok {
+			panic(fmt.Sprintf("invalid epoch in volume report key %X", iter.Key()))
+		}
+		var reportRecord types.VolumeReportRecord
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &reportRecord)
+		if handler(epoch, reportRecord) {
+			break
+		}
+	}
+}
+
 func (k Keeper) IsSPNode(ctx sdk.Context, addr sdk.AccAddress) (found bool) {
 	_, found = k.RegisterKeeper.GetIndexingNode(ctx, addr)
 	return found
@@ -87,17 +115,5 @@ func (k Keeper) getNodeOwnerMap(ctx sdk.Context) map[string]sdk.AccAddress {
 }
 
 func (k Keeper) FoundationDeposit(ctx sdk.Context, amount sdk.Coin, from sdk.AccAddress) (err error) {
-	_, err = k.BankKeeper.SubtractCoins(ctx, from, sdk.NewCoins(amount))
-	if err != nil {
-		return err
-	}
-
-	foundationAccountAddr := k.SupplyKeeper.GetModuleAddress(types.FoundationAccount)
-	_, err = k.BankKeeper.AddCoins(ctx, foundationAccountAddr, sdk.NewCoins(amount))
-	if err != nil {
-		return err
-	}
-
-	return nil
-
+	return k.SupplyKeeper.SendCoinsFromAccountToModule(ctx, from, types.FoundationAccount, sdk.NewCoins(amount))
 }
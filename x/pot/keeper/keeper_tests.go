@@ -17,6 +17,7 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
 	dbm "github.com/tendermint/tm-db"
+	"sync"
 	"testing"
 )
 
@@ -33,7 +34,7 @@ var (
 	SdsNodeP2PKeyPrefix    = StratosBech32Prefix + "sdsp2p"
 )
 
-func CreateTestInput(t *testing.T, isCheckTx bool) (
+func CreateTestInput(t testing.TB, isCheckTx bool) (
 	sdk.Context, auth.AccountKeeper, bank.Keeper, Keeper, staking.Keeper, params.Keeper, supply.Keeper, register.Keeper) {
 
 	SetConfig()
@@ -77,16 +78,22 @@ func CreateTestInput(t *testing.T, isCheckTx bool) (
 	accountKeeper := auth.NewAccountKeeper(cdc, keyAcc, pk.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
 	bankKeeper := bank.NewBaseKeeper(accountKeeper, pk.Subspace(bank.DefaultParamspace), blacklistedAddrs)
 	maccPerms := map[string][]string{
-		auth.FeeCollectorName:     nil,
-		staking.NotBondedPoolName: {supply.Burner, supply.Staking},
-		staking.BondedPoolName:    {supply.Burner, supply.Staking},
-		types.FoundationAccount:   nil,
+		auth.FeeCollectorName:                  nil,
+		staking.NotBondedPoolName:              {supply.Burner, supply.Staking},
+		staking.BondedPoolName:                 {supply.Burner, supply.Staking},
+		types.FoundationAccount:                nil,
+		types.TotalRewardPoolName:              nil,
+		types.TotalUnissuedPrepayPoolName:      nil,
+		register.ResourceNodeBondedPoolName:    nil,
+		register.ResourceNodeNotBondedPoolName: {supply.Burner},
+		register.IndexingNodeBondedPoolName:    nil,
+		register.IndexingNodeNotBondedPoolName: {supply.Burner},
 	}
 	supplyKeeper := supply.NewKeeper(cdc, keySupply, accountKeeper, bankKeeper, maccPerms)
 	stakingKeeper := staking.NewKeeper(cdc, keyStaking, supplyKeeper, pk.Subspace(staking.DefaultParamspace))
 	StakingParam := staking.NewParams(staking.DefaultUnbondingTime, staking.DefaultMaxValidators, staking.DefaultMaxEntries, 0, "ustos")
 	stakingKeeper.SetParams(ctx, StakingParam)
-	registerKeeper := register.NewKeeper(cdc, keyRegister, pk.Subspace(register.DefaultParamSpace), accountKeeper, bankKeeper)
+	registerKeeper := register.NewKeeper(cdc, keyRegister, pk.Subspace(register.DefaultParamSpace), accountKeeper, bankKeeper, supplyKeeper)
 	registerKeeper.SetParams(ctx, register.DefaultParams())
 
 	keeper := NewKeeper(cdc, keyPot, pk.Subspace(types.DefaultParamSpace), auth.FeeCollectorName, bankKeeper, supplyKeeper, accountKeeper, stakingKeeper, registerKeeper)
@@ -105,13 +112,18 @@ func CreateTestInput(t *testing.T, isCheckTx bool) (
 	return ctx, accountKeeper, bankKeeper, keeper, stakingKeeper, pk, supplyKeeper, registerKeeper
 }
 
+// setConfigOnce lets CreateTestInput run more than once per test binary, as benchmarks do, once the config is sealed
+var setConfigOnce sync.Once
+
 func SetConfig() {
-	config := stratos.GetConfig()
-	config.SetBech32PrefixForAccount(StratosBech32Prefix, AccountPubKeyPrefix)
-	config.SetBech32PrefixForValidator(ValidatorAddressPrefix, ValidatorPubKeyPrefix)
-	config.SetBech32PrefixForConsensusNode(ConsNodeAddressPrefix, ConsNodePubKeyPrefix)
-	config.SetBech32PrefixForSdsNodeP2P(SdsNodeP2PKeyPrefix)
-	config.Seal()
+	setConfigOnce.Do(func() {
+		config := stratos.GetConfig()
+		config.SetBech32PrefixForAccount(StratosBech32Prefix, AccountPubKeyPrefix)
+		config.SetBech32PrefixForValidator(ValidatorAddressPrefix, ValidatorPubKeyPrefix)
+		config.SetBech32PrefixForConsensusNode(ConsNodeAddressPrefix, ConsNodePubKeyPrefix)
+		config.SetBech32PrefixForSdsNodeP2P(SdsNodeP2PKeyPrefix)
+		config.Seal()
+	})
 }
 
 // create a codec used only for testing
@@ -0,0 +1,234 @@
+package keeper
+
+import (
+	"bytes"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authexported "github.com/cosmos/cosmos-sdk/x/auth/exported"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+	"github.com/stratosnet/stratos-chain/x/pot/types"
+)
+
+// legacyIndividualRewardKeyInfix separates the node address from the decimal epoch in the individual reward keys
+// written before the epoch was big endian encoded: prefix{address}_individual_{epoch}
+var legacyIndividualRewardKeyInfix = []byte("_individual_")
+
+// legacyRewardAddressPoolKey held the whole reward address pool as a single list
+var legacyRewardAddressPoolKey = []byte{0x11}
+
+// MigrateIndividualRewards rewrites the individual rewards stored under the legacy key layout, holding a single amount,
+// into the big endian epoch key layout holding the mining/traffic split. The split of the rewards distributed before
+// the migration was never stored, so their whole amount is recorded as reward from the mining pool.
+func (k Keeper) MigrateIndividualRewards(ctx sdk.Context) (migrated int) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.IndividualRewardKeyPrefix)
+
+	var legacyKeys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		if bytes.Contains(iter.Key()[len(types.IndividualRewardKeyPrefix):], legacyIndividualRewardKeyInfix) {
+			legacyKeys = append(legacyKeys, iter.Key())
+		}
+	}
+	iter.Close()
+
+	for _, legacyKey := range legacyKeys {
+		keyBody := legacyKey[len(types.IndividualRewardKeyPrefix):]
+		infixIndex := bytes.LastIndex(keyBody, legacyIndividualRewardKeyInfix)
+		acc := sdk.AccAddress(keyBody[:infixIndex])
+		epoch, ok := sdk.NewIntFromString(string(keyBody[infixIndex+len(legacyIndividualRewardKeyInfix):]))
+		if !ok {
+			panic(fmt.Sprintf("invalid epoch in legacy individual reward key %X", legacyKey))
+		}
+
+		var amount sdk.Int
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(store.Get(legacyKey), &amount)
+		store.Delete(legacyKey)
+		k.SetIndividualReward(ctx, acc, epoch, types.NewIndividualReward(amount, sdk.ZeroInt()))
+		migrated++
+	}
+
+	k.Logger(ctx).Info(fmt.Sprintf("migrated %d individual rewards to the big endian epoch key layout", migrated))
+	return migrated
+}
+
+// MigrateWithdrawnTotalRewards records the rewards withdrawn by each node before the withdrawn totals were tracked.
+// Withdrawals only ever lowered the mature total, so they are the part of the individual rewards missing from the totals.
+func (k Keeper) MigrateWithdrawnTotalRewards(ctx sdk.Context) (migrated int) {
+	withdrawn := make(map[string]sdk.Int)
+	var nodes []sdk.AccAddress
+	k.IterateAllIndividualRewards(ctx, func(acc sdk.AccAddress, _ sdk.Int, reward types.IndividualReward) (stop bool) {
+		total, found := withdrawn[acc.String()]
+		if !found {
+			total = sdk.ZeroInt()
+			nodes = append(nodes, acc)
+		}
+		withdrawn[acc.String()] = total.Add(reward.Total())
+		return false
+	})
+
+	for _, acc := range nodes {
+		if !k.GetWithdrawnTotalReward(ctx, acc).IsZero() {
+			continue
+		}
+		total := withdrawn[acc.String()].
+			Sub(k.GetMatureTotalReward(ctx, acc)).
+			Sub(k.GetImmatureTotalReward(ctx, acc))
+		if !total.IsPositive() {
+			continue
+		}
+		k.SetWithdrawnTotalReward(ctx, acc, total)
+		migrated++
+	}
+
+	k.Logger(ctx).Info(fmt.Sprintf("recorded the withdrawn reward total of %d nodes", migrated))
+	return migrated
+}
+
+// MigrateTokenPools credits the total reward pool with the rewards not withdrawn yet and the total unissued prepay pool
+// with the unissued prepay, which were only counted in the store before they were held by module accounts.
+// Withdrawals, validator rewards and prepays used to be added to or subtracted from accounts without going through
+// the supply module, so the total supply is recomputed from the account balances afterwards.
+func (k Keeper) MigrateTokenPools(ctx sdk.Context) {
+	bondDenom := k.BondDenom(ctx)
+	pools := []struct {
+		poolName string
+		amount   sdk.Int
+	}{
+		{types.TotalRewardPoolName, k.SumUnwithdrawnRewards(ctx)},
+		{types.TotalUnissuedPrepayPoolName, k.GetTotalUnissuedPrepay(ctx)},
+	}
+	for _, p := range pools {
+		pool := k.GetTokenPool(ctx, p.poolName)
+		if err := pool.SetCoins(sdk.NewCoins(sdk.NewCoin(bondDenom, p.amount))); err != nil {
+			panic(err)
+		}
+		k.SetTokenPool(ctx, pool)
+	}
+
+	total := sdk.NewCoins()
+	k.AccountKeeper.IterateAccounts(ctx, func(acc authexported.Account) (stop bool) {
+		total = total.Add(acc.GetCoins()...)
+		return false
+	})
+	k.SupplyKeeper.SetSupply(ctx, supply.NewSupply(total))
+
+	k.Logger(ctx).Info(fmt.Sprintf("moved the pot token pools to their module accounts, total supply is now %s", total))
+}
+
+// MigrateMaturityQueue queues the individual rewards maturing after the last reported epoch. Rewards used to mature
+// only when their node earned a new reward, so the part of the immature totals that is not queued has already reached
+// its mature epoch and is moved to the mature totals.
+func (k Keeper) MigrateMaturityQueue(ctx sdk.Context) (matured int) {
+	lastReportedEpoch := k.GetLastReportedEpoch(ctx)
+	queued := make(map[string]sdk.Int)
+	k.IterateAllIndividualRewards(ctx, func(acc sdk.AccAddress, epoch sdk.Int, reward types.IndividualReward) (stop bool) {
+		if epoch.LTE(lastReportedEpoch) {
+			return false
+		}
+		k.InsertMaturityQueue(ctx, epoch, acc)
+		total, found := queued[acc.String()]
+		if !found {
+			total = sdk.ZeroInt()
+		}
+		queued[acc.String()] = total.Add(reward.Total())
+		return false
+	})
+
+	var nodes []sdk.AccAddress
+	k.IterateImmatureTotalRewards(ctx, func(acc sdk.AccAddress, total sdk.Int) (stop bool) {
+		nodes = append(nodes, acc)
+		return false
+	})
+	for _, acc := range nodes {
+		immatureTotal := k.GetImmatureTotalReward(ctx, acc)
+		stillImmature, found := queued[acc.String()]
+		if !found {
+			stillImmature = sdk.ZeroInt()
+		}
+		overdue := immatureTotal.Sub(stillImmature)
+		if !overdue.IsPositive() {
+			continue
+		}
+		k.SetMatureTotalReward(ctx, acc, k.GetMatureTotalReward(ctx, acc).Add(overdue))
+		k.SetImmatureTotalReward(ctx, acc, stillImmature)
+		matured++
+	}
+
+	k.Logger(ctx).Info(fmt.Sprintf("queued the immature rewards and matured the overdue rewards of %d nodes", matured))
+	return matured
+}
+
+// MigrateRewardAddressPool moves the reward address pool stored as a single list under one key
+// to one key per node
+func (k Keeper) MigrateRewardAddressPool(ctx sdk.Context) (migrated int) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(legacyRewardAddressPoolKey)
+	if b == nil {
+		return 0
+	}
+	var addressList []sdk.AccAddress
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(b, &addressList)
+	for _, acc := range addressList {
+		k.SetRewardAddress(ctx, acc)
+		migrated++
+	}
+	store.Delete(legacyRewardAddressPoolKey)
+
+	k.Logger(ctx).Info(fmt.Sprintf("moved %d nodes of the reward address pool to their own keys", migrated))
+	return migrated
+}
+
+// MigrateRewardOwners records the owner of the rewards of every address of the reward address pool distributed before
+// the owners were recorded. The addresses that are no registered node are delegators, or nodes removed before the
+// upgrade whose rewards could only ever be withdrawn with their own key, so they keep owning their rewards.
+func (k Keeper) MigrateRewardOwners(ctx sdk.Context) (migrated int) {
+	k.IterateRewardAddresses(ctx, func(acc sdk.AccAddress) (stop bool) {
+		if _, found := k.GetRewardOwner(ctx, acc); !found {
+			k.SetRewardOwner(ctx, acc, k.getRewardOwnerToRecord(ctx, acc))
+			migrated++
+		}
+		return false
+	})
+
+	k.Logger(ctx).Info(fmt.Sprintf("recorded the reward owner of %d nodes", migrated))
+	return migrated
+}
+
+// MigrateVolumeReportProposals moves the volume reports waiting for votes from the decimal epoch keys, which held
+// a single report per epoch, to the keys of the competing reports of an epoch: prefix{epoch big endian}{report reference}
+func (k Keeper) MigrateVolumeReportProposals(ctx sdk.Context) (migrated int) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.VolumeReportProposalKeyPrefix)
+
+	var legacyKeys [][]byte
+	var proposals []types.VolumeReportProposal
+	for ; iter.Valid(); iter.Next() {
+		var proposal types.VolumeReportProposal
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &proposal)
+		if !bytes.Equal(iter.Key(), types.GetVolumeReportProposalKey(proposal.Epoch, proposal.ReportReference)) {
+			legacyKeys = append(legacyKeys, iter.Key())
+			proposals = append(proposals, proposal)
+		}
+	}
+	iter.Close()
+
+	for i, legacyKey := range legacyKeys {
+		store.Delete(legacyKey)
+		k.SetVolumeReportProposal(ctx, proposals[i])
+		migrated++
+	}
+
+	k.Logger(ctx).Info(fmt.Sprintf("migrated %d volume report proposals to the report reference key layout", migrated))
+	return migrated
+}
+
+// MigrateParams sets the pot params added since the chain started to their default value
+func (k Keeper) MigrateParams(ctx sdk.Context) {
+	if !k.paramSpace.Has(ctx, types.KeyMaxEpochJump) {
+		k.paramSpace.Set(ctx, types.KeyMaxEpochJump, int64(types.DefaultMaxEpochJump))
+	}
+	if !k.paramSpace.Has(ctx, types.KeyVolumeReportVotingPeriod) {
+		k.paramSpace.Set(ctx, types.KeyVolumeReportVotingPeriod, types.DefaultVolumeReportVotingPeriod)
+	}
+}
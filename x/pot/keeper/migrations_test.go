@@ -0,0 +1,222 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authexported "github.com/cosmos/cosmos-sdk/x/auth/exported"
+	"github.com/stratosnet/stratos-chain/x/pot/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+func testMigrateIndividualRewards(t *testing.T, ctx sdk.Context, k Keeper) {
+	// keep the legacy rewards out of the state checked by the following tests
+	ctx, _ = ctx.CacheContext()
+	store := ctx.KVStore(k.storeKey)
+	nodeAddr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+
+	// individual rewards written under the legacy layout: prefix{address}_individual_{epoch}
+	legacyRewards := map[int64]sdk.Int{
+		2:    sdk.NewInt(200),
+		10:   sdk.NewInt(1000),
+		2017: sdk.NewInt(2017),
+	}
+	for epoch, amount := range legacyRewards {
+		key := append(append(append([]byte{}, types.IndividualRewardKeyPrefix...), nodeAddr.Bytes()...),
+			[]byte("_individual_"+sdk.NewInt(epoch).String())...)
+		store.Set(key, k.cdc.MustMarshalBinaryLengthPrefixed(amount))
+	}
+
+	require.Equal(t, len(legacyRewards), k.MigrateIndividualRewards(ctx))
+	require.Equal(t, 0, k.MigrateIndividualRewards(ctx))
+
+	for epoch, amount := range legacyRewards {
+		reward, found := k.GetIndividualRewardDetail(ctx, nodeAddr, sdk.NewInt(epoch))
+		require.True(t, found)
+		require.Equal(t, amount, reward.RewardFromMiningPool)
+		require.Equal(t, sdk.ZeroInt(), reward.RewardFromTrafficPool)
+	}
+
+	// epochs are ordered numerically, not lexically, once big endian encoded
+	k.SetLastReportedEpoch(ctx, sdk.NewInt(10))
+	history := k.GetRewardHistory(ctx, NewQueryRewardHistoryParams(1, 2, nodeAddr, sdk.NewInt(-1), sdk.NewInt(-1)))
+	require.Len(t, history, 2)
+	require.Equal(t, sdk.NewInt(2), history[0].MatureEpoch)
+	require.Equal(t, sdk.NewInt(10), history[1].MatureEpoch)
+	require.True(t, history[1].Mature)
+
+	history = k.GetRewardHistory(ctx, NewQueryRewardHistoryParams(2, 2, nodeAddr, sdk.NewInt(-1), sdk.NewInt(-1)))
+	require.Len(t, history, 1)
+	require.Equal(t, sdk.NewInt(2017), history[0].MatureEpoch)
+	require.False(t, history[0].Mature)
+
+	history = k.GetRewardHistory(ctx, NewQueryRewardHistoryParams(1, 0, nodeAddr, sdk.NewInt(3), sdk.NewInt(10)))
+	require.Len(t, history, 1)
+	require.Equal(t, sdk.NewInt(1000), history[0].RewardFromMiningPool)
+
+	require.Empty(t, k.GetRewardHistory(ctx, NewQueryRewardHistoryParams(1, 0, addrIdx1, sdk.NewInt(3), sdk.NewInt(10))))
+
+	// rewards distributed at epoch 1 and 2017 keep their mining/traffic split
+	history = k.GetRewardHistory(ctx, NewQueryRewardHistoryParams(1, 0, addrRes1, sdk.NewInt(-1), sdk.NewInt(-1)))
+	require.Len(t, history, 2)
+	require.Equal(t, epoch2017, history[0].MatureEpoch)
+	require.True(t, history[0].RewardFromTrafficPool.IsPositive())
+	require.Equal(t, k.GetIndividualReward(ctx, addrRes1, epoch2017),
+		history[0].RewardFromMiningPool.Add(history[0].RewardFromTrafficPool))
+}
+
+func testMigrateWithdrawnTotalRewards(t *testing.T, ctx sdk.Context, k Keeper) {
+	ctx, _ = ctx.CacheContext()
+	withdrawn := k.GetWithdrawnTotalReward(ctx, addrRes1)
+	require.True(t, withdrawn.IsPositive())
+
+	// a withdrawal made before the withdrawn totals were tracked
+	k.SetWithdrawnTotalReward(ctx, addrRes1, sdk.ZeroInt())
+	_, broken := RewardTotalsInvariant(k)(ctx)
+	require.True(t, broken)
+
+	require.Equal(t, 1, k.MigrateWithdrawnTotalRewards(ctx))
+	require.Equal(t, 0, k.MigrateWithdrawnTotalRewards(ctx))
+	require.Equal(t, withdrawn, k.GetWithdrawnTotalReward(ctx, addrRes1))
+	msg, broken := RewardTotalsInvariant(k)(ctx)
+	require.False(t, broken, msg)
+}
+
+func testMigrateTokenPools(t *testing.T, ctx sdk.Context, k Keeper) {
+	ctx, _ = ctx.CacheContext()
+	require.True(t, k.GetTotalRewardPoolToken(ctx).IsPositive())
+	require.True(t, k.GetTotalUnissuedPrepayPoolToken(ctx).IsPositive())
+
+	// the rewards and the unissued prepay were only counted in the store
+	for _, poolName := range []string{types.TotalRewardPoolName, types.TotalUnissuedPrepayPoolName} {
+		pool := k.GetTokenPool(ctx, poolName)
+		require.NoError(t, pool.SetCoins(sdk.NewCoins()))
+		k.SetTokenPool(ctx, pool)
+	}
+	_, broken := TokenPoolsInvariant(k)(ctx)
+	require.True(t, broken)
+
+	k.MigrateTokenPools(ctx)
+	msg, broken := TokenPoolsInvariant(k)(ctx)
+	require.False(t, broken, msg)
+
+	total := sdk.NewCoins()
+	k.AccountKeeper.IterateAccounts(ctx, func(acc authexported.Account) (stop bool) {
+		total = total.Add(acc.GetCoins()...)
+		return false
+	})
+	require.Equal(t, total, k.SupplyKeeper.GetSupply(ctx).GetTotal())
+}
+
+func testMigrateMaturityQueue(t *testing.T, ctx sdk.Context, k Keeper) {
+	ctx, _ = ctx.CacheContext()
+	lastReportedEpoch := k.GetLastReportedEpoch(ctx)
+
+	// a node that earned nothing after its reward reached the mature epoch, so it never matured
+	idleNode := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	k.SetIndividualReward(ctx, idleNode, lastReportedEpoch, types.NewIndividualReward(sdk.NewInt(100), sdk.ZeroInt()))
+	k.SetImmatureTotalReward(ctx, idleNode, sdk.NewInt(100))
+
+	// the maturity queue did not exist yet
+	var queued []sdk.AccAddress
+	var epochs []sdk.Int
+	k.IterateAllMaturityQueue(ctx, func(epoch sdk.Int, acc sdk.AccAddress) (stop bool) {
+		queued = append(queued, acc)
+		epochs = append(epochs, epoch)
+		return false
+	})
+	require.NotEmpty(t, queued)
+	for i, acc := range queued {
+		k.RemoveFromMaturityQueue(ctx, epochs[i], acc)
+	}
+	_, broken := MaturityQueueInvariant(k)(ctx)
+	require.True(t, broken)
+
+	require.Equal(t, 1, k.MigrateMaturityQueue(ctx))
+	require.Equal(t, 0, k.MigrateMaturityQueue(ctx))
+	require.Equal(t, sdk.NewInt(100), k.GetMatureTotalReward(ctx, idleNode))
+	require.Equal(t, sdk.ZeroInt(), k.GetImmatureTotalReward(ctx, idleNode))
+
+	msg, broken := MaturityQueueInvariant(k)(ctx)
+	require.False(t, broken, msg)
+	msg, broken = RewardTotalsInvariant(k)(ctx)
+	require.False(t, broken, msg)
+}
+
+func testMigrateRewardAddressPool(t *testing.T, ctx sdk.Context, k Keeper) {
+	ctx, _ = ctx.CacheContext()
+	store := ctx.KVStore(k.storeKey)
+	pool := k.GetRewardAddressPool(ctx)
+	require.NotEmpty(t, pool)
+
+	// the pool stored as a single list under one key
+	legacyNode := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	legacyPool := append([]sdk.AccAddress{legacyNode}, pool...)
+	store.Set(legacyRewardAddressPoolKey, k.cdc.MustMarshalBinaryLengthPrefixed(legacyPool))
+	for _, acc := range pool {
+		store.Delete(types.GetRewardAddressKey(acc))
+	}
+	require.Empty(t, k.GetRewardAddressPool(ctx))
+
+	require.Equal(t, len(legacyPool), k.MigrateRewardAddressPool(ctx))
+	require.Equal(t, 0, k.MigrateRewardAddressPool(ctx))
+	require.False(t, store.Has(legacyRewardAddressPoolKey))
+	require.True(t, k.HasRewardAddress(ctx, legacyNode))
+	require.Len(t, k.GetRewardAddressPool(ctx), len(legacyPool))
+
+	// pages follow the address byte order of the pool
+	migratedPool := k.GetRewardAddressPool(ctx)
+	page := k.GetRewardAddressPoolPage(ctx, NewQueryRewardAddressPoolParams(2, 2))
+	require.Equal(t, migratedPool[2:4], page)
+	page = k.GetRewardAddressPoolPage(ctx, NewQueryRewardAddressPoolParams(len(legacyPool)+1, 1))
+	require.Empty(t, page)
+}
+
+func testMigrateRewardOwners(t *testing.T, ctx sdk.Context, k Keeper) {
+	ctx, _ = ctx.CacheContext()
+	store := ctx.KVStore(k.storeKey)
+	pool := k.GetRewardAddressPool(ctx)
+	require.NotEmpty(t, pool)
+
+	// the rewards distributed before the owners were recorded
+	delegator := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	k.SetRewardAddress(ctx, delegator)
+	for _, acc := range pool {
+		store.Delete(types.GetRewardOwnerKey(acc))
+	}
+
+	require.Equal(t, len(pool)+1, k.MigrateRewardOwners(ctx))
+	require.Equal(t, 0, k.MigrateRewardOwners(ctx))
+	owner, found := k.GetRewardOwner(ctx, addrRes1)
+	require.True(t, found)
+	require.Equal(t, resOwner1, owner)
+	owner, found = k.GetRewardOwner(ctx, addrIdx1)
+	require.True(t, found)
+	require.Equal(t, idxOwner1, owner)
+	owner, found = k.GetRewardOwner(ctx, delegator)
+	require.True(t, found)
+	require.Equal(t, delegator, owner)
+}
+
+func testMigrateVolumeReportProposals(t *testing.T, ctx sdk.Context, k Keeper, trafficList []types.SingleNodeVolume) {
+	ctx, _ = ctx.CacheContext()
+	store := ctx.KVStore(k.storeKey)
+	epoch := sdk.NewInt(10)
+
+	// the single report of an epoch stored under its decimal epoch
+	legacy := types.NewVolumeReportProposal(epoch, trafficList, nil, addrIdx1, "ref", "hash", ctx.BlockHeader().Time)
+	legacyKey := append(types.VolumeReportProposalKeyPrefix, epoch.String()...)
+	store.Set(legacyKey, k.cdc.MustMarshalBinaryLengthPrefixed(legacy))
+	current := types.NewVolumeReportProposal(epoch.AddRaw(1), trafficList, nil, addrIdx2, "ref", "hash", ctx.BlockHeader().Time)
+	k.SetVolumeReportProposal(ctx, current)
+
+	require.Equal(t, 1, k.MigrateVolumeReportProposals(ctx))
+	require.Equal(t, 0, k.MigrateVolumeReportProposals(ctx))
+	require.False(t, store.Has(legacyKey))
+	proposal, found := k.GetVolumeReportProposal(ctx, epoch, "ref")
+	require.True(t, found)
+	require.Equal(t, addrIdx1, proposal.Reporter)
+	_, found = k.GetVolumeReportProposal(ctx, epoch.AddRaw(1), "ref")
+	require.True(t, found)
+}
@@ -1,6 +1,8 @@
 package keeper
 
 import (
+	"time"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stratosnet/stratos-chain/x/pot/types"
 )
@@ -27,6 +29,18 @@ func (k Keeper) MatureEpoch(ctx sdk.Context) (res int64) {
 	return
 }
 
+// MaxEpochJump - how far past the last reported epoch a volume report may be
+func (k Keeper) MaxEpochJump(ctx sdk.Context) (res int64) {
+	k.paramSpace.Get(ctx, types.KeyMaxEpochJump, &res)
+	return
+}
+
+// VolumeReportVotingPeriod - how long the indexing nodes may vote on a volume report
+func (k Keeper) VolumeReportVotingPeriod(ctx sdk.Context) (res time.Duration) {
+	k.paramSpace.Get(ctx, types.KeyVolumeReportVotingPeriod, &res)
+	return
+}
+
 func (k Keeper) MiningRewardParams(ctx sdk.Context) (res []types.MiningRewardParam) {
 	k.paramSpace.Get(ctx, types.KeyMiningRewardParams, &res)
 	return
@@ -0,0 +1,50 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	supplyexported "github.com/cosmos/cosmos-sdk/x/supply/exported"
+	"github.com/stratosnet/stratos-chain/x/pot/types"
+)
+
+// GetTokenPool returns the module account of a token pool, creating it if it does not exist yet
+func (k Keeper) GetTokenPool(ctx sdk.Context, poolName string) supplyexported.ModuleAccountI {
+	return k.SupplyKeeper.GetModuleAccount(ctx, poolName)
+}
+
+// SetTokenPool stores the module account of a token pool
+func (k Keeper) SetTokenPool(ctx sdk.Context, pool supplyexported.ModuleAccountI) {
+	k.SupplyKeeper.SetModuleAccount(ctx, pool)
+}
+
+// getPoolToken returns the bond denom balance of a token pool
+func (k Keeper) getPoolToken(ctx sdk.Context, poolName string) sdk.Coin {
+	bondDenom := k.BondDenom(ctx)
+	pool := k.AccountKeeper.GetAccount(ctx, k.SupplyKeeper.GetModuleAddress(poolName))
+	if pool == nil {
+		return sdk.NewCoin(bondDenom, sdk.ZeroInt())
+	}
+	return sdk.NewCoin(bondDenom, pool.GetCoins().AmountOf(bondDenom))
+}
+
+// GetTotalRewardPoolToken returns the rewards held for the sds nodes until they are withdrawn
+func (k Keeper) GetTotalRewardPoolToken(ctx sdk.Context) sdk.Coin {
+	return k.getPoolToken(ctx, types.TotalRewardPoolName)
+}
+
+// GetTotalUnissuedPrepayPoolToken returns the prepaid tokens held until they are issued as traffic rewards
+func (k Keeper) GetTotalUnissuedPrepayPoolToken(ctx sdk.Context) sdk.Coin {
+	return k.getPoolToken(ctx, types.TotalUnissuedPrepayPoolName)
+}
+
+// SumUnwithdrawnRewards returns the sum of the mature and immature reward totals of all nodes,
+// which the total reward pool must hold
+func (k Keeper) SumUnwithdrawnRewards(ctx sdk.Context) sdk.Int {
+	sum := sdk.ZeroInt()
+	sumTotals := func(_ sdk.AccAddress, total sdk.Int) (stop bool) {
+		sum = sum.Add(total)
+		return false
+	}
+	k.IterateMatureTotalRewards(ctx, sumTotals)
+	k.IterateImmatureTotalRewards(ctx, sumTotals)
+	return sum
+}
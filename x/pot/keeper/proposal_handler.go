@@ -0,0 +1,23 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stratosnet/stratos-chain/x/pot/types"
+)
+
+// HandleUpdateParamsProposal is a handler for executing a passed pot params update proposal
+func HandleUpdateParamsProposal(ctx sdk.Context, k Keeper, p types.UpdateParamsProposal) error {
+	if err := p.Params.ValidateBasic(); err != nil {
+		return err
+	}
+	// tokens already bonded or rewarded in the current denom would be stranded
+	if p.Params.BondDenom != k.BondDenom(ctx) {
+		return types.ErrBondDenomChange
+	}
+
+	k.SetParams(ctx, p.Params)
+	ctx.Logger().Info(fmt.Sprintf("updated pot params: %s", p.Params))
+	return nil
+}
@@ -8,13 +8,17 @@ import (
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/stratosnet/stratos-chain/x/pot/types"
 	abci "github.com/tendermint/tendermint/abci/types"
+	"math"
 	"strconv"
 )
 
 const (
+	QueryParams            = "params"
 	QueryVolumeReport      = "volume_report"
 	QueryPotRewardsByEpoch = "pot_rewards_by_epoch"
 	QueryPotRewardsByOwner = "pot_rewards_by_owner"
+	QueryRewardHistory     = "pot_reward_history"
+	QueryRewardAddressPool = "reward_address_pool"
 	QueryDefaultLimit      = 100
 )
 
@@ -22,18 +26,34 @@ const (
 func NewQuerier(k Keeper) sdk.Querier {
 	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
 		switch path[0] {
+		case QueryParams:
+			return queryParams(ctx, req, k)
 		case QueryVolumeReport:
 			return queryVolumeReport(ctx, req, k)
 		case QueryPotRewardsByEpoch:
 			return queryPotRewardsByEpoch(ctx, req, k)
 		case QueryPotRewardsByOwner:
 			return queryPotRewardsWithOwnerHeight(ctx, req, k)
+		case QueryRewardHistory:
+			return queryRewardHistory(ctx, req, k)
+		case QueryRewardAddressPool:
+			return queryRewardAddressPool(ctx, req, k)
 		default:
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown pot query endpoint")
 		}
 	}
 }
 
+// queryParams fetch the pot params.
+func queryParams(ctx sdk.Context, _ abci.RequestQuery, k Keeper) ([]byte, error) {
+	params := k.GetParams(ctx)
+	res, err := codec.MarshalJSONIndent(k.cdc, params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
 // queryVolumeReport fetches a hash of report volume for the supplied epoch.
 func queryVolumeReport(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
 	epoch, err := strconv.ParseInt(string(req.Data), 10, 64)
@@ -179,3 +199,96 @@ func (k Keeper) getOwnerRewards(ctx sdk.Context, params QueryPotRewardsWithOwner
 		return
 	}
 }
+
+// queryRewardHistory fetches the mining/traffic split and maturity status of the rewards of a node over an epoch range.
+func queryRewardHistory(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params QueryRewardHistoryParams
+	err := k.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	history := k.GetRewardHistory(ctx, params)
+	bz, err := codec.MarshalJSONIndent(k.cdc, history)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+// GetRewardHistory returns a page of the rewards of a node maturing from params.StartEpoch to params.EndEpoch,
+// in epoch order. A nil or negative start epoch starts from the first epoch and a nil, negative or out of 64 bits
+// end epoch runs to the last one. No epoch is stored beyond 64 bits, so neither is any reward past such a start epoch.
+func (k Keeper) GetRewardHistory(ctx sdk.Context, params QueryRewardHistoryParams) []RewardHistoryRecord {
+	page, limit := params.Page, params.Limit
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = QueryDefaultLimit
+	}
+	skip := (page - 1) * limit
+
+	startEpoch, endEpoch := params.StartEpoch, params.EndEpoch
+	if startEpoch.IsNil() || startEpoch.IsNegative() {
+		startEpoch = sdk.ZeroInt()
+	}
+	if endEpoch.IsNil() || endEpoch.IsNegative() || !endEpoch.IsUint64() {
+		endEpoch = sdk.NewIntFromUint64(math.MaxUint64)
+	}
+	records := make([]RewardHistoryRecord, 0)
+	if !startEpoch.IsUint64() {
+		return records
+	}
+
+	lastReportedEpoch := k.GetLastReportedEpoch(ctx)
+	i := 0
+	k.IterateIndividualRewards(ctx, params.NodeAddr, startEpoch, endEpoch, func(epoch sdk.Int, reward types.IndividualReward) bool {
+		if i++; i <= skip {
+			return false
+		}
+		records = append(records, NewRewardHistoryRecord(epoch, reward, epoch.LTE(lastReportedEpoch)))
+		return len(records) >= limit
+	})
+	return records
+}
+
+// queryRewardAddressPool fetches a page of the nodes that ever received a reward.
+func queryRewardAddressPool(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params QueryRewardAddressPoolParams
+	err := k.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	addrs := k.GetRewardAddressPoolPage(ctx, params)
+	bz, err := codec.MarshalJSONIndent(k.cdc, addrs)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+// GetRewardAddressPoolPage returns a page of the reward address pool, in address byte order, without loading the
+// rest of the pool
+func (k Keeper) GetRewardAddressPoolPage(ctx sdk.Context, params QueryRewardAddressPoolParams) []sdk.AccAddress {
+	page, limit := params.Page, params.Limit
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = QueryDefaultLimit
+	}
+	skip := (page - 1) * limit
+
+	addrs := make([]sdk.AccAddress, 0)
+	i := 0
+	k.IterateRewardAddresses(ctx, func(acc sdk.AccAddress) bool {
+		if i++; i <= skip {
+			return false
+		}
+		addrs = append(addrs, acc)
+		return len(addrs) >= limit
+	})
+	return addrs
+}
@@ -0,0 +1,55 @@
+package keeper
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stratosnet/stratos-chain/x/pot/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+func TestQueryRewardHistoryEpochBounds(t *testing.T) {
+	ctx, _, _, k, _, _, _, _ := CreateTestInput(t, false)
+	querier := NewQuerier(k)
+	nodeAddr := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	maxEpoch := sdk.NewIntFromUint64(math.MaxUint64)
+	beyondMaxEpoch := maxEpoch.AddRaw(1)
+
+	for _, epoch := range []sdk.Int{sdk.NewInt(1), sdk.NewInt(2), maxEpoch} {
+		k.SetIndividualReward(ctx, nodeAddr, epoch, types.NewIndividualReward(sdk.NewInt(10), sdk.ZeroInt()))
+	}
+
+	queryHistory := func(startEpoch, endEpoch sdk.Int) []RewardHistoryRecord {
+		params := NewQueryRewardHistoryParams(1, 0, nodeAddr, startEpoch, endEpoch)
+		bz, err := querier(ctx, []string{QueryRewardHistory}, abci.RequestQuery{Data: k.cdc.MustMarshalJSON(params)})
+		require.NoError(t, err)
+		var records []RewardHistoryRecord
+		k.cdc.MustUnmarshalJSON(bz, &records)
+		return records
+	}
+
+	// an end epoch beyond 64 bits runs to the last epoch
+	require.Len(t, queryHistory(sdk.NewInt(2), beyondMaxEpoch), 2)
+	// a start epoch beyond 64 bits is past every stored epoch
+	require.Empty(t, queryHistory(beyondMaxEpoch, beyondMaxEpoch))
+	require.Empty(t, queryHistory(beyondMaxEpoch, sdk.NewInt(-1)))
+	require.Len(t, queryHistory(sdk.NewInt(-1), sdk.NewInt(-1)), 3)
+}
+
+func TestQueryParams(t *testing.T) {
+	ctx, _, _, k, _, _, _, _ := CreateTestInput(t, false)
+	params := types.DefaultParams()
+	params.VolumeReportVotingPeriod = 10 * time.Minute
+	k.SetParams(ctx, params)
+
+	bz, err := NewQuerier(k)(ctx, []string{QueryParams}, abci.RequestQuery{})
+	require.NoError(t, err)
+	var res types.Params
+	k.cdc.MustUnmarshalJSON(bz, &res)
+	require.Equal(t, params, res)
+	require.Equal(t, 10*time.Minute, k.VolumeReportVotingPeriod(ctx))
+}
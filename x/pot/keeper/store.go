@@ -1,6 +1,10 @@
 package keeper
 
 import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stratosnet/stratos-chain/x/pot/types"
 )
@@ -21,7 +25,7 @@ func (k Keeper) GetInitialUOzonePrice(ctx sdk.Context) (price sdk.Dec) {
 	return
 }
 
-func (k Keeper) setTotalMinedTokens(ctx sdk.Context, totalMinedToken sdk.Int) {
+func (k Keeper) SetTotalMinedTokens(ctx sdk.Context, totalMinedToken sdk.Int) {
 	store := ctx.KVStore(k.storeKey)
 	b := k.cdc.MustMarshalBinaryLengthPrefixed(totalMinedToken)
 	store.Set(types.TotalMinedTokensKey, b)
@@ -37,7 +41,7 @@ func (k Keeper) GetTotalMinedTokens(ctx sdk.Context) (totalMinedToken sdk.Int) {
 	return
 }
 
-func (k Keeper) setMinedTokens(ctx sdk.Context, epoch sdk.Int, minedToken sdk.Int) {
+func (k Keeper) SetMinedTokens(ctx sdk.Context, epoch sdk.Int, minedToken sdk.Int) {
 	store := ctx.KVStore(k.storeKey)
 	b := k.cdc.MustMarshalBinaryLengthPrefixed(minedToken)
 	store.Set(types.GetMinedTokensKey(epoch), b)
@@ -69,23 +73,69 @@ func (k Keeper) GetTotalUnissuedPrepay(ctx sdk.Context) (totalUnissuedPrepay sdk
 	return
 }
 
-func (k Keeper) setRewardAddressPool(ctx sdk.Context, addressList []sdk.AccAddress) {
+// SetRewardAddress adds a node to the reward address pool
+func (k Keeper) SetRewardAddress(ctx sdk.Context, acc sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetRewardAddressKey(acc), acc.Bytes())
+}
+
+// HasRewardAddress returns true if the node is in the reward address pool
+func (k Keeper) HasRewardAddress(ctx sdk.Context, acc sdk.AccAddress) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.GetRewardAddressKey(acc))
+}
+
+// IterateRewardAddresses iterates over the reward address pool, in address byte order
+func (k Keeper) IterateRewardAddresses(ctx sdk.Context, handler func(acc sdk.AccAddress) (stop bool)) {
 	store := ctx.KVStore(k.storeKey)
-	b := k.cdc.MustMarshalBinaryLengthPrefixed(addressList)
-	store.Set(types.RewardAddressPoolKey, b)
+	iter := sdk.KVStorePrefixIterator(store, types.RewardAddressKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if handler(sdk.AccAddress(iter.Value())) {
+			break
+		}
+	}
 }
 
+// GetRewardAddressPool returns all the nodes of the reward address pool, in address byte order
 func (k Keeper) GetRewardAddressPool(ctx sdk.Context) (addressList []sdk.AccAddress) {
+	k.IterateRewardAddresses(ctx, func(acc sdk.AccAddress) (stop bool) {
+		addressList = append(addressList, acc)
+		return false
+	})
+	return
+}
+
+// SetRewardOwner records the owner of the rewards of a node, which outlives the removal of the node
+func (k Keeper) SetRewardOwner(ctx sdk.Context, acc sdk.AccAddress, owner sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetRewardOwnerKey(acc), owner.Bytes())
+}
+
+// GetRewardOwner returns the recorded owner of the rewards of a node
+func (k Keeper) GetRewardOwner(ctx sdk.Context, acc sdk.AccAddress) (owner sdk.AccAddress, found bool) {
 	store := ctx.KVStore(k.storeKey)
-	b := store.Get(types.RewardAddressPoolKey)
+	b := store.Get(types.GetRewardOwnerKey(acc))
 	if b == nil {
-		return nil
+		return nil, false
 	}
-	k.cdc.MustUnmarshalBinaryLengthPrefixed(b, &addressList)
-	return
+	return sdk.AccAddress(b), true
 }
 
-func (k Keeper) setLastReportedEpoch(ctx sdk.Context, epoch sdk.Int) {
+// IterateRewardOwners iterates over the recorded reward owners, in node address byte order
+func (k Keeper) IterateRewardOwners(ctx sdk.Context, handler func(acc sdk.AccAddress, owner sdk.AccAddress) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.RewardOwnerKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		acc := sdk.AccAddress(iter.Key()[len(types.RewardOwnerKeyPrefix):])
+		if handler(acc, sdk.AccAddress(iter.Value())) {
+			break
+		}
+	}
+}
+
+func (k Keeper) SetLastReportedEpoch(ctx sdk.Context, epoch sdk.Int) {
 	store := ctx.KVStore(k.storeKey)
 	b := k.cdc.MustMarshalBinaryLengthPrefixed(epoch)
 	store.Set(types.LastReportedEpochKey, b)
@@ -101,23 +151,53 @@ func (k Keeper) GetLastReportedEpoch(ctx sdk.Context) (epoch sdk.Int) {
 	return
 }
 
-func (k Keeper) setIndividualReward(ctx sdk.Context, acc sdk.AccAddress, epoch sdk.Int, value sdk.Int) {
+func (k Keeper) SetIndividualReward(ctx sdk.Context, acc sdk.AccAddress, epoch sdk.Int, value types.IndividualReward) {
 	store := ctx.KVStore(k.storeKey)
 	b := k.cdc.MustMarshalBinaryLengthPrefixed(value)
 	store.Set(types.GetIndividualRewardKey(acc, epoch), b)
 }
 
-func (k Keeper) GetIndividualReward(ctx sdk.Context, acc sdk.AccAddress, epoch sdk.Int) (value sdk.Int) {
+// GetIndividualRewardDetail returns the split of the reward of a node that is matured at epoch
+func (k Keeper) GetIndividualRewardDetail(ctx sdk.Context, acc sdk.AccAddress, epoch sdk.Int) (value types.IndividualReward, found bool) {
 	store := ctx.KVStore(k.storeKey)
 	b := store.Get(types.GetIndividualRewardKey(acc, epoch))
 	if b == nil {
-		return sdk.ZeroInt()
+		return value, false
 	}
 	k.cdc.MustUnmarshalBinaryLengthPrefixed(b, &value)
-	return
+	return value, true
+}
+
+// GetIndividualReward returns the total reward of a node that is matured at epoch
+func (k Keeper) GetIndividualReward(ctx sdk.Context, acc sdk.AccAddress, epoch sdk.Int) (value sdk.Int) {
+	reward, found := k.GetIndividualRewardDetail(ctx, acc, epoch)
+	if !found {
+		return sdk.ZeroInt()
+	}
+	return reward.Total()
 }
 
-func (k Keeper) setMatureTotalReward(ctx sdk.Context, acc sdk.AccAddress, value sdk.Int) {
+// IterateIndividualRewards iterates over the rewards of a node maturing from startEpoch to endEpoch (inclusive), in epoch order
+func (k Keeper) IterateIndividualRewards(ctx sdk.Context, acc sdk.AccAddress, startEpoch, endEpoch sdk.Int,
+	handler func(epoch sdk.Int, reward types.IndividualReward) (stop bool)) {
+
+	store := ctx.KVStore(k.storeKey)
+	iter := store.Iterator(types.GetIndividualRewardKey(acc, startEpoch),
+		sdk.PrefixEndBytes(types.GetIndividualRewardKey(acc, endEpoch)))
+	defer iter.Close()
+
+	prefixLen := len(types.GetIndividualRewardsKey(acc))
+	for ; iter.Valid(); iter.Next() {
+		epoch := sdk.NewIntFromUint64(binary.BigEndian.Uint64(iter.Key()[prefixLen:]))
+		var reward types.IndividualReward
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &reward)
+		if handler(epoch, reward) {
+			break
+		}
+	}
+}
+
+func (k Keeper) SetMatureTotalReward(ctx sdk.Context, acc sdk.AccAddress, value sdk.Int) {
 	store := ctx.KVStore(k.storeKey)
 	b := k.cdc.MustMarshalBinaryLengthPrefixed(value)
 	store.Set(types.GetMatureTotalRewardKey(acc), b)
@@ -133,7 +213,7 @@ func (k Keeper) GetMatureTotalReward(ctx sdk.Context, acc sdk.AccAddress) (value
 	return
 }
 
-func (k Keeper) setImmatureTotalReward(ctx sdk.Context, acc sdk.AccAddress, value sdk.Int) {
+func (k Keeper) SetImmatureTotalReward(ctx sdk.Context, acc sdk.AccAddress, value sdk.Int) {
 	store := ctx.KVStore(k.storeKey)
 	b := k.cdc.MustMarshalBinaryLengthPrefixed(value)
 	store.Set(types.GetImmatureTotalRewardKey(acc), b)
@@ -148,3 +228,187 @@ func (k Keeper) GetImmatureTotalReward(ctx sdk.Context, acc sdk.AccAddress) (val
 	k.cdc.MustUnmarshalBinaryLengthPrefixed(b, &value)
 	return
 }
+
+// SetWithdrawnTotalReward sets the total of the mature rewards withdrawn by a node
+func (k Keeper) SetWithdrawnTotalReward(ctx sdk.Context, acc sdk.AccAddress, value sdk.Int) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinaryLengthPrefixed(value)
+	store.Set(types.GetWithdrawnTotalRewardKey(acc), b)
+}
+
+// GetWithdrawnTotalReward returns the total of the mature rewards withdrawn by a node
+func (k Keeper) GetWithdrawnTotalReward(ctx sdk.Context, acc sdk.AccAddress) (value sdk.Int) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(types.GetWithdrawnTotalRewardKey(acc))
+	if b == nil {
+		return sdk.ZeroInt()
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(b, &value)
+	return
+}
+
+// SetRewardWithheld withholds the rewards of a resource node until RemoveRewardWithheld is called
+func (k Keeper) SetRewardWithheld(ctx sdk.Context, acc sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetRewardWithheldKey(acc), acc.Bytes())
+}
+
+// RemoveRewardWithheld resumes the rewards of a resource node
+func (k Keeper) RemoveRewardWithheld(ctx sdk.Context, acc sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetRewardWithheldKey(acc))
+}
+
+// IsRewardWithheld returns true if the rewards of a resource node are withheld
+func (k Keeper) IsRewardWithheld(ctx sdk.Context, acc sdk.AccAddress) bool {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(types.GetRewardWithheldKey(acc))
+}
+
+// SetWithdrawAddress sets the address credited by the withdrawals of a node
+func (k Keeper) SetWithdrawAddress(ctx sdk.Context, acc sdk.AccAddress, withdrawAddr sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetWithdrawAddressKey(acc), withdrawAddr.Bytes())
+}
+
+// RemoveWithdrawAddress credits the withdrawals of a node to its owner again
+func (k Keeper) RemoveWithdrawAddress(ctx sdk.Context, acc sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetWithdrawAddressKey(acc))
+}
+
+// GetWithdrawAddress returns the address credited by the withdrawals of a node, if one was set
+func (k Keeper) GetWithdrawAddress(ctx sdk.Context, acc sdk.AccAddress) (withdrawAddr sdk.AccAddress, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(types.GetWithdrawAddressKey(acc))
+	if b == nil {
+		return nil, false
+	}
+	return sdk.AccAddress(b), true
+}
+
+// IterateMinedTokens iterates over the tokens mined at each epoch
+func (k Keeper) IterateMinedTokens(ctx sdk.Context, handler func(epoch sdk.Int, minedToken sdk.Int) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.MinedTokensKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		epoch, ok := sdk.NewIntFromString(string(iter.Key()[len(types.MinedTokensKeyPrefix):]))
+		if !ok {
+			panic(fmt.Sprintf("invalid epoch in mined tokens key %X", iter.Key()))
+		}
+		var minedToken sdk.Int
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &minedToken)
+		if handler(epoch, minedToken) {
+			break
+		}
+	}
+}
+
+// IterateAllIndividualRewards iterates over the individual rewards of all nodes
+func (k Keeper) IterateAllIndividualRewards(ctx sdk.Context,
+	handler func(acc sdk.AccAddress, epoch sdk.Int, reward types.IndividualReward) (stop bool)) {
+
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.IndividualRewardKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		key := iter.Key()[len(types.IndividualRewardKeyPrefix):]
+		acc := sdk.AccAddress(key[:len(key)-8])
+		epoch := sdk.NewIntFromUint64(binary.BigEndian.Uint64(key[len(key)-8:]))
+		var reward types.IndividualReward
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &reward)
+		if handler(acc, epoch, reward) {
+			break
+		}
+	}
+}
+
+// iterateNodeRewardTotals iterates over the reward totals stored under prefix{address}{suffix}
+func (k Keeper) iterateNodeRewardTotals(ctx sdk.Context, prefix []byte, suffix string,
+	handler func(acc sdk.AccAddress, total sdk.Int) (stop bool)) {
+
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		key := iter.Key()[len(prefix):]
+		acc := sdk.AccAddress(key[:len(key)-len(suffix)])
+		var total sdk.Int
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &total)
+		if handler(acc, total) {
+			break
+		}
+	}
+}
+
+// IterateMatureTotalRewards iterates over the mature reward totals of all nodes
+func (k Keeper) IterateMatureTotalRewards(ctx sdk.Context, handler func(acc sdk.AccAddress, total sdk.Int) (stop bool)) {
+	k.iterateNodeRewardTotals(ctx, types.MatureTotalRewardKeyPrefix, "_mature_total", handler)
+}
+
+// IterateImmatureTotalRewards iterates over the immature reward totals of all nodes
+func (k Keeper) IterateImmatureTotalRewards(ctx sdk.Context, handler func(acc sdk.AccAddress, total sdk.Int) (stop bool)) {
+	k.iterateNodeRewardTotals(ctx, types.ImmatureTotalRewardKeyPrefix, "_immature_total", handler)
+}
+
+// IterateWithdrawnTotalRewards iterates over the withdrawn reward totals of all nodes
+func (k Keeper) IterateWithdrawnTotalRewards(ctx sdk.Context, handler func(acc sdk.AccAddress, total sdk.Int) (stop bool)) {
+	k.iterateNodeRewardTotals(ctx, types.WithdrawnTotalRewardKeyPrefix, "_withdrawn_total", handler)
+}
+
+// GetRewardsWithheld returns the resource nodes whose rewards are withheld
+func (k Keeper) GetRewardsWithheld(ctx sdk.Context) (nodes []sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.RewardWithheldKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		nodes = append(nodes, sdk.AccAddress(iter.Value()))
+	}
+	return nodes
+}
+
+// IterateWithdrawAddresses iterates over the withdraw addresses set by the nodes
+func (k Keeper) IterateWithdrawAddresses(ctx sdk.Context, handler func(acc sdk.AccAddress, withdrawAddr sdk.AccAddress) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.WithdrawAddressKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		acc := sdk.AccAddress(iter.Key()[len(types.WithdrawAddressKeyPrefix):])
+		if handler(acc, sdk.AccAddress(iter.Value())) {
+			break
+		}
+	}
+}
+
+// InsertMaturityQueue queues the individual reward of a node maturing at the given epoch
+func (k Keeper) InsertMaturityQueue(ctx sdk.Context, epoch sdk.Int, acc sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(types.GetMaturityQueueKey(epoch, acc), acc.Bytes())
+}
+
+// RemoveFromMaturityQueue removes the individual reward of a node maturing at the given epoch from the queue
+func (k Keeper) RemoveFromMaturityQueue(ctx sdk.Context, epoch sdk.Int, acc sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetMaturityQueueKey(epoch, acc))
+}
+
+// IterateMaturityQueue iterates over the queued individual rewards maturing up to endEpoch (inclusive), in epoch order
+func (k Keeper) IterateMaturityQueue(ctx sdk.Context, endEpoch sdk.Int, handler func(epoch sdk.Int, acc sdk.AccAddress) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iter := store.Iterator(types.MaturityQueueKeyPrefix, sdk.PrefixEndBytes(types.GetMaturityQueueEpochKey(endEpoch)))
+	defer iter.Close()
+
+	prefixLen := len(types.MaturityQueueKeyPrefix)
+	for ; iter.Valid(); iter.Next() {
+		epoch := sdk.NewIntFromUint64(binary.BigEndian.Uint64(iter.Key()[prefixLen : prefixLen+8]))
+		if handler(epoch, sdk.AccAddress(iter.Value())) {
+			break
+		}
+	}
+}
+
+// IterateAllMaturityQueue iterates over all the queued individual rewards, in epoch order
+func (k Keeper) IterateAllMaturityQueue(ctx sdk.Context, handler func(epoch sdk.Int, acc sdk.AccAddress) (stop bool)) {
+	k.IterateMaturityQueue(ctx, sdk.NewIntFromUint64(math.MaxUint64), handler)
+}
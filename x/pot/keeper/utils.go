@@ -89,6 +89,56 @@ func NewQueryPotRewardsWithOwnerHeightParams(page, limit int, ownerAddr sdk.AccA
 	}
 }
 
+type QueryRewardHistoryParams struct {
+	Page       int
+	Limit      int
+	NodeAddr   sdk.AccAddress
+	StartEpoch sdk.Int
+	EndEpoch   sdk.Int
+}
+
+// NewQueryRewardHistoryParams creates a new instance of QueryRewardHistoryParams
+func NewQueryRewardHistoryParams(page, limit int, nodeAddr sdk.AccAddress, startEpoch, endEpoch sdk.Int) QueryRewardHistoryParams {
+	return QueryRewardHistoryParams{
+		Page:       page,
+		Limit:      limit,
+		NodeAddr:   nodeAddr,
+		StartEpoch: startEpoch,
+		EndEpoch:   endEpoch,
+	}
+}
+
+type QueryRewardAddressPoolParams struct {
+	Page  int
+	Limit int
+}
+
+// NewQueryRewardAddressPoolParams creates a new instance of QueryRewardAddressPoolParams
+func NewQueryRewardAddressPoolParams(page, limit int) QueryRewardAddressPoolParams {
+	return QueryRewardAddressPoolParams{
+		Page:  page,
+		Limit: limit,
+	}
+}
+
+// RewardHistoryRecord is the reward of a node maturing at an epoch
+type RewardHistoryRecord struct {
+	MatureEpoch           sdk.Int `json:"mature_epoch" yaml:"mature_epoch"`
+	RewardFromMiningPool  sdk.Int `json:"reward_from_mining_pool" yaml:"reward_from_mining_pool"`
+	RewardFromTrafficPool sdk.Int `json:"reward_from_traffic_pool" yaml:"reward_from_traffic_pool"`
+	Mature                bool    `json:"mature" yaml:"mature"`
+}
+
+// NewRewardHistoryRecord creates a new instance of RewardHistoryRecord
+func NewRewardHistoryRecord(matureEpoch sdk.Int, reward types.IndividualReward, mature bool) RewardHistoryRecord {
+	return RewardHistoryRecord{
+		MatureEpoch:           matureEpoch,
+		RewardFromMiningPool:  reward.RewardFromMiningPool,
+		RewardFromTrafficPool: reward.RewardFromTrafficPool,
+		Mature:                mature,
+	}
+}
+
 type NodeRewardsInfo struct {
 	NodeAddress         sdk.AccAddress
 	MatureTotalReward   sdk.Coin
@@ -0,0 +1,281 @@
+package keeper
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stratosnet/stratos-chain/x/pot/types"
+)
+
+func (k Keeper) GetVolumeReportProposal(ctx sdk.Context, epoch sdk.Int, reportReference string) (proposal types.VolumeReportProposal, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetVolumeReportProposalKey(epoch, reportReference))
+	if bz == nil {
+		return proposal, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &proposal)
+	return proposal, true
+}
+
+func (k Keeper) SetVolumeReportProposal(ctx sdk.Context, proposal types.VolumeReportProposal) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(proposal)
+	store.Set(types.GetVolumeReportProposalKey(proposal.Epoch, proposal.ReportReference), bz)
+}
+
+func (k Keeper) DeleteVolumeReportProposal(ctx sdk.Context, epoch sdk.Int, reportReference string) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetVolumeReportProposalKey(epoch, reportReference))
+}
+
+// IterateVolumeReportProposals iterates over the volume reports waiting for votes, by epoch
+func (k Keeper) IterateVolumeReportProposals(ctx sdk.Context, handler func(proposal types.VolumeReportProposal) (stop bool)) {
+	k.iterateVolumeReportProposals(ctx, types.VolumeReportProposalKeyPrefix, handler)
+}
+
+// IterateEpochVolumeReportProposals iterates over the competing volume reports of an epoch waiting for votes
+func (k Keeper) IterateEpochVolumeReportProposals(ctx sdk.Context, epoch sdk.Int, handler func(proposal types.VolumeReportProposal) (stop bool)) {
+	k.iterateVolumeReportProposals(ctx, types.GetEpochVolumeReportProposalsKey(epoch), handler)
+}
+
+func (k Keeper) iterateVolumeReportProposals(ctx sdk.Context, prefix []byte, handler func(proposal types.VolumeReportProposal) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var proposal types.VolumeReportProposal
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &proposal)
+		if handler(proposal) {
+			break
+		}
+	}
+}
+
+// HasApprovedVolumeReport returns true if the voter approved one of the volume reports of the epoch that are
+// still open for votes. An indexing node approves at most one of the competing reports of an epoch.
+func (k Keeper) HasApprovedVolumeReport(ctx sdk.Context, epoch sdk.Int, voter sdk.AccAddress) (approved bool) {
+	k.IterateEpochVolumeReportProposals(ctx, epoch, func(proposal types.VolumeReportProposal) (stop bool) {
+		if proposal.ExpireTime.Before(ctx.BlockHeader().Time) {
+			return false
+		}
+		for _, addr := range proposal.ApproveList {
+			if addr.Equals(voter) {
+				approved = true
+				return true
+			}
+		}
+		return false
+	})
+	return approved
+}
+
+// checkValidIndexingNode ensures the node is a bonded, unsuspended indexing node owned by ownerAddr
+func (k Keeper) checkValidIndexingNode(ctx sdk.Context, nodeAddr sdk.AccAddress, ownerAddr sdk.AccAddress) error {
+	node, found := k.RegisterKeeper.GetIndexingNode(ctx, nodeAddr)
+	if !found || node.IsSuspended() || !node.GetStatus().Equal(sdk.Bonded) {
+		return types.ErrNotValidIndexingNode
+	}
+	if !node.OwnerAddress.Equals(ownerAddr) {
+		return types.ErrNotTheOwner
+	}
+	return nil
+}
+
+// checkNodesVolume ensures every entry of the nodes volume credits a bonded, unsuspended resource node
+// at most its declared capacity, listing every rejected entry in the returned error
+func (k Keeper) checkNodesVolume(ctx sdk.Context, nodesVolume []types.SingleNodeVolume) error {
+	errs := types.ValidateNodesVolume(nodesVolume)
+	for i, item := range nodesVolume {
+		if item.NodeAddress.Empty() {
+			continue
+		}
+		node, found := k.RegisterKeeper.GetResourceNode(ctx, item.NodeAddress)
+		if !found || node.IsSuspended() || !node.GetStatus().Equal(sdk.Bonded) {
+			errs = append(errs, types.NodeVolumeError{Index: i, NodeAddress: item.NodeAddress, Err: types.ErrNotValidResourceNode})
+			continue
+		}
+		if !item.Volume.IsNil() && node.ExceedsCapacity(item.Volume) {
+			errs = append(errs, types.NodeVolumeError{Index: i, NodeAddress: item.NodeAddress,
+				Err: sdkerrors.Wrapf(types.ErrVolumeExceedsCapacity, "%s > %s", item.Volume, node.Capacity)})
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Index < errs[j].Index })
+	return errs
+}
+
+// SubmitVolumeReport opens a volume report proposal for the epoch, approved by its reporter. Reports of the same epoch
+// with different references compete for the votes, the pot rewards are distributed for the first one approved.
+// The pot rewards are distributed right away if the reporter alone reaches the quorum.
+func (k Keeper) SubmitVolumeReport(ctx sdk.Context, nodesVolume []types.SingleNodeVolume, usersVolume []types.SingleUserVolume,
+	reporter sdk.AccAddress, reporterOwner sdk.AccAddress, epoch sdk.Int, reportReference string, txHash string,
+) (status types.VolumeReportProposalStatus, totalConsumedOzone sdk.Dec, err error) {
+
+	if err = k.checkValidIndexingNode(ctx, reporter, reporterOwner); err != nil {
+		return status, totalConsumedOzone, err
+	}
+
+	// ensure epoch increment
+	lastEpoch := k.GetLastReportedEpoch(ctx)
+	if epoch.LTE(lastEpoch) {
+		return status, totalConsumedOzone, sdkerrors.Wrapf(types.ErrMatureEpoch,
+			"expected epoch should be greater than %s, got %s", lastEpoch.String(), epoch.String())
+	}
+	// bound the epochs skipped by a single report, the first report counts from the genesis epoch 0
+	if maxEpoch := lastEpoch.AddRaw(k.MaxEpochJump(ctx)); epoch.GT(maxEpoch) {
+		return status, totalConsumedOzone, sdkerrors.Wrapf(types.ErrEpochJumpTooLarge,
+			"expected epoch should not be greater than %s, got %s", maxEpoch.String(), epoch.String())
+	}
+
+	if proposal, found := k.GetVolumeReportProposal(ctx, epoch, reportReference); found && !proposal.ExpireTime.Before(ctx.BlockHeader().Time) {
+		return status, totalConsumedOzone, types.ErrVolumeReportProposalExists
+	}
+	if k.HasApprovedVolumeReport(ctx, epoch, reporter) {
+		return status, totalConsumedOzone, types.ErrVolumeReportAlreadyApproved
+	}
+
+	if err = k.checkNodesVolume(ctx, nodesVolume); err != nil {
+		return status, totalConsumedOzone, err
+	}
+
+	expireTime := ctx.BlockHeader().Time.Add(k.VolumeReportVotingPeriod(ctx))
+	proposal := types.NewVolumeReportProposal(epoch, nodesVolume, usersVolume, reporter, reportReference, txHash, expireTime)
+	k.SetVolumeReportProposal(ctx, proposal)
+
+	return k.tallyVolumeReport(ctx, proposal)
+}
+
+// VoteVolumeReport records the opinion of an indexing node on a pending volume report of an epoch, found by its
+// reference, and distributes the pot rewards once 2/3+1 of the valid indexing nodes approved the report.
+func (k Keeper) VoteVolumeReport(ctx sdk.Context, epoch sdk.Int, reportReference string, opinion bool,
+	voter sdk.AccAddress, voterOwner sdk.AccAddress,
+) (status types.VolumeReportProposalStatus, totalConsumedOzone sdk.Dec, err error) {
+
+	proposal, found := k.GetVolumeReportProposal(ctx, epoch, reportReference)
+	if !found {
+		pending := false
+		k.IterateEpochVolumeReportProposals(ctx, epoch, func(types.VolumeReportProposal) (stop bool) {
+			pending = true
+			return true
+		})
+		if pending {
+			return status, totalConsumedOzone, types.ErrReportReferenceMismatch
+		}
+		return status, totalConsumedOzone, types.ErrNoVolumeReportProposalFound
+	}
+	if proposal.ExpireTime.Before(ctx.BlockHeader().Time) {
+		return status, totalConsumedOzone, types.ErrVolumeReportVoteExpired
+	}
+	if err = k.checkValidIndexingNode(ctx, voter, voterOwner); err != nil {
+		return status, totalConsumedOzone, err
+	}
+	if proposal.HasVoted(voter) {
+		return status, totalConsumedOzone, types.ErrDuplicateVolumeReportVote
+	}
+	if opinion && k.HasApprovedVolumeReport(ctx, epoch, voter) {
+		return status, totalConsumedOzone, types.ErrVolumeReportAlreadyApproved
+	}
+
+	if opinion {
+		proposal.ApproveList = append(proposal.ApproveList, voter)
+	} else {
+		proposal.RejectList = append(proposal.RejectList, voter)
+	}
+	k.SetVolumeReportProposal(ctx, proposal)
+
+	return k.tallyVolumeReport(ctx, proposal)
+}
+
+// tallyVolumeReport settles the proposal once it is approved, dropping the competing reports of its epoch,
+// or drops it once it can no longer be approved
+func (k Keeper) tallyVolumeReport(ctx sdk.Context, proposal types.VolumeReportProposal,
+) (status types.VolumeReportProposalStatus, totalConsumedOzone sdk.Dec, err error) {
+
+	totalSpCount := len(k.RegisterKeeper.GetAllValidIndexingNodes(ctx))
+	voteCountRequiredToPass := k.RegisterKeeper.GetVoteCountRequiredToPass(ctx)
+
+	if len(proposal.ApproveList) >= voteCountRequiredToPass {
+		k.deleteEpochVolumeReportProposals(ctx, proposal.Epoch)
+		// a later epoch has been settled in the meantime
+		if proposal.Epoch.LTE(k.GetLastReportedEpoch(ctx)) {
+			return types.VolumeReportProposalRejected, totalConsumedOzone, nil
+		}
+
+		totalConsumedOzone, err = k.SettleVolumeReport(ctx, proposal)
+		if err != nil {
+			return status, totalConsumedOzone, err
+		}
+		return types.VolumeReportProposalAccepted, totalConsumedOzone, nil
+	}
+
+	if len(proposal.RejectList) > totalSpCount-voteCountRequiredToPass {
+		k.DeleteVolumeReportProposal(ctx, proposal.Epoch, proposal.ReportReference)
+		return types.VolumeReportProposalRejected, totalConsumedOzone, nil
+	}
+
+	return types.VolumeReportProposalPending, totalConsumedOzone, nil
+}
+
+// deleteEpochVolumeReportProposals drops all the volume reports of an epoch waiting for votes
+func (k Keeper) deleteEpochVolumeReportProposals(ctx sdk.Context, epoch sdk.Int) {
+	var proposals []types.VolumeReportProposal
+	k.IterateEpochVolumeReportProposals(ctx, epoch, func(proposal types.VolumeReportProposal) (stop bool) {
+		proposals = append(proposals, proposal)
+		return false
+	})
+	for _, proposal := range proposals {
+		k.DeleteVolumeReportProposal(ctx, proposal.Epoch, proposal.ReportReference)
+	}
+}
+
+// SettleVolumeReport records the approved volume report, distributes its pot rewards and debits the ozone
+// consumed by its users. The report fails if one of its nodes is no longer valid, e.g. it has been suspended
+// or lowered its capacity while the report was waiting for votes.
+func (k Keeper) SettleVolumeReport(ctx sdk.Context, proposal types.VolumeReportProposal) (totalConsumedOzone sdk.Dec, err error) {
+	if err = k.checkNodesVolume(ctx, proposal.NodesVolume); err != nil {
+		return totalConsumedOzone, err
+	}
+
+	reportRecord := types.NewReportRecord(proposal.Reporter, proposal.ReportReference, proposal.TxHash)
+	k.SetVolumeReport(ctx, proposal.Epoch, reportRecord)
+	totalConsumedOzone, err = k.DistributePotReward(ctx, proposal.NodesVolume, proposal.Epoch)
+	if err != nil {
+		return totalConsumedOzone, err
+	}
+	if k.hooks != nil {
+		if err = k.hooks.AfterVolumeReportSettled(ctx, proposal.Epoch, proposal.UsersVolume); err != nil {
+			return totalConsumedOzone, err
+		}
+	}
+	return totalConsumedOzone, nil
+}
+
+// RemoveExpiredVolumeReports drops the volume reports that did not reach the quorum before their expire time
+func (k Keeper) RemoveExpiredVolumeReports(ctx sdk.Context) {
+	var expired []types.VolumeReportProposal
+	k.IterateVolumeReportProposals(ctx, func(proposal types.VolumeReportProposal) (stop bool) {
+		if proposal.ExpireTime.Before(ctx.BlockHeader().Time) {
+			expired = append(expired, proposal)
+		}
+		return false
+	})
+
+	for _, proposal := range expired {
+		k.DeleteVolumeReportProposal(ctx, proposal.Epoch, proposal.ReportReference)
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeVolumeReportExpired,
+				sdk.NewAttribute(types.AttributeKeyEpoch, proposal.Epoch.String()),
+				sdk.NewAttribute(types.AttributeKeyReporter, proposal.Reporter.String()),
+				sdk.NewAttribute(types.AttributeKeyReportReference, hex.EncodeToString([]byte(proposal.ReportReference))),
+			),
+		)
+		ctx.Logger().Info(fmt.Sprintf("Volume report of epoch %s expired with %d approvals and %d rejections",
+			proposal.Epoch, len(proposal.ApproveList), len(proposal.RejectList)))
+	}
+}
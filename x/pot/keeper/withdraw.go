@@ -16,27 +16,87 @@ func (k Keeper) Withdraw(ctx sdk.Context, amount sdk.Coin, nodeAddress sdk.AccAd
 		return types.ErrInsufficientMatureTotal
 	}
 
-	_, err := k.BankKeeper.AddCoins(ctx, ownerAddress, sdk.NewCoins(amount))
+	err := k.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, types.TotalRewardPoolName, k.GetWithdrawTarget(ctx, nodeAddress, ownerAddress),
+		sdk.NewCoins(amount))
 	if err != nil {
 		return err
 	}
 	newMatureReward := matureReward.Sub(amount)
 
-	k.setMatureTotalReward(ctx, nodeAddress, newMatureReward.Amount)
+	k.SetMatureTotalReward(ctx, nodeAddress, newMatureReward.Amount)
+	k.SetWithdrawnTotalReward(ctx, nodeAddress, k.GetWithdrawnTotalReward(ctx, nodeAddress).Add(amount.Amount))
 
 	return nil
 }
 
+// WithdrawAll withdraws the whole mature reward of each node once the owner of all of them is checked, skipping the
+// nodes that have none yet
+func (k Keeper) WithdrawAll(ctx sdk.Context, nodeAddresses []sdk.AccAddress, ownerAddress sdk.AccAddress) (total sdk.Coin, err error) {
+	total = sdk.NewCoin(k.BondDenom(ctx), sdk.ZeroInt())
+	for _, nodeAddress := range nodeAddresses {
+		if k.checkOwner(ctx, nodeAddress, ownerAddress) == false {
+			return total, types.ErrNotTheOwner
+		}
+	}
+
+	for _, nodeAddress := range nodeAddresses {
+		amount := sdk.NewCoin(k.BondDenom(ctx), k.GetMatureTotalReward(ctx, nodeAddress))
+		if amount.IsZero() {
+			continue
+		}
+		if err = k.Withdraw(ctx, amount, nodeAddress, ownerAddress); err != nil {
+			return total, err
+		}
+		total = total.Add(amount)
+	}
+
+	if total.IsZero() {
+		return total, types.ErrNoMatureReward
+	}
+	return total, nil
+}
+
+// UpdateWithdrawAddress sets the address credited by the withdrawals of a node, withdrawals are credited to the owner
+// again when it is set to the owner address
+func (k Keeper) UpdateWithdrawAddress(ctx sdk.Context, nodeAddress sdk.AccAddress, ownerAddress sdk.AccAddress,
+	withdrawAddress sdk.AccAddress) error {
+
+	if k.checkOwner(ctx, nodeAddress, ownerAddress) == false {
+		return types.ErrNotTheOwner
+	}
+	if withdrawAddress.Equals(ownerAddress) {
+		k.RemoveWithdrawAddress(ctx, nodeAddress)
+		return nil
+	}
+	k.SetWithdrawAddress(ctx, nodeAddress, withdrawAddress)
+	return nil
+}
+
+// GetWithdrawTarget returns the address credited by the withdrawals of a node, the owner unless a withdraw address is set
+func (k Keeper) GetWithdrawTarget(ctx sdk.Context, nodeAddress sdk.AccAddress, ownerAddress sdk.AccAddress) sdk.AccAddress {
+	if withdrawAddress, found := k.GetWithdrawAddress(ctx, nodeAddress); found {
+		return withdrawAddress
+	}
+	return ownerAddress
+}
+
+// checkOwner ensures the rewards recorded under nodeAddress belong to ownerAddress. Only the owner of the rewards can use
+// them, never the network key of a node.
 func (k Keeper) checkOwner(ctx sdk.Context, nodeAddress sdk.AccAddress, ownerAddress sdk.Address) (found bool) {
-	resourceNode, found := k.RegisterKeeper.GetResourceNode(ctx, nodeAddress)
-	if found && resourceNode.OwnerAddress.Equals(ownerAddress) {
-		return true
+	owner, found := k.LookupRewardOwner(ctx, nodeAddress)
+	return found && owner.Equals(ownerAddress)
+}
+
+// LookupRewardOwner returns the owner of the rewards recorded under nodeAddress: the owner stored on the registered node,
+// else the owner recorded when the rewards were distributed, which outlives the removal of the node.
+func (k Keeper) LookupRewardOwner(ctx sdk.Context, nodeAddress sdk.AccAddress) (owner sdk.AccAddress, found bool) {
+	if resourceNode, found := k.RegisterKeeper.GetResourceNode(ctx, nodeAddress); found {
+		return resourceNode.OwnerAddress, true
 	}
 
-	indexingNode, found := k.RegisterKeeper.GetIndexingNode(ctx, nodeAddress)
-	if found && indexingNode.OwnerAddress.Equals(ownerAddress) {
-		return true
+	if indexingNode, found := k.RegisterKeeper.GetIndexingNode(ctx, nodeAddress); found {
+		return indexingNode.OwnerAddress, true
 	}
 
-	return false
+	return k.GetRewardOwner(ctx, nodeAddress)
 }
@@ -2,6 +2,8 @@ package pot
 
 import (
 	"encoding/json"
+	"math/rand"
+
 	"github.com/cosmos/cosmos-sdk/x/staking"
 	"github.com/cosmos/cosmos-sdk/x/supply"
 	"github.com/stratosnet/stratos-chain/x/register"
@@ -16,16 +18,19 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/module"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	sim "github.com/cosmos/cosmos-sdk/x/simulation"
 	"github.com/stratosnet/stratos-chain/x/pot/client/cli"
 	"github.com/stratosnet/stratos-chain/x/pot/client/rest"
 	"github.com/stratosnet/stratos-chain/x/pot/keeper"
+	"github.com/stratosnet/stratos-chain/x/pot/simulation"
 	"github.com/stratosnet/stratos-chain/x/pot/types"
 )
 
 // Type check to ensure the interface is properly implemented
 var (
-	_ module.AppModule      = AppModule{}
-	_ module.AppModuleBasic = AppModuleBasic{}
+	_ module.AppModule           = AppModule{}
+	_ module.AppModuleBasic      = AppModuleBasic{}
+	_ module.AppModuleSimulation = AppModule{}
 )
 
 // AppModuleBasic defines the basic application module used by the pot module.
@@ -83,11 +88,12 @@ type AppModule struct {
 	accountKeeper  types.AccountKeeper
 	stakingKeeper  staking.Keeper
 	registerKeeper register.Keeper
+	ozoneKeeper    types.OzoneKeeper
 }
 
 // NewAppModule creates a new AppModule object
 func NewAppModule(k keeper.Keeper, bankKeeper bank.Keeper, supplyKeeper supply.Keeper,
-	accountKeeper types.AccountKeeper, stakingKeeper staking.Keeper, registerKeeper register.Keeper) AppModule {
+	accountKeeper types.AccountKeeper, stakingKeeper staking.Keeper, registerKeeper register.Keeper, ozoneKeeper types.OzoneKeeper) AppModule {
 	return AppModule{
 		AppModuleBasic: AppModuleBasic{},
 		keeper:         k,
@@ -96,6 +102,7 @@ func NewAppModule(k keeper.Keeper, bankKeeper bank.Keeper, supplyKeeper supply.K
 		accountKeeper:  accountKeeper,
 		stakingKeeper:  stakingKeeper,
 		registerKeeper: registerKeeper,
+		ozoneKeeper:    ozoneKeeper,
 	}
 }
 
@@ -105,7 +112,9 @@ func (AppModule) Name() string {
 }
 
 // RegisterInvariants registers the pot module invariants.
-func (am AppModule) RegisterInvariants(_ sdk.InvariantRegistry) {}
+func (am AppModule) RegisterInvariants(ir sdk.InvariantRegistry) {
+	keeper.RegisterInvariants(ir, am.keeper)
+}
 
 // Route returns the message routing key for the pot module.
 func (AppModule) Route() string {
@@ -150,6 +159,36 @@ func (am AppModule) BeginBlock(ctx sdk.Context, req abci.RequestBeginBlock) {
 
 // EndBlock returns the end blocker for the pot module. It returns no validator
 // updates.
-func (AppModule) EndBlock(_ sdk.Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
+func (am AppModule) EndBlock(ctx sdk.Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
+	EndBlocker(ctx, am.keeper)
 	return []abci.ValidatorUpdate{}
 }
+
+//____________________________________________________________________________
+
+// AppModuleSimulation functions
+
+// GenerateGenesisState creates a randomized GenState of the pot module.
+func (AppModule) GenerateGenesisState(simState *module.SimulationState) {
+	simulation.RandomizedGenState(simState)
+}
+
+// ProposalContents doesn't return any content functions for governance proposals.
+func (AppModule) ProposalContents(_ module.SimulationState) []sim.WeightedProposalContent {
+	return nil
+}
+
+// RandomizedParams creates randomized pot param changes for the simulator.
+func (AppModule) RandomizedParams(r *rand.Rand) []sim.ParamChange {
+	return simulation.ParamChanges(r)
+}
+
+// RegisterStoreDecoder registers a decoder for pot module's types
+func (AppModule) RegisterStoreDecoder(sdr sdk.StoreDecoderRegistry) {
+	sdr[types.StoreKey] = simulation.DecodeStore
+}
+
+// WeightedOperations returns the all the pot module operations with their respective weights.
+func (am AppModule) WeightedOperations(simState module.SimulationState) []sim.WeightedOperation {
+	return simulation.WeightedOperations(simState.AppParams, simState.Cdc, am.accountKeeper, am.ozoneKeeper, am.keeper)
+}
@@ -0,0 +1,63 @@
+package simulation
+
+import (
+	"bytes"
+	"fmt"
+
+	tmkv "github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stratosnet/stratos-chain/x/pot/types"
+)
+
+// DecodeStore unmarshals the KVPair's Value to the corresponding pot type
+func DecodeStore(cdc *codec.Codec, kvA, kvB tmkv.Pair) string {
+	switch {
+	case bytes.Equal(kvA.Key[:1], types.InitialUOzonePriceKey):
+		var priceA, priceB sdk.Dec
+		cdc.MustUnmarshalBinaryLengthPrefixed(kvA.Value, &priceA)
+		cdc.MustUnmarshalBinaryLengthPrefixed(kvB.Value, &priceB)
+		return fmt.Sprintf("%v\n%v", priceA, priceB)
+
+	case bytes.Equal(kvA.Key[:1], types.TotalMinedTokensKey),
+		bytes.Equal(kvA.Key[:1], types.MinedTokensKeyPrefix),
+		bytes.Equal(kvA.Key[:1], types.TotalUnissuedPrepayKey),
+		bytes.Equal(kvA.Key[:1], types.LastReportedEpochKey),
+		bytes.Equal(kvA.Key[:1], types.MatureTotalRewardKeyPrefix),
+		bytes.Equal(kvA.Key[:1], types.ImmatureTotalRewardKeyPrefix),
+		bytes.Equal(kvA.Key[:1], types.WithdrawnTotalRewardKeyPrefix):
+		var valueA, valueB sdk.Int
+		cdc.MustUnmarshalBinaryLengthPrefixed(kvA.Value, &valueA)
+		cdc.MustUnmarshalBinaryLengthPrefixed(kvB.Value, &valueB)
+		return fmt.Sprintf("%v\n%v", valueA, valueB)
+
+	case bytes.Equal(kvA.Key[:1], types.IndividualRewardKeyPrefix):
+		var rewardA, rewardB types.IndividualReward
+		cdc.MustUnmarshalBinaryLengthPrefixed(kvA.Value, &rewardA)
+		cdc.MustUnmarshalBinaryLengthPrefixed(kvB.Value, &rewardB)
+		return fmt.Sprintf("%v\n%v", rewardA, rewardB)
+
+	case bytes.Equal(kvA.Key[:1], types.RewardWithheldKeyPrefix),
+		bytes.Equal(kvA.Key[:1], types.WithdrawAddressKeyPrefix),
+		bytes.Equal(kvA.Key[:1], types.MaturityQueueKeyPrefix),
+		bytes.Equal(kvA.Key[:1], types.RewardAddressKeyPrefix),
+		bytes.Equal(kvA.Key[:1], types.RewardOwnerKeyPrefix):
+		return fmt.Sprintf("%v\n%v", sdk.AccAddress(kvA.Value), sdk.AccAddress(kvB.Value))
+
+	case bytes.Equal(kvA.Key[:1], types.VolumeReportStoreKeyPrefix):
+		var recordA, recordB types.VolumeReportRecord
+		cdc.MustUnmarshalBinaryLengthPrefixed(kvA.Value, &recordA)
+		cdc.MustUnmarshalBinaryLengthPrefixed(kvB.Value, &recordB)
+		return fmt.Sprintf("%v\n%v", recordA, recordB)
+
+	case bytes.Equal(kvA.Key[:1], types.VolumeReportProposalKeyPrefix):
+		var proposalA, proposalB types.VolumeReportProposal
+		cdc.MustUnmarshalBinaryLengthPrefixed(kvA.Value, &proposalA)
+		cdc.MustUnmarshalBinaryLengthPrefixed(kvB.Value, &proposalB)
+		return fmt.Sprintf("%v\n%v", proposalA, proposalB)
+
+	default:
+		panic(fmt.Sprintf("invalid pot key prefix %X", kvA.Key[:1]))
+	}
+}
@@ -0,0 +1,66 @@
+package simulation
+
+// DONTCOVER
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+	"github.com/stratosnet/stratos-chain/x/pot/types"
+)
+
+// Simulation parameter constants
+const (
+	MatureEpoch              = "mature_epoch"
+	MaxEpochJump             = "max_epoch_jump"
+	VolumeReportVotingPeriod = "volume_report_voting_period"
+)
+
+// GenMatureEpoch randomized MatureEpoch, short enough for the rewards to mature during the simulation
+func GenMatureEpoch(r *rand.Rand) int64 {
+	return int64(r.Intn(10) + 1)
+}
+
+// GenMaxEpochJump randomized MaxEpochJump
+func GenMaxEpochJump(r *rand.Rand) int64 {
+	return int64(r.Intn(types.DefaultMaxEpochJump) + 1)
+}
+
+// GenVolumeReportVotingPeriod randomized VolumeReportVotingPeriod, from a minute to two hours
+func GenVolumeReportVotingPeriod(r *rand.Rand) time.Duration {
+	return time.Duration(simulation.RandIntBetween(r, 60, 2*60*60)) * time.Second
+}
+
+// RandomizedGenState generates a random GenesisState for pot
+func RandomizedGenState(simState *module.SimulationState) {
+	var matureEpoch int64
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, MatureEpoch, &matureEpoch, simState.Rand,
+		func(r *rand.Rand) { matureEpoch = GenMatureEpoch(r) },
+	)
+
+	var maxEpochJump int64
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, MaxEpochJump, &maxEpochJump, simState.Rand,
+		func(r *rand.Rand) { maxEpochJump = GenMaxEpochJump(r) },
+	)
+
+	var volumeReportVotingPeriod time.Duration
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, VolumeReportVotingPeriod, &volumeReportVotingPeriod, simState.Rand,
+		func(r *rand.Rand) { volumeReportVotingPeriod = GenVolumeReportVotingPeriod(r) },
+	)
+
+	// the staking module of the simulation bonds the same denomination
+	params := types.NewParams(sdk.DefaultBondDenom, matureEpoch, types.DefaultParams().MiningRewardParams, maxEpochJump,
+		volumeReportVotingPeriod)
+	potGenesis := types.NewGenesisState(params, types.DefaultUozPrice)
+
+	fmt.Printf("Selected randomly generated pot parameters:\n%s\n", codec.MustMarshalJSONIndent(simState.Cdc, potGenesis.Params))
+	simState.GenState[types.ModuleName] = simState.Cdc.MustMarshalJSON(potGenesis)
+}
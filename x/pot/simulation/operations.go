@@ -0,0 +1,414 @@
+package simulation
+
+import (
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/simapp/helpers"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+	"github.com/stratosnet/stratos-chain/x/pot/keeper"
+	"github.com/stratosnet/stratos-chain/x/pot/types"
+	regtypes "github.com/stratosnet/stratos-chain/x/register/types"
+)
+
+// Simulation operation weights constants
+const (
+	OpWeightMsgVolumeReport      = "op_weight_msg_volume_report"
+	OpWeightMsgVolumeReportVote  = "op_weight_msg_volume_report_vote"
+	OpWeightMsgWithdraw          = "op_weight_msg_withdraw"
+	OpWeightMsgWithdrawAll       = "op_weight_msg_withdraw_all"
+	OpWeightMsgSetWithdrawAddr   = "op_weight_msg_set_withdraw_address"
+	OpWeightMsgFoundationDeposit = "op_weight_msg_foundation_deposit"
+)
+
+// settlementGas is the gas limit of the volume reports and votes. Settling a volume report distributes
+// the rewards of every node within the same tx.
+const settlementGas = 10000 * helpers.DefaultGenTxGas
+
+// Default simulation operation weights
+const (
+	DefaultWeightMsgVolumeReport      = 30
+	DefaultWeightMsgVolumeReportVote  = 60
+	DefaultWeightMsgWithdraw          = 40
+	DefaultWeightMsgWithdrawAll       = 20
+	DefaultWeightMsgSetWithdrawAddr   = 10
+	DefaultWeightMsgFoundationDeposit = 10
+)
+
+// WeightedOperations returns all the operations from the module with their respective weights
+func WeightedOperations(
+	appParams simulation.AppParams, cdc *codec.Codec, ak types.AccountKeeper, ok types.OzoneKeeper, k keeper.Keeper,
+) simulation.WeightedOperations {
+
+	var (
+		weightMsgVolumeReport      int
+		weightMsgVolumeReportVote  int
+		weightMsgWithdraw          int
+		weightMsgWithdrawAll       int
+		weightMsgSetWithdrawAddr   int
+		weightMsgFoundationDeposit int
+	)
+
+	appParams.GetOrGenerate(cdc, OpWeightMsgVolumeReport, &weightMsgVolumeReport, nil,
+		func(_ *rand.Rand) {
+			weightMsgVolumeReport = DefaultWeightMsgVolumeReport
+		},
+	)
+
+	appParams.GetOrGenerate(cdc, OpWeightMsgVolumeReportVote, &weightMsgVolumeReportVote, nil,
+		func(_ *rand.Rand) {
+			weightMsgVolumeReportVote = DefaultWeightMsgVolumeReportVote
+		},
+	)
+
+	appParams.GetOrGenerate(cdc, OpWeightMsgWithdraw, &weightMsgWithdraw, nil,
+		func(_ *rand.Rand) {
+			weightMsgWithdraw = DefaultWeightMsgWithdraw
+		},
+	)
+
+	appParams.GetOrGenerate(cdc, OpWeightMsgWithdrawAll, &weightMsgWithdrawAll, nil,
+		func(_ *rand.Rand) {
+			weightMsgWithdrawAll = DefaultWeightMsgWithdrawAll
+		},
+	)
+
+	appParams.GetOrGenerate(cdc, OpWeightMsgSetWithdrawAddr, &weightMsgSetWithdrawAddr, nil,
+		func(_ *rand.Rand) {
+			weightMsgSetWithdrawAddr = DefaultWeightMsgSetWithdrawAddr
+		},
+	)
+
+	appParams.GetOrGenerate(cdc, OpWeightMsgFoundationDeposit, &weightMsgFoundationDeposit, nil,
+		func(_ *rand.Rand) {
+			weightMsgFoundationDeposit = DefaultWeightMsgFoundationDeposit
+		},
+	)
+
+	return simulation.WeightedOperations{
+		simulation.NewWeightedOperation(
+			weightMsgVolumeReport,
+			SimulateMsgVolumeReport(ak, ok, k),
+		),
+		simulation.NewWeightedOperation(
+			weightMsgVolumeReportVote,
+			SimulateMsgVolumeReportVote(ak, k),
+		),
+		simulation.NewWeightedOperation(
+			weightMsgWithdraw,
+			SimulateMsgWithdraw(ak, k),
+		),
+		simulation.NewWeightedOperation(
+			weightMsgWithdrawAll,
+			SimulateMsgWithdrawAll(ak, k),
+		),
+		simulation.NewWeightedOperation(
+			weightMsgSetWithdrawAddr,
+			SimulateMsgSetWithdrawAddress(ak, k),
+		),
+		simulation.NewWeightedOperation(
+			weightMsgFoundationDeposit,
+			SimulateMsgFoundationDeposit(ak, k),
+		),
+	}
+}
+
+// SimulateMsgVolumeReport generates a MsgVolumeReport of the next epoch with random volumes of the bonded, unsuspended
+// resource nodes, capped at their capacity and paid by the users owning ozone
+func SimulateMsgVolumeReport(ak types.AccountKeeper, ok types.OzoneKeeper, k keeper.Keeper) simulation.Operation {
+	return func(
+		r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, chainID string,
+	) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+
+		reporters := k.RegisterKeeper.GetAllValidIndexingNodes(ctx)
+		if len(reporters) == 0 {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+		reporter := reporters[r.Intn(len(reporters))]
+
+		simAccount, found := simulation.FindAccount(accs, reporter.OwnerAddress)
+		if !found {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		epoch := k.GetLastReportedEpoch(ctx).AddRaw(1)
+		// the reporter approves its own report, so it must not have approved a competing one
+		if k.HasApprovedVolumeReport(ctx, epoch, reporter.GetNetworkAddr()) {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		var usersVolume []types.SingleUserVolume
+		availableOzone := sdk.ZeroInt()
+		ok.IterateOzoneBalances(ctx, func(user sdk.AccAddress, balance sdk.Int) (stop bool) {
+			usersVolume = append(usersVolume, types.NewSingleUserVolume(user, balance))
+			availableOzone = availableOzone.Add(balance)
+			return false
+		})
+
+		// the traffic of the nodes can't exceed the ozone owned by the users
+		var nodesVolume []types.SingleNodeVolume
+		totalVolume := sdk.ZeroInt()
+		for _, node := range k.RegisterKeeper.GetAllResourceNodes(ctx) {
+			if node.GetStatus() != sdk.Bonded || node.IsSuspended() || r.Intn(2) == 0 {
+				continue
+			}
+			volume := sdk.NewInt(int64(simulation.RandIntBetween(r, 1, 1e6)))
+			if node.ExceedsCapacity(volume) {
+				volume = node.Capacity
+			}
+			volume = sdk.MinInt(volume, availableOzone.Sub(totalVolume))
+			if !volume.IsPositive() {
+				break
+			}
+			nodesVolume = append(nodesVolume, types.NewSingleNodeVolume(node.GetNetworkAddr(), volume))
+			totalVolume = totalVolume.Add(volume)
+		}
+		if len(nodesVolume) == 0 {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		// the users pay for the traffic out of their balance, in turn
+		remaining := totalVolume
+		for i := range usersVolume {
+			usersVolume[i].Volume = sdk.MinInt(usersVolume[i].Volume, remaining)
+			remaining = remaining.Sub(usersVolume[i].Volume)
+			if remaining.IsZero() {
+				usersVolume = usersVolume[:i+1]
+				break
+			}
+		}
+
+		reportReference := simulation.RandStringOfLength(r, 32)
+		proposal := types.NewVolumeReportProposal(epoch, nodesVolume, usersVolume, reporter.GetNetworkAddr(), reportReference, "",
+			ctx.BlockHeader().Time)
+
+		// the reporter alone may reach the quorum, in which case the report must be affordable
+		if k.RegisterKeeper.GetVoteCountRequiredToPass(ctx) <= 1 && !canSettle(ctx, k, proposal) {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		msg := types.NewMsgVolumeReport(nodesVolume, reporter.GetNetworkAddr(), epoch,
+			reportReference, reporter.OwnerAddress, usersVolume)
+		return deliverTx(r, app, ctx, ak, chainID, simAccount, msg, nil, settlementGas)
+	}
+}
+
+// SimulateMsgVolumeReportVote generates a MsgVolumeReportVote of a valid indexing node on a pending volume report
+func SimulateMsgVolumeReportVote(ak types.AccountKeeper, k keeper.Keeper) simulation.Operation {
+	return func(
+		r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, chainID string,
+	) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+
+		var proposals []types.VolumeReportProposal
+		k.IterateVolumeReportProposals(ctx, func(proposal types.VolumeReportProposal) (stop bool) {
+			if !proposal.ExpireTime.Before(ctx.BlockHeader().Time) {
+				proposals = append(proposals, proposal)
+			}
+			return false
+		})
+		if len(proposals) == 0 {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+		proposal := proposals[r.Intn(len(proposals))]
+
+		var voters []regtypes.IndexingNode
+		for _, node := range k.RegisterKeeper.GetAllValidIndexingNodes(ctx) {
+			if !proposal.HasVoted(node.GetNetworkAddr()) {
+				voters = append(voters, node)
+			}
+		}
+		if len(voters) == 0 {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+		voter := voters[r.Intn(len(voters))]
+
+		simAccount, found := simulation.FindAccount(accs, voter.OwnerAddress)
+		if !found {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		// any vote settles the report once the approvals reach the quorum, which shrinks when nodes are removed
+		settles := func(opinion bool) bool {
+			approvals := len(proposal.ApproveList)
+			if opinion {
+				approvals++
+			}
+			return approvals >= k.RegisterKeeper.GetVoteCountRequiredToPass(ctx) && proposal.Epoch.GT(k.GetLastReportedEpoch(ctx))
+		}
+
+		// most of the reports are approved, as long as the voter approved no competing report and the approval can be settled
+		opinion := r.Intn(10) != 0
+		if opinion && k.HasApprovedVolumeReport(ctx, proposal.Epoch, voter.GetNetworkAddr()) {
+			opinion = false
+		}
+		if settles(opinion) && !canSettle(ctx, k, proposal) {
+			opinion = false
+			if settles(opinion) {
+				return simulation.NoOpMsg(types.ModuleName), nil, nil
+			}
+		}
+
+		msg := types.NewMsgVolumeReportVote(proposal.Epoch, proposal.ReportReference, opinion,
+			voter.GetNetworkAddr(), voter.OwnerAddress)
+		return deliverTx(r, app, ctx, ak, chainID, simAccount, msg, nil, settlementGas)
+	}
+}
+
+// SimulateMsgWithdraw generates a MsgWithdraw of a random part of the mature rewards of a node
+func SimulateMsgWithdraw(ak types.AccountKeeper, k keeper.Keeper) simulation.Operation {
+	return func(
+		r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, chainID string,
+	) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+
+		addrs := k.GetRewardAddressPool(ctx)
+		if len(addrs) == 0 {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+		nodeAddr := addrs[r.Intn(len(addrs))]
+
+		amount, err := simulation.RandPositiveInt(r, k.GetMatureTotalReward(ctx, nodeAddr))
+		if err != nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		ownerAddr := getRewardOwner(ctx, k, nodeAddr)
+		simAccount, found := simulation.FindAccount(accs, ownerAddr)
+		if !found {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		msg := types.NewMsgWithdraw(sdk.NewCoin(k.BondDenom(ctx), amount), nodeAddr, ownerAddr)
+		return deliverTx(r, app, ctx, ak, chainID, simAccount, msg, nil, helpers.DefaultGenTxGas)
+	}
+}
+
+// SimulateMsgWithdrawAll generates a MsgWithdrawAll of every address of the reward pool owned by the owner of a random one
+func SimulateMsgWithdrawAll(ak types.AccountKeeper, k keeper.Keeper) simulation.Operation {
+	return func(
+		r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, chainID string,
+	) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+
+		addrs := k.GetRewardAddressPool(ctx)
+		if len(addrs) == 0 {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+		ownerAddr := getRewardOwner(ctx, k, addrs[r.Intn(len(addrs))])
+
+		simAccount, found := simulation.FindAccount(accs, ownerAddr)
+		if !found {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		var nodeAddrs []sdk.AccAddress
+		total := sdk.ZeroInt()
+		for _, addr := range addrs {
+			if getRewardOwner(ctx, k, addr).Equals(ownerAddr) {
+				nodeAddrs = append(nodeAddrs, addr)
+				total = total.Add(k.GetMatureTotalReward(ctx, addr))
+			}
+		}
+		if !total.IsPositive() {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		msg := types.NewMsgWithdrawAll(nodeAddrs, ownerAddr)
+		return deliverTx(r, app, ctx, ak, chainID, simAccount, msg, nil, helpers.DefaultGenTxGas)
+	}
+}
+
+// SimulateMsgSetWithdrawAddress generates a MsgSetWithdrawAddress of a random node of the reward pool to a random account
+func SimulateMsgSetWithdrawAddress(ak types.AccountKeeper, k keeper.Keeper) simulation.Operation {
+	return func(
+		r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, chainID string,
+	) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+
+		addrs := k.GetRewardAddressPool(ctx)
+		if len(addrs) == 0 {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+		nodeAddr := addrs[r.Intn(len(addrs))]
+
+		ownerAddr := getRewardOwner(ctx, k, nodeAddr)
+		simAccount, found := simulation.FindAccount(accs, ownerAddr)
+		if !found {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+		withdrawAccount, _ := simulation.RandomAcc(r, accs)
+
+		msg := types.NewMsgSetWithdrawAddress(nodeAddr, ownerAddr, withdrawAccount.Address)
+		return deliverTx(r, app, ctx, ak, chainID, simAccount, msg, nil, helpers.DefaultGenTxGas)
+	}
+}
+
+// SimulateMsgFoundationDeposit generates a MsgFoundationDeposit of a random amount of the spendable bond denom
+func SimulateMsgFoundationDeposit(ak types.AccountKeeper, k keeper.Keeper) simulation.Operation {
+	return func(
+		r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, chainID string,
+	) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+
+		simAccount, _ := simulation.RandomAcc(r, accs)
+		account := ak.GetAccount(ctx, simAccount.Address)
+		if account == nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		denom := k.BondDenom(ctx)
+		spendable := account.SpendableCoins(ctx.BlockTime()).AmountOf(denom)
+		amount, err := simulation.RandPositiveInt(r, spendable.QuoRaw(2))
+		if err != nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		deposit := sdk.NewCoin(denom, amount)
+		msg := types.NewMsgFoundationDeposit(deposit, simAccount.Address)
+		return deliverTx(r, app, ctx, ak, chainID, simAccount, msg, sdk.NewCoins(deposit), helpers.DefaultGenTxGas)
+	}
+}
+
+// getRewardOwner returns the owner of the node, or the address itself for the rewards recorded under a delegator
+func getRewardOwner(ctx sdk.Context, k keeper.Keeper, nodeAddr sdk.AccAddress) sdk.AccAddress {
+	owner, _ := k.LookupRewardOwner(ctx, nodeAddr)
+	return owner
+}
+
+// canSettle returns true if the foundation account and the unissued prepay can pay the rewards of the volume report
+func canSettle(ctx sdk.Context, k keeper.Keeper, proposal types.VolumeReportProposal) bool {
+	cacheCtx, _ := ctx.CacheContext()
+	_, err := k.SettleVolumeReport(cacheCtx, proposal)
+	return err == nil
+}
+
+// deliverTx signs the msg with the simulation account, paying random fees out of the coins left once spent is deducted
+func deliverTx(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, ak types.AccountKeeper, chainID string,
+	simAccount simulation.Account, msg sdk.Msg, spent sdk.Coins, gas uint64,
+) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+
+	account := ak.GetAccount(ctx, simAccount.Address)
+	coins, hasNeg := account.SpendableCoins(ctx.BlockTime()).SafeSub(spent)
+	if hasNeg {
+		return simulation.NoOpMsg(types.ModuleName), nil, nil
+	}
+	fees, err := simulation.RandomFees(r, ctx, coins)
+	if err != nil {
+		return simulation.NoOpMsg(types.ModuleName), nil, err
+	}
+
+	tx := helpers.GenTx(
+		[]sdk.Msg{msg},
+		fees,
+		gas,
+		chainID,
+		[]uint64{account.GetAccountNumber()},
+		[]uint64{account.GetSequence()},
+		simAccount.PrivKey,
+	)
+
+	_, _, err = app.Deliver(tx)
+	if err != nil {
+		return simulation.NoOpMsg(types.ModuleName), nil, err
+	}
+
+	return simulation.NewOperationMsg(msg, true, ""), nil, nil
+}
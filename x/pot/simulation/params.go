@@ -0,0 +1,33 @@
+package simulation
+
+// DONTCOVER
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+	"github.com/stratosnet/stratos-chain/x/pot/types"
+)
+
+// ParamChanges defines the parameters that can be modified by param change proposals
+// on the simulation
+func ParamChanges(r *rand.Rand) []simulation.ParamChange {
+	return []simulation.ParamChange{
+		simulation.NewSimParamChange(types.ModuleName, string(types.KeyMatureEpoch),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%d\"", GenMatureEpoch(r))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.KeyMaxEpochJump),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%d\"", GenMaxEpochJump(r))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.KeyVolumeReportVotingPeriod),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%d\"", GenVolumeReportVotingPeriod(r))
+			},
+		),
+	}
+}
@@ -9,7 +9,12 @@ func RegisterCodec(cdc *codec.Codec) {
 	// this line is used by starport scaffolding # 1
 	cdc.RegisterConcrete(MsgVolumeReport{}, "pot/MsgVolumeReport", nil)
 	cdc.RegisterConcrete(MsgWithdraw{}, "pot/MsgWithdraw", nil)
+	cdc.RegisterConcrete(MsgWithdrawAll{}, "pot/MsgWithdrawAll", nil)
+	cdc.RegisterConcrete(MsgSetWithdrawAddress{}, "pot/MsgSetWithdrawAddress", nil)
 	cdc.RegisterConcrete(MsgFoundationDeposit{}, "pot/MsgFoundationDeposit", nil)
+	cdc.RegisterConcrete(MsgVolumeReportVote{}, "pot/MsgVolumeReportVote", nil)
+
+	cdc.RegisterConcrete(UpdateParamsProposal{}, "pot/UpdateParamsProposal", nil)
 }
 
 // ModuleCdc defines the module codec
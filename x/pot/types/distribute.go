@@ -6,7 +6,9 @@ import (
 )
 
 const (
-	FoundationAccount = "foundation_account"
+	FoundationAccount           = "foundation_account"
+	TotalRewardPoolName         = "total_reward_pool"          // module account holding the rewards distributed to the sds nodes and not withdrawn yet
+	TotalUnissuedPrepayPoolName = "total_unissued_prepay_pool" // module account holding the prepaid tokens not issued as traffic rewards yet
 )
 
 var (
@@ -159,3 +161,29 @@ func (r Reward) String() string {
   		RewardFromTrafficPool:	%s
 	}`, r.NodeAddress, r.RewardFromMiningPool, r.RewardFromTrafficPool)
 }
+
+// IndividualReward is the reward distributed to a node that matures at a given epoch
+type IndividualReward struct {
+	RewardFromMiningPool  sdk.Int `json:"reward_from_mining_pool" yaml:"reward_from_mining_pool"`
+	RewardFromTrafficPool sdk.Int `json:"reward_from_traffic_pool" yaml:"reward_from_traffic_pool"`
+}
+
+func NewIndividualReward(rewardFromMiningPool sdk.Int, rewardFromTrafficPool sdk.Int) IndividualReward {
+	return IndividualReward{
+		RewardFromMiningPool:  rewardFromMiningPool,
+		RewardFromTrafficPool: rewardFromTrafficPool,
+	}
+}
+
+// Total returns the sum of the rewards from the mining pool and from the traffic pool
+func (r IndividualReward) Total() sdk.Int {
+	return r.RewardFromMiningPool.Add(r.RewardFromTrafficPool)
+}
+
+// String returns a human readable string representation of an IndividualReward.
+func (r IndividualReward) String() string {
+	return fmt.Sprintf(`IndividualReward:{
+  		RewardFromMiningPool:	%s
+  		RewardFromTrafficPool:	%s
+	}`, r.RewardFromMiningPool, r.RewardFromTrafficPool)
+}
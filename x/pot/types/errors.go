@@ -24,4 +24,27 @@ var (
 	ErrEmptyReportReference              = sdkerrors.Register(ModuleName, 17, "missing report reference")
 	ErrEmptyReporterOwnerAddr            = sdkerrors.Register(ModuleName, 18, "missing reporter owner address")
 	ErrNegativeVolume                    = sdkerrors.Register(ModuleName, 19, "report volume is negative")
+	ErrBondDenomChange                   = sdkerrors.Register(ModuleName, 20, "bond denom cannot be changed by a params update proposal")
+	ErrVolumeReportProposalExists        = sdkerrors.Register(ModuleName, 21, "a volume report with this reference is already waiting for votes at this epoch")
+	ErrNoVolumeReportProposalFound       = sdkerrors.Register(ModuleName, 22, "volume report proposal does not exist")
+	ErrVolumeReportVoteExpired           = sdkerrors.Register(ModuleName, 23, "volume report proposal has expired")
+	ErrDuplicateVolumeReportVote         = sdkerrors.Register(ModuleName, 24, "duplicate vote on the volume report")
+	ErrNotValidIndexingNode              = sdkerrors.Register(ModuleName, 25, "not a bonded and unsuspended indexing node")
+	ErrReportReferenceMismatch           = sdkerrors.Register(ModuleName, 26, "report reference does not match any pending volume report of the epoch")
+	ErrEmptyVoterAddr                    = sdkerrors.Register(ModuleName, 27, "missing voter address")
+	ErrEmptyVoterOwnerAddr               = sdkerrors.Register(ModuleName, 28, "missing voter owner address")
+	ErrMissingUserAddress                = sdkerrors.Register(ModuleName, 29, "missing user address")
+	ErrInvalidGenesisPool                = sdkerrors.Register(ModuleName, 30, "genesis token pools do not match the pot records")
+	ErrNoMatureReward                    = sdkerrors.Register(ModuleName, 31, "no mature reward to withdraw")
+	ErrMissingWithdrawAddress            = sdkerrors.Register(ModuleName, 32, "missing withdraw address")
+	ErrDuplicateNodeAddress              = sdkerrors.Register(ModuleName, 33, "duplicate node address")
+	ErrEpochJumpTooLarge                 = sdkerrors.Register(ModuleName, 34, "the epoch is too far past the last reported epoch")
+	ErrVolumeNotPositive                 = sdkerrors.Register(ModuleName, 35, "report volume is not positive")
+	ErrNotValidResourceNode              = sdkerrors.Register(ModuleName, 36, "not a bonded and unsuspended resource node")
+	ErrVolumeExceedsCapacity             = sdkerrors.Register(ModuleName, 37, "report volume exceeds the capacity of the resource node")
+	ErrInvalidNodesVolume                = sdkerrors.Register(ModuleName, 38, "invalid nodes volume")
+	ErrDuplicateUserAddress              = sdkerrors.Register(ModuleName, 39, "duplicate user address")
+	ErrVolumeSumMismatch                 = sdkerrors.Register(ModuleName, 40, "the users volume does not add up to the nodes volume")
+	ErrEpochTooLarge                     = sdkerrors.Register(ModuleName, 41, "epoch does not fit in 64 bits")
+	ErrVolumeReportAlreadyApproved       = sdkerrors.Register(ModuleName, 42, "the node already approved another volume report of the epoch")
 )
@@ -2,16 +2,26 @@ package types
 
 // pot module event types
 const (
-	EventTypeVolumeReport      = "volume_report"
-	EventTypeWithdraw          = "withdraw"
-	EventTypeFoundationDeposit = "foundation_deposit"
+	EventTypeVolumeReport         = "volume_report"
+	EventTypeWithdraw             = "withdraw"
+	EventTypeWithdrawAll          = "withdraw_all"
+	EventTypeSetWithdrawAddress   = "set_withdraw_address"
+	EventTypeFoundationDeposit    = "foundation_deposit"
+	EventTypeVolumeReportVote     = "volume_report_vote"
+	EventTypeVolumeReportAccepted = "volume_report_accepted"
+	EventTypeVolumeReportRejected = "volume_report_rejected"
+	EventTypeVolumeReportExpired  = "volume_report_expired"
 
 	AttributeKeyEpoch              = "report_epoch"
 	AttributeKeyReportReference    = "report_reference"
 	AttributeKeyAmount             = "amount"
 	AttributeKeyNodeAddress        = "node_address"
 	AttributeKeyOwnerAddress       = "owner_address"
+	AttributeKeyWithdrawAddress    = "withdraw_address"
 	AttributeKeyTotalConsumedOzone = "total_consumed_ozone"
+	AttributeKeyReporter           = "reporter"
+	AttributeKeyVoter              = "voter"
+	AttributeKeyOpinion            = "opinion"
 
 	AttributeValueCategory = ModuleName
 )
@@ -19,3 +19,13 @@ type AccountKeeper interface {
 	IterateAccounts(ctx sdk.Context, process func(authexported.Account) (stop bool))
 	GetAccount(ctx sdk.Context, addr sdk.AccAddress) authexported.Account // only used for simulation
 }
+
+// OzoneKeeper defines the expected keeper of the ozone balances of the users (noalias)
+type OzoneKeeper interface {
+	IterateOzoneBalances(ctx sdk.Context, handler func(user sdk.AccAddress, balance sdk.Int) (stop bool)) // only used for simulation
+}
+
+// PotHooks event hooks for settled volume reports
+type PotHooks interface {
+	AfterVolumeReportSettled(ctx sdk.Context, epoch sdk.Int, usersVolume []SingleUserVolume) error // Must be called when the pot rewards of a volume report are distributed, the report fails on error
+}
@@ -1,12 +1,65 @@
 package types
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 type GenesisState struct {
-	Params          Params  `json:"params" yaml:"params"`
-	InitialUozPrice sdk.Dec `json:"initial_uoz_price" yaml:"initial_uoz_price"` //initial price of uoz
+	Params                Params                   `json:"params" yaml:"params"`
+	InitialUozPrice       sdk.Dec                  `json:"initial_uoz_price" yaml:"initial_uoz_price"` //initial price of uoz
+	TotalMinedTokens      sdk.Int                  `json:"total_mined_tokens" yaml:"total_mined_tokens"`
+	MinedTokens           []MinedTokensRecord      `json:"mined_tokens" yaml:"mined_tokens"`
+	TotalUnissuedPrepay   sdk.Int                  `json:"total_unissued_prepay" yaml:"total_unissued_prepay"`
+	RewardAddressPool     []sdk.AccAddress         `json:"reward_address_pool" yaml:"reward_address_pool"`
+	LastReportedEpoch     sdk.Int                  `json:"last_reported_epoch" yaml:"last_reported_epoch"`
+	IndividualRewards     []IndividualRewardRecord `json:"individual_rewards" yaml:"individual_rewards"`
+	MatureTotalRewards    []NodeRewardTotal        `json:"mature_total_rewards" yaml:"mature_total_rewards"`
+	ImmatureTotalRewards  []NodeRewardTotal        `json:"immature_total_rewards" yaml:"immature_total_rewards"`
+	WithdrawnTotalRewards []NodeRewardTotal        `json:"withdrawn_total_rewards" yaml:"withdrawn_total_rewards"`
+	RewardsWithheld       []sdk.AccAddress         `json:"rewards_withheld" yaml:"rewards_withheld"` // resource nodes whose rewards are withheld
+	WithdrawAddresses     []NodeWithdrawAddress    `json:"withdraw_addresses" yaml:"withdraw_addresses"`
+	RewardOwners          []NodeRewardOwner        `json:"reward_owners" yaml:"reward_owners"`
+	VolumeReports         []EpochVolumeReport      `json:"volume_reports" yaml:"volume_reports"`
+	VolumeReportProposals []VolumeReportProposal   `json:"volume_report_proposals" yaml:"volume_report_proposals"`
+}
+
+// MinedTokensRecord is the amount of tokens mined at an epoch
+type MinedTokensRecord struct {
+	Epoch       sdk.Int `json:"epoch" yaml:"epoch"`
+	MinedTokens sdk.Int `json:"mined_tokens" yaml:"mined_tokens"`
+}
+
+// IndividualRewardRecord is the reward of a node that is matured at an epoch
+type IndividualRewardRecord struct {
+	NodeAddress sdk.AccAddress   `json:"node_address" yaml:"node_address"`
+	MatureEpoch sdk.Int          `json:"mature_epoch" yaml:"mature_epoch"`
+	Reward      IndividualReward `json:"reward" yaml:"reward"`
+}
+
+// NodeRewardTotal is the mature or immature reward total of a node
+type NodeRewardTotal struct {
+	NodeAddress sdk.AccAddress `json:"node_address" yaml:"node_address"`
+	Total       sdk.Int        `json:"total" yaml:"total"`
+}
+
+// NodeWithdrawAddress is the address credited by the withdrawals of a node
+type NodeWithdrawAddress struct {
+	NodeAddress     sdk.AccAddress `json:"node_address" yaml:"node_address"`
+	WithdrawAddress sdk.AccAddress `json:"withdraw_address" yaml:"withdraw_address"`
+}
+
+// NodeRewardOwner is the owner of the rewards recorded under a node
+type NodeRewardOwner struct {
+	NodeAddress  sdk.AccAddress `json:"node_address" yaml:"node_address"`
+	OwnerAddress sdk.AccAddress `json:"owner_address" yaml:"owner_address"`
+}
+
+// EpochVolumeReport is the volume report settled for an epoch
+type EpochVolumeReport struct {
+	Epoch  sdk.Int            `json:"epoch" yaml:"epoch"`
+	Record VolumeReportRecord `json:"record" yaml:"record"`
 }
 
 // NewGenesisState creates a new GenesisState object
@@ -19,16 +72,182 @@ func NewGenesisState(params Params, initialUOzonePrice sdk.Dec) GenesisState {
 
 // DefaultGenesisState - default GenesisState used by Cosmos Hub
 func DefaultGenesisState() GenesisState {
-	return GenesisState{
-		Params:          DefaultParams(),
-		InitialUozPrice: DefaultUozPrice,
-	}
+	return NewGenesisState(DefaultParams(), DefaultUozPrice)
 }
 
 // ValidateGenesis validates the pot genesis parameters
 func ValidateGenesis(data GenesisState) error {
-	if data.InitialUozPrice.LTE(sdk.ZeroDec()) {
+	if err := data.Params.ValidateBasic(); err != nil {
+		return err
+	}
+	if data.InitialUozPrice.IsNil() || data.InitialUozPrice.LTE(sdk.ZeroDec()) {
 		return ErrInitialUOzonePrice
 	}
+	// totals left out of the genesis file start from zero
+	for name, value := range map[string]sdk.Int{
+		"total mined tokens":    data.TotalMinedTokens,
+		"total unissued prepay": data.TotalUnissuedPrepay,
+		"last reported epoch":   data.LastReportedEpoch,
+	} {
+		if !value.IsNil() && value.IsNegative() {
+			return fmt.Errorf("%s should not be negative: %s", name, value)
+		}
+	}
+
+	minedEpochs := make(map[string]bool)
+	for _, record := range data.MinedTokens {
+		if record.Epoch.IsNil() || !record.Epoch.IsPositive() {
+			return ErrEpochNotPositive
+		}
+		if err := validateNonNegative("mined tokens", record.MinedTokens); err != nil {
+			return err
+		}
+		if minedEpochs[record.Epoch.String()] {
+			return fmt.Errorf("duplicate mined tokens at epoch %s", record.Epoch)
+		}
+		minedEpochs[record.Epoch.String()] = true
+	}
+
+	if err := validateAddressSet("reward address pool", data.RewardAddressPool); err != nil {
+		return err
+	}
+	if err := validateAddressSet("rewards withheld", data.RewardsWithheld); err != nil {
+		return err
+	}
+
+	individualRewards := make(map[string]bool)
+	for _, record := range data.IndividualRewards {
+		if record.NodeAddress.Empty() {
+			return fmt.Errorf("empty node address in individual rewards")
+		}
+		if record.MatureEpoch.IsNil() || !record.MatureEpoch.IsPositive() {
+			return ErrEpochNotPositive
+		}
+		if !record.MatureEpoch.IsUint64() {
+			return ErrEpochTooLarge
+		}
+		if err := validateNonNegative("reward from mining pool", record.Reward.RewardFromMiningPool); err != nil {
+			return err
+		}
+		if err := validateNonNegative("reward from traffic pool", record.Reward.RewardFromTrafficPool); err != nil {
+			return err
+		}
+		key := record.NodeAddress.String() + "/" + record.MatureEpoch.String()
+		if individualRewards[key] {
+			return fmt.Errorf("duplicate individual reward of node %s at epoch %s", record.NodeAddress, record.MatureEpoch)
+		}
+		individualRewards[key] = true
+	}
+
+	if err := validateNodeRewardTotals("mature total rewards", data.MatureTotalRewards); err != nil {
+		return err
+	}
+	if err := validateNodeRewardTotals("immature total rewards", data.ImmatureTotalRewards); err != nil {
+		return err
+	}
+	if err := validateNodeRewardTotals("withdrawn total rewards", data.WithdrawnTotalRewards); err != nil {
+		return err
+	}
+
+	withdrawAddresses := make(map[string]bool)
+	for _, record := range data.WithdrawAddresses {
+		if record.NodeAddress.Empty() {
+			return fmt.Errorf("empty node address in withdraw addresses")
+		}
+		if record.WithdrawAddress.Empty() {
+			return ErrMissingWithdrawAddress
+		}
+		if withdrawAddresses[record.NodeAddress.String()] {
+			return fmt.Errorf("duplicate withdraw address of node %s", record.NodeAddress)
+		}
+		withdrawAddresses[record.NodeAddress.String()] = true
+	}
+
+	rewardOwners := make(map[string]bool)
+	for _, record := range data.RewardOwners {
+		if record.NodeAddress.Empty() {
+			return fmt.Errorf("empty node address in reward owners")
+		}
+		if record.OwnerAddress.Empty() {
+			return fmt.Errorf("empty owner address of the rewards of node %s", record.NodeAddress)
+		}
+		if rewardOwners[record.NodeAddress.String()] {
+			return fmt.Errorf("duplicate reward owner of node %s", record.NodeAddress)
+		}
+		rewardOwners[record.NodeAddress.String()] = true
+	}
+
+	reportEpochs := make(map[string]bool)
+	for _, report := range data.VolumeReports {
+		if report.Epoch.IsNil() || !report.Epoch.IsPositive() {
+			return ErrEpochNotPositive
+		}
+		if report.Record.Reporter.Empty() {
+			return ErrEmptyReporterAddr
+		}
+		if reportEpochs[report.Epoch.String()] {
+			return fmt.Errorf("duplicate volume report at epoch %s", report.Epoch)
+		}
+		reportEpochs[report.Epoch.String()] = true
+	}
+
+	proposalKeys := make(map[string]bool)
+	for _, proposal := range data.VolumeReportProposals {
+		if proposal.Epoch.IsNil() || !proposal.Epoch.IsPositive() {
+			return ErrEpochNotPositive
+		}
+		if !proposal.Epoch.IsUint64() {
+			return ErrEpochTooLarge
+		}
+		if proposal.Reporter.Empty() {
+			return ErrEmptyReporterAddr
+		}
+		if proposal.ReportReference == "" {
+			return ErrEmptyReportReference
+		}
+		key := proposal.Epoch.String() + "/" + proposal.ReportReference
+		if proposalKeys[key] {
+			return ErrVolumeReportProposalExists
+		}
+		proposalKeys[key] = true
+	}
+	return nil
+}
+
+func validateNonNegative(name string, value sdk.Int) error {
+	if value.IsNil() || value.IsNegative() {
+		return fmt.Errorf("%s should not be negative: %s", name, value)
+	}
+	return nil
+}
+
+func validateAddressSet(name string, addrs []sdk.AccAddress) error {
+	seen := make(map[string]bool)
+	for _, addr := range addrs {
+		if addr.Empty() {
+			return fmt.Errorf("empty address in %s", name)
+		}
+		if seen[addr.String()] {
+			return fmt.Errorf("duplicate address %s in %s", addr, name)
+		}
+		seen[addr.String()] = true
+	}
+	return nil
+}
+
+func validateNodeRewardTotals(name string, totals []NodeRewardTotal) error {
+	seen := make(map[string]bool)
+	for _, total := range totals {
+		if total.NodeAddress.Empty() {
+			return fmt.Errorf("empty node address in %s", name)
+		}
+		if err := validateNonNegative(name, total.Total); err != nil {
+			return err
+		}
+		if seen[total.NodeAddress.String()] {
+			return fmt.Errorf("duplicate node %s in %s", total.NodeAddress, name)
+		}
+		seen[total.NodeAddress.String()] = true
+	}
 	return nil
 }
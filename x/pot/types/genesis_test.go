@@ -0,0 +1,47 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+func TestValidateGenesis(t *testing.T) {
+	require.NoError(t, ValidateGenesis(DefaultGenesisState()))
+
+	node := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	reward := IndividualRewardRecord{NodeAddress: node, MatureEpoch: sdk.NewInt(2017), Reward: NewIndividualReward(sdk.NewInt(10), sdk.NewInt(5))}
+
+	data := DefaultGenesisState()
+	data.LastReportedEpoch = sdk.NewInt(1)
+	data.RewardAddressPool = []sdk.AccAddress{node}
+	data.IndividualRewards = []IndividualRewardRecord{reward}
+	data.ImmatureTotalRewards = []NodeRewardTotal{{NodeAddress: node, Total: sdk.NewInt(15)}}
+	require.NoError(t, ValidateGenesis(data))
+
+	data.IndividualRewards = []IndividualRewardRecord{reward, reward}
+	require.Error(t, ValidateGenesis(data))
+
+	data = DefaultGenesisState()
+	data.RewardAddressPool = []sdk.AccAddress{node, node}
+	require.Error(t, ValidateGenesis(data))
+
+	data = DefaultGenesisState()
+	withdrawAddress := NodeWithdrawAddress{NodeAddress: node, WithdrawAddress: sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())}
+	data.WithdrawAddresses = []NodeWithdrawAddress{withdrawAddress}
+	require.NoError(t, ValidateGenesis(data))
+	data.WithdrawAddresses = []NodeWithdrawAddress{withdrawAddress, withdrawAddress}
+	require.Error(t, ValidateGenesis(data))
+	data.WithdrawAddresses = []NodeWithdrawAddress{{NodeAddress: node}}
+	require.Equal(t, ErrMissingWithdrawAddress, ValidateGenesis(data))
+
+	data = DefaultGenesisState()
+	data.TotalUnissuedPrepay = sdk.NewInt(-1)
+	require.Error(t, ValidateGenesis(data))
+
+	data = DefaultGenesisState()
+	data.MinedTokens = []MinedTokensRecord{{Epoch: sdk.ZeroInt(), MinedTokens: sdk.NewInt(1)}}
+	require.Equal(t, ErrEpochNotPositive, ValidateGenesis(data))
+}
@@ -24,14 +24,22 @@ var (
 	MinedTokensKeyPrefix   = []byte{0x04} // key: prefix_epoch
 	TotalUnissuedPrepayKey = []byte{0x05}
 
-	RewardAddressPoolKey         = []byte{0x11}
-	LastReportedEpochKey         = []byte{0x12}
-	IndividualRewardKeyPrefix    = []byte{0x13} // key: prefix{address}_individual_{epoch}, the amount that is matured at {epoch}
-	MatureTotalRewardKeyPrefix   = []byte{0x14} // key: prefix{address}_mature_total
-	ImmatureTotalRewardKeyPrefix = []byte{0x15} // key: prefix{address}_immature_total
+	LastReportedEpochKey          = []byte{0x12}
+	IndividualRewardKeyPrefix     = []byte{0x13} // key: prefix{address}{epoch big endian}, the amount that is matured at {epoch}
+	MatureTotalRewardKeyPrefix    = []byte{0x14} // key: prefix{address}_mature_total
+	ImmatureTotalRewardKeyPrefix  = []byte{0x15} // key: prefix{address}_immature_total
+	RewardWithheldKeyPrefix       = []byte{0x16} // key: prefix{address}, resource nodes whose rewards are withheld
+	WithdrawnTotalRewardKeyPrefix = []byte{0x17} // key: prefix{address}_withdrawn_total
+	WithdrawAddressKeyPrefix      = []byte{0x18} // key: prefix{address}, the address credited by the withdrawals of a node
+	MaturityQueueKeyPrefix        = []byte{0x19} // key: prefix{epoch big endian}{address}, the nodes whose individual reward matures at {epoch}
+	RewardAddressKeyPrefix        = []byte{0x1a} // key: prefix{address}, the nodes that ever received a reward
+	RewardOwnerKeyPrefix          = []byte{0x1b} // key: prefix{address}, the owner of the rewards recorded under {address}
 
 	// VolumeReportStoreKeyPrefix prefix for volumeReport store
 	VolumeReportStoreKeyPrefix = []byte{0x41}
+	// VolumeReportProposalKeyPrefix prefix for the volume reports waiting for the votes of the indexing nodes,
+	// key: prefix{epoch big endian}{report reference}, several reports of an epoch compete for the votes
+	VolumeReportProposalKeyPrefix = []byte{0x42}
 )
 
 func GetMinedTokensKey(epoch sdk.Int) []byte {
@@ -43,15 +51,24 @@ func VolumeReportStoreKey(epoch sdk.Int) []byte {
 	return append(VolumeReportStoreKeyPrefix, epoch.String()...)
 }
 
-// GetIndividualRewardKey prefix{address}_individual_{epoch}, the amount that is matured at {epoch}
-func GetIndividualRewardKey(acc sdk.AccAddress, epoch sdk.Int) []byte {
-	bKeyStr := []byte("_individual_")
-	bEpoch := []byte(epoch.String())
+// GetEpochVolumeReportProposalsKey prefix{epoch big endian}, the prefix of the volume reports of an epoch waiting for votes
+func GetEpochVolumeReportProposalsKey(epoch sdk.Int) []byte {
+	return append(VolumeReportProposalKeyPrefix, sdk.Uint64ToBigEndian(epoch.Uint64())...)
+}
 
-	key := append(IndividualRewardKeyPrefix, acc...)
-	key = append(key, bKeyStr...)
-	key = append(key, bEpoch...)
-	return key
+// GetVolumeReportProposalKey prefix{epoch big endian}{report reference}
+func GetVolumeReportProposalKey(epoch sdk.Int, reportReference string) []byte {
+	return append(GetEpochVolumeReportProposalsKey(epoch), reportReference...)
+}
+
+// GetIndividualRewardsKey prefix{address}, the prefix of the individual rewards of a node ordered by epoch
+func GetIndividualRewardsKey(acc sdk.AccAddress) []byte {
+	return append(IndividualRewardKeyPrefix, acc.Bytes()...)
+}
+
+// GetIndividualRewardKey prefix{address}{epoch big endian}, the amount that is matured at {epoch}
+func GetIndividualRewardKey(acc sdk.AccAddress, epoch sdk.Int) []byte {
+	return append(GetIndividualRewardsKey(acc), sdk.Uint64ToBigEndian(epoch.Uint64())...)
 }
 
 // GetMatureTotalRewardKey prefix{address}_mature_total
@@ -62,6 +79,11 @@ func GetMatureTotalRewardKey(acc sdk.AccAddress) []byte {
 	return key
 }
 
+// GetRewardWithheldKey prefix{address}
+func GetRewardWithheldKey(acc sdk.AccAddress) []byte {
+	return append(RewardWithheldKeyPrefix, acc.Bytes()...)
+}
+
 // GetImmatureTotalRewardKey prefix{address}_immature_total
 func GetImmatureTotalRewardKey(acc sdk.AccAddress) []byte {
 	bKeyStr := []byte("_immature_total")
@@ -69,3 +91,36 @@ func GetImmatureTotalRewardKey(acc sdk.AccAddress) []byte {
 	key = append(key, bKeyStr...)
 	return key
 }
+
+// GetWithdrawnTotalRewardKey prefix{address}_withdrawn_total
+func GetWithdrawnTotalRewardKey(acc sdk.AccAddress) []byte {
+	bKeyStr := []byte("_withdrawn_total")
+	key := append(WithdrawnTotalRewardKeyPrefix, acc.Bytes()...)
+	key = append(key, bKeyStr...)
+	return key
+}
+
+// GetWithdrawAddressKey prefix{address}
+func GetWithdrawAddressKey(acc sdk.AccAddress) []byte {
+	return append(WithdrawAddressKeyPrefix, acc.Bytes()...)
+}
+
+// GetRewardAddressKey prefix{address}
+func GetRewardAddressKey(acc sdk.AccAddress) []byte {
+	return append(RewardAddressKeyPrefix, acc.Bytes()...)
+}
+
+// GetRewardOwnerKey prefix{address}
+func GetRewardOwnerKey(acc sdk.AccAddress) []byte {
+	return append(RewardOwnerKeyPrefix, acc.Bytes()...)
+}
+
+// GetMaturityQueueEpochKey prefix{epoch big endian}, the prefix of the nodes whose individual reward matures at {epoch}
+func GetMaturityQueueEpochKey(epoch sdk.Int) []byte {
+	return append(MaturityQueueKeyPrefix, sdk.Uint64ToBigEndian(epoch.Uint64())...)
+}
+
+// GetMaturityQueueKey prefix{epoch big endian}{address}
+func GetMaturityQueueKey(epoch sdk.Int, acc sdk.AccAddress) []byte {
+	return append(GetMaturityQueueEpochKey(epoch), acc.Bytes()...)
+}
@@ -2,19 +2,26 @@ package types
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
 const (
 	VolumeReportMsgType      = "volume_report"
 	WithdrawMsgType          = "withdraw"
+	WithdrawAllMsgType       = "withdraw_all"
+	SetWithdrawAddrMsgType   = "set_withdraw_address"
 	FoundationDepositMsgType = "foundation_deposit"
+	VolumeReportVoteMsgType  = "volume_report_vote"
 )
 
 // verify interface at compile time
 var (
 	_ sdk.Msg = &MsgVolumeReport{}
 	_ sdk.Msg = &MsgWithdraw{}
+	_ sdk.Msg = &MsgWithdrawAll{}
+	_ sdk.Msg = &MsgSetWithdrawAddress{}
 	_ sdk.Msg = &MsgFoundationDeposit{}
+	_ sdk.Msg = &MsgVolumeReportVote{}
 )
 
 type MsgVolumeReport struct {
@@ -23,6 +30,7 @@ type MsgVolumeReport struct {
 	Epoch           sdk.Int            `json:"report_epoch" yaml:"report_epoch"`         // volume report epoch
 	ReportReference string             `json:"report_reference" yaml:"report_reference"` // volume report reference
 	ReporterOwner   sdk.AccAddress     `json:"reporter_owner" yaml:"reporter_owner"`     // owner address of the reporter
+	UsersVolume     []SingleUserVolume `json:"users_volume" yaml:"users_volume"`         // ozone consumed by each user
 }
 
 // NewMsgVolumeReport creates a new Msg<Action> instance
@@ -32,6 +40,7 @@ func NewMsgVolumeReport(
 	epoch sdk.Int,
 	reportReference string,
 	reporterOwner sdk.AccAddress,
+	usersVolume []SingleUserVolume,
 ) MsgVolumeReport {
 	return MsgVolumeReport{
 		NodesVolume:     nodesVolume,
@@ -39,6 +48,7 @@ func NewMsgVolumeReport(
 		Epoch:           epoch,
 		ReportReference: reportReference,
 		ReporterOwner:   reporterOwner,
+		UsersVolume:     usersVolume,
 	}
 }
 
@@ -100,9 +110,12 @@ func (msg MsgVolumeReport) ValidateBasic() error {
 		return ErrEmptyNodesVolume
 	}
 
-	if !(msg.Epoch.IsPositive()) {
+	if msg.Epoch.IsNil() || !msg.Epoch.IsPositive() {
 		return ErrEpochNotPositive
 	}
+	if !msg.Epoch.IsUint64() {
+		return ErrEpochTooLarge
+	}
 
 	if !(len(msg.ReportReference) > 0) {
 		return ErrEmptyReportReference
@@ -111,13 +124,31 @@ func (msg MsgVolumeReport) ValidateBasic() error {
 		return ErrEmptyReporterOwnerAddr
 	}
 
-	for _, item := range msg.NodesVolume {
-		if item.Volume.IsNegative() {
+	if errs := ValidateNodesVolume(msg.NodesVolume); len(errs) > 0 {
+		return errs
+	}
+	// the ozone consumed by the users pays for the traffic of the nodes
+	usersVolume := sdk.ZeroInt()
+	seenUsers := make(map[string]bool)
+	for _, item := range msg.UsersVolume {
+		if item.Volume.IsNil() || item.Volume.IsNegative() {
 			return ErrNegativeVolume
 		}
-		if item.NodeAddress.Empty() {
-			return ErrMissingNodeAddress
+		if item.UserAddress.Empty() {
+			return ErrMissingUserAddress
 		}
+		if seenUsers[item.UserAddress.String()] {
+			return sdkerrors.Wrap(ErrDuplicateUserAddress, item.UserAddress.String())
+		}
+		seenUsers[item.UserAddress.String()] = true
+		usersVolume = usersVolume.Add(item.Volume)
+	}
+	nodesVolume := sdk.ZeroInt()
+	for _, item := range msg.NodesVolume {
+		nodesVolume = nodesVolume.Add(item.Volume)
+	}
+	if !usersVolume.Equal(nodesVolume) {
+		return sdkerrors.Wrapf(ErrVolumeSumMismatch, "users volume %s, nodes volume %s", usersVolume, nodesVolume)
 	}
 	return nil
 }
@@ -167,6 +198,104 @@ func (msg MsgWithdraw) ValidateBasic() error {
 	return nil
 }
 
+// MsgWithdrawAll - struct for withdrawing the whole mature reward of one or more nodes of the same owner
+type MsgWithdrawAll struct {
+	NodeAddresses []sdk.AccAddress `json:"node_addresses" yaml:"node_addresses"`
+	OwnerAddress  sdk.AccAddress   `json:"owner_address" yaml:"owner_address"`
+}
+
+func NewMsgWithdrawAll(nodeAddresses []sdk.AccAddress, ownerAddress sdk.AccAddress) MsgWithdrawAll {
+	return MsgWithdrawAll{
+		NodeAddresses: nodeAddresses,
+		OwnerAddress:  ownerAddress,
+	}
+}
+
+// Route Implement
+func (msg MsgWithdrawAll) Route() string { return RouterKey }
+
+// GetSigners Implement
+func (msg MsgWithdrawAll) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.OwnerAddress}
+}
+
+// Type Implement
+func (msg MsgWithdrawAll) Type() string { return WithdrawAllMsgType }
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgWithdrawAll) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic validity check for the AnteHandler
+func (msg MsgWithdrawAll) ValidateBasic() error {
+	if len(msg.NodeAddresses) == 0 {
+		return ErrMissingNodeAddress
+	}
+	seen := make(map[string]bool)
+	for _, nodeAddress := range msg.NodeAddresses {
+		if nodeAddress.Empty() {
+			return ErrMissingNodeAddress
+		}
+		if seen[nodeAddress.String()] {
+			return ErrDuplicateNodeAddress
+		}
+		seen[nodeAddress.String()] = true
+	}
+	if msg.OwnerAddress.Empty() {
+		return ErrMissingOwnerAddress
+	}
+	return nil
+}
+
+// MsgSetWithdrawAddress - struct for setting the address credited by the withdrawals of a node
+type MsgSetWithdrawAddress struct {
+	NodeAddress     sdk.AccAddress `json:"node_address" yaml:"node_address"`
+	OwnerAddress    sdk.AccAddress `json:"owner_address" yaml:"owner_address"`
+	WithdrawAddress sdk.AccAddress `json:"withdraw_address" yaml:"withdraw_address"`
+}
+
+func NewMsgSetWithdrawAddress(nodeAddress sdk.AccAddress, ownerAddress sdk.AccAddress, withdrawAddress sdk.AccAddress,
+) MsgSetWithdrawAddress {
+	return MsgSetWithdrawAddress{
+		NodeAddress:     nodeAddress,
+		OwnerAddress:    ownerAddress,
+		WithdrawAddress: withdrawAddress,
+	}
+}
+
+// Route Implement
+func (msg MsgSetWithdrawAddress) Route() string { return RouterKey }
+
+// GetSigners Implement
+func (msg MsgSetWithdrawAddress) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.OwnerAddress}
+}
+
+// Type Implement
+func (msg MsgSetWithdrawAddress) Type() string { return SetWithdrawAddrMsgType }
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgSetWithdrawAddress) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic validity check for the AnteHandler
+func (msg MsgSetWithdrawAddress) ValidateBasic() error {
+	if msg.NodeAddress.Empty() {
+		return ErrMissingNodeAddress
+	}
+	if msg.OwnerAddress.Empty() {
+		return ErrMissingOwnerAddress
+	}
+	if msg.WithdrawAddress.Empty() {
+		return ErrMissingWithdrawAddress
+	}
+	return nil
+}
+
 type MsgFoundationDeposit struct {
 	Amount sdk.Coin       `json:"amount" yaml:"amount"`
 	From   sdk.AccAddress `json:"from" yaml:"from"`
@@ -206,3 +335,60 @@ func (msg MsgFoundationDeposit) ValidateBasic() error {
 	}
 	return nil
 }
+
+// MsgVolumeReportVote - struct for an indexing node approving or rejecting the pending volume report of an epoch
+type MsgVolumeReportVote struct {
+	Epoch               sdk.Int        `json:"epoch" yaml:"epoch"`                                 // epoch of the volume report
+	ReportReference     string         `json:"report_reference" yaml:"report_reference"`           // reference of the volume report being voted on
+	Opinion             bool           `json:"opinion" yaml:"opinion"`                             // true to approve the report
+	VoterNetworkAddress sdk.AccAddress `json:"voter_network_address" yaml:"voter_network_address"` // node address of the voting indexing node
+	VoterOwnerAddress   sdk.AccAddress `json:"voter_owner_address" yaml:"voter_owner_address"`     // owner address of the voting indexing node
+}
+
+func NewMsgVolumeReportVote(epoch sdk.Int, reportReference string, opinion bool, voterNetworkAddress sdk.AccAddress,
+	voterOwnerAddress sdk.AccAddress) MsgVolumeReportVote {
+	return MsgVolumeReportVote{
+		Epoch:               epoch,
+		ReportReference:     reportReference,
+		Opinion:             opinion,
+		VoterNetworkAddress: voterNetworkAddress,
+		VoterOwnerAddress:   voterOwnerAddress,
+	}
+}
+
+// Route Implement
+func (msg MsgVolumeReportVote) Route() string { return RouterKey }
+
+// GetSigners Implement
+func (msg MsgVolumeReportVote) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.VoterOwnerAddress}
+}
+
+// Type Implement
+func (msg MsgVolumeReportVote) Type() string { return VolumeReportVoteMsgType }
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgVolumeReportVote) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic validity check for the AnteHandler
+func (msg MsgVolumeReportVote) ValidateBasic() error {
+	if msg.Epoch.IsNil() || !msg.Epoch.IsPositive() {
+		return ErrEpochNotPositive
+	}
+	if !msg.Epoch.IsUint64() {
+		return ErrEpochTooLarge
+	}
+	if !(len(msg.ReportReference) > 0) {
+		return ErrEmptyReportReference
+	}
+	if msg.VoterNetworkAddress.Empty() {
+		return ErrEmptyVoterAddr
+	}
+	if msg.VoterOwnerAddress.Empty() {
+		return ErrEmptyVoterOwnerAddr
+	}
+	return nil
+}
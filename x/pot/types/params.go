@@ -7,6 +7,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/params/subspace"
 	"strings"
+	"time"
 )
 
 // DefaultParamSpace Default parameter namespace
@@ -14,22 +15,30 @@ const (
 	DefaultParamSpace  = ModuleName
 	DefaultBondDenom   = "ustos"
 	DefaultMatureEpoch = 2016
+	// DefaultMaxEpochJump lets a volume report skip up to 14 days of epochs
+	DefaultMaxEpochJump = 2016
+	// DefaultVolumeReportVotingPeriod is how long the indexing nodes may vote on a volume report
+	DefaultVolumeReportVotingPeriod = time.Hour
 )
 
 // Parameter store keys
 var (
-	KeyBondDenom          = []byte("BondDenom")
-	KeyMatureEpoch        = []byte("matureEpoch")
-	KeyMiningRewardParams = []byte("MiningRewardParams")
+	KeyBondDenom                = []byte("BondDenom")
+	KeyMatureEpoch              = []byte("matureEpoch")
+	KeyMiningRewardParams       = []byte("MiningRewardParams")
+	KeyMaxEpochJump             = []byte("MaxEpochJump")
+	KeyVolumeReportVotingPeriod = []byte("VolumeReportVotingPeriod")
 )
 
 var _ subspace.ParamSet = &Params{}
 
 // Params - used for initializing default parameter for pot at genesis
 type Params struct {
-	BondDenom          string              `json:"bond_denom" yaml:"bond_denom"` // bondable coin denomination
-	MatureEpoch        int64               `json:"mature_epoch" yaml:"mature_epoch"`
-	MiningRewardParams []MiningRewardParam `json:"mining_reward_params" yaml:"mining_reward_params"`
+	BondDenom                string              `json:"bond_denom" yaml:"bond_denom"` // bondable coin denomination
+	MatureEpoch              int64               `json:"mature_epoch" yaml:"mature_epoch"`
+	MiningRewardParams       []MiningRewardParam `json:"mining_reward_params" yaml:"mining_reward_params"`
+	MaxEpochJump             int64               `json:"max_epoch_jump" yaml:"max_epoch_jump"`                           // how far past the last reported epoch a volume report may be
+	VolumeReportVotingPeriod time.Duration       `json:"volume_report_voting_period" yaml:"volume_report_voting_period"` // how long the indexing nodes may vote on a volume report
 }
 
 // ParamKeyTable for pot module
@@ -38,11 +47,14 @@ func ParamKeyTable() params.KeyTable {
 }
 
 // NewParams creates a new Params object
-func NewParams(bondDenom string, matureEpoch int64, miningRewardParams []MiningRewardParam) Params {
+func NewParams(bondDenom string, matureEpoch int64, miningRewardParams []MiningRewardParam, maxEpochJump int64,
+	volumeReportVotingPeriod time.Duration) Params {
 	return Params{
-		BondDenom:          bondDenom,
-		MatureEpoch:        matureEpoch,
-		MiningRewardParams: miningRewardParams,
+		BondDenom:                bondDenom,
+		MatureEpoch:              matureEpoch,
+		MiningRewardParams:       miningRewardParams,
+		MaxEpochJump:             maxEpochJump,
+		VolumeReportVotingPeriod: volumeReportVotingPeriod,
 	}
 }
 
@@ -72,7 +84,7 @@ func DefaultParams() Params {
 	miningRewardParams = append(miningRewardParams, NewMiningRewardParam(
 		sdk.NewInt(32587200000000000), sdk.NewInt(40000000000000000), sdk.NewInt(2500000000),
 		sdk.NewInt(7000), sdk.NewInt(1000), sdk.NewInt(2000)))
-	return NewParams(DefaultBondDenom, DefaultMatureEpoch, miningRewardParams)
+	return NewParams(DefaultBondDenom, DefaultMatureEpoch, miningRewardParams, DefaultMaxEpochJump, DefaultVolumeReportVotingPeriod)
 }
 
 // String implements the stringer interface for Params
@@ -80,8 +92,10 @@ func (p Params) String() string {
 	return fmt.Sprintf(`Params:
 	BondDenom:			%s
 	MatureEpoch:        %d
-  	MiningRewardParams:	%s`,
-		p.BondDenom, p.MatureEpoch, p.MiningRewardParams)
+  	MiningRewardParams:	%s
+	MaxEpochJump:		%d
+	VolumeReportVotingPeriod:	%s`,
+		p.BondDenom, p.MatureEpoch, p.MiningRewardParams, p.MaxEpochJump, p.VolumeReportVotingPeriod)
 }
 
 // ParamSetPairs - Implements params.ParamSet
@@ -90,6 +104,8 @@ func (p *Params) ParamSetPairs() params.ParamSetPairs {
 		params.NewParamSetPair(KeyBondDenom, &p.BondDenom, validateBondDenom),
 		params.NewParamSetPair(KeyMatureEpoch, &p.MatureEpoch, validateMatureEpoch),
 		params.NewParamSetPair(KeyMiningRewardParams, &p.MiningRewardParams, validateMiningRewardParams),
+		params.NewParamSetPair(KeyMaxEpochJump, &p.MaxEpochJump, validateMaxEpochJump),
+		params.NewParamSetPair(KeyVolumeReportVotingPeriod, &p.VolumeReportVotingPeriod, validateVolumeReportVotingPeriod),
 	}
 }
 
@@ -122,7 +138,55 @@ func validateMatureEpoch(i interface{}) error {
 	return nil
 }
 
+func validateMaxEpochJump(i interface{}) error {
+	v, ok := i.(int64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v <= 0 {
+		return fmt.Errorf("max epoch jump must be positive: %d", v)
+	}
+
+	return nil
+}
+
+func validateVolumeReportVotingPeriod(i interface{}) error {
+	v, ok := i.(time.Duration)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v <= 0 {
+		return fmt.Errorf("volume report voting period must be positive: %s", v)
+	}
+
+	return nil
+}
+
 func validateMiningRewardParams(i interface{}) error {
+	v, ok := i.([]MiningRewardParam)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if len(v) == 0 {
+		return errors.New("mining reward params cannot be empty")
+	}
+	// the valve ranges must cover the mined total from zero without gaps or overlaps
+	if !v[0].TotalMinedValveStart.IsZero() {
+		return fmt.Errorf("first mining reward valve must start at 0: %s", v[0].TotalMinedValveStart)
+	}
+	for i, param := range v {
+		if err := param.Validate(); err != nil {
+			return fmt.Errorf("invalid mining reward param at index %d: %w", i, err)
+		}
+		if i > 0 && !param.TotalMinedValveStart.Equal(v[i-1].TotalMinedValveEnd) {
+			return fmt.Errorf("mining reward valves are not contiguous: %s != %s",
+				param.TotalMinedValveStart, v[i-1].TotalMinedValveEnd)
+		}
+	}
+
 	return nil
 }
 
@@ -133,5 +197,14 @@ func (p Params) ValidateBasic() error {
 	if err := validateMatureEpoch(p.MatureEpoch); err != nil {
 		return err
 	}
+	if err := validateMiningRewardParams(p.MiningRewardParams); err != nil {
+		return err
+	}
+	if err := validateMaxEpochJump(p.MaxEpochJump); err != nil {
+		return err
+	}
+	if err := validateVolumeReportVotingPeriod(p.VolumeReportVotingPeriod); err != nil {
+		return err
+	}
 	return nil
 }
@@ -0,0 +1,73 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateMiningRewardParams(t *testing.T) {
+	require.NoError(t, DefaultParams().ValidateBasic())
+
+	contiguous := []MiningRewardParam{
+		NewMiningRewardParam(sdk.NewInt(0), sdk.NewInt(100), sdk.NewInt(10),
+			sdk.NewInt(6000), sdk.NewInt(2000), sdk.NewInt(2000)),
+		NewMiningRewardParam(sdk.NewInt(100), sdk.NewInt(200), sdk.NewInt(5),
+			sdk.NewInt(7000), sdk.NewInt(1000), sdk.NewInt(2000)),
+	}
+	require.NoError(t, validateMiningRewardParams(contiguous))
+
+	gap := []MiningRewardParam{
+		contiguous[0],
+		NewMiningRewardParam(sdk.NewInt(150), sdk.NewInt(200), sdk.NewInt(5),
+			sdk.NewInt(7000), sdk.NewInt(1000), sdk.NewInt(2000)),
+	}
+	require.Error(t, validateMiningRewardParams(gap))
+
+	notFromZero := []MiningRewardParam{contiguous[1]}
+	require.Error(t, validateMiningRewardParams(notFromZero))
+
+	badPercentages := []MiningRewardParam{
+		NewMiningRewardParam(sdk.NewInt(0), sdk.NewInt(100), sdk.NewInt(10),
+			sdk.NewInt(6000), sdk.NewInt(2000), sdk.NewInt(1999)),
+	}
+	require.Error(t, validateMiningRewardParams(badPercentages))
+
+	require.Error(t, validateMiningRewardParams([]MiningRewardParam{}))
+}
+
+func TestUpdateParamsProposalValidateBasic(t *testing.T) {
+	proposal := NewUpdateParamsProposal("title", "description", DefaultParams())
+	require.NoError(t, proposal.ValidateBasic())
+
+	params := DefaultParams()
+	params.MiningRewardParams[1].TotalMinedValveStart = sdk.NewInt(1)
+	proposal = NewUpdateParamsProposal("title", "description", params)
+	require.Error(t, proposal.ValidateBasic())
+}
+
+func TestValidateMaxEpochJump(t *testing.T) {
+	params := DefaultParams()
+	require.Equal(t, int64(DefaultMaxEpochJump), params.MaxEpochJump)
+
+	params.MaxEpochJump = 0
+	require.Error(t, params.ValidateBasic())
+	params.MaxEpochJump = -1
+	require.Error(t, params.ValidateBasic())
+	params.MaxEpochJump = 1
+	require.NoError(t, params.ValidateBasic())
+}
+
+func TestValidateVolumeReportVotingPeriod(t *testing.T) {
+	params := DefaultParams()
+	require.Equal(t, DefaultVolumeReportVotingPeriod, params.VolumeReportVotingPeriod)
+
+	params.VolumeReportVotingPeriod = 0
+	require.Error(t, params.ValidateBasic())
+	params.VolumeReportVotingPeriod = -time.Second
+	require.Error(t, params.ValidateBasic())
+	params.VolumeReportVotingPeriod = time.Second
+	require.NoError(t, params.ValidateBasic())
+}
@@ -4,8 +4,10 @@ import "github.com/cosmos/cosmos-sdk/types"
 
 // querier keys
 const (
-	QueryParams           = "params"
-	QueryVolumeReportHash = "volume_report"
+	QueryParams            = "params"
+	QueryVolumeReportHash  = "volume_report"
+	QueryRewardHistory     = "pot_reward_history"
+	QueryRewardAddressPool = "reward_address_pool"
 )
 
 // QueryVolumeReportParams for query 'custom/distr/validator_outstanding_rewards'
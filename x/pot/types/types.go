@@ -1,6 +1,15 @@
 package types
 
-import sdk "github.com/cosmos/cosmos-sdk/types"
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// PercentageInTenThousandTotal is the sum that the reward percentages of a MiningRewardParam must add up to
+var PercentageInTenThousandTotal = sdk.NewInt(10000)
 
 type SingleNodeVolume struct {
 	NodeAddress sdk.AccAddress `json:"node_address" yaml:"node_address"`
@@ -18,6 +27,80 @@ func NewSingleNodeVolume(
 	}
 }
 
+// NodeVolumeError is the reason a single entry of the nodes volume of a report is rejected
+type NodeVolumeError struct {
+	Index       int
+	NodeAddress sdk.AccAddress
+	Err         error
+}
+
+func (e NodeVolumeError) Error() string {
+	return fmt.Sprintf("nodes_volume[%d] %s: %s", e.Index, e.NodeAddress, e.Err)
+}
+
+// NodeVolumeErrors lists every rejected entry of the nodes volume of a report. It is reported with the
+// ErrInvalidNodesVolume code, and errors.Is matches the reason of any of its entries.
+type NodeVolumeErrors []NodeVolumeError
+
+func (e NodeVolumeErrors) Error() string {
+	reasons := make([]string, len(e))
+	for i, nodeErr := range e {
+		reasons[i] = nodeErr.Error()
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidNodesVolume, strings.Join(reasons, "; "))
+}
+
+// Cause returns ErrInvalidNodesVolume, so that the ABCI code of the report is the one of the list
+func (e NodeVolumeErrors) Cause() error {
+	return ErrInvalidNodesVolume
+}
+
+// Unpack returns the reasons of the rejected entries
+func (e NodeVolumeErrors) Unpack() []error {
+	errs := make([]error, len(e))
+	for i, nodeErr := range e {
+		errs[i] = nodeErr.Err
+	}
+	return errs
+}
+
+// ValidateNodesVolume checks every entry of the nodes volume of a report for a missing address, a volume
+// that is not positive and a node reported more than once
+func ValidateNodesVolume(nodesVolume []SingleNodeVolume) (errs NodeVolumeErrors) {
+	seen := make(map[string]bool)
+	for i, item := range nodesVolume {
+		if item.NodeAddress.Empty() {
+			errs = append(errs, NodeVolumeError{Index: i, NodeAddress: item.NodeAddress, Err: ErrMissingNodeAddress})
+			continue
+		}
+		if item.Volume.IsNil() || !item.Volume.IsPositive() {
+			errs = append(errs, NodeVolumeError{Index: i, NodeAddress: item.NodeAddress, Err: ErrVolumeNotPositive})
+		}
+		if seen[item.NodeAddress.String()] {
+			errs = append(errs, NodeVolumeError{Index: i, NodeAddress: item.NodeAddress, Err: ErrDuplicateNodeAddress})
+		}
+		seen[item.NodeAddress.String()] = true
+	}
+	return errs
+}
+
+// SingleUserVolume is the traffic consumed by a user within an epoch, paid with its ozone balance
+type SingleUserVolume struct {
+	UserAddress sdk.AccAddress `json:"user_address" yaml:"user_address"`
+	Volume      sdk.Int        `json:"user_volume" yaml:"user_volume"` //uoz
+}
+
+// NewSingleUserVolume creates a new SingleUserVolume instance
+func NewSingleUserVolume(
+	userAddress sdk.AccAddress,
+	volume sdk.Int,
+) SingleUserVolume {
+	return SingleUserVolume{
+		UserAddress: userAddress,
+		Volume:      volume,
+	}
+}
+
 type MiningRewardParam struct {
 	TotalMinedValveStart                sdk.Int `json:"total_mined_valve_start" yaml:"total_mined_valve_start"`
 	TotalMinedValveEnd                  sdk.Int `json:"total_mined_valve_end" yaml:"total_mined_valve_end"`
@@ -38,3 +121,27 @@ func NewMiningRewardParam(totalMinedValveStart sdk.Int, totalMinedValveEnd sdk.I
 		MetaNodePercentageInTenThousand:     metaNodePercentageInTenThousand,
 	}
 }
+
+// Validate checks the valve range, the reward and the percentages of a single mining reward param
+func (p MiningRewardParam) Validate() error {
+	if p.TotalMinedValveStart.IsNil() || p.TotalMinedValveEnd.IsNil() || p.MiningReward.IsNil() ||
+		p.BlockChainPercentageInTenThousand.IsNil() || p.ResourceNodePercentageInTenThousand.IsNil() ||
+		p.MetaNodePercentageInTenThousand.IsNil() {
+		return errors.New("mining reward param fields cannot be empty")
+	}
+	if p.TotalMinedValveStart.IsNegative() || p.TotalMinedValveEnd.LTE(p.TotalMinedValveStart) {
+		return fmt.Errorf("invalid valve range [%s, %s)", p.TotalMinedValveStart, p.TotalMinedValveEnd)
+	}
+	if p.MiningReward.IsNegative() {
+		return fmt.Errorf("mining reward cannot be negative: %s", p.MiningReward)
+	}
+	if p.BlockChainPercentageInTenThousand.IsNegative() || p.ResourceNodePercentageInTenThousand.IsNegative() ||
+		p.MetaNodePercentageInTenThousand.IsNegative() {
+		return errors.New("reward percentages cannot be negative")
+	}
+	total := p.BlockChainPercentageInTenThousand.Add(p.ResourceNodePercentageInTenThousand).Add(p.MetaNodePercentageInTenThousand)
+	if !total.Equal(PercentageInTenThousandTotal) {
+		return fmt.Errorf("reward percentages must sum to %s: %s", PercentageInTenThousandTotal, total)
+	}
+	return nil
+}
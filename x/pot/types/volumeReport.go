@@ -1,6 +1,9 @@
 package types
 
 import (
+	"fmt"
+	"time"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -15,3 +18,79 @@ func NewReportInfo(epoch sdk.Int, reference string) ReportInfo {
 		Reference: reference,
 	}
 }
+
+// VolumeReportProposal is a volume report waiting for the approval of the other bonded indexing nodes.
+// The pot rewards of the epoch are only distributed once 2/3+1 of the valid indexing nodes approved it.
+type VolumeReportProposal struct {
+	Epoch           sdk.Int            `json:"epoch" yaml:"epoch"`
+	NodesVolume     []SingleNodeVolume `json:"nodes_volume" yaml:"nodes_volume"`
+	UsersVolume     []SingleUserVolume `json:"users_volume" yaml:"users_volume"`
+	Reporter        sdk.AccAddress     `json:"reporter" yaml:"reporter"`
+	ReportReference string             `json:"report_reference" yaml:"report_reference"`
+	TxHash          string             `json:"tx_hash" yaml:"tx_hash"`
+	ApproveList     []sdk.AccAddress   `json:"approve_list" yaml:"approve_list"`
+	RejectList      []sdk.AccAddress   `json:"reject_list" yaml:"reject_list"`
+	ExpireTime      time.Time          `json:"expire_time" yaml:"expire_time"`
+}
+
+// NewVolumeReportProposal creates a new volume report proposal approved by its reporter
+func NewVolumeReportProposal(epoch sdk.Int, nodesVolume []SingleNodeVolume, usersVolume []SingleUserVolume,
+	reporter sdk.AccAddress, reportReference string, txHash string, expireTime time.Time) VolumeReportProposal {
+	return VolumeReportProposal{
+		Epoch:           epoch,
+		NodesVolume:     nodesVolume,
+		UsersVolume:     usersVolume,
+		Reporter:        reporter,
+		ReportReference: reportReference,
+		TxHash:          txHash,
+		ApproveList:     []sdk.AccAddress{reporter},
+		RejectList:      make([]sdk.AccAddress, 0),
+		ExpireTime:      expireTime,
+	}
+}
+
+// HasVoted returns true if the voter already approved or rejected the proposal
+func (p VolumeReportProposal) HasVoted(voter sdk.AccAddress) bool {
+	for _, addr := range append(p.ApproveList, p.RejectList...) {
+		if addr.Equals(voter) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns a human readable string representation of a VolumeReportProposal.
+func (p VolumeReportProposal) String() string {
+	return fmt.Sprintf(`VolumeReportProposal:{
+		Epoch:				%s
+		Reporter:			%s
+		ReportReference:	%s
+		TxHash:				%s
+		ApproveList:		%s
+		RejectList:			%s
+		ExpireTime:			%s
+	}`, p.Epoch, p.Reporter, p.ReportReference, p.TxHash, p.ApproveList, p.RejectList, p.ExpireTime)
+}
+
+// VolumeReportProposalStatus is the outcome of a vote on a volume report proposal
+type VolumeReportProposalStatus byte
+
+const (
+	VolumeReportProposalPending  VolumeReportProposalStatus = 0x01
+	VolumeReportProposalAccepted VolumeReportProposalStatus = 0x02
+	VolumeReportProposalRejected VolumeReportProposalStatus = 0x03
+)
+
+// String implements the Stringer interface for VolumeReportProposalStatus.
+func (s VolumeReportProposalStatus) String() string {
+	switch s {
+	case VolumeReportProposalPending:
+		return "Pending"
+	case VolumeReportProposalAccepted:
+		return "Accepted"
+	case VolumeReportProposalRejected:
+		return "Rejected"
+	default:
+		return ""
+	}
+}
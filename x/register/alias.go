@@ -13,6 +13,11 @@ const (
 	NodeTypeComputation = types.COMPUTATION
 	NodeTypeDataBase    = types.DATABASE
 	NodeTypeStorage     = types.STORAGE
+
+	ResourceNodeBondedPoolName    = types.ResourceNodeBondedPoolName
+	ResourceNodeNotBondedPoolName = types.ResourceNodeNotBondedPoolName
+	IndexingNodeBondedPoolName    = types.IndexingNodeBondedPoolName
+	IndexingNodeNotBondedPoolName = types.IndexingNodeNotBondedPoolName
 )
 
 var (
@@ -43,6 +48,23 @@ var (
 	NewDescription           = types.NewDescription
 	NewMsgCreateResourceNode = types.NewMsgCreateResourceNode
 	NewMsgCreateIndexingNode = types.NewMsgCreateIndexingNode
+	NewSlashEvidence         = types.NewSlashEvidence
+	NewMsgSlashResourceNode  = types.NewMsgSlashResourceNode
+	NewMsgSlashIndexingNode  = types.NewMsgSlashIndexingNode
+
+	NewMsgUnbondResourceNodeStake = types.NewMsgUnbondResourceNodeStake
+	NewMsgUnbondIndexingNodeStake = types.NewMsgUnbondIndexingNodeStake
+	NewMsgAddResourceNodeStake    = types.NewMsgAddResourceNodeStake
+	NewMsgAddIndexingNodeStake    = types.NewMsgAddIndexingNodeStake
+
+	NewDelegation                      = types.NewDelegation
+	NewCommission                      = types.NewCommission
+	NewMsgDelegateResourceNode         = types.NewMsgDelegateResourceNode
+	NewMsgDelegateIndexingNode         = types.NewMsgDelegateIndexingNode
+	NewMsgUndelegateResourceNode       = types.NewMsgUndelegateResourceNode
+	NewMsgUndelegateIndexingNode       = types.NewMsgUndelegateIndexingNode
+	NewMsgUpdateResourceNodeCommission = types.NewMsgUpdateResourceNodeCommission
+	NewMsgUpdateIndexingNodeCommission = types.NewMsgUpdateIndexingNodeCommission
 
 	GetGenesisStateFromAppState = types.GetGenesisStateFromAppState
 
@@ -60,4 +82,9 @@ type (
 	LastResourceNodeStake = types.LastResourceNodeStake
 	LastIndexingNodeStake = types.LastIndexingNodeStake
 	VoteOpinion           = types.VoteOpinion
+	SlashType             = types.SlashType
+	SlashEvidence         = types.SlashEvidence
+	Delegation            = types.Delegation
+	Delegations           = types.Delegations
+	Commission            = types.Commission
 )
@@ -32,6 +32,7 @@ func TestRegister(t *testing.T) {
 		resOwnerAddr3,
 		NewDescription("sds://resourceNode3", "", "", "", ""),
 		"4",
+		sdk.ZeroInt(),
 	)
 	t.Log("registerResNodeMsg: ", registerResNodeMsg)
 
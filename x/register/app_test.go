@@ -41,7 +41,7 @@ func Test(t *testing.T) {
 	/********************* send register resource node msg *********************/
 	header = abci.Header{Height: mApp.LastBlockHeight() + 1}
 	ctx = mApp.BaseApp.NewContext(true, header)
-	registerResNodeMsg := types.NewMsgCreateResourceNode("sds://resourceNode2", resNodePubKey2, sdk.NewCoin(k.BondDenom(ctx), resNodeInitStake), resOwnerAddr2, NewDescription("sds://resourceNode2", "", "", "", ""), "4")
+	registerResNodeMsg := types.NewMsgCreateResourceNode("sds://resourceNode2", resNodePubKey2, sdk.NewCoin(k.BondDenom(ctx), resNodeInitStake), resOwnerAddr2, NewDescription("sds://resourceNode2", "", "", "", ""), "4", sdk.ZeroInt())
 	resNodeOwnerAcc2 := mApp.AccountKeeper.GetAccount(ctx, resOwnerAddr2)
 	accNumOwner := resNodeOwnerAcc2.GetAccountNumber()
 	accSeqOwner := resNodeOwnerAcc2.GetSequence()
@@ -140,13 +140,17 @@ func getMockApp(t *testing.T) (*mock.App, Keeper, bank.Keeper, supply.Keeper) {
 
 	bankKeeper := bank.NewBaseKeeper(mApp.AccountKeeper, mApp.ParamsKeeper.Subspace(bank.DefaultParamspace), blacklistedAddrs)
 	maccPerms := map[string][]string{
-		auth.FeeCollectorName:     {"fee_collector"},
-		staking.NotBondedPoolName: {supply.Burner, supply.Staking},
-		staking.BondedPoolName:    {supply.Burner, supply.Staking},
+		auth.FeeCollectorName:         {"fee_collector"},
+		staking.NotBondedPoolName:     {supply.Burner, supply.Staking},
+		staking.BondedPoolName:        {supply.Burner, supply.Staking},
+		ResourceNodeBondedPoolName:    nil,
+		ResourceNodeNotBondedPoolName: {supply.Burner},
+		IndexingNodeBondedPoolName:    nil,
+		IndexingNodeNotBondedPoolName: {supply.Burner},
 	}
 	supplyKeeper := supply.NewKeeper(mApp.Cdc, keySupply, mApp.AccountKeeper, bankKeeper, maccPerms)
 	stakingKeeper := staking.NewKeeper(mApp.Cdc, keyStaking, supplyKeeper, mApp.ParamsKeeper.Subspace(staking.DefaultParamspace))
-	keeper := NewKeeper(mApp.Cdc, keyRegister, mApp.ParamsKeeper.Subspace(DefaultParamSpace), mApp.AccountKeeper, bankKeeper)
+	keeper := NewKeeper(mApp.Cdc, keyRegister, mApp.ParamsKeeper.Subspace(DefaultParamSpace), mApp.AccountKeeper, bankKeeper, supplyKeeper)
 
 	anteHandler := ante.NewAnteHandler(mApp.AccountKeeper, supplyKeeper, helpers.StSigVerificationGasConsumer)
 	mApp.SetAnteHandler(anteHandler)
@@ -9,6 +9,7 @@ const (
 	FlagAmount    = "amount"
 	FlagNetworkID = "network-id"
 	FlagNodeType  = "node-type"
+	FlagCapacity  = "capacity"
 
 	FlagMoniker         = "moniker"
 	FlagIdentity        = "identity"
@@ -21,6 +22,11 @@ const (
 	FlagCandidateNetworkAddress = "candidate-network-address"
 	FlagOpinion                 = "opinion"
 	FlagVoterNetworkAddress     = "voter-network-address"
+
+	FlagReporterNetworkAddress = "reporter-network-address"
+	FlagSlashType              = "slash-type"
+	FlagEvidenceHeight         = "evidence-height"
+	FlagEvidenceReference      = "evidence-reference"
 )
 
 // common flagsets to add to various functions
@@ -29,12 +35,14 @@ var (
 	FsAmount                  = flag.NewFlagSet("", flag.ContinueOnError)
 	FsNetworkID               = flag.NewFlagSet("", flag.ContinueOnError)
 	FsNodeType                = flag.NewFlagSet("", flag.ContinueOnError)
+	FsCapacity                = flag.NewFlagSet("", flag.ContinueOnError)
 	FsDescription             = flag.NewFlagSet("", flag.ContinueOnError)
 	FsNetworkAddress          = flag.NewFlagSet("", flag.ContinueOnError)
 	FsCandidateNetworkAddress = flag.NewFlagSet("", flag.ContinueOnError)
 	FsCandidateOwnerAddress   = flag.NewFlagSet("", flag.ContinueOnError)
 	FsOpinion                 = flag.NewFlagSet("", flag.ContinueOnError)
 	FsVoterNetworkAddress     = flag.NewFlagSet("", flag.ContinueOnError)
+	FsSlashEvidence           = flag.NewFlagSet("", flag.ContinueOnError)
 )
 
 func init() {
@@ -61,6 +69,8 @@ As a result, the value of node_type should be one of the following digits:
 	6:  "database/storage",
 	7:  "computation/database/storage"`)
 
+	FsCapacity.String(FlagCapacity, "", "The max volume (in uoz) credited to the resource node per epoch, up to the max capacity param, which it defaults to")
+
 	FsDescription.String(FlagMoniker, "", "The node's name")
 	FsDescription.String(FlagIdentity, "", "The optional identity signature (ex. UPort or Keybase)")
 	FsDescription.String(FlagWebsite, "", "The node's (optional) website")
@@ -72,4 +82,9 @@ As a result, the value of node_type should be one of the following digits:
 	FsCandidateOwnerAddress.String(FlagCandidateOwnerAddress, "The owner address of the candidate PP node", "")
 	FsOpinion.Bool(FlagOpinion, false, "Opinion of the vote for the registration of Indexing node.")
 	FsVoterNetworkAddress.String(FlagVoterNetworkAddress, "The address of the PP node that made the vote.", "")
+
+	FsSlashEvidence.String(FlagReporterNetworkAddress, "", "The network address of the indexing node reporting the fault")
+	FsSlashEvidence.String(FlagSlashType, "", "The type of the fault, either 'downtime' or 'misbehavior'")
+	FsSlashEvidence.Int64(FlagEvidenceHeight, 0, "The block height at which the fault was observed")
+	FsSlashEvidence.String(FlagEvidenceReference, "", "The off-chain reference backing the report (e.g. task id or record hash)")
 }
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/spf13/cobra"
+	"github.com/stratosnet/stratos-chain/x/register/types"
+)
+
+// UpdateParamsProposalJSON defines an UpdateParamsProposal with a deposit
+type UpdateParamsProposalJSON struct {
+	Title       string       `json:"title" yaml:"title"`
+	Description string       `json:"description" yaml:"description"`
+	Params      types.Params `json:"params" yaml:"params"`
+	Deposit     sdk.Coins    `json:"deposit" yaml:"deposit"`
+}
+
+// ParseUpdateParamsProposalJSON reads and parses an UpdateParamsProposalJSON from a file.
+func ParseUpdateParamsProposalJSON(cdc *codec.Codec, proposalFile string) (UpdateParamsProposalJSON, error) {
+	proposal := UpdateParamsProposalJSON{}
+
+	contents, err := ioutil.ReadFile(proposalFile)
+	if err != nil {
+		return proposal, err
+	}
+
+	if err := cdc.UnmarshalJSON(contents, &proposal); err != nil {
+		return proposal, err
+	}
+
+	return proposal, nil
+}
+
+// GetCmdSubmitUpdateParamsProposal implements the command to submit a register params update proposal
+func GetCmdSubmitUpdateParamsProposal(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "register-params-update [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a register params update proposal",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal replacing the params of the register module along with an initial deposit.
+The proposal details must be supplied via a JSON file. The whole params set must be provided,
+the current values can be retrieved with the register params query.
+
+Example:
+$ %s tx gov submit-proposal register-params-update <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+  "title": "Update register params",
+  "description": "EXAMPLE",
+  "params": {
+    "bond_denom": "ustos",
+    "unbonding_threashold_time": "15552000000000000",
+    "unbonding_completion_time": "1209600000000000",
+    "max_entries": 16,
+    "slash_fraction_downtime": "0.010000000000000000",
+    "slash_fraction_misbehavior": "0.050000000000000000"
+  },
+  "deposit": [
+    {
+      "denom": "ustos",
+      "amount": "10000"
+    }
+  ]
+}
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			proposal, err := ParseUpdateParamsProposalJSON(cdc, args[0])
+			if err != nil {
+				return err
+			}
+
+			from := cliCtx.GetFromAddress()
+			content := types.NewUpdateParamsProposal(proposal.Title, proposal.Description, proposal.Params)
+
+			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, from)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	return cmd
+}
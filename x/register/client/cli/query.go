@@ -8,6 +8,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/stratosnet/stratos-chain/x/register/keeper"
@@ -31,6 +32,8 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			// this line is used by starport scaffolding # 1
 			GetCmdQueryResourceNodeList(queryRoute, cdc),
 			GetCmdQueryIndexingNodeList(queryRoute, cdc),
+			GetCmdQueryNodeDelegations(queryRoute, cdc),
+			GetCmdQueryDelegatorDelegations(queryRoute, cdc),
 		)...,
 	)
 
@@ -192,3 +195,67 @@ func QueryIndexingNodes(cliCtx context.CLIContext, queryRoute, networkID string)
 	route := fmt.Sprintf("custom/%s/%s", queryRoute, keeper.QueryIndexingNodesByNetworkID)
 	return cliCtx.QueryWithData(route, []byte(networkID))
 }
+
+// GetCmdQueryNodeDelegations implements the query delegations by node address command.
+func GetCmdQueryNodeDelegations(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "node-delegations [node_address]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Query all delegations made to a resource or indexing node",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			addr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			bz, err := cdc.MarshalJSON(keeper.NewQuerynodeStakingParams(addr))
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, keeper.QueryNodeDelegations)
+			res, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var delegations types.Delegations
+			cdc.MustUnmarshalJSON(res, &delegations)
+			return cliCtx.PrintOutput(delegations)
+		},
+	}
+	return cmd
+}
+
+// GetCmdQueryDelegatorDelegations implements the query delegations by delegator address command.
+func GetCmdQueryDelegatorDelegations(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delegator-delegations [delegator_address]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Query all delegations made by a delegator",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			addr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			bz, err := cdc.MarshalJSON(keeper.NewQuerynodeStakingParams(addr))
+			if err != nil {
+				return err
+			}
+
+			route := fmt.Sprintf("custom/%s/%s", queryRoute, keeper.QueryDelegatorDelegations)
+			res, _, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			var delegations types.Delegations
+			cdc.MustUnmarshalJSON(res, &delegations)
+			return cliCtx.PrintOutput(delegations)
+		},
+	}
+	return cmd
+}
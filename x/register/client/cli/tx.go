@@ -36,6 +36,23 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 		RemoveIndexingNodeCmd(cdc),
 		UpdateIndexingNodeCmd(cdc),
 		IndexingNodeRegistrationVoteCmd(cdc),
+
+		SlashResourceNodeCmd(cdc),
+		SlashIndexingNodeCmd(cdc),
+		UnsuspendResourceNodeCmd(cdc),
+		UnsuspendIndexingNodeCmd(cdc),
+
+		UnbondResourceNodeStakeCmd(cdc),
+		UnbondIndexingNodeStakeCmd(cdc),
+		AddResourceNodeStakeCmd(cdc),
+		AddIndexingNodeStakeCmd(cdc),
+
+		DelegateResourceNodeCmd(cdc),
+		DelegateIndexingNodeCmd(cdc),
+		UndelegateResourceNodeCmd(cdc),
+		UndelegateIndexingNodeCmd(cdc),
+		UpdateResourceNodeCommissionCmd(cdc),
+		UpdateIndexingNodeCommissionCmd(cdc),
 	)...)
 
 	return registerTxCmd
@@ -69,6 +86,7 @@ func CreateResourceNodeCmd(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().AddFlagSet(FsAmount)
 	cmd.Flags().AddFlagSet(FsNetworkID)
 	cmd.Flags().AddFlagSet(FsNodeType)
+	cmd.Flags().AddFlagSet(FsCapacity)
 	cmd.Flags().AddFlagSet(FsDescription)
 
 	_ = cmd.MarkFlagRequired(flags.FlagFrom)
@@ -143,10 +161,27 @@ func buildCreateResourceNodeMsg(cliCtx context.CLIContext, txBldr auth.TxBuilder
 	if t := types.NodeType(nodeTypeRef).Type(); t == "UNKNOWN" {
 		return txBldr, nil, types.ErrNodeType
 	}
-	msg := types.NewMsgCreateResourceNode(networkID, pubKey, amount, ownerAddr, desc, fmt.Sprintf("%d: %s", nodeTypeRef, types.NodeType(nodeTypeRef).Type()))
+	capacity, err := getCapacity()
+	if err != nil {
+		return txBldr, nil, err
+	}
+	msg := types.NewMsgCreateResourceNode(networkID, pubKey, amount, ownerAddr, desc, fmt.Sprintf("%d: %s", nodeTypeRef, types.NodeType(nodeTypeRef).Type()), capacity)
 	return txBldr, msg, nil
 }
 
+// getCapacity parses the capacity flag, leaving it unset when the flag is empty
+func getCapacity() (capacity sdk.Int, err error) {
+	capacityStr := viper.GetString(FlagCapacity)
+	if capacityStr == "" {
+		return capacity, nil
+	}
+	capacity, ok := sdk.NewIntFromString(capacityStr)
+	if !ok {
+		return capacity, fmt.Errorf("invalid capacity %s", capacityStr)
+	}
+	return capacity, nil
+}
+
 // makes a new MsgCreateIndexingNode.
 func buildCreateIndexingNodeMsg(cliCtx context.CLIContext, txBldr auth.TxBuilder) (auth.TxBuilder, sdk.Msg, error) {
 	amountStr := viper.GetString(FlagAmount)
@@ -295,6 +330,7 @@ func UpdateResourceNodeCmd(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().AddFlagSet(FsNetworkID)
 	cmd.Flags().AddFlagSet(FsDescription)
 	cmd.Flags().AddFlagSet(FsNodeType)
+	cmd.Flags().AddFlagSet(FsCapacity)
 	cmd.Flags().AddFlagSet(FsNetworkAddress)
 
 	_ = cmd.MarkFlagRequired(FlagNetworkID)
@@ -326,9 +362,14 @@ func buildUpdateResourceNodeMsg(cliCtx context.CLIContext, txBldr auth.TxBuilder
 		return txBldr, nil, err
 	}
 
+	capacity, err := getCapacity()
+	if err != nil {
+		return txBldr, nil, err
+	}
+
 	ownerAddr := cliCtx.GetFromAddress()
 
-	msg := types.NewMsgUpdateResourceNode(networkID, desc, nodeType, nodeAddr, ownerAddr)
+	msg := types.NewMsgUpdateResourceNode(networkID, desc, nodeType, capacity, nodeAddr, ownerAddr)
 	return txBldr, msg, nil
 }
 
@@ -383,3 +424,396 @@ func buildUpdateIndexingNodeMsg(cliCtx context.CLIContext, txBldr auth.TxBuilder
 	msg := types.NewMsgUpdateIndexingNode(networkID, desc, nodeAddr, ownerAddr)
 	return txBldr, msg, nil
 }
+
+// SlashResourceNodeCmd reports a faulty resource node. It must be signed by the owner of a bonded indexing node.
+func SlashResourceNodeCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "slash-resource-node [flags]",
+		Short: "report a faulty resource node and slash its stake",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+			nodeAddr, reporterAddr, evidence, err := buildSlashParams()
+			if err != nil {
+				return err
+			}
+			msg := types.NewMsgSlashResourceNode(nodeAddr, evidence, reporterAddr, cliCtx.GetFromAddress())
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(FsNetworkAddress)
+	cmd.Flags().AddFlagSet(FsSlashEvidence)
+
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	_ = cmd.MarkFlagRequired(FlagNetworkAddress)
+	_ = cmd.MarkFlagRequired(FlagReporterNetworkAddress)
+	_ = cmd.MarkFlagRequired(FlagSlashType)
+	_ = cmd.MarkFlagRequired(FlagEvidenceHeight)
+	_ = cmd.MarkFlagRequired(FlagEvidenceReference)
+	return cmd
+}
+
+// SlashIndexingNodeCmd reports a faulty indexing node. It must be signed by the owner of another bonded indexing node.
+func SlashIndexingNodeCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "slash-indexing-node [flags]",
+		Short: "report a faulty indexing node and slash its stake",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+			nodeAddr, reporterAddr, evidence, err := buildSlashParams()
+			if err != nil {
+				return err
+			}
+			msg := types.NewMsgSlashIndexingNode(nodeAddr, evidence, reporterAddr, cliCtx.GetFromAddress())
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(FsNetworkAddress)
+	cmd.Flags().AddFlagSet(FsSlashEvidence)
+
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	_ = cmd.MarkFlagRequired(FlagNetworkAddress)
+	_ = cmd.MarkFlagRequired(FlagReporterNetworkAddress)
+	_ = cmd.MarkFlagRequired(FlagSlashType)
+	_ = cmd.MarkFlagRequired(FlagEvidenceHeight)
+	_ = cmd.MarkFlagRequired(FlagEvidenceReference)
+	return cmd
+}
+
+func buildSlashParams() (nodeAddr sdk.AccAddress, reporterAddr sdk.AccAddress, evidence types.SlashEvidence, err error) {
+	nodeAddr, err = sdk.AccAddressFromBech32(viper.GetString(FlagNetworkAddress))
+	if err != nil {
+		return
+	}
+	reporterAddr, err = sdk.AccAddressFromBech32(viper.GetString(FlagReporterNetworkAddress))
+	if err != nil {
+		return
+	}
+	slashType, err := types.SlashTypeFromString(viper.GetString(FlagSlashType))
+	if err != nil {
+		return
+	}
+	evidence = types.NewSlashEvidence(slashType, viper.GetInt64(FlagEvidenceHeight), viper.GetString(FlagEvidenceReference))
+	return
+}
+
+func UnsuspendResourceNodeCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unsuspend-resource-node [resource_node_address] [owner_address]",
+		Args:  cobra.ExactArgs(2),
+		Short: "lift the suspension of a resource node",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInputAndFrom(inBuf, args[1]).WithCodec(cdc)
+
+			resourceNodeAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			ownerAddr := cliCtx.GetFromAddress()
+
+			msg := types.NewMsgUnsuspendResourceNode(resourceNodeAddr, ownerAddr)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	return cmd
+}
+
+func UnsuspendIndexingNodeCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unsuspend-indexing-node [indexing_node_address] [owner_address]",
+		Args:  cobra.ExactArgs(2),
+		Short: "lift the suspension of an indexing node",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInputAndFrom(inBuf, args[1]).WithCodec(cdc)
+
+			indexingNodeAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			ownerAddr := cliCtx.GetFromAddress()
+
+			msg := types.NewMsgUnsuspendIndexingNode(indexingNodeAddr, ownerAddr)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	return cmd
+}
+
+func UnbondResourceNodeStakeCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unbond-resource-node-stake [resource_node_address] [owner_address] [amount]",
+		Args:  cobra.ExactArgs(3),
+		Short: "unbond part of the stake of a resource node",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInputAndFrom(inBuf, args[1]).WithCodec(cdc)
+
+			resourceNodeAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			amount, err := sdk.ParseCoin(args[2])
+			if err != nil {
+				return err
+			}
+			ownerAddr := cliCtx.GetFromAddress()
+
+			msg := types.NewMsgUnbondResourceNodeStake(resourceNodeAddr, ownerAddr, amount)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	return cmd
+}
+
+func UnbondIndexingNodeStakeCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unbond-indexing-node-stake [indexing_node_address] [owner_address] [amount]",
+		Args:  cobra.ExactArgs(3),
+		Short: "unbond part of the stake of an indexing node",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInputAndFrom(inBuf, args[1]).WithCodec(cdc)
+
+			indexingNodeAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			amount, err := sdk.ParseCoin(args[2])
+			if err != nil {
+				return err
+			}
+			ownerAddr := cliCtx.GetFromAddress()
+
+			msg := types.NewMsgUnbondIndexingNodeStake(indexingNodeAddr, ownerAddr, amount)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	return cmd
+}
+
+func AddResourceNodeStakeCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add-resource-node-stake [resource_node_address] [owner_address] [amount]",
+		Args:  cobra.ExactArgs(3),
+		Short: "add stake to a resource node",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInputAndFrom(inBuf, args[1]).WithCodec(cdc)
+
+			resourceNodeAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			amount, err := sdk.ParseCoin(args[2])
+			if err != nil {
+				return err
+			}
+			ownerAddr := cliCtx.GetFromAddress()
+
+			msg := types.NewMsgAddResourceNodeStake(resourceNodeAddr, ownerAddr, amount)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	return cmd
+}
+
+func AddIndexingNodeStakeCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add-indexing-node-stake [indexing_node_address] [owner_address] [amount]",
+		Args:  cobra.ExactArgs(3),
+		Short: "add stake to an indexing node",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInputAndFrom(inBuf, args[1]).WithCodec(cdc)
+
+			indexingNodeAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			amount, err := sdk.ParseCoin(args[2])
+			if err != nil {
+				return err
+			}
+			ownerAddr := cliCtx.GetFromAddress()
+
+			msg := types.NewMsgAddIndexingNodeStake(indexingNodeAddr, ownerAddr, amount)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	return cmd
+}
+
+func DelegateResourceNodeCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delegate-resource-node [resource_node_address] [delegator_address] [amount]",
+		Args:  cobra.ExactArgs(3),
+		Short: "delegate tokens to a resource node",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInputAndFrom(inBuf, args[1]).WithCodec(cdc)
+
+			resourceNodeAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			amount, err := sdk.ParseCoin(args[2])
+			if err != nil {
+				return err
+			}
+			delegatorAddr := cliCtx.GetFromAddress()
+
+			msg := types.NewMsgDelegateResourceNode(resourceNodeAddr, delegatorAddr, amount)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	return cmd
+}
+
+func DelegateIndexingNodeCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delegate-indexing-node [indexing_node_address] [delegator_address] [amount]",
+		Args:  cobra.ExactArgs(3),
+		Short: "delegate tokens to an indexing node",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInputAndFrom(inBuf, args[1]).WithCodec(cdc)
+
+			indexingNodeAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			amount, err := sdk.ParseCoin(args[2])
+			if err != nil {
+				return err
+			}
+			delegatorAddr := cliCtx.GetFromAddress()
+
+			msg := types.NewMsgDelegateIndexingNode(indexingNodeAddr, delegatorAddr, amount)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	return cmd
+}
+
+func UndelegateResourceNodeCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "undelegate-resource-node [resource_node_address] [delegator_address] [amount]",
+		Args:  cobra.ExactArgs(3),
+		Short: "unbond tokens delegated to a resource node",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInputAndFrom(inBuf, args[1]).WithCodec(cdc)
+
+			resourceNodeAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			amount, err := sdk.ParseCoin(args[2])
+			if err != nil {
+				return err
+			}
+			delegatorAddr := cliCtx.GetFromAddress()
+
+			msg := types.NewMsgUndelegateResourceNode(resourceNodeAddr, delegatorAddr, amount)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	return cmd
+}
+
+func UndelegateIndexingNodeCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "undelegate-indexing-node [indexing_node_address] [delegator_address] [amount]",
+		Args:  cobra.ExactArgs(3),
+		Short: "unbond tokens delegated to an indexing node",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInputAndFrom(inBuf, args[1]).WithCodec(cdc)
+
+			indexingNodeAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			amount, err := sdk.ParseCoin(args[2])
+			if err != nil {
+				return err
+			}
+			delegatorAddr := cliCtx.GetFromAddress()
+
+			msg := types.NewMsgUndelegateIndexingNode(indexingNodeAddr, delegatorAddr, amount)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	return cmd
+}
+
+func UpdateResourceNodeCommissionCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-resource-node-commission [resource_node_address] [owner_address] [commission_rate]",
+		Args:  cobra.ExactArgs(3),
+		Short: "update the commission rate charged to the delegators of a resource node",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInputAndFrom(inBuf, args[1]).WithCodec(cdc)
+
+			resourceNodeAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			rate, err := sdk.NewDecFromStr(args[2])
+			if err != nil {
+				return err
+			}
+			ownerAddr := cliCtx.GetFromAddress()
+
+			msg := types.NewMsgUpdateResourceNodeCommission(resourceNodeAddr, ownerAddr, rate)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	return cmd
+}
+
+func UpdateIndexingNodeCommissionCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-indexing-node-commission [indexing_node_address] [owner_address] [commission_rate]",
+		Args:  cobra.ExactArgs(3),
+		Short: "update the commission rate charged to the delegators of an indexing node",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInputAndFrom(inBuf, args[1]).WithCodec(cdc)
+
+			indexingNodeAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+			rate, err := sdk.NewDecFromStr(args[2])
+			if err != nil {
+				return err
+			}
+			ownerAddr := cliCtx.GetFromAddress()
+
+			msg := types.NewMsgUpdateIndexingNodeCommission(indexingNodeAddr, ownerAddr, rate)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,12 @@
+package client
+
+import (
+	govclient "github.com/cosmos/cosmos-sdk/x/gov/client"
+	"github.com/stratosnet/stratos-chain/x/register/client/cli"
+	"github.com/stratosnet/stratos-chain/x/register/client/rest"
+)
+
+// ProposalHandler is the register params update proposal handler
+var (
+	ProposalHandler = govclient.NewProposalHandler(cli.GetCmdSubmitUpdateParamsProposal, rest.ProposalRESTHandler)
+)
@@ -17,6 +17,8 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router) {
 	r.HandleFunc("/register/staking", nodeStakingHandlerFn(cliCtx, keeper.QueryNodesTotalStakes)).Methods("GET")
 	r.HandleFunc("/register/staking/address/{nodeAddress}", nodeStakingByNodeAddressFn(cliCtx, keeper.QueryNodeStakeByNodeAddr)).Methods("GET")
 	r.HandleFunc("/register/staking/owner/{ownerAddress}", nodeStakingByOwnerFn(cliCtx, keeper.QueryNodeStakeByOwner)).Methods("GET")
+	r.HandleFunc("/register/delegations/node/{nodeAddress}", delegationsHandlerFn(cliCtx, "nodeAddress", keeper.QueryNodeDelegations)).Methods("GET")
+	r.HandleFunc("/register/delegations/delegator/{delegatorAddress}", delegationsHandlerFn(cliCtx, "delegatorAddress", keeper.QueryDelegatorDelegations)).Methods("GET")
 	r.HandleFunc("/register/params", registerParamsHandlerFn(cliCtx, keeper.QueryRegisterParams)).Methods("GET")
 }
 
@@ -183,3 +185,36 @@ func nodeStakingByOwnerFn(cliCtx context.CLIContext, queryPath string) http.Hand
 		rest.PostProcessResponse(w, cliCtx, res)
 	}
 }
+
+// GET request handler to query delegations by node address or by delegator address
+func delegationsHandlerFn(cliCtx context.CLIContext, addrVar string, queryPath string) http.HandlerFunc {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		addr, ok := keeper.CheckAccAddr(w, r, mux.Vars(r)[addrVar])
+		if !ok {
+			return
+		}
+
+		params := keeper.NewQuerynodeStakingParams(addr)
+		bz, err := cliCtx.Codec.MarshalJSON(params)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/%s", types.QuerierRoute, queryPath)
+		res, height, err := cliCtx.QueryWithData(route, bz)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
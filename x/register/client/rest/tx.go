@@ -42,6 +42,65 @@ func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router) {
 		"/register/indexingNodeRegVote",
 		postIndexingNodeRegVoteFn(cliCtx),
 	).Methods("POST")
+
+	r.HandleFunc(
+		"/register/slashResourceNode",
+		postSlashResourceNodeHandlerFn(cliCtx),
+	).Methods("POST")
+	r.HandleFunc(
+		"/register/slashIndexingNode",
+		postSlashIndexingNodeHandlerFn(cliCtx),
+	).Methods("POST")
+	r.HandleFunc(
+		"/register/unsuspendResourceNode",
+		postUnsuspendResourceNodeHandlerFn(cliCtx),
+	).Methods("POST")
+	r.HandleFunc(
+		"/register/unsuspendIndexingNode",
+		postUnsuspendIndexingNodeHandlerFn(cliCtx),
+	).Methods("POST")
+
+	r.HandleFunc(
+		"/register/unbondResourceNodeStake",
+		postUnbondResourceNodeStakeHandlerFn(cliCtx),
+	).Methods("POST")
+	r.HandleFunc(
+		"/register/unbondIndexingNodeStake",
+		postUnbondIndexingNodeStakeHandlerFn(cliCtx),
+	).Methods("POST")
+	r.HandleFunc(
+		"/register/addResourceNodeStake",
+		postAddResourceNodeStakeHandlerFn(cliCtx),
+	).Methods("POST")
+	r.HandleFunc(
+		"/register/addIndexingNodeStake",
+		postAddIndexingNodeStakeHandlerFn(cliCtx),
+	).Methods("POST")
+
+	r.HandleFunc(
+		"/register/delegateResourceNode",
+		postDelegateResourceNodeHandlerFn(cliCtx),
+	).Methods("POST")
+	r.HandleFunc(
+		"/register/delegateIndexingNode",
+		postDelegateIndexingNodeHandlerFn(cliCtx),
+	).Methods("POST")
+	r.HandleFunc(
+		"/register/undelegateResourceNode",
+		postUndelegateResourceNodeHandlerFn(cliCtx),
+	).Methods("POST")
+	r.HandleFunc(
+		"/register/undelegateIndexingNode",
+		postUndelegateIndexingNodeHandlerFn(cliCtx),
+	).Methods("POST")
+	r.HandleFunc(
+		"/register/updateResourceNodeCommission",
+		postUpdateResourceNodeCommissionHandlerFn(cliCtx),
+	).Methods("POST")
+	r.HandleFunc(
+		"/register/updateIndexingNodeCommission",
+		postUpdateIndexingNodeCommissionHandlerFn(cliCtx),
+	).Methods("POST")
 }
 
 type (
@@ -52,6 +111,7 @@ type (
 		Amount      sdk.Coin          `json:"amount" yaml:"amount"`
 		Description types.Description `json:"description" yaml:"description"`
 		NodeType    int               `json:"node_type" yaml:"node_type"`
+		Capacity    sdk.Int           `json:"capacity" yaml:"capacity"` // max volume credited to the node per epoch, optional
 	}
 
 	RemoveResourceNodeRequest struct {
@@ -64,6 +124,7 @@ type (
 		NetworkID      string            `json:"network_id" yaml:"network_id"`
 		Description    types.Description `json:"description" yaml:"description"`
 		NodeType       int               `json:"node_type" yaml:"node_type"`
+		Capacity       sdk.Int           `json:"capacity" yaml:"capacity"` // keeps the current capacity when not set
 		NetworkAddress string            `json:"network_address" yaml:"network_address"`
 	}
 
@@ -94,6 +155,32 @@ type (
 		Opinion                 bool         `json:"opinion" yaml:"opinion"`
 		VoterNetworkAddress     string       `json:"voter_network_address" yaml:"voter_network_address"`
 	}
+
+	SlashNodeRequest struct {
+		BaseReq                rest.BaseReq `json:"base_req" yaml:"base_req"`
+		NetworkAddress         string       `json:"network_address" yaml:"network_address"`                   // in bech32
+		ReporterNetworkAddress string       `json:"reporter_network_address" yaml:"reporter_network_address"` // in bech32
+		SlashType              string       `json:"slash_type" yaml:"slash_type"`
+		EvidenceHeight         int64        `json:"evidence_height" yaml:"evidence_height"`
+		EvidenceReference      string       `json:"evidence_reference" yaml:"evidence_reference"`
+	}
+
+	UnsuspendNodeRequest struct {
+		BaseReq        rest.BaseReq `json:"base_req" yaml:"base_req"`
+		NetworkAddress string       `json:"network_address" yaml:"network_address"` // in bech32
+	}
+
+	NodeStakeRequest struct {
+		BaseReq        rest.BaseReq `json:"base_req" yaml:"base_req"`
+		NetworkAddress string       `json:"network_address" yaml:"network_address"` // in bech32
+		Amount         sdk.Coin     `json:"amount" yaml:"amount"`
+	}
+
+	NodeCommissionRequest struct {
+		BaseReq        rest.BaseReq `json:"base_req" yaml:"base_req"`
+		NetworkAddress string       `json:"network_address" yaml:"network_address"` // in bech32
+		CommissionRate sdk.Dec      `json:"commission_rate" yaml:"commission_rate"`
+	}
 )
 
 func postCreateResourceNodeHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
@@ -126,7 +213,7 @@ func postCreateResourceNodeHandlerFn(cliCtx context.CLIContext) http.HandlerFunc
 			return
 		}
 		msg := types.NewMsgCreateResourceNode(req.NetworkID, pubKey, req.Amount, ownerAddr, req.Description,
-			fmt.Sprintf("%d: %s", nodeTypeRef, types.NodeType(nodeTypeRef).Type()))
+			fmt.Sprintf("%d: %s", nodeTypeRef, types.NodeType(nodeTypeRef).Type()), req.Capacity)
 		if err := msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -272,7 +359,7 @@ func postUpdateResourceNodeHandlerFn(cliCtx context.CLIContext) http.HandlerFunc
 			return
 		}
 		msg := types.NewMsgUpdateResourceNode(req.NetworkID, req.Description,
-			fmt.Sprintf("%d: %s", nodeTypeRef, types.NodeType(nodeTypeRef).Type()), networkAddr, ownerAddr)
+			fmt.Sprintf("%d: %s", nodeTypeRef, types.NodeType(nodeTypeRef).Type()), req.Capacity, networkAddr, ownerAddr)
 		if err := msg.ValidateBasic(); err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
@@ -363,3 +450,485 @@ func postIndexingNodeRegVoteFn(cliCtx context.CLIContext) http.HandlerFunc {
 		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
 	}
 }
+
+func postSlashResourceNodeHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return postSlashNodeHandlerFn(cliCtx, func(nodeAddr sdk.AccAddress, evidence types.SlashEvidence, reporterAddr, reporterOwner sdk.AccAddress) sdk.Msg {
+		return types.NewMsgSlashResourceNode(nodeAddr, evidence, reporterAddr, reporterOwner)
+	})
+}
+
+func postSlashIndexingNodeHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return postSlashNodeHandlerFn(cliCtx, func(nodeAddr sdk.AccAddress, evidence types.SlashEvidence, reporterAddr, reporterOwner sdk.AccAddress) sdk.Msg {
+		return types.NewMsgSlashIndexingNode(nodeAddr, evidence, reporterAddr, reporterOwner)
+	})
+}
+
+func postSlashNodeHandlerFn(cliCtx context.CLIContext,
+	newMsg func(nodeAddr sdk.AccAddress, evidence types.SlashEvidence, reporterAddr, reporterOwner sdk.AccAddress) sdk.Msg,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req SlashNodeRequest
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		nodeAddr, err := sdk.AccAddressFromBech32(req.NetworkAddress)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		reporterAddr, err := sdk.AccAddressFromBech32(req.ReporterNetworkAddress)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		reporterOwner, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		slashType, err := types.SlashTypeFromString(req.SlashType)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		evidence := types.NewSlashEvidence(slashType, req.EvidenceHeight, req.EvidenceReference)
+		msg := newMsg(nodeAddr, evidence, reporterAddr, reporterOwner)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func postUnsuspendResourceNodeHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req UnsuspendNodeRequest
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		nodeAddr, err := sdk.AccAddressFromBech32(req.NetworkAddress)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		ownerAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgUnsuspendResourceNode(nodeAddr, ownerAddr)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func postUnsuspendIndexingNodeHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req UnsuspendNodeRequest
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		nodeAddr, err := sdk.AccAddressFromBech32(req.NetworkAddress)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		ownerAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgUnsuspendIndexingNode(nodeAddr, ownerAddr)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func postUnbondResourceNodeStakeHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req NodeStakeRequest
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		nodeAddr, err := sdk.AccAddressFromBech32(req.NetworkAddress)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		ownerAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgUnbondResourceNodeStake(nodeAddr, ownerAddr, req.Amount)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func postUnbondIndexingNodeStakeHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req NodeStakeRequest
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		nodeAddr, err := sdk.AccAddressFromBech32(req.NetworkAddress)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		ownerAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgUnbondIndexingNodeStake(nodeAddr, ownerAddr, req.Amount)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func postAddResourceNodeStakeHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req NodeStakeRequest
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		nodeAddr, err := sdk.AccAddressFromBech32(req.NetworkAddress)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		ownerAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgAddResourceNodeStake(nodeAddr, ownerAddr, req.Amount)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func postAddIndexingNodeStakeHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req NodeStakeRequest
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		nodeAddr, err := sdk.AccAddressFromBech32(req.NetworkAddress)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		ownerAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgAddIndexingNodeStake(nodeAddr, ownerAddr, req.Amount)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func postDelegateResourceNodeHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req NodeStakeRequest
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		nodeAddr, err := sdk.AccAddressFromBech32(req.NetworkAddress)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		delegatorAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgDelegateResourceNode(nodeAddr, delegatorAddr, req.Amount)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func postDelegateIndexingNodeHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req NodeStakeRequest
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		nodeAddr, err := sdk.AccAddressFromBech32(req.NetworkAddress)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		delegatorAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgDelegateIndexingNode(nodeAddr, delegatorAddr, req.Amount)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func postUndelegateResourceNodeHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req NodeStakeRequest
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		nodeAddr, err := sdk.AccAddressFromBech32(req.NetworkAddress)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		delegatorAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgUndelegateResourceNode(nodeAddr, delegatorAddr, req.Amount)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func postUndelegateIndexingNodeHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req NodeStakeRequest
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		nodeAddr, err := sdk.AccAddressFromBech32(req.NetworkAddress)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		delegatorAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgUndelegateIndexingNode(nodeAddr, delegatorAddr, req.Amount)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func postUpdateResourceNodeCommissionHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req NodeCommissionRequest
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		nodeAddr, err := sdk.AccAddressFromBech32(req.NetworkAddress)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		ownerAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgUpdateResourceNodeCommission(nodeAddr, ownerAddr, req.CommissionRate)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+func postUpdateIndexingNodeCommissionHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req NodeCommissionRequest
+
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		nodeAddr, err := sdk.AccAddressFromBech32(req.NetworkAddress)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		ownerAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgUpdateIndexingNodeCommission(nodeAddr, ownerAddr, req.CommissionRate)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
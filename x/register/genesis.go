@@ -1,7 +1,10 @@
 package register
 
 import (
+	"time"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/stratosnet/stratos-chain/x/register/types"
 )
 
@@ -10,34 +13,47 @@ import (
 func InitGenesis(ctx sdk.Context, keeper Keeper, data types.GenesisState) {
 	keeper.SetParams(ctx, data.Params)
 
+	// the unbonding entries are set first, the tokens they return are already in the not bonded pools
+	for _, ubd := range data.UnbondingNodes {
+		keeper.SetUnbondingNode(ctx, ubd)
+	}
+
 	initialStakeTotal := sdk.ZeroInt()
 	resNodeBondedToken := sdk.ZeroInt()
 	resNodeNotBondedToken := sdk.ZeroInt()
 	for _, resourceNode := range data.ResourceNodes {
 		if resourceNode.GetStatus() == sdk.Bonded {
+			unbonding := keeper.GetUnbondingNodeBalance(ctx, resourceNode.GetNetworkAddr())
 			initialStakeTotal = initialStakeTotal.Add(resourceNode.GetTokens())
-			resNodeBondedToken = resNodeBondedToken.Add(resourceNode.GetTokens())
-		} else if resourceNode.GetStatus() == sdk.Unbonded {
+			resNodeBondedToken = resNodeBondedToken.Add(resourceNode.GetTokens().Sub(unbonding))
+			resNodeNotBondedToken = resNodeNotBondedToken.Add(unbonding)
+		} else {
 			resNodeNotBondedToken = resNodeNotBondedToken.Add(resourceNode.GetTokens())
 		}
+		if resourceNode.Capacity.IsNil() || resourceNode.Capacity.IsZero() {
+			resourceNode.Capacity = data.Params.MaxCapacity
+		}
 		keeper.SetResourceNode(ctx, resourceNode)
 	}
-	keeper.SetResourceNodeBondedToken(ctx, sdk.NewCoin(keeper.BondDenom(ctx), resNodeBondedToken))
-	keeper.SetResourceNodeNotBondedToken(ctx, sdk.NewCoin(keeper.BondDenom(ctx), resNodeNotBondedToken))
 
 	idxNodeBondedToken := sdk.ZeroInt()
 	idxNodeNotBondedToken := sdk.ZeroInt()
 	for _, indexingNode := range data.IndexingNodes {
 		if indexingNode.GetStatus() == sdk.Bonded {
+			unbonding := keeper.GetUnbondingNodeBalance(ctx, indexingNode.GetNetworkAddr())
 			initialStakeTotal = initialStakeTotal.Add(indexingNode.GetTokens())
-			idxNodeBondedToken = idxNodeBondedToken.Add(indexingNode.GetTokens())
-		} else if indexingNode.GetStatus() == sdk.Unbonded {
+			idxNodeBondedToken = idxNodeBondedToken.Add(indexingNode.GetTokens().Sub(unbonding))
+			idxNodeNotBondedToken = idxNodeNotBondedToken.Add(unbonding)
+		} else {
 			idxNodeNotBondedToken = idxNodeNotBondedToken.Add(indexingNode.GetTokens())
 		}
 		keeper.SetIndexingNode(ctx, indexingNode)
 	}
-	keeper.SetIndexingNodeBondedToken(ctx, sdk.NewCoin(keeper.BondDenom(ctx), idxNodeBondedToken))
-	keeper.SetIndexingNodeNotBondedToken(ctx, sdk.NewCoin(keeper.BondDenom(ctx), idxNodeNotBondedToken))
+
+	initTokenPool(ctx, keeper, types.ResourceNodeBondedPoolName, resNodeBondedToken)
+	initTokenPool(ctx, keeper, types.ResourceNodeNotBondedPoolName, resNodeNotBondedToken)
+	initTokenPool(ctx, keeper, types.IndexingNodeBondedPoolName, idxNodeBondedToken)
+	initTokenPool(ctx, keeper, types.IndexingNodeNotBondedPoolName, idxNodeNotBondedToken)
 
 	for _, resStake := range data.LastResourceNodeStakes {
 		keeper.SetLastResourceNodeStake(ctx, resStake.Address, resStake.Stake)
@@ -47,8 +63,49 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data types.GenesisState) {
 		keeper.SetLastIndexingNodeStake(ctx, idxStake.Address, idxStake.Stake)
 	}
 
+	for _, delegation := range data.Delegations {
+		keeper.SetDelegation(ctx, delegation)
+	}
+
+	for _, timeSlice := range data.UnbondingNodeQueue {
+		keeper.SetUnbondingNodeQueueTimeSlice(ctx, timeSlice.Time, timeSlice.NetworkAddrs)
+	}
+	for _, votePool := range data.IndexingNodeRegistrationVotePools {
+		keeper.SetIndexingNodeRegistrationVotePool(ctx, votePool)
+	}
+	for _, report := range data.SlashReports {
+		keeper.SetSlashReport(ctx, report)
+	}
+
+	if !data.InitialGenesisStakeTotal.IsNil() {
+		initialStakeTotal = data.InitialGenesisStakeTotal
+	}
+	remainingOzoneLimit := initialStakeTotal
+	if !data.RemainingOzoneLimit.IsNil() {
+		remainingOzoneLimit = data.RemainingOzoneLimit
+	}
 	keeper.SetInitialGenesisStakeTotal(ctx, initialStakeTotal)
-	keeper.SetRemainingOzoneLimit(ctx, initialStakeTotal)
+	keeper.SetRemainingOzoneLimit(ctx, remainingOzoneLimit)
+	// the ozone issued to users is added back by the sds genesis
+	keeper.SetTotalOzoneSupply(ctx, remainingOzoneLimit)
+}
+
+// initTokenPool sets the balance of a token pool from the node stakes when the pool is not part of the genesis
+// accounts, otherwise the balance it was exported with must match them
+func initTokenPool(ctx sdk.Context, keeper Keeper, poolName string, amount sdk.Int) {
+	bondDenom := keeper.BondDenom(ctx)
+	pool := keeper.GetTokenPool(ctx, poolName)
+	if pool.GetCoins().IsZero() {
+		if err := pool.SetCoins(sdk.NewCoins(sdk.NewCoin(bondDenom, amount))); err != nil {
+			panic(err)
+		}
+		keeper.SetTokenPool(ctx, pool)
+		return
+	}
+	if !pool.GetCoins().AmountOf(bondDenom).Equal(amount) {
+		panic(sdkerrors.Wrapf(types.ErrInvalidGenesisPool, "%s holds %s, the node stakes add up to %s%s",
+			poolName, pool.GetCoins(), amount, bondDenom))
+	}
 }
 
 // ExportGenesis writes the current store values
@@ -71,12 +128,39 @@ func ExportGenesis(ctx sdk.Context, keeper Keeper) (data types.GenesisState) {
 
 	resourceNodes := keeper.GetAllResourceNodes(ctx)
 	indexingNodes := keeper.GetAllIndexingNodes(ctx)
+	delegations := keeper.GetAllDelegations(ctx)
+	unbondingNodes := keeper.GetAllUnbondingNodes(ctx)
+
+	var unbondingNodeQueue []types.UnbondingNodeQueueTimeSlice
+	keeper.IterateUnbondingNodeQueue(ctx, func(timestamp time.Time, networkAddrs []sdk.AccAddress) (stop bool) {
+		unbondingNodeQueue = append(unbondingNodeQueue, types.UnbondingNodeQueueTimeSlice{Time: timestamp, NetworkAddrs: networkAddrs})
+		return false
+	})
+
+	var votePools []types.IndexingNodeRegistrationVotePool
+	keeper.IterateIndexingNodeRegistrationVotePools(ctx, func(votePool types.IndexingNodeRegistrationVotePool) (stop bool) {
+		votePools = append(votePools, votePool)
+		return false
+	})
+
+	var slashReports []types.SlashReport
+	keeper.IterateSlashReports(ctx, func(report types.SlashReport) (stop bool) {
+		slashReports = append(slashReports, report)
+		return false
+	})
 
 	return types.GenesisState{
-		Params:                 params,
-		LastResourceNodeStakes: lastResourceNodeStakes,
-		ResourceNodes:          resourceNodes,
-		LastIndexingNodeStakes: lastIndexingNodeStakes,
-		IndexingNodes:          indexingNodes,
+		Params:                            params,
+		LastResourceNodeStakes:            lastResourceNodeStakes,
+		ResourceNodes:                     resourceNodes,
+		LastIndexingNodeStakes:            lastIndexingNodeStakes,
+		IndexingNodes:                     indexingNodes,
+		Delegations:                       delegations,
+		UnbondingNodes:                    unbondingNodes,
+		UnbondingNodeQueue:                unbondingNodeQueue,
+		IndexingNodeRegistrationVotePools: votePools,
+		SlashReports:                      slashReports,
+		InitialGenesisStakeTotal:          keeper.GetInitialGenesisStakeTotal(ctx),
+		RemainingOzoneLimit:               keeper.GetRemainingOzoneLimit(ctx),
 	}
 }
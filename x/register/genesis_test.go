@@ -0,0 +1,82 @@
+package register
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stratosnet/stratos-chain/x/register/keeper"
+	"github.com/stratosnet/stratos-chain/x/register/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+func TestExportImportGenesis(t *testing.T) {
+	ctx, accountKeeper, bankKeeper, k, _ := keeper.CreateTestInput(t, false)
+	InitGenesis(ctx, k, types.DefaultGenesisState())
+	// the ozone limit is left out of the default genesis file
+	require.NoError(t, AppModuleBasic{}.ValidateGenesis(AppModuleBasic{}.DefaultGenesis()))
+
+	resOwner := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	resPubKey := ed25519.GenPrivKey().PubKey()
+	idxOwner := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	idxPubKey := ed25519.GenPrivKey().PubKey()
+	stake := sdk.NewInt(100000000)
+
+	for _, owner := range []sdk.AccAddress{resOwner, idxOwner} {
+		accountKeeper.SetAccount(ctx, accountKeeper.NewAccountWithAddress(ctx, owner))
+		_, err := bankKeeper.AddCoins(ctx, owner, sdk.NewCoins(sdk.NewCoin(k.BondDenom(ctx), stake)))
+		require.NoError(t, err)
+	}
+
+	// a bonded resource node in the middle of a partial unbonding
+	_, err := k.RegisterResourceNode(ctx, "sds://resourceNode", resPubKey, resOwner,
+		types.NewDescription("sds://resourceNode", "", "", "", ""), "4", sdk.ZeroInt(), sdk.NewCoin(k.BondDenom(ctx), stake))
+	require.NoError(t, err)
+	resNode, found := k.GetResourceNode(ctx, sdk.AccAddress(resPubKey.Address()))
+	require.True(t, found)
+	_, _, err = k.UnbondResourceNode(ctx, resNode, stake.QuoRaw(4))
+	require.NoError(t, err)
+
+	// an indexing node waiting for the votes on its registration
+	_, err = k.RegisterIndexingNode(ctx, "sds://indexingNode", idxPubKey, idxOwner,
+		types.NewDescription("sds://indexingNode", "", "", "", ""), sdk.NewCoin(k.BondDenom(ctx), stake))
+	require.NoError(t, err)
+
+	exported := ExportGenesis(ctx, k)
+	require.NoError(t, types.ValidateGenesis(exported))
+	require.Len(t, exported.UnbondingNodes, 1)
+	require.Len(t, exported.UnbondingNodeQueue, 1)
+	require.Len(t, exported.IndexingNodeRegistrationVotePools, 1)
+	require.NoError(t, AppModuleBasic{}.ValidateGenesis(types.ModuleCdc.MustMarshalJSON(exported)))
+
+	newCtx, _, _, newKeeper, _ := keeper.CreateTestInput(t, false)
+	InitGenesis(newCtx, newKeeper, exported)
+	require.Equal(t, exported, ExportGenesis(newCtx, newKeeper))
+	require.Equal(t, k.GetAllUnbondingNodesTotalBalance(ctx), newKeeper.GetAllUnbondingNodesTotalBalance(newCtx))
+	require.Equal(t, k.GetTotalOzoneSupply(ctx), newKeeper.GetTotalOzoneSupply(newCtx))
+	msg, broken := keeper.AllInvariants(newKeeper)(newCtx)
+	require.False(t, broken, msg)
+
+	// the pools are computed from the node stakes when they are not part of the genesis accounts
+	require.Equal(t, k.GetResourceNodeBondedToken(ctx), newKeeper.GetResourceNodeBondedToken(newCtx))
+	require.Equal(t, k.GetResourceNodeNotBondedToken(ctx), newKeeper.GetResourceNodeNotBondedToken(newCtx))
+	require.Equal(t, k.GetIndexingNodeBondedToken(ctx), newKeeper.GetIndexingNodeBondedToken(newCtx))
+	require.Equal(t, k.GetIndexingNodeNotBondedToken(ctx), newKeeper.GetIndexingNodeNotBondedToken(newCtx))
+
+	// the pools found in the genesis accounts must hold the node stakes
+	poolCtx, _, _, poolKeeper, _ := keeper.CreateTestInput(t, false)
+	pool := poolKeeper.GetTokenPool(poolCtx, types.ResourceNodeBondedPoolName)
+	require.NoError(t, pool.SetCoins(sdk.NewCoins(k.GetResourceNodeBondedToken(ctx).Add(sdk.NewCoin(k.BondDenom(ctx), sdk.OneInt())))))
+	poolKeeper.SetTokenPool(poolCtx, pool)
+	require.Panics(t, func() { InitGenesis(poolCtx, poolKeeper, exported) })
+
+	// every unbonding entry must be scheduled in the queue
+	invalid := exported
+	invalid.UnbondingNodeQueue = nil
+	require.Error(t, types.ValidateGenesis(invalid))
+
+	// vote pools must belong to an indexing node
+	invalid = exported
+	invalid.IndexingNodes = nil
+	require.Error(t, types.ValidateGenesis(invalid))
+}
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	"github.com/stratosnet/stratos-chain/x/register/keeper"
 	"github.com/stratosnet/stratos-chain/x/register/types"
+	"strconv"
 	"time"
 )
 
@@ -31,6 +33,37 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 		case types.MsgIndexingNodeRegistrationVote:
 			return handleMsgIndexingNodeRegistrationVote(ctx, msg, k)
 
+		case types.MsgSlashResourceNode:
+			return handleMsgSlashResourceNode(ctx, msg, k)
+		case types.MsgSlashIndexingNode:
+			return handleMsgSlashIndexingNode(ctx, msg, k)
+		case types.MsgUnsuspendResourceNode:
+			return handleMsgUnsuspendResourceNode(ctx, msg, k)
+		case types.MsgUnsuspendIndexingNode:
+			return handleMsgUnsuspendIndexingNode(ctx, msg, k)
+
+		case types.MsgUnbondResourceNodeStake:
+			return handleMsgUnbondResourceNodeStake(ctx, msg, k)
+		case types.MsgUnbondIndexingNodeStake:
+			return handleMsgUnbondIndexingNodeStake(ctx, msg, k)
+		case types.MsgAddResourceNodeStake:
+			return handleMsgAddResourceNodeStake(ctx, msg, k)
+		case types.MsgAddIndexingNodeStake:
+			return handleMsgAddIndexingNodeStake(ctx, msg, k)
+
+		case types.MsgDelegateResourceNode:
+			return handleMsgDelegateResourceNode(ctx, msg, k)
+		case types.MsgDelegateIndexingNode:
+			return handleMsgDelegateIndexingNode(ctx, msg, k)
+		case types.MsgUndelegateResourceNode:
+			return handleMsgUndelegateResourceNode(ctx, msg, k)
+		case types.MsgUndelegateIndexingNode:
+			return handleMsgUndelegateIndexingNode(ctx, msg, k)
+		case types.MsgUpdateResourceNodeCommission:
+			return handleMsgUpdateResourceNodeCommission(ctx, msg, k)
+		case types.MsgUpdateIndexingNodeCommission:
+			return handleMsgUpdateIndexingNodeCommission(ctx, msg, k)
+
 		// this line is used by starport scaffolding # 1
 		default:
 			errMsg := fmt.Sprintf("unrecognized %s message type: %T", types.ModuleName, msg)
@@ -49,7 +82,7 @@ func handleMsgCreateResourceNode(ctx sdk.Context, msg types.MsgCreateResourceNod
 		return nil, ErrBadDenom
 	}
 
-	ozoneLimitChange, err := k.RegisterResourceNode(ctx, msg.NetworkID, msg.PubKey, msg.OwnerAddress, msg.Description, msg.NodeType, msg.Value)
+	ozoneLimitChange, err := k.RegisterResourceNode(ctx, msg.NetworkID, msg.PubKey, msg.OwnerAddress, msg.Description, msg.NodeType, msg.Capacity, msg.Value)
 	if err != nil {
 		return nil, err
 	}
@@ -109,7 +142,8 @@ func handleMsgRemoveResourceNode(ctx sdk.Context, msg types.MsgRemoveResourceNod
 		return nil, types.ErrUnbondingNode
 	}
 
-	ozoneLimitChange, completionTime, err := k.UnbondResourceNode(ctx, resourceNode, resourceNode.Tokens)
+	availableStake := k.GetOwnerAvailableStake(ctx, resourceNode.GetNetworkAddr(), resourceNode.GetTokens())
+	ozoneLimitChange, completionTime, err := k.UnbondResourceNode(ctx, resourceNode, availableStake)
 	if err != nil {
 		return nil, err
 	}
@@ -143,7 +177,8 @@ func handleMsgRemoveIndexingNode(ctx sdk.Context, msg types.MsgRemoveIndexingNod
 		return nil, types.ErrUnbondingNode
 	}
 
-	ozoneLimitChange, completionTime, err := k.UnbondIndexingNode(ctx, indexingNode, indexingNode.Tokens)
+	availableStake := k.GetOwnerAvailableStake(ctx, indexingNode.GetNetworkAddr(), indexingNode.GetTokens())
+	ozoneLimitChange, completionTime, err := k.UnbondIndexingNode(ctx, indexingNode, availableStake)
 	if err != nil {
 		return nil, err
 	}
@@ -206,7 +241,7 @@ func handleMsgIndexingNodeRegistrationVote(ctx sdk.Context, msg types.MsgIndexin
 }
 
 func handleMsgUpdateResourceNode(ctx sdk.Context, msg types.MsgUpdateResourceNode, k keeper.Keeper) (*sdk.Result, error) {
-	err := k.UpdateResourceNode(ctx, msg.NetworkID, msg.Description, msg.NodeType, msg.NetworkAddress, msg.OwnerAddress)
+	err := k.UpdateResourceNode(ctx, msg.NetworkID, msg.Description, msg.NodeType, msg.Capacity, msg.NetworkAddress, msg.OwnerAddress)
 	if err != nil {
 		return nil, err
 	}
@@ -242,3 +277,499 @@ func handleMsgUpdateIndexingNode(ctx sdk.Context, msg types.MsgUpdateIndexingNod
 	})
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
+
+// checkSlashReporter makes sure the reporter is a bonded, unsuspended indexing node owned by the signer
+// and that the reported evidence is not from the future
+func checkSlashReporter(ctx sdk.Context, k keeper.Keeper, reporterAddr, reporterOwner sdk.AccAddress, evidence types.SlashEvidence) error {
+	reporter, found := k.GetIndexingNode(ctx, reporterAddr)
+	if !found {
+		return types.ErrInvalidReporter
+	}
+	if !reporter.GetOwnerAddr().Equals(reporterOwner) {
+		return ErrInvalidOwnerAddr
+	}
+	if !reporter.GetStatus().Equal(sdk.Bonded) || reporter.IsSuspended() {
+		return types.ErrInvalidReporter
+	}
+	if evidence.Height > ctx.BlockHeight() {
+		return types.ErrInvalidEvidenceHeight
+	}
+	return nil
+}
+
+func handleMsgSlashResourceNode(ctx sdk.Context, msg types.MsgSlashResourceNode, k keeper.Keeper) (*sdk.Result, error) {
+	if err := checkSlashReporter(ctx, k, msg.ReporterAddress, msg.ReporterOwner, msg.Evidence); err != nil {
+		return nil, err
+	}
+
+	resourceNode, found := k.GetResourceNode(ctx, msg.NodeAddress)
+	if !found {
+		return nil, ErrNoResourceNodeFound
+	}
+	if resourceNode.IsSuspended() {
+		return nil, types.ErrNodeSuspended
+	}
+
+	report, quorumReached, err := k.ReportSlashEvidence(ctx, msg.NodeAddress, false, msg.Evidence, msg.ReporterAddress)
+	if err != nil {
+		return nil, err
+	}
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeSlashReport,
+			sdk.NewAttribute(types.AttributeKeyReporter, msg.ReporterAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyResourceNode, msg.NodeAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyEvidenceReference, msg.Evidence.Reference),
+			sdk.NewAttribute(types.AttributeKeyReportCount, strconv.Itoa(len(report.Reporters))),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.ReporterOwner.String()),
+		),
+	})
+	// the node is only slashed once a quorum of indexing nodes reported the evidence
+	if !quorumReached {
+		return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+	}
+
+	slashed, ozoneLimitChange, err := k.SlashResourceNode(ctx, resourceNode, msg.Evidence.SlashType)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeSlashResourceNode,
+			sdk.NewAttribute(types.AttributeKeyReporter, msg.ReporterAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyResourceNode, msg.NodeAddress.String()),
+			sdk.NewAttribute(types.AttributeKeySlashType, msg.Evidence.SlashType.String()),
+			sdk.NewAttribute(types.AttributeKeySlashedAmount, slashed.String()),
+			sdk.NewAttribute(types.AttributeKeyOZoneLimitChanges, ozoneLimitChange.Neg().String()),
+		),
+	})
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgSlashIndexingNode(ctx sdk.Context, msg types.MsgSlashIndexingNode, k keeper.Keeper) (*sdk.Result, error) {
+	if err := checkSlashReporter(ctx, k, msg.ReporterAddress, msg.ReporterOwner, msg.Evidence); err != nil {
+		return nil, err
+	}
+
+	indexingNode, found := k.GetIndexingNode(ctx, msg.NodeAddress)
+	if !found {
+		return nil, ErrNoIndexingNodeFound
+	}
+	if indexingNode.IsSuspended() {
+		return nil, types.ErrNodeSuspended
+	}
+
+	report, quorumReached, err := k.ReportSlashEvidence(ctx, msg.NodeAddress, true, msg.Evidence, msg.ReporterAddress)
+	if err != nil {
+		return nil, err
+	}
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeSlashReport,
+			sdk.NewAttribute(types.AttributeKeyReporter, msg.ReporterAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyIndexingNode, msg.NodeAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyEvidenceReference, msg.Evidence.Reference),
+			sdk.NewAttribute(types.AttributeKeyReportCount, strconv.Itoa(len(report.Reporters))),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.ReporterOwner.String()),
+		),
+	})
+	// the node is only slashed once a quorum of indexing nodes reported the evidence
+	if !quorumReached {
+		return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+	}
+
+	slashed, ozoneLimitChange, err := k.SlashIndexingNode(ctx, indexingNode, msg.Evidence.SlashType)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeSlashIndexingNode,
+			sdk.NewAttribute(types.AttributeKeyReporter, msg.ReporterAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyIndexingNode, msg.NodeAddress.String()),
+			sdk.NewAttribute(types.AttributeKeySlashType, msg.Evidence.SlashType.String()),
+			sdk.NewAttribute(types.AttributeKeySlashedAmount, slashed.String()),
+			sdk.NewAttribute(types.AttributeKeyOZoneLimitChanges, ozoneLimitChange.Neg().String()),
+		),
+	})
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgUnsuspendResourceNode(ctx sdk.Context, msg types.MsgUnsuspendResourceNode, k keeper.Keeper) (*sdk.Result, error) {
+	err := k.UnsuspendResourceNode(ctx, msg.NetworkAddress, msg.OwnerAddress)
+	if err != nil {
+		return nil, err
+	}
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeUnsuspendResourceNode,
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.OwnerAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyNetworkAddress, msg.NetworkAddress.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		),
+	})
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgUnsuspendIndexingNode(ctx sdk.Context, msg types.MsgUnsuspendIndexingNode, k keeper.Keeper) (*sdk.Result, error) {
+	err := k.UnsuspendIndexingNode(ctx, msg.NetworkAddress, msg.OwnerAddress)
+	if err != nil {
+		return nil, err
+	}
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeUnsuspendIndexingNode,
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.OwnerAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyNetworkAddress, msg.NetworkAddress.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		),
+	})
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgUnbondResourceNodeStake(ctx sdk.Context, msg types.MsgUnbondResourceNodeStake, k keeper.Keeper) (*sdk.Result, error) {
+	resourceNode, found := k.GetResourceNode(ctx, msg.NetworkAddress)
+	if !found {
+		return nil, ErrNoResourceNodeFound
+	}
+	if !resourceNode.OwnerAddress.Equals(msg.OwnerAddress) {
+		return nil, ErrInvalidOwnerAddr
+	}
+	if resourceNode.GetStatus() == sdk.Unbonding {
+		return nil, types.ErrUnbondingNode
+	}
+	if msg.Amount.Denom != k.BondDenom(ctx) {
+		return nil, ErrBadDenom
+	}
+
+	ozoneLimitChange, completionTime, err := k.UnbondResourceNode(ctx, resourceNode, msg.Amount.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	completionTimeBz := types.ModuleCdc.MustMarshalBinaryLengthPrefixed(completionTime)
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeUnbondingResourceNode,
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.OwnerAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyResourceNode, msg.NetworkAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyStakeAmount, msg.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyOZoneLimitChanges, ozoneLimitChange.Neg().String()),
+			sdk.NewAttribute(types.AttributeKeyUnbondingMatureTime, completionTime.Format(time.RFC3339)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.OwnerAddress.String()),
+		),
+	})
+
+	return &sdk.Result{Data: completionTimeBz, Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgAddResourceNodeStake(ctx sdk.Context, msg types.MsgAddResourceNodeStake, k keeper.Keeper) (*sdk.Result, error) {
+	resourceNode, found := k.GetResourceNode(ctx, msg.NetworkAddress)
+	if !found {
+		return nil, ErrNoResourceNodeFound
+	}
+	if !resourceNode.OwnerAddress.Equals(msg.OwnerAddress) {
+		return nil, ErrInvalidOwnerAddr
+	}
+	if resourceNode.GetStatus() == sdk.Unbonding {
+		return nil, types.ErrUnbondingNode
+	}
+	if msg.Amount.Denom != k.BondDenom(ctx) {
+		return nil, ErrBadDenom
+	}
+
+	ozoneLimitChange, err := k.AddResourceNodeStake(ctx, resourceNode, msg.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeAddResourceNodeStake,
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.OwnerAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyResourceNode, msg.NetworkAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyStakeAmount, msg.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyOZoneLimitChanges, ozoneLimitChange.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.OwnerAddress.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgUnbondIndexingNodeStake(ctx sdk.Context, msg types.MsgUnbondIndexingNodeStake, k keeper.Keeper) (*sdk.Result, error) {
+	indexingNode, found := k.GetIndexingNode(ctx, msg.NetworkAddress)
+	if !found {
+		return nil, ErrNoIndexingNodeFound
+	}
+	if !indexingNode.OwnerAddress.Equals(msg.OwnerAddress) {
+		return nil, ErrInvalidOwnerAddr
+	}
+	if indexingNode.GetStatus() == sdk.Unbonding {
+		return nil, types.ErrUnbondingNode
+	}
+	if msg.Amount.Denom != k.BondDenom(ctx) {
+		return nil, ErrBadDenom
+	}
+
+	ozoneLimitChange, completionTime, err := k.UnbondIndexingNode(ctx, indexingNode, msg.Amount.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	completionTimeBz := types.ModuleCdc.MustMarshalBinaryLengthPrefixed(completionTime)
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeUnbondingIndexingNode,
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.OwnerAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyIndexingNode, msg.NetworkAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyStakeAmount, msg.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyOZoneLimitChanges, ozoneLimitChange.Neg().String()),
+			sdk.NewAttribute(types.AttributeKeyUnbondingMatureTime, completionTime.Format(time.RFC3339)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.OwnerAddress.String()),
+		),
+	})
+
+	return &sdk.Result{Data: completionTimeBz, Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgAddIndexingNodeStake(ctx sdk.Context, msg types.MsgAddIndexingNodeStake, k keeper.Keeper) (*sdk.Result, error) {
+	indexingNode, found := k.GetIndexingNode(ctx, msg.NetworkAddress)
+	if !found {
+		return nil, ErrNoIndexingNodeFound
+	}
+	if !indexingNode.OwnerAddress.Equals(msg.OwnerAddress) {
+		return nil, ErrInvalidOwnerAddr
+	}
+	if indexingNode.GetStatus() == sdk.Unbonding {
+		return nil, types.ErrUnbondingNode
+	}
+	if msg.Amount.Denom != k.BondDenom(ctx) {
+		return nil, ErrBadDenom
+	}
+
+	ozoneLimitChange, err := k.AddIndexingNodeStake(ctx, indexingNode, msg.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeAddIndexingNodeStake,
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.OwnerAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyIndexingNode, msg.NetworkAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyStakeAmount, msg.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyOZoneLimitChanges, ozoneLimitChange.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.OwnerAddress.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgDelegateResourceNode(ctx sdk.Context, msg types.MsgDelegateResourceNode, k keeper.Keeper) (*sdk.Result, error) {
+	if msg.Amount.Denom != k.BondDenom(ctx) {
+		return nil, ErrBadDenom
+	}
+
+	ozoneLimitChange, err := k.Delegate(ctx, msg.DelegatorAddress, msg.NetworkAddress, false, msg.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeDelegate,
+			sdk.NewAttribute(types.AttributeKeyDelegator, msg.DelegatorAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyResourceNode, msg.NetworkAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyStakeAmount, msg.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyOZoneLimitChanges, ozoneLimitChange.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.DelegatorAddress.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgUndelegateResourceNode(ctx sdk.Context, msg types.MsgUndelegateResourceNode, k keeper.Keeper) (*sdk.Result, error) {
+	if msg.Amount.Denom != k.BondDenom(ctx) {
+		return nil, ErrBadDenom
+	}
+
+	ozoneLimitChange, completionTime, err := k.Undelegate(ctx, msg.DelegatorAddress, msg.NetworkAddress, false, msg.Amount.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	completionTimeBz := types.ModuleCdc.MustMarshalBinaryLengthPrefixed(completionTime)
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeUndelegate,
+			sdk.NewAttribute(types.AttributeKeyDelegator, msg.DelegatorAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyResourceNode, msg.NetworkAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyStakeAmount, msg.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyOZoneLimitChanges, ozoneLimitChange.Neg().String()),
+			sdk.NewAttribute(types.AttributeKeyUnbondingMatureTime, completionTime.Format(time.RFC3339)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.DelegatorAddress.String()),
+		),
+	})
+
+	return &sdk.Result{Data: completionTimeBz, Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgUpdateResourceNodeCommission(ctx sdk.Context, msg types.MsgUpdateResourceNodeCommission, k keeper.Keeper) (*sdk.Result, error) {
+	err := k.UpdateResourceNodeCommission(ctx, msg.NetworkAddress, msg.OwnerAddress, msg.CommissionRate)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeUpdateCommission,
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.OwnerAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyResourceNode, msg.NetworkAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyCommissionRate, msg.CommissionRate.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.OwnerAddress.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgDelegateIndexingNode(ctx sdk.Context, msg types.MsgDelegateIndexingNode, k keeper.Keeper) (*sdk.Result, error) {
+	if msg.Amount.Denom != k.BondDenom(ctx) {
+		return nil, ErrBadDenom
+	}
+
+	ozoneLimitChange, err := k.Delegate(ctx, msg.DelegatorAddress, msg.NetworkAddress, true, msg.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeDelegate,
+			sdk.NewAttribute(types.AttributeKeyDelegator, msg.DelegatorAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyIndexingNode, msg.NetworkAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyStakeAmount, msg.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyOZoneLimitChanges, ozoneLimitChange.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.DelegatorAddress.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgUndelegateIndexingNode(ctx sdk.Context, msg types.MsgUndelegateIndexingNode, k keeper.Keeper) (*sdk.Result, error) {
+	if msg.Amount.Denom != k.BondDenom(ctx) {
+		return nil, ErrBadDenom
+	}
+
+	ozoneLimitChange, completionTime, err := k.Undelegate(ctx, msg.DelegatorAddress, msg.NetworkAddress, true, msg.Amount.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	completionTimeBz := types.ModuleCdc.MustMarshalBinaryLengthPrefixed(completionTime)
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeUndelegate,
+			sdk.NewAttribute(types.AttributeKeyDelegator, msg.DelegatorAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyIndexingNode, msg.NetworkAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyStakeAmount, msg.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyOZoneLimitChanges, ozoneLimitChange.Neg().String()),
+			sdk.NewAttribute(types.AttributeKeyUnbondingMatureTime, completionTime.Format(time.RFC3339)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.DelegatorAddress.String()),
+		),
+	})
+
+	return &sdk.Result{Data: completionTimeBz, Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgUpdateIndexingNodeCommission(ctx sdk.Context, msg types.MsgUpdateIndexingNodeCommission, k keeper.Keeper) (*sdk.Result, error) {
+	err := k.UpdateIndexingNodeCommission(ctx, msg.NetworkAddress, msg.OwnerAddress, msg.CommissionRate)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeUpdateCommission,
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.OwnerAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyIndexingNode, msg.NetworkAddress.String()),
+			sdk.NewAttribute(types.AttributeKeyCommissionRate, msg.CommissionRate.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.OwnerAddress.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// NewUpdateParamsProposalHandler handles the register params update proposals
+func NewUpdateParamsProposalHandler(k keeper.Keeper) govtypes.Handler {
+	return func(ctx sdk.Context, content govtypes.Content) error {
+		switch c := content.(type) {
+		case types.UpdateParamsProposal:
+			return keeper.HandleUpdateParamsProposal(ctx, k, c)
+
+		default:
+			return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized register proposal content type: %T", c)
+		}
+	}
+}
@@ -0,0 +1,323 @@
+package keeper
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stratosnet/stratos-chain/x/register/types"
+)
+
+// GetDelegation returns the delegation of a delegator to a node
+func (k Keeper) GetDelegation(ctx sdk.Context, networkAddr sdk.AccAddress, delegatorAddr sdk.AccAddress,
+) (delegation types.Delegation, found bool) {
+
+	store := ctx.KVStore(k.storeKey)
+	value := store.Get(types.GetDelegationKey(networkAddr, delegatorAddr))
+	if value == nil {
+		return delegation, false
+	}
+	delegation = types.MustUnmarshalDelegation(k.cdc, value)
+	return delegation, true
+}
+
+// SetDelegation sets the delegation of a delegator to a node
+func (k Keeper) SetDelegation(ctx sdk.Context, delegation types.Delegation) {
+	store := ctx.KVStore(k.storeKey)
+	bz := types.MustMarshalDelegation(k.cdc, delegation)
+	store.Set(types.GetDelegationKey(delegation.NetworkAddr, delegation.DelegatorAddress), bz)
+}
+
+// RemoveDelegation deletes the delegation of a delegator to a node
+func (k Keeper) RemoveDelegation(ctx sdk.Context, delegation types.Delegation) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetDelegationKey(delegation.NetworkAddr, delegation.DelegatorAddress))
+}
+
+// GetNodeDelegations returns all the delegations to a node
+func (k Keeper) GetNodeDelegations(ctx sdk.Context, networkAddr sdk.AccAddress) (delegations types.Delegations) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.GetNodeDelegationsKey(networkAddr))
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		delegation := types.MustUnmarshalDelegation(k.cdc, iterator.Value())
+		delegations = append(delegations, delegation)
+	}
+	return delegations
+}
+
+// GetDelegatorDelegations returns all the delegations of a delegator
+func (k Keeper) GetDelegatorDelegations(ctx sdk.Context, delegatorAddr sdk.AccAddress) (delegations types.Delegations) {
+	k.IterateDelegations(ctx, func(delegation types.Delegation) (stop bool) {
+		if delegation.DelegatorAddress.Equals(delegatorAddr) {
+			delegations = append(delegations, delegation)
+		}
+		return false
+	})
+	return delegations
+}
+
+// GetAllDelegations returns all the delegations
+func (k Keeper) GetAllDelegations(ctx sdk.Context) (delegations types.Delegations) {
+	k.IterateDelegations(ctx, func(delegation types.Delegation) (stop bool) {
+		delegations = append(delegations, delegation)
+		return false
+	})
+	return delegations
+}
+
+// IterateDelegations iterates through all the delegations
+func (k Keeper) IterateDelegations(ctx sdk.Context, handler func(delegation types.Delegation) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.DelegationKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		delegation := types.MustUnmarshalDelegation(k.cdc, iterator.Value())
+		if handler(delegation) {
+			break
+		}
+	}
+}
+
+// GetDelegatedTokens returns the part of the node tokens delegated by third parties
+func (k Keeper) GetDelegatedTokens(ctx sdk.Context, networkAddr sdk.AccAddress) sdk.Int {
+	delegated := sdk.ZeroInt()
+	for _, delegation := range k.GetNodeDelegations(ctx, networkAddr) {
+		delegated = delegated.Add(delegation.Amount)
+	}
+	return delegated
+}
+
+// GetOwnerAvailableStake returns the part of the available stake of a node that belongs to its owner
+func (k Keeper) GetOwnerAvailableStake(ctx sdk.Context, networkAddr sdk.AccAddress, tokens sdk.Int) sdk.Int {
+	available := k.GetAvailableStake(ctx, networkAddr, tokens).Sub(k.GetDelegatedTokens(ctx, networkAddr))
+	if available.IsNegative() {
+		return sdk.ZeroInt()
+	}
+	return available
+}
+
+// Delegate bonds tokens of a third party to a resource node or an indexing node
+func (k Keeper) Delegate(ctx sdk.Context, delegatorAddr sdk.AccAddress, networkAddr sdk.AccAddress, isIndexingNode bool,
+	amt sdk.Coin) (ozoneLimitChange sdk.Int, err error) {
+
+	if isIndexingNode {
+		indexingNode, found := k.GetIndexingNode(ctx, networkAddr)
+		if !found {
+			return sdk.ZeroInt(), types.ErrNoIndexingNodeFound
+		}
+		if indexingNode.OwnerAddress.Equals(delegatorAddr) {
+			return sdk.ZeroInt(), types.ErrSelfDelegation
+		}
+		if indexingNode.GetStatus() == sdk.Unbonding {
+			return sdk.ZeroInt(), types.ErrUnbondingNode
+		}
+		ozoneLimitChange, err = k.addIndexingNodeStake(ctx, indexingNode, delegatorAddr, amt)
+	} else {
+		resourceNode, found := k.GetResourceNode(ctx, networkAddr)
+		if !found {
+			return sdk.ZeroInt(), types.ErrNoResourceNodeFound
+		}
+		if resourceNode.OwnerAddress.Equals(delegatorAddr) {
+			return sdk.ZeroInt(), types.ErrSelfDelegation
+		}
+		if resourceNode.GetStatus() == sdk.Unbonding {
+			return sdk.ZeroInt(), types.ErrUnbondingNode
+		}
+		ozoneLimitChange, err = k.addResourceNodeStake(ctx, resourceNode, delegatorAddr, amt)
+	}
+	if err != nil {
+		return sdk.ZeroInt(), err
+	}
+
+	delegation, found := k.GetDelegation(ctx, networkAddr, delegatorAddr)
+	if !found {
+		delegation = types.NewDelegation(delegatorAddr, networkAddr, isIndexingNode, sdk.ZeroInt())
+	}
+	delegation.Amount = delegation.Amount.Add(amt.Amount)
+	k.SetDelegation(ctx, delegation)
+
+	return ozoneLimitChange, nil
+}
+
+// Undelegate starts unbonding the tokens delegated to a node. The tokens are returned to the delegator
+// through the unbonding node queue once the unbonding entry is mature.
+func (k Keeper) Undelegate(ctx sdk.Context, delegatorAddr sdk.AccAddress, networkAddr sdk.AccAddress, isIndexingNode bool,
+	amt sdk.Int) (ozoneLimitChange sdk.Int, unbondingMatureTime time.Time, err error) {
+
+	var (
+		status       sdk.BondStatus
+		creationTime time.Time
+	)
+	if isIndexingNode {
+		indexingNode, found := k.GetIndexingNode(ctx, networkAddr)
+		if !found {
+			return sdk.ZeroInt(), time.Time{}, types.ErrNoIndexingNodeFound
+		}
+		status, creationTime = indexingNode.GetStatus(), indexingNode.GetCreationTime()
+	} else {
+		resourceNode, found := k.GetResourceNode(ctx, networkAddr)
+		if !found {
+			return sdk.ZeroInt(), time.Time{}, types.ErrNoResourceNodeFound
+		}
+		status, creationTime = resourceNode.GetStatus(), resourceNode.GetCreationTime()
+	}
+	if status == sdk.Unbonding {
+		return sdk.ZeroInt(), time.Time{}, types.ErrUnbondingNode
+	}
+
+	delegation, found := k.GetDelegation(ctx, networkAddr, delegatorAddr)
+	if !found {
+		return sdk.ZeroInt(), time.Time{}, types.ErrNoDelegationFound
+	}
+	if !amt.IsPositive() || amt.GT(delegation.Amount) {
+		return sdk.ZeroInt(), time.Time{}, types.ErrInsufficientDelegation
+	}
+	if k.HasMaxUnbondingDelegatorEntries(ctx, networkAddr, delegatorAddr) {
+		return sdk.ZeroInt(), time.Time{}, types.ErrMaxUnbondingNodeEntries
+	}
+
+	unbondingMatureTime = calcUnbondingMatureTime(ctx, status, creationTime, k.UnbondingThreasholdTime(ctx), k.UnbondingCompletionTime(ctx))
+	ozoneLimitChange, err = k.unbondDelegation(ctx, delegation, amt, status == sdk.Bonded, unbondingMatureTime)
+	if err != nil {
+		return sdk.ZeroInt(), time.Time{}, err
+	}
+
+	unbondingNode, _ := k.GetUnbondingNode(ctx, networkAddr)
+	k.InsertUnbondingNodeQueue(ctx, unbondingNode, unbondingMatureTime)
+	ctx.Logger().Info(fmt.Sprintf("Undelegating %s from node %s by %s, mature time %s",
+		amt, networkAddr, delegatorAddr, unbondingMatureTime))
+
+	return ozoneLimitChange, unbondingMatureTime, nil
+}
+
+// unbondDelegation moves part of a delegation into an unbonding entry of the node
+func (k Keeper) unbondDelegation(ctx sdk.Context, delegation types.Delegation, amt sdk.Int, isBonded bool,
+	unbondingMatureTime time.Time) (ozoneLimitChange sdk.Int, err error) {
+
+	if isBonded {
+		// the node stays bonded with the remaining stake
+		err = k.transferBondedTokenToNotBondedPool(ctx, delegation.IsIndexingNode, sdk.NewCoin(k.BondDenom(ctx), amt))
+		if err != nil {
+			return sdk.ZeroInt(), err
+		}
+	}
+	ozoneLimitChange = k.decreaseOzoneLimitBySubtractStake(ctx, amt)
+
+	delegation.Amount = delegation.Amount.Sub(amt)
+	if delegation.Amount.IsZero() {
+		k.RemoveDelegation(ctx, delegation)
+	} else {
+		k.SetDelegation(ctx, delegation)
+	}
+
+	k.SetUnbondingDelegatorEntry(ctx, delegation.NetworkAddr, delegation.IsIndexingNode, delegation.DelegatorAddress,
+		ctx.BlockHeight(), unbondingMatureTime, amt)
+	return ozoneLimitChange, nil
+}
+
+// unbondAllDelegations unbonds every delegation of a node whose owner is leaving.
+// The entries are added regardless of the max entries param since the delegators did not ask for them.
+func (k Keeper) unbondAllDelegations(ctx sdk.Context, networkAddr sdk.AccAddress, isBonded bool,
+	unbondingMatureTime time.Time) (ozoneLimitChange sdk.Int, err error) {
+
+	ozoneLimitChange = sdk.ZeroInt()
+	for _, delegation := range k.GetNodeDelegations(ctx, networkAddr) {
+		change, err := k.unbondDelegation(ctx, delegation, delegation.Amount, isBonded, unbondingMatureTime)
+		if err != nil {
+			return sdk.ZeroInt(), err
+		}
+		ozoneLimitChange = ozoneLimitChange.Add(change)
+	}
+	return ozoneLimitChange, nil
+}
+
+// slashDelegations reduces every delegation of a node by the slash fraction.
+// Amounts are rounded up so the delegations never exceed the stake left on the node.
+func (k Keeper) slashDelegations(ctx sdk.Context, networkAddr sdk.AccAddress, slashFraction sdk.Dec) {
+	for _, delegation := range k.GetNodeDelegations(ctx, networkAddr) {
+		slashed := delegation.Amount.ToDec().Mul(slashFraction).Ceil().TruncateInt()
+		if slashed.GT(delegation.Amount) {
+			slashed = delegation.Amount
+		}
+		delegation.Amount = delegation.Amount.Sub(slashed)
+		if delegation.Amount.IsZero() {
+			k.RemoveDelegation(ctx, delegation)
+		} else {
+			k.SetDelegation(ctx, delegation)
+		}
+	}
+}
+
+// subtractUBDDelegatorStake removes the tokens of a mature delegator entry from the node and returns them to the delegator
+func (k Keeper) subtractUBDDelegatorStake(ctx sdk.Context, ubd types.UnbondingNode, delegatorAddr sdk.AccAddress,
+	tokenToSub sdk.Coin) error {
+
+	if ubd.IsIndexingNode {
+		indexingNode, found := k.GetIndexingNode(ctx, ubd.NetworkAddr)
+		if !found {
+			return types.ErrNoIndexingNodeFound
+		}
+		if indexingNode.GetStatus() == sdk.Bonded && tokenToSub.Amount.GTE(indexingNode.GetTokens()) {
+			return types.ErrSubAllTokens
+		}
+		if err := k.subtractIndexingNodeStake(ctx, indexingNode, tokenToSub); err != nil {
+			return err
+		}
+	} else {
+		resourceNode, found := k.GetResourceNode(ctx, ubd.NetworkAddr)
+		if !found {
+			return types.ErrNoResourceNodeFound
+		}
+		if resourceNode.GetStatus() == sdk.Bonded && tokenToSub.Amount.GTE(resourceNode.GetTokens()) {
+			return types.ErrSubAllTokens
+		}
+		if err := k.subtractResourceNodeStake(ctx, resourceNode, tokenToSub); err != nil {
+			return err
+		}
+	}
+
+	return k.sendNotBondedTokenToAccount(ctx, ubd.IsIndexingNode, delegatorAddr, tokenToSub)
+}
+
+// UpdateResourceNodeCommission sets the commission rate charged to the delegators of a resource node
+func (k Keeper) UpdateResourceNodeCommission(ctx sdk.Context, networkAddr sdk.AccAddress, ownerAddr sdk.AccAddress,
+	rate sdk.Dec) error {
+
+	if err := types.ValidateCommissionRate(rate); err != nil {
+		return err
+	}
+	node, found := k.GetResourceNode(ctx, networkAddr)
+	if !found {
+		return types.ErrNoResourceNodeFound
+	}
+	if !node.OwnerAddress.Equals(ownerAddr) {
+		return types.ErrInvalidOwnerAddr
+	}
+
+	node.Commission = types.NewCommission(rate, ctx.BlockHeader().Time)
+	k.SetResourceNode(ctx, node)
+	return nil
+}
+
+// UpdateIndexingNodeCommission sets the commission rate charged to the delegators of an indexing node
+func (k Keeper) UpdateIndexingNodeCommission(ctx sdk.Context, networkAddr sdk.AccAddress, ownerAddr sdk.AccAddress,
+	rate sdk.Dec) error {
+
+	if err := types.ValidateCommissionRate(rate); err != nil {
+		return err
+	}
+	node, found := k.GetIndexingNode(ctx, networkAddr)
+	if !found {
+		return types.ErrNoIndexingNodeFound
+	}
+	if !node.OwnerAddress.Equals(ownerAddr) {
+		return types.ErrInvalidOwnerAddr
+	}
+
+	node.Commission = types.NewCommission(rate, ctx.BlockHeader().Time)
+	k.SetIndexingNode(ctx, node)
+	return nil
+}
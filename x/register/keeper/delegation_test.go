@@ -0,0 +1,150 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stratosnet/stratos-chain/x/register/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+var (
+	resNodeOwnerDel  = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	resNodePubKeyDel = ed25519.GenPrivKey().PubKey()
+	resNodeAddrDel   = sdk.AccAddress(resNodePubKeyDel.Address())
+	delegatorAddr1   = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	resNodeStakeDel  = sdk.NewInt(100000000)
+	delegationAmt    = sdk.NewInt(50000000)
+)
+
+func TestDelegateAndUndelegateResourceNode(t *testing.T) {
+	ctx, _, bankKeeper, k, _ := CreateTestInput(t, false)
+
+	k.SetInitialGenesisStakeTotal(ctx, initialGenesisStake)
+	k.SetRemainingOzoneLimit(ctx, initialOzoneLimit)
+	k.SetTotalOzoneSupply(ctx, initialOzoneLimit)
+
+	createAccount(t, ctx, k, resNodeOwnerDel, sdk.NewCoins(sdk.NewCoin("ustos", resNodeStakeDel)))
+	createAccount(t, ctx, k, delegatorAddr1, sdk.NewCoins(sdk.NewCoin("ustos", delegationAmt)))
+	_, err := k.RegisterResourceNode(ctx, "sds://resourceNodeDel", resNodePubKeyDel, resNodeOwnerDel,
+		types.NewDescription("sds://resourceNodeDel", "", "", "", ""), "4", sdk.ZeroInt(), sdk.NewCoin("ustos", resNodeStakeDel))
+	require.NoError(t, err)
+
+	// the owner cannot delegate to its own node
+	_, err = k.Delegate(ctx, resNodeOwnerDel, resNodeAddrDel, false, sdk.NewCoin("ustos", delegationAmt))
+	require.Equal(t, types.ErrSelfDelegation, err)
+
+	ozoneLimitBefore := k.GetRemainingOzoneLimit(ctx)
+	ozoneSupplyBefore := k.GetTotalOzoneSupply(ctx)
+	ozoneLimitChange, err := k.Delegate(ctx, delegatorAddr1, resNodeAddrDel, false, sdk.NewCoin("ustos", delegationAmt))
+	require.NoError(t, err)
+	require.True(t, ozoneLimitChange.IsPositive())
+	require.Equal(t, ozoneLimitBefore.Add(ozoneLimitChange), k.GetRemainingOzoneLimit(ctx))
+	require.Equal(t, ozoneSupplyBefore.Add(ozoneLimitChange), k.GetTotalOzoneSupply(ctx))
+
+	totalStake := resNodeStakeDel.Add(delegationAmt)
+	node, _ := k.GetResourceNode(ctx, resNodeAddrDel)
+	require.Equal(t, totalStake, node.GetTokens())
+	require.Equal(t, totalStake, k.GetResourceNodeBondedToken(ctx).Amount)
+	require.True(t, bankKeeper.GetCoins(ctx, delegatorAddr1).IsZero())
+
+	delegation, found := k.GetDelegation(ctx, resNodeAddrDel, delegatorAddr1)
+	require.True(t, found)
+	require.Equal(t, delegationAmt, delegation.Amount)
+	require.Len(t, k.GetNodeDelegations(ctx, resNodeAddrDel), 1)
+	require.Len(t, k.GetDelegatorDelegations(ctx, delegatorAddr1), 1)
+
+	// the owner can only unbond its own stake
+	require.Equal(t, resNodeStakeDel, k.GetOwnerAvailableStake(ctx, resNodeAddrDel, node.GetTokens()))
+	_, _, err = k.UnbondResourceNode(ctx, node, totalStake)
+	require.Equal(t, types.ErrInsufficientStake, err)
+
+	// a slash is shared with the delegator
+	_, _, err = k.SlashResourceNode(ctx, node, types.SlashTypeDowntime)
+	require.NoError(t, err)
+	ctx = ctx.WithBlockTime(ctx.BlockTime().Add(k.SuspendDuration(ctx)))
+	require.NoError(t, k.UnsuspendResourceNode(ctx, resNodeAddrDel, resNodeOwnerDel))
+	slashedDelegation := delegationAmt.Sub(delegationAmt.ToDec().Mul(types.DefaultSlashFractionDowntime).Ceil().TruncateInt())
+	delegation, _ = k.GetDelegation(ctx, resNodeAddrDel, delegatorAddr1)
+	require.Equal(t, slashedDelegation, delegation.Amount)
+	requireInvariants(t, ctx, k)
+
+	// undelegate everything, the delegator gets the tokens back once the entry is mature
+	_, _, err = k.Undelegate(ctx, delegatorAddr1, resNodeAddrDel, false, slashedDelegation.AddRaw(1))
+	require.Equal(t, types.ErrInsufficientDelegation, err)
+	_, matureTime, err := k.Undelegate(ctx, delegatorAddr1, resNodeAddrDel, false, slashedDelegation)
+	require.NoError(t, err)
+	require.Equal(t, k.GetRemainingOzoneLimit(ctx), k.GetTotalOzoneSupply(ctx))
+	requireInvariants(t, ctx, k)
+	_, found = k.GetDelegation(ctx, resNodeAddrDel, delegatorAddr1)
+	require.False(t, found)
+
+	ctx = ctx.WithBlockTime(matureTime)
+	balances, isIndexingNode, err := k.CompleteUnbondingWithAmount(ctx, resNodeAddrDel)
+	require.NoError(t, err)
+	require.False(t, isIndexingNode)
+	require.Equal(t, slashedDelegation, balances.AmountOf("ustos"))
+	require.Equal(t, slashedDelegation, bankKeeper.GetCoins(ctx, delegatorAddr1).AmountOf("ustos"))
+	require.True(t, bankKeeper.GetCoins(ctx, resNodeOwnerDel).IsZero())
+
+	node, found = k.GetResourceNode(ctx, resNodeAddrDel)
+	require.True(t, found)
+	require.Equal(t, sdk.Bonded, node.GetStatus())
+	require.True(t, k.GetResourceNodeNotBondedToken(ctx).IsZero())
+	requireInvariants(t, ctx, k)
+}
+
+func TestUpdateResourceNodeCommission(t *testing.T) {
+	ctx, _, _, k, _ := CreateTestInput(t, false)
+
+	k.SetInitialGenesisStakeTotal(ctx, initialGenesisStake)
+	k.SetRemainingOzoneLimit(ctx, initialOzoneLimit)
+
+	createAccount(t, ctx, k, resNodeOwnerDel, sdk.NewCoins(sdk.NewCoin("ustos", resNodeStakeDel)))
+	_, err := k.RegisterResourceNode(ctx, "sds://resourceNodeDel", resNodePubKeyDel, resNodeOwnerDel,
+		types.NewDescription("sds://resourceNodeDel", "", "", "", ""), "4", sdk.ZeroInt(), sdk.NewCoin("ustos", resNodeStakeDel))
+	require.NoError(t, err)
+
+	rate := sdk.NewDecWithPrec(1, 1)
+	require.Equal(t, types.ErrInvalidOwnerAddr, k.UpdateResourceNodeCommission(ctx, resNodeAddrDel, delegatorAddr1, rate))
+	require.Equal(t, types.ErrInvalidCommissionRate, k.UpdateResourceNodeCommission(ctx, resNodeAddrDel, resNodeOwnerDel, sdk.NewDec(2)))
+	require.NoError(t, k.UpdateResourceNodeCommission(ctx, resNodeAddrDel, resNodeOwnerDel, rate))
+
+	node, _ := k.GetResourceNode(ctx, resNodeAddrDel)
+	require.Equal(t, rate, node.GetCommissionRate())
+}
+
+func TestUndelegateMaxEntries(t *testing.T) {
+	ctx, _, _, k, _ := CreateTestInput(t, false)
+
+	k.SetInitialGenesisStakeTotal(ctx, initialGenesisStake)
+	k.SetRemainingOzoneLimit(ctx, initialOzoneLimit)
+	k.SetTotalOzoneSupply(ctx, initialOzoneLimit)
+	params := k.GetParams(ctx)
+	params.MaxEntries = 2
+	k.SetParams(ctx, params)
+
+	createAccount(t, ctx, k, resNodeOwnerDel, sdk.NewCoins(sdk.NewCoin("ustos", resNodeStakeDel)))
+	createAccount(t, ctx, k, delegatorAddr1, sdk.NewCoins(sdk.NewCoin("ustos", delegationAmt)))
+	_, err := k.RegisterResourceNode(ctx, "sds://resourceNodeDel", resNodePubKeyDel, resNodeOwnerDel,
+		types.NewDescription("sds://resourceNodeDel", "", "", "", ""), "4", sdk.ZeroInt(), sdk.NewCoin("ustos", resNodeStakeDel))
+	require.NoError(t, err)
+	_, err = k.Delegate(ctx, delegatorAddr1, resNodeAddrDel, false, sdk.NewCoin("ustos", delegationAmt))
+	require.NoError(t, err)
+
+	// a delegator fills its own entries with dust undelegations
+	for i := 0; i < 2; i++ {
+		_, _, err = k.Undelegate(ctx, delegatorAddr1, resNodeAddrDel, false, sdk.OneInt())
+		require.NoError(t, err)
+	}
+	_, _, err = k.Undelegate(ctx, delegatorAddr1, resNodeAddrDel, false, sdk.OneInt())
+	require.Equal(t, types.ErrMaxUnbondingNodeEntries, err)
+
+	// the owner keeps its own entries
+	require.False(t, k.HasMaxUnbondingNodeEntries(ctx, resNodeAddrDel))
+	node, _ := k.GetResourceNode(ctx, resNodeAddrDel)
+	_, _, err = k.UnbondResourceNode(ctx, node, sdk.OneInt())
+	require.NoError(t, err)
+	requireInvariants(t, ctx, k)
+}
@@ -157,6 +157,12 @@ func (k Keeper) RegisterIndexingNode(ctx sdk.Context, networkID string, pubKey c
 
 // AddIndexingNodeStake Update the tokens of an existing indexing node
 func (k Keeper) AddIndexingNodeStake(ctx sdk.Context, indexingNode types.IndexingNode, tokenToAdd sdk.Coin,
+) (ozoneLimitChange sdk.Int, err error) {
+	return k.addIndexingNodeStake(ctx, indexingNode, indexingNode.GetOwnerAddr(), tokenToAdd)
+}
+
+// addIndexingNodeStake moves tokens from the given account into the stake of an existing indexing node
+func (k Keeper) addIndexingNodeStake(ctx sdk.Context, indexingNode types.IndexingNode, fromAddr sdk.AccAddress, tokenToAdd sdk.Coin,
 ) (ozoneLimitChange sdk.Int, err error) {
 
 	nodeAcc := k.accountKeeper.GetAccount(ctx, indexingNode.GetNetworkAddr())
@@ -166,31 +172,21 @@ func (k Keeper) AddIndexingNodeStake(ctx sdk.Context, indexingNode types.Indexin
 	}
 
 	coins := sdk.NewCoins(tokenToAdd)
-	hasCoin := k.bankKeeper.HasCoins(ctx, indexingNode.OwnerAddress, coins)
+	hasCoin := k.bankKeeper.HasCoins(ctx, fromAddr, coins)
 	if !hasCoin {
 		return sdk.ZeroInt(), types.ErrInsufficientBalance
 	}
 
-	_, err = k.bankKeeper.SubtractCoins(ctx, indexingNode.GetOwnerAddr(), coins)
+	if indexingNode.GetStatus() == sdk.Unbonding {
+		return sdk.ZeroInt(), types.ErrUnbondingNode
+	}
+	err = k.sendTokenToPool(ctx, fromAddr, true, indexingNode.GetStatus() == sdk.Bonded, tokenToAdd)
 	if err != nil {
 		return sdk.ZeroInt(), err
 	}
 
 	indexingNode = indexingNode.AddToken(tokenToAdd.Amount)
 
-	switch indexingNode.GetStatus() {
-	case sdk.Unbonded:
-		notBondedTokenInPool := k.GetIndexingNodeNotBondedToken(ctx)
-		notBondedTokenInPool = notBondedTokenInPool.Add(tokenToAdd)
-		k.SetIndexingNodeNotBondedToken(ctx, notBondedTokenInPool)
-	case sdk.Bonded:
-		bondedTokenInPool := k.GetIndexingNodeBondedToken(ctx)
-		bondedTokenInPool = bondedTokenInPool.Add(tokenToAdd)
-		k.SetIndexingNodeBondedToken(ctx, bondedTokenInPool)
-	case sdk.Unbonding:
-		return sdk.ZeroInt(), types.ErrUnbondingNode
-	}
-
 	newStake := indexingNode.GetTokens()
 
 	k.SetIndexingNode(ctx, indexingNode)
@@ -204,19 +200,8 @@ func (k Keeper) RemoveTokenFromPoolWhileUnbondingIndexingNode(ctx sdk.Context, i
 	// change node status to unbonding
 	indexingNode.Status = sdk.Unbonding
 	k.SetIndexingNode(ctx, indexingNode)
-	// get pools
-	bondedTokenInPool := k.GetResourceNodeBondedToken(ctx)
-	notBondedTokenInPool := k.GetResourceNodeNotBondedToken(ctx)
-	if bondedTokenInPool.IsLT(tokenToSub) {
-		return types.ErrInsufficientBalanceOfBondedPool
-	}
-	// remove token from BondedPool
-	bondedTokenInPool = bondedTokenInPool.Sub(tokenToSub)
-	k.SetIndexingNodeBondedToken(ctx, bondedTokenInPool)
-	// add token into NotBondedPool
-	notBondedTokenInPool = notBondedTokenInPool.Add(tokenToSub)
-	k.SetIndexingNodeNotBondedToken(ctx, notBondedTokenInPool)
-	return nil
+	// remove token from BondedPool and add it into NotBondedPool
+	return k.transferBondedTokenToNotBondedPool(ctx, true, tokenToSub)
 }
 
 // SubtractIndexingNodeStake Update the tokens of an existing indexing node
@@ -226,31 +211,32 @@ func (k Keeper) SubtractIndexingNodeStake(ctx sdk.Context, indexingNode types.In
 		return types.ErrNoOwnerAccountFound
 	}
 
-	coins := sdk.NewCoins(tokenToSub)
-
-	if indexingNode.GetStatus() == sdk.Unbonded || indexingNode.GetStatus() == sdk.Unbonding {
-		notBondedTokenInPool := k.GetIndexingNodeNotBondedToken(ctx)
-		if notBondedTokenInPool.IsLT(tokenToSub) {
-			return types.ErrInsufficientBalanceOfNotBondedPool
-		}
-		notBondedTokenInPool = notBondedTokenInPool.Sub(tokenToSub)
-		k.SetIndexingNodeNotBondedToken(ctx, notBondedTokenInPool)
-	}
-	if indexingNode.GetStatus() == sdk.Bonded {
-		return types.ErrInvalidNodeStatBonded
+	// a bonded node can only get back the tokens of a partial unbonding, which are kept in the not bonded pool
+	if indexingNode.GetStatus() == sdk.Bonded && tokenToSub.Amount.GTE(indexingNode.GetTokens()) {
+		return types.ErrSubAllTokens
 	}
 
-	_, err := k.bankKeeper.AddCoins(ctx, indexingNode.OwnerAddress, coins)
+	err := k.subtractIndexingNodeStake(ctx, indexingNode, tokenToSub)
 	if err != nil {
 		return err
 	}
 
+	return k.sendNotBondedTokenToAccount(ctx, true, indexingNode.OwnerAddress, tokenToSub)
+}
+
+// subtractIndexingNodeStake removes tokens from the node itself, deleting the node once its tokens reach zero.
+// The tokens are left in the not bonded pool, the caller decides where they go.
+func (k Keeper) subtractIndexingNodeStake(ctx sdk.Context, indexingNode types.IndexingNode, tokenToSub sdk.Coin) error {
+	if k.GetIndexingNodeNotBondedToken(ctx).IsLT(tokenToSub) {
+		return types.ErrInsufficientBalanceOfNotBondedPool
+	}
+
 	indexingNode = indexingNode.SubToken(tokenToSub.Amount)
 	newStake := indexingNode.GetTokens()
 
 	k.SetIndexingNode(ctx, indexingNode)
 
-	if indexingNode.GetTokens().IsZero() {
+	if newStake.IsZero() {
 		k.DeleteLastIndexingNodeStake(ctx, indexingNode.GetNetworkAddr())
 		err := k.removeIndexingNode(ctx, indexingNode.GetNetworkAddr())
 		if err != nil {
@@ -342,30 +328,28 @@ func (k Keeper) HandleVoteForIndexingNodeRegistration(ctx sdk.Context, nodeAddr
 		return node.Status, nil
 	}
 
-	totalSpCount := len(k.GetAllValidIndexingNodes(ctx))
-	voteCountRequiredToPass := totalSpCount*2/3 + 1
 	//unbounded to bounded
-	if len(votePool.ApproveList) >= voteCountRequiredToPass {
+	if len(votePool.ApproveList) >= k.GetVoteCountRequiredToPass(ctx) {
 		node.Status = sdk.Bonded
 		k.SetIndexingNode(ctx, node)
 
-		// move stake from not bonded pool to bonded pool
-		tokenToBond := sdk.NewCoin(k.BondDenom(ctx), node.GetTokens())
-		notBondedToken := k.GetIndexingNodeNotBondedToken(ctx)
-		bondedToken := k.GetIndexingNodeBondedToken(ctx)
-
-		if notBondedToken.IsLT(tokenToBond) {
-			return node.Status, types.ErrInsufficientBalance
+		// move stake from not bonded pool to bonded pool, the tokens of the unbonding entries stay in the not bonded pool
+		tokenToBond := sdk.NewCoin(k.BondDenom(ctx), node.GetTokens().Sub(k.GetUnbondingNodeBalance(ctx, nodeAddr)))
+		err := k.transferNotBondedTokenToBondedPool(ctx, true, tokenToBond)
+		if err != nil {
+			return node.Status, err
 		}
-		notBondedToken = notBondedToken.Sub(tokenToBond)
-		bondedToken = bondedToken.Add(tokenToBond)
-		k.SetIndexingNodeNotBondedToken(ctx, notBondedToken)
-		k.SetIndexingNodeBondedToken(ctx, bondedToken)
 	}
 
 	return node.Status, nil
 }
 
+// GetVoteCountRequiredToPass returns the number of approvals from valid indexing nodes (2/3+1) needed to pass a vote
+func (k Keeper) GetVoteCountRequiredToPass(ctx sdk.Context) int {
+	totalSpCount := len(k.GetAllValidIndexingNodes(ctx))
+	return totalSpCount*2/3 + 1
+}
+
 func (k Keeper) hasValue(items []sdk.AccAddress, item sdk.AccAddress) bool {
 	for _, eachItem := range items {
 		if eachItem.Equals(item) {
@@ -392,6 +376,20 @@ func (k Keeper) SetIndexingNodeRegistrationVotePool(ctx sdk.Context, votePool ty
 	store.Set(types.GetIndexingNodeRegistrationVotesKey(nodeAddr), bz)
 }
 
+// IterateIndexingNodeRegistrationVotePools iterates through the vote pools of all the pending indexing node registrations
+func (k Keeper) IterateIndexingNodeRegistrationVotePools(ctx sdk.Context, handler func(votePool types.IndexingNodeRegistrationVotePool) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.IndexingNodeRegistrationVotesKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var votePool types.IndexingNodeRegistrationVotePool
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &votePool)
+		if handler(votePool) {
+			break
+		}
+	}
+}
+
 func (k Keeper) UpdateIndexingNode(ctx sdk.Context, networkID string, description types.Description,
 	networkAddr sdk.AccAddress, ownerAddr sdk.AccAddress) error {
 
@@ -412,38 +410,6 @@ func (k Keeper) UpdateIndexingNode(ctx sdk.Context, networkID string, descriptio
 	return nil
 }
 
-func (k Keeper) SetIndexingNodeBondedToken(ctx sdk.Context, token sdk.Coin) {
-	store := ctx.KVStore(k.storeKey)
-	bz := k.cdc.MustMarshalBinaryLengthPrefixed(token)
-	store.Set(types.IndexingNodeBondedTokenKey, bz)
-}
-
-func (k Keeper) GetIndexingNodeBondedToken(ctx sdk.Context) (token sdk.Coin) {
-	store := ctx.KVStore(k.storeKey)
-	bz := store.Get(types.IndexingNodeBondedTokenKey)
-	if bz == nil {
-		return sdk.NewCoin(k.BondDenom(ctx), sdk.ZeroInt())
-	}
-	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &token)
-	return token
-}
-
-func (k Keeper) SetIndexingNodeNotBondedToken(ctx sdk.Context, token sdk.Coin) {
-	store := ctx.KVStore(k.storeKey)
-	bz := k.cdc.MustMarshalBinaryLengthPrefixed(token)
-	store.Set(types.IndexingNodeNotBondedTokenKey, bz)
-}
-
-func (k Keeper) GetIndexingNodeNotBondedToken(ctx sdk.Context) (token sdk.Coin) {
-	store := ctx.KVStore(k.storeKey)
-	bz := store.Get(types.IndexingNodeNotBondedTokenKey)
-	if bz == nil {
-		return sdk.NewCoin(k.BondDenom(ctx), sdk.ZeroInt())
-	}
-	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &token)
-	return token
-}
-
 func (k Keeper) GetNodeOwnerMapFromIndexingNodes(ctx sdk.Context, nodeOwnerMap map[string]sdk.AccAddress) map[string]sdk.AccAddress {
 	store := ctx.KVStore(k.storeKey)
 	iterator := sdk.KVStorePrefixIterator(store, types.IndexingNodeKey)
@@ -2,8 +2,6 @@ package keeper
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
-	"github.com/cosmos/cosmos-sdk/x/auth"
-	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/stratosnet/stratos-chain/x/register/types"
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/crypto/ed25519"
@@ -41,7 +39,7 @@ var (
 
 func TestExpiredVote(t *testing.T) {
 
-	ctx, accountKeeper, bankKeeper, k, _ := CreateTestInput(t, false)
+	ctx, _, _, k, _ := CreateTestInput(t, false)
 	time, _ := time.Parse(time.RubyDate, "Fri Sep 24 10:37:13 -0400 2021")
 	//genesis init Sp nodes.
 	genesisSpNode1 := types.NewIndexingNode("sds://indexingNode1", spNodePubKey1, spNodeOwner1, types.NewDescription("sds://indexingNode1", "", "", "", ""), time)
@@ -65,11 +63,11 @@ func TestExpiredVote(t *testing.T) {
 	k.SetLastIndexingNodeStake(ctx, spNodeAddr2, initialStake2)
 	k.SetLastIndexingNodeStake(ctx, spNodeAddr3, initialStake3)
 	k.SetLastIndexingNodeStake(ctx, spNodeAddr4, initialStake4)
-	k.SetIndexingNodeBondedToken(ctx, sdk.NewCoin(k.BondDenom(ctx), initialStake1.Add(initialStake2).Add(initialStake3).Add(initialStake4)))
+	setTokenPool(t, ctx, k, types.IndexingNodeBondedPoolName, initialStake1.Add(initialStake2).Add(initialStake3).Add(initialStake4))
 	k.SetInitialGenesisStakeTotal(ctx, initialStake1.Add(initialStake2).Add(initialStake3).Add(initialStake4))
 
 	//Register new SP node after genesis initialized
-	createAccount(t, ctx, accountKeeper, bankKeeper, spNodeOwnerNew, sdk.NewCoins(sdk.NewCoin("ustos", spNodeStakeNew)))
+	createAccount(t, ctx, k, spNodeOwnerNew, sdk.NewCoins(sdk.NewCoin("ustos", spNodeStakeNew)))
 	_, err := k.RegisterIndexingNode(ctx, "sds://newIndexingNode", spNodePubKeyNew, spNodeOwnerNew,
 		types.NewDescription("sds://newIndexingNode", "", "", "", ""), sdk.NewCoin("ustos", spNodeStakeNew))
 	require.NoError(t, err)
@@ -89,7 +87,7 @@ func TestExpiredVote(t *testing.T) {
 
 func TestDuplicateVote(t *testing.T) {
 
-	ctx, accountKeeper, bankKeeper, k, _ := CreateTestInput(t, false)
+	ctx, _, _, k, _ := CreateTestInput(t, false)
 	time, _ := time.Parse(time.RubyDate, "Fri Sep 24 10:37:13 -0400 2021")
 	//genesis init Sp nodes.
 	genesisSpNode1 := types.NewIndexingNode("sds://indexingNode1", spNodePubKey1, spNodeOwner1, types.NewDescription("sds://indexingNode1", "", "", "", ""), time)
@@ -113,11 +111,11 @@ func TestDuplicateVote(t *testing.T) {
 	k.SetLastIndexingNodeStake(ctx, spNodeAddr2, initialStake2)
 	k.SetLastIndexingNodeStake(ctx, spNodeAddr3, initialStake3)
 	k.SetLastIndexingNodeStake(ctx, spNodeAddr4, initialStake4)
-	k.SetIndexingNodeBondedToken(ctx, sdk.NewCoin(k.BondDenom(ctx), initialStake1.Add(initialStake2).Add(initialStake3).Add(initialStake4)))
+	setTokenPool(t, ctx, k, types.IndexingNodeBondedPoolName, initialStake1.Add(initialStake2).Add(initialStake3).Add(initialStake4))
 	k.SetInitialGenesisStakeTotal(ctx, initialStake1.Add(initialStake2).Add(initialStake3).Add(initialStake4))
 
 	//Register new SP node after genesis initialized
-	createAccount(t, ctx, accountKeeper, bankKeeper, spNodeOwnerNew, sdk.NewCoins(sdk.NewCoin("ustos", spNodeStakeNew)))
+	createAccount(t, ctx, k, spNodeOwnerNew, sdk.NewCoins(sdk.NewCoin("ustos", spNodeStakeNew)))
 	_, err := k.RegisterIndexingNode(ctx, "sds://newIndexingNode", spNodePubKeyNew, spNodeOwnerNew,
 		types.NewDescription("sds://newIndexingNode", "", "", "", ""), sdk.NewCoin("ustos", spNodeStakeNew))
 	require.NoError(t, err)
@@ -141,7 +139,7 @@ func TestDuplicateVote(t *testing.T) {
 
 func TestSpRegistrationApproval(t *testing.T) {
 
-	ctx, accountKeeper, bankKeeper, k, _ := CreateTestInput(t, false)
+	ctx, _, _, k, _ := CreateTestInput(t, false)
 	time, _ := time.Parse(time.RubyDate, "Fri Sep 24 10:37:13 -0400 2021")
 	//genesis init Sp nodes.
 	genesisSpNode1 := types.NewIndexingNode("sds://indexingNode1", spNodePubKey1, spNodeOwner1, types.NewDescription("sds://indexingNode1", "", "", "", ""), time)
@@ -165,11 +163,11 @@ func TestSpRegistrationApproval(t *testing.T) {
 	k.SetLastIndexingNodeStake(ctx, spNodeAddr2, initialStake2)
 	k.SetLastIndexingNodeStake(ctx, spNodeAddr3, initialStake3)
 	k.SetLastIndexingNodeStake(ctx, spNodeAddr4, initialStake4)
-	k.SetIndexingNodeBondedToken(ctx, sdk.NewCoin(k.BondDenom(ctx), initialStake1.Add(initialStake2).Add(initialStake3).Add(initialStake4)))
+	setTokenPool(t, ctx, k, types.IndexingNodeBondedPoolName, initialStake1.Add(initialStake2).Add(initialStake3).Add(initialStake4))
 	k.SetInitialGenesisStakeTotal(ctx, initialStake1.Add(initialStake2).Add(initialStake3).Add(initialStake4))
 
 	//Register new SP node after genesis initialized
-	createAccount(t, ctx, accountKeeper, bankKeeper, spNodeOwnerNew, sdk.NewCoins(sdk.NewCoin("ustos", spNodeStakeNew)))
+	createAccount(t, ctx, k, spNodeOwnerNew, sdk.NewCoins(sdk.NewCoin("ustos", spNodeStakeNew)))
 	//_, err := k.bankKeeper.AddCoins(ctx, spNodeAddr4, sdk.NewCoins(sdk.NewCoin(k.BondDenom(ctx), sdk.NewInt(10000000000000))))
 	//require.NoError(t, err)
 
@@ -236,12 +234,14 @@ func handlerSimulate(ctx sdk.Context, k Keeper, msg types.MsgIndexingNodeRegistr
 	return nil
 }
 
-func createAccount(t *testing.T, ctx sdk.Context, accountKeeper auth.AccountKeeper, bankKeeper bank.Keeper, acc sdk.AccAddress, coins sdk.Coins) {
-	account := accountKeeper.GetAccount(ctx, acc)
+// createAccount credits coins to an account, adding them to the total supply
+func createAccount(t *testing.T, ctx sdk.Context, k Keeper, acc sdk.AccAddress, coins sdk.Coins) {
+	account := k.accountKeeper.GetAccount(ctx, acc)
 	if account == nil {
-		account = accountKeeper.NewAccountWithAddress(ctx, acc)
+		account = k.accountKeeper.NewAccountWithAddress(ctx, acc)
 		//fmt.Printf("create account: " + account.String() + "\n")
 	}
-	coins, err := bankKeeper.AddCoins(ctx, acc, coins)
+	_, err := k.bankKeeper.AddCoins(ctx, acc, coins)
 	require.NoError(t, err)
+	k.supplyKeeper.SetSupply(ctx, k.supplyKeeper.GetSupply(ctx).Inflate(coins))
 }
@@ -0,0 +1,78 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stratosnet/stratos-chain/x/register/types"
+)
+
+// RegisterInvariants registers all register invariants
+func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
+	ir.RegisterRoute(types.ModuleName, "bonded-tokens", BondedTokensInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "not-bonded-tokens", NotBondedTokensInvariant(k))
+}
+
+// AllInvariants runs all invariants of the register module.
+func AllInvariants(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		res, stop := BondedTokensInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+		return NotBondedTokensInvariant(k)(ctx)
+	}
+}
+
+// nodeStakes returns the tokens of the bonded and the not bonded nodes, leaving out
+// the tokens scheduled to be returned by an unbonding entry
+func nodeStakes(ctx sdk.Context, k Keeper) (bonded, notBonded sdk.Int) {
+	bonded, notBonded = sdk.ZeroInt(), sdk.ZeroInt()
+	addStake := func(networkAddr sdk.AccAddress, status sdk.BondStatus, tokens sdk.Int) {
+		stake := tokens.Sub(k.GetUnbondingNodeBalance(ctx, networkAddr))
+		if status == sdk.Bonded {
+			bonded = bonded.Add(stake)
+		} else {
+			notBonded = notBonded.Add(stake)
+		}
+	}
+	for _, node := range k.GetAllResourceNodes(ctx) {
+		addStake(node.GetNetworkAddr(), node.GetStatus(), node.GetTokens())
+	}
+	for _, node := range k.GetAllIndexingNodes(ctx) {
+		addStake(node.GetNetworkAddr(), node.GetStatus(), node.GetTokens())
+	}
+	return bonded, notBonded
+}
+
+// BondedTokensInvariant checks that the bonded pools hold the tokens of the bonded nodes,
+// except for the tokens of their partial unbondings which are moved to the not bonded pools
+func BondedTokensInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		bondedPools := k.GetResourceNodeBondedToken(ctx).Amount.Add(k.GetIndexingNodeBondedToken(ctx).Amount)
+		bonded, _ := nodeStakes(ctx, k)
+		broken := !bondedPools.Equal(bonded)
+
+		return sdk.FormatInvariant(types.ModuleName, "bonded tokens", fmt.Sprintf(
+			"\tsum of bonded pools: %v\n"+
+				"\tsum of bonded node tokens: %v\n",
+			bondedPools, bonded)), broken
+	}
+}
+
+// NotBondedTokensInvariant checks that the not bonded pools hold the balances of all the unbonding entries
+// and the remaining tokens of the nodes that are not bonded
+func NotBondedTokensInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		notBondedPools := k.GetResourceNodeNotBondedToken(ctx).Amount.Add(k.GetIndexingNodeNotBondedToken(ctx).Amount)
+		unbonding := k.GetAllUnbondingNodesTotalBalance(ctx)
+		_, notBonded := nodeStakes(ctx, k)
+		broken := !notBondedPools.Equal(unbonding.Add(notBonded))
+
+		return sdk.FormatInvariant(types.ModuleName, "not bonded tokens", fmt.Sprintf(
+			"\tsum of not bonded pools: %v\n"+
+				"\tsum of unbonding entries: %v\n"+
+				"\tsum of not bonded node tokens: %v\n",
+			notBondedPools, unbonding, notBonded)), broken
+	}
+}
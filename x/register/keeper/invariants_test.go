@@ -0,0 +1,39 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stratosnet/stratos-chain/x/register/types"
+	"github.com/stretchr/testify/require"
+)
+
+// setTokenPool sets the balance of a token pool, the way the genesis does, adjusting the total supply
+func setTokenPool(t *testing.T, ctx sdk.Context, k Keeper, poolName string, amount sdk.Int) {
+	pool := k.GetTokenPool(ctx, poolName)
+	supply := k.supplyKeeper.GetSupply(ctx)
+	supply = supply.Deflate(pool.GetCoins())
+	coins := sdk.NewCoins(sdk.NewCoin(k.BondDenom(ctx), amount))
+	require.NoError(t, pool.SetCoins(coins))
+	k.SetTokenPool(ctx, pool)
+	k.supplyKeeper.SetSupply(ctx, supply.Inflate(coins))
+}
+
+func requireInvariants(t *testing.T, ctx sdk.Context, k Keeper) {
+	msg, broken := AllInvariants(k)(ctx)
+	require.False(t, broken, msg)
+}
+
+func TestTokenPoolInvariants(t *testing.T) {
+	ctx, _, _, k, _ := CreateTestInput(t, false)
+	requireInvariants(t, ctx, k)
+
+	setTokenPool(t, ctx, k, types.ResourceNodeBondedPoolName, sdk.OneInt())
+	_, broken := BondedTokensInvariant(k)(ctx)
+	require.True(t, broken)
+	setTokenPool(t, ctx, k, types.ResourceNodeBondedPoolName, sdk.ZeroInt())
+
+	setTokenPool(t, ctx, k, types.IndexingNodeNotBondedPoolName, sdk.OneInt())
+	_, broken = NotBondedTokensInvariant(k)(ctx)
+	require.True(t, broken)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/supply"
 	"github.com/stratosnet/stratos-chain/x/register/types"
 	"github.com/tendermint/tendermint/libs/log"
 	"time"
@@ -20,6 +21,7 @@ type Keeper struct {
 	paramSpace            params.Subspace
 	accountKeeper         auth.AccountKeeper
 	bankKeeper            bank.Keeper
+	supplyKeeper          supply.Keeper
 	hooks                 types.RegisterHooks
 	resourceNodeCache     map[string]cachedResourceNode
 	resourceNodeCacheList *list.List
@@ -29,7 +31,17 @@ type Keeper struct {
 
 // NewKeeper creates a register keeper
 func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, paramSpace params.Subspace,
-	accountKeeper auth.AccountKeeper, bankKeeper bank.Keeper) Keeper {
+	accountKeeper auth.AccountKeeper, bankKeeper bank.Keeper, supplyKeeper supply.Keeper) Keeper {
+
+	// ensure the token pool module accounts are set
+	for _, poolName := range []string{
+		types.ResourceNodeBondedPoolName, types.ResourceNodeNotBondedPoolName,
+		types.IndexingNodeBondedPoolName, types.IndexingNodeNotBondedPoolName,
+	} {
+		if addr := supplyKeeper.GetModuleAddress(poolName); addr == nil {
+			panic(fmt.Sprintf("%s module account has not been set", poolName))
+		}
+	}
 
 	keeper := Keeper{
 		storeKey:              key,
@@ -37,6 +49,7 @@ func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, paramSpace params.Subspace,
 		paramSpace:            paramSpace.WithKeyTable(types.ParamKeyTable()),
 		accountKeeper:         accountKeeper,
 		bankKeeper:            bankKeeper,
+		supplyKeeper:          supplyKeeper,
 		hooks:                 nil,
 		resourceNodeCache:     make(map[string]cachedResourceNode, resourceNodeCacheSize),
 		resourceNodeCacheList: list.New(),
@@ -92,6 +105,34 @@ func (k Keeper) GetRemainingOzoneLimit(ctx sdk.Context) (value sdk.Int) {
 	return
 }
 
+func (k Keeper) SetTotalOzoneSupply(ctx sdk.Context, value sdk.Int) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinaryLengthPrefixed(value)
+	store.Set(types.TotalOzoneSupplyKey, b)
+}
+
+func (k Keeper) GetTotalOzoneSupply(ctx sdk.Context) (value sdk.Int) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(types.TotalOzoneSupplyKey)
+	if b == nil {
+		return sdk.ZeroInt()
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(b, &value)
+	return
+}
+
+// DecreaseTotalOzoneSupply removes the uoz consumed by users from the total supply
+func (k Keeper) DecreaseTotalOzoneSupply(ctx sdk.Context, consumed sdk.Int) {
+	k.SetTotalOzoneSupply(ctx, k.GetTotalOzoneSupply(ctx).Sub(consumed))
+}
+
+// setRemainingOzoneLimitBySupplyChange updates the remaining ozone limit and moves the total supply by the same amount
+func (k Keeper) setRemainingOzoneLimitBySupplyChange(ctx sdk.Context, newLimit sdk.Int) {
+	supplyChange := newLimit.Sub(k.GetRemainingOzoneLimit(ctx))
+	k.SetRemainingOzoneLimit(ctx, newLimit)
+	k.SetTotalOzoneSupply(ctx, k.GetTotalOzoneSupply(ctx).Add(supplyChange))
+}
+
 func (k Keeper) increaseOzoneLimitByAddStake(ctx sdk.Context, stake sdk.Int) (ozoneLimitChange sdk.Int) {
 	initialGenesisDeposit := k.GetInitialGenesisStakeTotal(ctx).ToDec() //ustos
 	if initialGenesisDeposit.Equal(sdk.ZeroDec()) {
@@ -101,7 +142,7 @@ func (k Keeper) increaseOzoneLimitByAddStake(ctx sdk.Context, stake sdk.Int) (oz
 	currentLimit := k.GetRemainingOzoneLimit(ctx).ToDec() //uoz
 	limitToAdd := currentLimit.Mul(stake.ToDec()).Quo(initialGenesisDeposit)
 	newLimit := currentLimit.Add(limitToAdd).TruncateInt()
-	k.SetRemainingOzoneLimit(ctx, newLimit)
+	k.setRemainingOzoneLimitBySupplyChange(ctx, newLimit)
 	return limitToAdd.TruncateInt()
 }
 
@@ -114,7 +155,7 @@ func (k Keeper) decreaseOzoneLimitBySubtractStake(ctx sdk.Context, stake sdk.Int
 	currentLimit := k.GetRemainingOzoneLimit(ctx).ToDec() //uoz
 	limitToSub := currentLimit.Mul(stake.ToDec()).Quo(initialGenesisDeposit)
 	newLimit := currentLimit.Sub(limitToSub).TruncateInt()
-	k.SetRemainingOzoneLimit(ctx, newLimit)
+	k.setRemainingOzoneLimitBySupplyChange(ctx, newLimit)
 	return limitToSub.TruncateInt()
 }
 
@@ -208,13 +249,19 @@ func (k Keeper) IterateUnbondingNodes(ctx sdk.Context, fn func(index int64, ubd
 	}
 }
 
-// HasMaxUnbondingIndexingNodeEntries - check if unbonding IndexingNode has maximum number of entries
+// HasMaxUnbondingNodeEntries - check if the owner of an unbonding node has the maximum number of entries.
+// The entries of the delegators are counted apart, so they can't keep the owner from unbonding.
 func (k Keeper) HasMaxUnbondingNodeEntries(ctx sdk.Context, networkAddr sdk.AccAddress) bool {
+	return k.HasMaxUnbondingDelegatorEntries(ctx, networkAddr, nil)
+}
+
+// HasMaxUnbondingDelegatorEntries - check if a delegator of an unbonding node has the maximum number of entries
+func (k Keeper) HasMaxUnbondingDelegatorEntries(ctx sdk.Context, networkAddr sdk.AccAddress, delegatorAddr sdk.AccAddress) bool {
 	ubd, found := k.GetUnbondingNode(ctx, networkAddr)
 	if !found {
 		return false
 	}
-	return len(ubd.Entries) >= int(k.MaxEntries(ctx))
+	return ubd.CountEntries(delegatorAddr) >= int(k.MaxEntries(ctx))
 }
 
 // set the unbonding IndexingNode
@@ -247,6 +294,23 @@ func (k Keeper) SetUnbondingNodeEntry(ctx sdk.Context, networkAddr sdk.AccAddres
 	return ubd
 }
 
+// SetUnbondingDelegatorEntry adds an entry refunding a delegator to the unbonding node at
+// the given addresses. It creates the unbonding node if it does not exist
+func (k Keeper) SetUnbondingDelegatorEntry(ctx sdk.Context, networkAddr sdk.AccAddress, isIndexingNode bool,
+	delegatorAddr sdk.AccAddress, creationHeight int64, minTime time.Time, balance sdk.Int) types.UnbondingNode {
+
+	ubd, found := k.GetUnbondingNode(ctx, networkAddr)
+	if !found {
+		ubd = types.UnbondingNode{
+			NetworkAddr:    networkAddr,
+			IsIndexingNode: isIndexingNode,
+		}
+	}
+	ubd.AddDelegatorEntry(delegatorAddr, creationHeight, minTime, balance)
+	k.SetUnbondingNode(ctx, ubd)
+	return ubd
+}
+
 // unbonding delegation queue timeslice operations
 
 // gets a specific unbonding queue timeslice. A timeslice is a slice of DVPairs
@@ -289,6 +353,25 @@ func (k Keeper) UnbondingNodeQueueIterator(ctx sdk.Context, endTime time.Time) s
 		sdk.InclusiveEndBytes(types.GetUBDTimeKey(endTime)))
 }
 
+// IterateUnbondingNodeQueue iterates through all the timeslices of the unbonding queue, used during genesis dump
+func (k Keeper) IterateUnbondingNodeQueue(ctx sdk.Context, handler func(timestamp time.Time, networkAddrs []sdk.AccAddress) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, types.UBDNodeQueueKey)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		timestamp, err := sdk.ParseTimeBytes(iterator.Key()[len(types.UBDNodeQueueKey):])
+		if err != nil {
+			panic(err)
+		}
+		var networkAddrs []sdk.AccAddress
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &networkAddrs)
+		if handler(timestamp, networkAddrs) {
+			break
+		}
+	}
+}
+
 // Returns a concatenated list of all the timeslices inclusively previous to
 // currTime, and deletes the timeslices from the queue
 func (k Keeper) DequeueAllMatureUBDQueue(ctx sdk.Context,
@@ -334,7 +417,12 @@ func (k Keeper) CompleteUnbondingWithAmount(ctx sdk.Context, networkAddr sdk.Acc
 			// track undelegation only when remaining or truncated shares are non-zero
 			if !entry.Balance.IsZero() {
 				amt := sdk.NewCoin(bondDenom, entry.Balance)
-				err := k.SubtractUBDNodeStake(ctx, ubd, amt)
+				var err error
+				if entry.IsDelegatorEntry() {
+					err = k.subtractUBDDelegatorStake(ctx, ubd, entry.DelegatorAddress, amt)
+				} else {
+					err = k.SubtractUBDNodeStake(ctx, ubd, amt)
+				}
 				if err != nil {
 					return nil, false, err
 				}
@@ -396,13 +484,38 @@ func (k Keeper) UnbondResourceNode(
 	}
 	unbondingMatureTime = calcUnbondingMatureTime(ctx, resourceNode.Status, resourceNode.CreationTime, k.UnbondingThreasholdTime(ctx), k.UnbondingCompletionTime(ctx))
 
+	// the delegated tokens are unbonded by the delegators themselves
+	availableStake := k.GetOwnerAvailableStake(ctx, networkAddr, resourceNode.GetTokens())
+	if !amt.IsPositive() || amt.GT(availableStake) {
+		return sdk.ZeroInt(), time.Time{}, types.ErrInsufficientStake
+	}
+	isFullUnbond := amt.Equal(availableStake)
+	isBonded := resourceNode.GetStatus() == sdk.Bonded
+
 	bondDenom := k.GetParams(ctx).BondDenom
 	coin := sdk.NewCoin(bondDenom, amt)
-	if resourceNode.GetStatus() == sdk.Bonded {
-		// transfer the node tokens to the not bonded pool
-		k.bondedToUnbonding(ctx, resourceNode, false, coin)
-		// adjust ozone limit
-		ozoneLimitChange = k.decreaseOzoneLimitBySubtractStake(ctx, amt)
+	if isBonded {
+		if isFullUnbond {
+			// unbond all the remaining stake, transfer the node tokens to the not bonded pool
+			k.bondedToUnbonding(ctx, resourceNode, false, coin)
+		} else {
+			// partial unbonding, the node stays bonded with the remaining stake
+			err = k.transferBondedTokenToNotBondedPool(ctx, false, coin)
+			if err != nil {
+				return sdk.ZeroInt(), time.Time{}, err
+			}
+		}
+	}
+	// adjust ozone limit
+	ozoneLimitChange = k.decreaseOzoneLimitBySubtractStake(ctx, amt)
+
+	// the owner is leaving, the delegations are unbonded along with the node
+	if isFullUnbond {
+		delegationOzoneLimitChange, err := k.unbondAllDelegations(ctx, networkAddr, isBonded, unbondingMatureTime)
+		if err != nil {
+			return sdk.ZeroInt(), time.Time{}, err
+		}
+		ozoneLimitChange = ozoneLimitChange.Add(delegationOzoneLimitChange)
 	}
 
 	// set the unbonding mature time and completion height appropriately
@@ -433,13 +546,38 @@ func (k Keeper) UnbondIndexingNode(
 
 	unbondingMatureTime = calcUnbondingMatureTime(ctx, indexingNode.Status, indexingNode.CreationTime, k.UnbondingThreasholdTime(ctx), k.UnbondingCompletionTime(ctx))
 
+	// the delegated tokens are unbonded by the delegators themselves
+	availableStake := k.GetOwnerAvailableStake(ctx, networkAddr, indexingNode.GetTokens())
+	if !amt.IsPositive() || amt.GT(availableStake) {
+		return sdk.ZeroInt(), time.Time{}, types.ErrInsufficientStake
+	}
+	isFullUnbond := amt.Equal(availableStake)
+	isBonded := indexingNode.GetStatus() == sdk.Bonded
+
 	bondDenom := k.GetParams(ctx).BondDenom
 	coin := sdk.NewCoin(bondDenom, amt)
-	if indexingNode.GetStatus() == sdk.Bonded {
-		// transfer the node tokens to the not bonded pool
-		k.bondedToUnbonding(ctx, indexingNode, true, coin)
-		// adjust ozone limit
-		ozoneLimitChange = k.decreaseOzoneLimitBySubtractStake(ctx, amt)
+	if isBonded {
+		if isFullUnbond {
+			// unbond all the remaining stake, transfer the node tokens to the not bonded pool
+			k.bondedToUnbonding(ctx, indexingNode, true, coin)
+		} else {
+			// partial unbonding, the node stays bonded with the remaining stake
+			err = k.transferBondedTokenToNotBondedPool(ctx, true, coin)
+			if err != nil {
+				return sdk.ZeroInt(), time.Time{}, err
+			}
+		}
+	}
+	// adjust ozone limit
+	ozoneLimitChange = k.decreaseOzoneLimitBySubtractStake(ctx, amt)
+
+	// the owner is leaving, the delegations are unbonded along with the node
+	if isFullUnbond {
+		delegationOzoneLimitChange, err := k.unbondAllDelegations(ctx, networkAddr, isBonded, unbondingMatureTime)
+		if err != nil {
+			return sdk.ZeroInt(), time.Time{}, err
+		}
+		ozoneLimitChange = ozoneLimitChange.Add(delegationOzoneLimitChange)
 	}
 
 	// Set the unbonding mature time and completion height appropriately
@@ -478,6 +616,15 @@ func (k Keeper) GetAllUnbondingNodesTotalBalance(ctx sdk.Context) sdk.Int {
 	return ubdTotal
 }
 
+// GetAvailableStake returns the part of the node tokens that is not scheduled to be returned by an unbonding entry
+func (k Keeper) GetAvailableStake(ctx sdk.Context, networkAddr sdk.AccAddress, tokens sdk.Int) sdk.Int {
+	available := tokens.Sub(k.GetUnbondingNodeBalance(ctx, networkAddr))
+	if available.IsNegative() {
+		return sdk.ZeroInt()
+	}
+	return available
+}
+
 // GetUnbondingNodeBalance returns an unbonding balance and an UnbondingNode
 func (k Keeper) GetUnbondingNodeBalance(ctx sdk.Context,
 	networkAddr sdk.AccAddress) sdk.Int {
@@ -0,0 +1,87 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stratosnet/stratos-chain/x/register/types"
+)
+
+// keys of the token pools counted in the store before the node stakes were held by module accounts
+var (
+	legacyResourceNodeNotBondedTokenKey = []byte{0x01}
+	legacyResourceNodeBondedTokenKey    = []byte{0x02}
+	legacyIndexingNodeNotBondedTokenKey = []byte{0x03}
+	legacyIndexingNodeBondedTokenKey    = []byte{0x04}
+)
+
+// MigrateTokenPools moves the token pools counted under the legacy keys into their module accounts.
+// The staked tokens were subtracted from the owner accounts without leaving the total supply,
+// so they are credited to the module accounts as they are.
+func (k Keeper) MigrateTokenPools(ctx sdk.Context) (migrated int) {
+	store := ctx.KVStore(k.storeKey)
+	legacyPools := []struct {
+		key      []byte
+		poolName string
+	}{
+		{legacyResourceNodeNotBondedTokenKey, types.ResourceNodeNotBondedPoolName},
+		{legacyResourceNodeBondedTokenKey, types.ResourceNodeBondedPoolName},
+		{legacyIndexingNodeNotBondedTokenKey, types.IndexingNodeNotBondedPoolName},
+		{legacyIndexingNodeBondedTokenKey, types.IndexingNodeBondedPoolName},
+	}
+
+	for _, legacyPool := range legacyPools {
+		bz := store.Get(legacyPool.key)
+		if bz == nil {
+			continue
+		}
+		var token sdk.Coin
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &token)
+		store.Delete(legacyPool.key)
+		migrated++
+
+		if !token.IsPositive() {
+			continue
+		}
+		pool := k.GetTokenPool(ctx, legacyPool.poolName)
+		if err := pool.SetCoins(pool.GetCoins().Add(token)); err != nil {
+			panic(err)
+		}
+		k.SetTokenPool(ctx, pool)
+	}
+
+	k.Logger(ctx).Info(fmt.Sprintf("moved %d token pools to their module accounts", migrated))
+	return migrated
+}
+
+// MigrateParams sets the register params added since the chain started to their default value
+func (k Keeper) MigrateParams(ctx sdk.Context) {
+	if !k.paramSpace.Has(ctx, types.KeySlashFractionDowntime) {
+		k.paramSpace.Set(ctx, types.KeySlashFractionDowntime, types.DefaultSlashFractionDowntime)
+	}
+	if !k.paramSpace.Has(ctx, types.KeySlashFractionMisbehavior) {
+		k.paramSpace.Set(ctx, types.KeySlashFractionMisbehavior, types.DefaultSlashFractionMisbehavior)
+	}
+	if !k.paramSpace.Has(ctx, types.KeySuspendDuration) {
+		k.paramSpace.Set(ctx, types.KeySuspendDuration, types.DefaultSuspendDuration)
+	}
+	if !k.paramSpace.Has(ctx, types.KeyMaxCapacity) {
+		k.paramSpace.Set(ctx, types.KeyMaxCapacity, types.DefaultMaxCapacity)
+	}
+}
+
+// MigrateResourceNodeCapacities sets the capacity of the resource nodes registered without one to the max capacity
+func (k Keeper) MigrateResourceNodeCapacities(ctx sdk.Context) (migrated int) {
+	maxCapacity := k.MaxCapacity(ctx)
+	for _, node := range k.GetAllResourceNodes(ctx) {
+		if !node.Capacity.IsNil() && node.Capacity.IsPositive() {
+			continue
+		}
+		node.Capacity = maxCapacity
+		k.SetResourceNode(ctx, node)
+		migrated++
+	}
+
+	k.Logger(ctx).Info(fmt.Sprintf("set the capacity of %d resource nodes to %s", migrated, maxCapacity))
+	return migrated
+}
@@ -0,0 +1,39 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stratosnet/stratos-chain/x/register/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateTokenPools(t *testing.T) {
+	ctx, _, _, k, _ := CreateTestInput(t, false)
+	store := ctx.KVStore(k.storeKey)
+
+	// token pools counted under the legacy keys
+	legacyPools := map[string]sdk.Coin{
+		string(legacyResourceNodeBondedTokenKey):    sdk.NewCoin(k.BondDenom(ctx), sdk.NewInt(1000)),
+		string(legacyResourceNodeNotBondedTokenKey): sdk.NewCoin(k.BondDenom(ctx), sdk.NewInt(200)),
+		string(legacyIndexingNodeBondedTokenKey):    sdk.NewCoin(k.BondDenom(ctx), sdk.NewInt(3000)),
+		string(legacyIndexingNodeNotBondedTokenKey): sdk.NewCoin(k.BondDenom(ctx), sdk.ZeroInt()),
+	}
+	for key, token := range legacyPools {
+		store.Set([]byte(key), k.cdc.MustMarshalBinaryLengthPrefixed(token))
+	}
+	// stake added to a pool once it is a module account is kept
+	setTokenPool(t, ctx, k, types.ResourceNodeBondedPoolName, sdk.NewInt(5))
+
+	require.Equal(t, len(legacyPools), k.MigrateTokenPools(ctx))
+	require.Equal(t, 0, k.MigrateTokenPools(ctx))
+
+	for key := range legacyPools {
+		require.False(t, store.Has([]byte(key)))
+	}
+	require.Equal(t, sdk.NewInt(1005), k.GetResourceNodeBondedToken(ctx).Amount)
+	require.Equal(t, sdk.NewInt(200), k.GetResourceNodeNotBondedToken(ctx).Amount)
+	require.Equal(t, sdk.NewInt(3000), k.GetIndexingNodeBondedToken(ctx).Amount)
+	require.True(t, k.GetIndexingNodeNotBondedToken(ctx).IsZero())
+	require.Equal(t, sdk.NewInt(3000), k.GetTokenPool(ctx, types.IndexingNodeBondedPoolName).GetCoins().AmountOf(k.BondDenom(ctx)))
+}
@@ -41,3 +41,27 @@ func (k Keeper) UnbondingCompletionTime(ctx sdk.Context) (res time.Duration) {
 	k.paramSpace.Get(ctx, types.KeyUnbondingCompletionTime, &res)
 	return
 }
+
+// SuspendDuration - minimum time a suspended node stays suspended
+func (k Keeper) SuspendDuration(ctx sdk.Context) (res time.Duration) {
+	k.paramSpace.Get(ctx, types.KeySuspendDuration, &res)
+	return
+}
+
+// SlashFractionDowntime - fraction of bonded stake slashed when a node is reported offline
+func (k Keeper) SlashFractionDowntime(ctx sdk.Context) (res sdk.Dec) {
+	k.paramSpace.Get(ctx, types.KeySlashFractionDowntime, &res)
+	return
+}
+
+// SlashFractionMisbehavior - fraction of bonded stake slashed when a node is reported misbehaving
+func (k Keeper) SlashFractionMisbehavior(ctx sdk.Context) (res sdk.Dec) {
+	k.paramSpace.Get(ctx, types.KeySlashFractionMisbehavior, &res)
+	return
+}
+
+// MaxCapacity - max volume a resource node can declare per epoch
+func (k Keeper) MaxCapacity(ctx sdk.Context) (res sdk.Int) {
+	k.paramSpace.Get(ctx, types.KeyMaxCapacity, &res)
+	return
+}
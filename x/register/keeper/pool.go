@@ -0,0 +1,98 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	supplyexported "github.com/cosmos/cosmos-sdk/x/supply/exported"
+	"github.com/stratosnet/stratos-chain/x/register/types"
+)
+
+// bondedPoolName returns the name of the module account holding the bonded stake of the given node type
+func bondedPoolName(isIndexingNode bool) string {
+	if isIndexingNode {
+		return types.IndexingNodeBondedPoolName
+	}
+	return types.ResourceNodeBondedPoolName
+}
+
+// notBondedPoolName returns the name of the module account holding the not bonded stake of the given node type
+func notBondedPoolName(isIndexingNode bool) string {
+	if isIndexingNode {
+		return types.IndexingNodeNotBondedPoolName
+	}
+	return types.ResourceNodeNotBondedPoolName
+}
+
+// GetTokenPool returns the module account of a token pool, creating it if it does not exist yet
+func (k Keeper) GetTokenPool(ctx sdk.Context, poolName string) supplyexported.ModuleAccountI {
+	return k.supplyKeeper.GetModuleAccount(ctx, poolName)
+}
+
+// SetTokenPool stores the module account of a token pool
+func (k Keeper) SetTokenPool(ctx sdk.Context, pool supplyexported.ModuleAccountI) {
+	k.supplyKeeper.SetModuleAccount(ctx, pool)
+}
+
+// getPoolToken returns the bond denom balance of a token pool
+func (k Keeper) getPoolToken(ctx sdk.Context, poolName string) sdk.Coin {
+	bondDenom := k.BondDenom(ctx)
+	pool := k.accountKeeper.GetAccount(ctx, k.supplyKeeper.GetModuleAddress(poolName))
+	if pool == nil {
+		return sdk.NewCoin(bondDenom, sdk.ZeroInt())
+	}
+	return sdk.NewCoin(bondDenom, pool.GetCoins().AmountOf(bondDenom))
+}
+
+func (k Keeper) GetResourceNodeBondedToken(ctx sdk.Context) (token sdk.Coin) {
+	return k.getPoolToken(ctx, types.ResourceNodeBondedPoolName)
+}
+
+func (k Keeper) GetResourceNodeNotBondedToken(ctx sdk.Context) (token sdk.Coin) {
+	return k.getPoolToken(ctx, types.ResourceNodeNotBondedPoolName)
+}
+
+func (k Keeper) GetIndexingNodeBondedToken(ctx sdk.Context) (token sdk.Coin) {
+	return k.getPoolToken(ctx, types.IndexingNodeBondedPoolName)
+}
+
+func (k Keeper) GetIndexingNodeNotBondedToken(ctx sdk.Context) (token sdk.Coin) {
+	return k.getPoolToken(ctx, types.IndexingNodeNotBondedPoolName)
+}
+
+// sendTokenToPool moves tokens from an account to the bonded or not bonded pool of the given node type
+func (k Keeper) sendTokenToPool(ctx sdk.Context, fromAddr sdk.AccAddress, isIndexingNode bool, isBonded bool,
+	token sdk.Coin) error {
+
+	poolName := notBondedPoolName(isIndexingNode)
+	if isBonded {
+		poolName = bondedPoolName(isIndexingNode)
+	}
+	return k.supplyKeeper.SendCoinsFromAccountToModule(ctx, fromAddr, poolName, sdk.NewCoins(token))
+}
+
+// transferNotBondedTokenToBondedPool moves tokens from the not bonded pool to the bonded pool of the given node type
+func (k Keeper) transferNotBondedTokenToBondedPool(ctx sdk.Context, isIndexingNode bool, token sdk.Coin) error {
+	if k.getPoolToken(ctx, notBondedPoolName(isIndexingNode)).IsLT(token) {
+		return types.ErrInsufficientBalanceOfNotBondedPool
+	}
+	return k.supplyKeeper.SendCoinsFromModuleToModule(ctx, notBondedPoolName(isIndexingNode), bondedPoolName(isIndexingNode),
+		sdk.NewCoins(token))
+}
+
+// transferBondedTokenToNotBondedPool moves tokens from the bonded pool to the not bonded pool of the given node type
+func (k Keeper) transferBondedTokenToNotBondedPool(ctx sdk.Context, isIndexingNode bool, token sdk.Coin) error {
+	if k.getPoolToken(ctx, bondedPoolName(isIndexingNode)).IsLT(token) {
+		return types.ErrInsufficientBalanceOfBondedPool
+	}
+	return k.supplyKeeper.SendCoinsFromModuleToModule(ctx, bondedPoolName(isIndexingNode), notBondedPoolName(isIndexingNode),
+		sdk.NewCoins(token))
+}
+
+// sendNotBondedTokenToAccount returns tokens from the not bonded pool of the given node type to an account
+func (k Keeper) sendNotBondedTokenToAccount(ctx sdk.Context, isIndexingNode bool, toAddr sdk.AccAddress, token sdk.Coin) error {
+	return k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, notBondedPoolName(isIndexingNode), toAddr, sdk.NewCoins(token))
+}
+
+// burnNotBondedToken burns tokens from the not bonded pool of the given node type, lowering the total supply
+func (k Keeper) burnNotBondedToken(ctx sdk.Context, isIndexingNode bool, token sdk.Coin) error {
+	return k.supplyKeeper.BurnCoins(ctx, notBondedPoolName(isIndexingNode), sdk.NewCoins(token))
+}
@@ -0,0 +1,30 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stratosnet/stratos-chain/x/register/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleUpdateParamsProposal(t *testing.T) {
+	ctx, _, _, k, _ := CreateTestInput(t, false)
+
+	params := k.GetParams(ctx)
+	params.MaxEntries = 32
+	params.UnbondingCompletionTime = params.UnbondingCompletionTime * 2
+	params.SlashFractionDowntime = sdk.NewDecWithPrec(2, 2)
+	require.NoError(t, HandleUpdateParamsProposal(ctx, k, types.NewUpdateParamsProposal("title", "description", params)))
+	require.Equal(t, params, k.GetParams(ctx))
+
+	invalid := params
+	invalid.MaxEntries = 0
+	require.Error(t, HandleUpdateParamsProposal(ctx, k, types.NewUpdateParamsProposal("title", "description", invalid)))
+
+	denomChange := params
+	denomChange.BondDenom = "stake"
+	require.Equal(t, types.ErrBondDenomChange,
+		HandleUpdateParamsProposal(ctx, k, types.NewUpdateParamsProposal("title", "description", denomChange)))
+	require.Equal(t, params, k.GetParams(ctx))
+}
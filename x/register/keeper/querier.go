@@ -25,6 +25,8 @@ const (
 	QueryNodeStakeByNodeAddr      = "node_stakes"
 	QueryNodeStakeByOwner         = "node_stakes_by_owner"
 	QueryRegisterParams           = "register_params"
+	QueryNodeDelegations          = "node_delegations"
+	QueryDelegatorDelegations     = "delegator_delegations"
 	QueryDefaultLimit             = 100
 	defaultDenom                  = "ustos"
 )
@@ -53,6 +55,10 @@ func NewQuerier(k Keeper) sdk.Querier {
 			return GetIndexingNodesByMoniker(ctx, req, k)
 		case QueryRegisterParams:
 			return GetRegisterParams(ctx, req, k)
+		case QueryNodeDelegations:
+			return GetNodeDelegations(ctx, req, k)
+		case QueryDelegatorDelegations:
+			return GetDelegatorDelegations(ctx, req, k)
 		default:
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown register query endpoint "+req.String()+string(req.Data))
 		}
@@ -64,6 +70,34 @@ func GetRegisterParams(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte
 	return types.ModuleCdc.MustMarshalJSON(params), nil
 }
 
+func GetNodeDelegations(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params QueryNodeStakingParams
+	err := k.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	delegations := k.GetNodeDelegations(ctx, params.AccAddr)
+	if delegations == nil {
+		delegations = types.Delegations{}
+	}
+	return codec.MarshalJSONIndent(k.cdc, delegations)
+}
+
+func GetDelegatorDelegations(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params QueryNodeStakingParams
+	err := k.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	delegations := k.GetDelegatorDelegations(ctx, params.AccAddr)
+	if delegations == nil {
+		delegations = types.Delegations{}
+	}
+	return codec.MarshalJSONIndent(k.cdc, delegations)
+}
+
 func GetResourceNodesByMoniker(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
 	nodeList, err := k.GetResourceNodeListByMoniker(ctx, string(req.Data))
 	if err != nil {
@@ -2,6 +2,7 @@ package keeper
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/stratosnet/stratos-chain/x/register/types"
 	"github.com/tendermint/tendermint/crypto"
 	"strings"
@@ -119,6 +120,12 @@ func (k Keeper) IterateLastResourceNodeStakes(ctx sdk.Context, handler func(node
 
 // AddResourceNodeStake Update the tokens of an existing resource node
 func (k Keeper) AddResourceNodeStake(ctx sdk.Context, resourceNode types.ResourceNode, tokenToAdd sdk.Coin,
+) (ozoneLimitChange sdk.Int, err error) {
+	return k.addResourceNodeStake(ctx, resourceNode, resourceNode.GetOwnerAddr(), tokenToAdd)
+}
+
+// addResourceNodeStake moves tokens from the given account into the stake of an existing resource node
+func (k Keeper) addResourceNodeStake(ctx sdk.Context, resourceNode types.ResourceNode, fromAddr sdk.AccAddress, tokenToAdd sdk.Coin,
 ) (ozoneLimitChange sdk.Int, err error) {
 
 	nodeAcc := k.accountKeeper.GetAccount(ctx, resourceNode.GetNetworkAddr())
@@ -128,29 +135,19 @@ func (k Keeper) AddResourceNodeStake(ctx sdk.Context, resourceNode types.Resourc
 	}
 
 	coins := sdk.NewCoins(tokenToAdd)
-	hasCoin := k.bankKeeper.HasCoins(ctx, resourceNode.GetOwnerAddr(), coins)
+	hasCoin := k.bankKeeper.HasCoins(ctx, fromAddr, coins)
 	if !hasCoin {
 		return sdk.ZeroInt(), types.ErrInsufficientBalance
 	}
 
-	_, err = k.bankKeeper.SubtractCoins(ctx, resourceNode.GetOwnerAddr(), coins)
+	if resourceNode.GetStatus() == sdk.Unbonding {
+		return sdk.ZeroInt(), types.ErrUnbondingNode
+	}
+	err = k.sendTokenToPool(ctx, fromAddr, false, resourceNode.GetStatus() == sdk.Bonded, tokenToAdd)
 	if err != nil {
 		return sdk.ZeroInt(), err
 	}
 
-	switch resourceNode.GetStatus() {
-	case sdk.Unbonded:
-		notBondedTokenInPool := k.GetResourceNodeNotBondedToken(ctx)
-		notBondedTokenInPool = notBondedTokenInPool.Add(tokenToAdd)
-		k.SetResourceNodeNotBondedToken(ctx, notBondedTokenInPool)
-	case sdk.Bonded:
-		bondedTokenInPool := k.GetResourceNodeBondedToken(ctx)
-		bondedTokenInPool = bondedTokenInPool.Add(tokenToAdd)
-		k.SetResourceNodeBondedToken(ctx, bondedTokenInPool)
-	case sdk.Unbonding:
-		return sdk.ZeroInt(), types.ErrUnbondingNode
-	}
-
 	resourceNode = resourceNode.AddToken(tokenToAdd.Amount)
 
 	// set status from unBonded to bonded & move stake from not bonded token pool to bonded token pool
@@ -158,17 +155,13 @@ func (k Keeper) AddResourceNodeStake(ctx sdk.Context, resourceNode types.Resourc
 	if resourceNode.Status.Equal(sdk.Unbonded) {
 		resourceNode.Status = sdk.Bonded
 
-		tokenToBond := sdk.NewCoin(k.BondDenom(ctx), resourceNode.GetTokens())
-		notBondedToken := k.GetResourceNodeNotBondedToken(ctx)
-		bondedToken := k.GetResourceNodeBondedToken(ctx)
-
-		if notBondedToken.IsLT(tokenToBond) {
-			return sdk.ZeroInt(), types.ErrInsufficientBalanceOfNotBondedPool
+		// the tokens of the unbonding entries stay in the not bonded pool
+		unbonding := k.GetUnbondingNodeBalance(ctx, resourceNode.GetNetworkAddr())
+		tokenToBond := sdk.NewCoin(k.BondDenom(ctx), resourceNode.GetTokens().Sub(unbonding))
+		err = k.transferNotBondedTokenToBondedPool(ctx, false, tokenToBond)
+		if err != nil {
+			return sdk.ZeroInt(), err
 		}
-		notBondedToken = notBondedToken.Sub(tokenToBond)
-		bondedToken = bondedToken.Add(tokenToBond)
-		k.SetResourceNodeNotBondedToken(ctx, notBondedToken)
-		k.SetResourceNodeBondedToken(ctx, bondedToken)
 	}
 
 	newStake := resourceNode.GetTokens()
@@ -184,19 +177,8 @@ func (k Keeper) RemoveTokenFromPoolWhileUnbondingResourceNode(ctx sdk.Context, r
 	// change node status to unbonding
 	resourceNode.Status = sdk.Unbonding
 	k.SetResourceNode(ctx, resourceNode)
-	// get pools
-	bondedTokenInPool := k.GetResourceNodeBondedToken(ctx)
-	notBondedTokenInPool := k.GetResourceNodeNotBondedToken(ctx)
-	if bondedTokenInPool.IsLT(tokenToSub) {
-		return types.ErrInsufficientBalanceOfBondedPool
-	}
-	// remove token from BondedPool
-	bondedTokenInPool = bondedTokenInPool.Sub(tokenToSub)
-	k.SetResourceNodeBondedToken(ctx, bondedTokenInPool)
-	// add token into NotBondedPool
-	notBondedTokenInPool = notBondedTokenInPool.Add(tokenToSub)
-	k.SetResourceNodeNotBondedToken(ctx, notBondedTokenInPool)
-	return nil
+	// remove token from BondedPool and add it into NotBondedPool
+	return k.transferBondedTokenToNotBondedPool(ctx, false, tokenToSub)
 }
 
 // SubtractResourceNodeStake Update the tokens of an existing resource node
@@ -206,25 +188,26 @@ func (k Keeper) SubtractResourceNodeStake(ctx sdk.Context, resourceNode types.Re
 		return types.ErrNoOwnerAccountFound
 	}
 
-	coins := sdk.NewCoins(tokenToSub)
-
-	if resourceNode.GetStatus() == sdk.Unbonded || resourceNode.GetStatus() == sdk.Unbonding {
-		notBondedTokenInPool := k.GetResourceNodeNotBondedToken(ctx)
-		if notBondedTokenInPool.IsLT(tokenToSub) {
-			return types.ErrInsufficientBalanceOfNotBondedPool
-		}
-		notBondedTokenInPool = notBondedTokenInPool.Sub(tokenToSub)
-		k.SetResourceNodeNotBondedToken(ctx, notBondedTokenInPool)
-	}
-	if resourceNode.GetStatus() == sdk.Bonded {
-		return types.ErrInvalidNodeStatBonded
+	// a bonded node can only get back the tokens of a partial unbonding, which are kept in the not bonded pool
+	if resourceNode.GetStatus() == sdk.Bonded && tokenToSub.Amount.GTE(resourceNode.GetTokens()) {
+		return types.ErrSubAllTokens
 	}
 
-	_, err := k.bankKeeper.AddCoins(ctx, resourceNode.OwnerAddress, coins)
+	err := k.subtractResourceNodeStake(ctx, resourceNode, tokenToSub)
 	if err != nil {
 		return err
 	}
 
+	return k.sendNotBondedTokenToAccount(ctx, false, resourceNode.OwnerAddress, tokenToSub)
+}
+
+// subtractResourceNodeStake removes tokens from the node itself, deleting the node once its tokens reach zero.
+// The tokens are left in the not bonded pool, the caller decides where they go.
+func (k Keeper) subtractResourceNodeStake(ctx sdk.Context, resourceNode types.ResourceNode, tokenToSub sdk.Coin) error {
+	if k.GetResourceNodeNotBondedToken(ctx).IsLT(tokenToSub) {
+		return types.ErrInsufficientBalanceOfNotBondedPool
+	}
+
 	resourceNode = resourceNode.SubToken(tokenToSub.Amount)
 	newStake := resourceNode.GetTokens()
 
@@ -288,14 +271,33 @@ func (k Keeper) GetResourceNodeListByMoniker(ctx sdk.Context, moniker string) (r
 }
 
 func (k Keeper) RegisterResourceNode(ctx sdk.Context, networkID string, pubKey crypto.PubKey, ownerAddr sdk.AccAddress,
-	description types.Description, nodeType string, stake sdk.Coin) (ozoneLimitChange sdk.Int, err error) {
+	description types.Description, nodeType string, capacity sdk.Int, stake sdk.Coin) (ozoneLimitChange sdk.Int, err error) {
 
 	resourceNode := types.NewResourceNode(networkID, pubKey, ownerAddr, description, nodeType, ctx.BlockHeader().Time)
+	// the capacity defaults to the max capacity
+	resourceNode.Capacity = k.MaxCapacity(ctx)
+	if !capacity.IsNil() && !capacity.IsZero() {
+		if err = k.checkCapacity(ctx, capacity); err != nil {
+			return sdk.ZeroInt(), err
+		}
+		resourceNode.Capacity = capacity
+	}
 	ozoneLimitChange, err = k.AddResourceNodeStake(ctx, resourceNode, stake)
 	return ozoneLimitChange, err
 }
 
-func (k Keeper) UpdateResourceNode(ctx sdk.Context, networkID string, description types.Description, nodeType string,
+// checkCapacity ensures the capacity declared by a resource node is positive and within the max capacity
+func (k Keeper) checkCapacity(ctx sdk.Context, capacity sdk.Int) error {
+	if !capacity.IsPositive() {
+		return types.ErrInvalidCapacity
+	}
+	if maxCapacity := k.MaxCapacity(ctx); capacity.GT(maxCapacity) {
+		return sdkerrors.Wrapf(types.ErrCapacityTooLarge, "%s > %s", capacity, maxCapacity)
+	}
+	return nil
+}
+
+func (k Keeper) UpdateResourceNode(ctx sdk.Context, networkID string, description types.Description, nodeType string, capacity sdk.Int,
 	networkAddr sdk.AccAddress, ownerAddr sdk.AccAddress) error {
 
 	node, found := k.GetResourceNode(ctx, networkAddr)
@@ -310,44 +312,19 @@ func (k Keeper) UpdateResourceNode(ctx sdk.Context, networkID string, descriptio
 	node.NetworkID = networkID
 	node.Description = description
 	node.NodeType = nodeType
+	// the capacity is kept when left at 0
+	if !capacity.IsNil() && !capacity.IsZero() {
+		if err := k.checkCapacity(ctx, capacity); err != nil {
+			return err
+		}
+		node.Capacity = capacity
+	}
 
 	k.SetResourceNode(ctx, node)
 
 	return nil
 }
 
-func (k Keeper) SetResourceNodeBondedToken(ctx sdk.Context, token sdk.Coin) {
-	store := ctx.KVStore(k.storeKey)
-	bz := k.cdc.MustMarshalBinaryLengthPrefixed(token)
-	store.Set(types.ResourceNodeBondedTokenKey, bz)
-}
-
-func (k Keeper) GetResourceNodeBondedToken(ctx sdk.Context) (token sdk.Coin) {
-	store := ctx.KVStore(k.storeKey)
-	bz := store.Get(types.ResourceNodeBondedTokenKey)
-	if bz == nil {
-		return sdk.NewCoin(k.BondDenom(ctx), sdk.ZeroInt())
-	}
-	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &token)
-	return token
-}
-
-func (k Keeper) SetResourceNodeNotBondedToken(ctx sdk.Context, token sdk.Coin) {
-	store := ctx.KVStore(k.storeKey)
-	bz := k.cdc.MustMarshalBinaryLengthPrefixed(token)
-	store.Set(types.ResourceNodeNotBondedTokenKey, bz)
-}
-
-func (k Keeper) GetResourceNodeNotBondedToken(ctx sdk.Context) (token sdk.Coin) {
-	store := ctx.KVStore(k.storeKey)
-	bz := store.Get(types.ResourceNodeNotBondedTokenKey)
-	if bz == nil {
-		return sdk.NewCoin(k.BondDenom(ctx), sdk.ZeroInt())
-	}
-	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &token)
-	return token
-}
-
 func (k Keeper) GetNodeOwnerMapFromResourceNodes(ctx sdk.Context, nodeOwnerMap map[string]sdk.AccAddress) map[string]sdk.AccAddress {
 	store := ctx.KVStore(k.storeKey)
 	iterator := sdk.KVStorePrefixIterator(store, types.ResourceNodeKey)
@@ -0,0 +1,120 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stratosnet/stratos-chain/x/register/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+var (
+	resNodeOwnerStake  = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	resNodePubKeyStake = ed25519.GenPrivKey().PubKey()
+	resNodeAddrStake   = sdk.AccAddress(resNodePubKeyStake.Address())
+	resNodeStake       = sdk.NewInt(100000000)
+)
+
+func TestAddAndUnbondResourceNodeStake(t *testing.T) {
+	ctx, _, bankKeeper, k, _ := CreateTestInput(t, false)
+
+	k.SetInitialGenesisStakeTotal(ctx, initialGenesisStake)
+	k.SetRemainingOzoneLimit(ctx, initialOzoneLimit)
+
+	createAccount(t, ctx, k, resNodeOwnerStake, sdk.NewCoins(sdk.NewCoin("ustos", resNodeStake.MulRaw(2))))
+	_, err := k.RegisterResourceNode(ctx, "sds://resourceNodeStake", resNodePubKeyStake, resNodeOwnerStake,
+		types.NewDescription("sds://resourceNodeStake", "", "", "", ""), "4", sdk.ZeroInt(), sdk.NewCoin("ustos", resNodeStake))
+	require.NoError(t, err)
+
+	// add stake to a bonded node
+	node, found := k.GetResourceNode(ctx, resNodeAddrStake)
+	require.True(t, found)
+	ozoneLimitBefore := k.GetRemainingOzoneLimit(ctx)
+	ozoneLimitChange, err := k.AddResourceNodeStake(ctx, node, sdk.NewCoin("ustos", resNodeStake))
+	require.NoError(t, err)
+	require.True(t, ozoneLimitChange.IsPositive())
+	require.Equal(t, ozoneLimitBefore.Add(ozoneLimitChange), k.GetRemainingOzoneLimit(ctx))
+
+	totalStake := resNodeStake.MulRaw(2)
+	node, _ = k.GetResourceNode(ctx, resNodeAddrStake)
+	require.Equal(t, totalStake, node.GetTokens())
+	require.Equal(t, totalStake, k.GetResourceNodeBondedToken(ctx).Amount)
+	require.True(t, bankKeeper.GetCoins(ctx, resNodeOwnerStake).IsZero())
+
+	// partially unbond, the node stays bonded
+	unbondAmt := resNodeStake.QuoRaw(2)
+	ozoneLimitBefore = k.GetRemainingOzoneLimit(ctx)
+	ozoneLimitChange, matureTime, err := k.UnbondResourceNode(ctx, node, unbondAmt)
+	require.NoError(t, err)
+	require.True(t, ozoneLimitChange.IsPositive())
+	require.Equal(t, ozoneLimitBefore.Sub(ozoneLimitChange), k.GetRemainingOzoneLimit(ctx))
+
+	node, _ = k.GetResourceNode(ctx, resNodeAddrStake)
+	require.Equal(t, sdk.Bonded, node.GetStatus())
+	require.Equal(t, totalStake.Sub(unbondAmt), k.GetResourceNodeBondedToken(ctx).Amount)
+	require.Equal(t, unbondAmt, k.GetResourceNodeNotBondedToken(ctx).Amount)
+	require.Equal(t, totalStake.Sub(unbondAmt), k.GetAvailableStake(ctx, resNodeAddrStake, node.GetTokens()))
+	requireInvariants(t, ctx, k)
+
+	// cannot unbond more than the stake left after pending unbonding entries
+	_, _, err = k.UnbondResourceNode(ctx, node, totalStake)
+	require.Equal(t, types.ErrInsufficientStake, err)
+
+	// complete the unbonding entry, the owner gets the tokens back
+	ctx = ctx.WithBlockTime(matureTime)
+	balances, isIndexingNode, err := k.CompleteUnbondingWithAmount(ctx, resNodeAddrStake)
+	require.NoError(t, err)
+	require.False(t, isIndexingNode)
+	require.Equal(t, unbondAmt, balances.AmountOf("ustos"))
+
+	node, found = k.GetResourceNode(ctx, resNodeAddrStake)
+	require.True(t, found)
+	require.Equal(t, sdk.Bonded, node.GetStatus())
+	require.Equal(t, totalStake.Sub(unbondAmt), node.GetTokens())
+	require.True(t, k.GetResourceNodeNotBondedToken(ctx).IsZero())
+	require.Equal(t, unbondAmt, bankKeeper.GetCoins(ctx, resNodeOwnerStake).AmountOf("ustos"))
+	requireInvariants(t, ctx, k)
+}
+
+func TestResourceNodeCapacity(t *testing.T) {
+	ctx, _, _, k, _ := CreateTestInput(t, false)
+
+	k.SetInitialGenesisStakeTotal(ctx, initialGenesisStake)
+	k.SetRemainingOzoneLimit(ctx, initialOzoneLimit)
+	maxCapacity := k.MaxCapacity(ctx)
+	description := types.NewDescription("sds://resourceNodeStake", "", "", "", "")
+
+	// a capacity above the max capacity is rejected
+	createAccount(t, ctx, k, resNodeOwnerStake, sdk.NewCoins(sdk.NewCoin("ustos", resNodeStake)))
+	_, err := k.RegisterResourceNode(ctx, "sds://resourceNodeStake", resNodePubKeyStake, resNodeOwnerStake,
+		description, "4", maxCapacity.AddRaw(1), sdk.NewCoin("ustos", resNodeStake))
+	require.True(t, types.ErrCapacityTooLarge.Is(err))
+
+	// the capacity defaults to the max capacity
+	_, err = k.RegisterResourceNode(ctx, "sds://resourceNodeStake", resNodePubKeyStake, resNodeOwnerStake,
+		description, "4", sdk.ZeroInt(), sdk.NewCoin("ustos", resNodeStake))
+	require.NoError(t, err)
+	node, _ := k.GetResourceNode(ctx, resNodeAddrStake)
+	require.Equal(t, maxCapacity, node.Capacity)
+	require.False(t, node.ExceedsCapacity(maxCapacity))
+	require.True(t, node.ExceedsCapacity(maxCapacity.AddRaw(1)))
+
+	// the owner can lower the capacity, but not raise it above the max capacity
+	err = k.UpdateResourceNode(ctx, node.NetworkID, description, node.NodeType, sdk.NewInt(100), resNodeAddrStake, resNodeOwnerStake)
+	require.NoError(t, err)
+	err = k.UpdateResourceNode(ctx, node.NetworkID, description, node.NodeType, maxCapacity.AddRaw(1), resNodeAddrStake, resNodeOwnerStake)
+	require.True(t, types.ErrCapacityTooLarge.Is(err))
+	err = k.UpdateResourceNode(ctx, node.NetworkID, description, node.NodeType, sdk.ZeroInt(), resNodeAddrStake, resNodeOwnerStake)
+	require.NoError(t, err)
+	node, _ = k.GetResourceNode(ctx, resNodeAddrStake)
+	require.Equal(t, sdk.NewInt(100), node.Capacity)
+
+	// the nodes registered without a capacity are capped by the migration
+	node.Capacity = sdk.ZeroInt()
+	k.SetResourceNode(ctx, node)
+	require.Equal(t, 1, k.MigrateResourceNodeCapacities(ctx))
+	node, _ = k.GetResourceNode(ctx, resNodeAddrStake)
+	require.Equal(t, maxCapacity, node.Capacity)
+	require.Equal(t, 0, k.MigrateResourceNodeCapacities(ctx))
+}
@@ -0,0 +1,230 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stratosnet/stratos-chain/x/register/types"
+)
+
+// SlashFraction returns the fraction of bonded stake to be slashed for the given slash type
+func (k Keeper) SlashFraction(ctx sdk.Context, slashType types.SlashType) (sdk.Dec, error) {
+	switch slashType {
+	case types.SlashTypeDowntime:
+		return k.SlashFractionDowntime(ctx), nil
+	case types.SlashTypeMisbehavior:
+		return k.SlashFractionMisbehavior(ctx), nil
+	default:
+		return sdk.ZeroDec(), types.ErrInvalidSlashType
+	}
+}
+
+// SlashResourceNode burns a fraction of the bonded stake of a resource node, suspends it
+// and decreases the ozone limit accordingly. Only bonded nodes can be slashed.
+func (k Keeper) SlashResourceNode(ctx sdk.Context, resourceNode types.ResourceNode, slashType types.SlashType,
+) (slashed sdk.Coin, ozoneLimitChange sdk.Int, err error) {
+
+	if !resourceNode.GetStatus().Equal(sdk.Bonded) {
+		return slashed, sdk.ZeroInt(), types.ErrNodeNotBonded
+	}
+	slashFraction, err := k.SlashFraction(ctx, slashType)
+	if err != nil {
+		return slashed, sdk.ZeroInt(), err
+	}
+
+	slashable := k.GetAvailableStake(ctx, resourceNode.GetNetworkAddr(), resourceNode.GetTokens())
+	slashed = sdk.NewCoin(k.BondDenom(ctx), slashable.ToDec().Mul(slashFraction).TruncateInt())
+	ozoneLimitChange = sdk.ZeroInt()
+
+	if slashed.IsPositive() {
+		// move the slashed tokens out of the bonded pool, then burn them from the not bonded pool
+		err = k.transferBondedTokenToNotBondedPool(ctx, false, slashed)
+		if err != nil {
+			return slashed, sdk.ZeroInt(), err
+		}
+		err = k.subtractResourceNodeStake(ctx, resourceNode, slashed)
+		if err != nil {
+			return slashed, sdk.ZeroInt(), err
+		}
+		err = k.burnNotBondedToken(ctx, false, slashed)
+		if err != nil {
+			return slashed, sdk.ZeroInt(), err
+		}
+		// the delegators bear their share of the slash
+		k.slashDelegations(ctx, resourceNode.GetNetworkAddr(), slashFraction)
+		ozoneLimitChange = k.decreaseOzoneLimitBySubtractStake(ctx, slashed.Amount)
+	}
+
+	// the node might have been removed if all of its tokens were slashed
+	if node, found := k.GetResourceNode(ctx, resourceNode.GetNetworkAddr()); found {
+		node.Suspend = true
+		node.SuspendedUntil = ctx.BlockTime().Add(k.SuspendDuration(ctx))
+		k.SetResourceNode(ctx, node)
+	}
+
+	ctx.Logger().Info(fmt.Sprintf("Slashed resource node %s: type[%s], amount[%s]",
+		resourceNode.GetNetworkAddr(), slashType, slashed))
+	return slashed, ozoneLimitChange, nil
+}
+
+// SlashIndexingNode burns a fraction of the bonded stake of an indexing node, suspends it
+// and decreases the ozone limit accordingly. Only bonded nodes can be slashed.
+func (k Keeper) SlashIndexingNode(ctx sdk.Context, indexingNode types.IndexingNode, slashType types.SlashType,
+) (slashed sdk.Coin, ozoneLimitChange sdk.Int, err error) {
+
+	if !indexingNode.GetStatus().Equal(sdk.Bonded) {
+		return slashed, sdk.ZeroInt(), types.ErrNodeNotBonded
+	}
+	slashFraction, err := k.SlashFraction(ctx, slashType)
+	if err != nil {
+		return slashed, sdk.ZeroInt(), err
+	}
+
+	slashable := k.GetAvailableStake(ctx, indexingNode.GetNetworkAddr(), indexingNode.GetTokens())
+	slashed = sdk.NewCoin(k.BondDenom(ctx), slashable.ToDec().Mul(slashFraction).TruncateInt())
+	ozoneLimitChange = sdk.ZeroInt()
+
+	if slashed.IsPositive() {
+		// move the slashed tokens out of the bonded pool, then burn them from the not bonded pool
+		err = k.transferBondedTokenToNotBondedPool(ctx, true, slashed)
+		if err != nil {
+			return slashed, sdk.ZeroInt(), err
+		}
+		err = k.subtractIndexingNodeStake(ctx, indexingNode, slashed)
+		if err != nil {
+			return slashed, sdk.ZeroInt(), err
+		}
+		err = k.burnNotBondedToken(ctx, true, slashed)
+		if err != nil {
+			return slashed, sdk.ZeroInt(), err
+		}
+		// the delegators bear their share of the slash
+		k.slashDelegations(ctx, indexingNode.GetNetworkAddr(), slashFraction)
+		ozoneLimitChange = k.decreaseOzoneLimitBySubtractStake(ctx, slashed.Amount)
+	}
+
+	// the node might have been removed if all of its tokens were slashed
+	if node, found := k.GetIndexingNode(ctx, indexingNode.GetNetworkAddr()); found {
+		node.Suspend = true
+		node.SuspendedUntil = ctx.BlockTime().Add(k.SuspendDuration(ctx))
+		k.SetIndexingNode(ctx, node)
+	}
+
+	ctx.Logger().Info(fmt.Sprintf("Slashed indexing node %s: type[%s], amount[%s]",
+		indexingNode.GetNetworkAddr(), slashType, slashed))
+	return slashed, ozoneLimitChange, nil
+}
+
+// SuspendResourceNode suspends a resource node without slashing its stake, e.g. when it fails a storage challenge
+func (k Keeper) SuspendResourceNode(ctx sdk.Context, networkAddr sdk.AccAddress) error {
+	node, found := k.GetResourceNode(ctx, networkAddr)
+	if !found {
+		return types.ErrNoResourceNodeFound
+	}
+
+	node.Suspend = true
+	node.SuspendedUntil = ctx.BlockTime().Add(k.SuspendDuration(ctx))
+	k.SetResourceNode(ctx, node)
+	return nil
+}
+
+// UnsuspendResourceNode lifts the suspension of a resource node
+func (k Keeper) UnsuspendResourceNode(ctx sdk.Context, networkAddr sdk.AccAddress, ownerAddr sdk.AccAddress) error {
+	node, found := k.GetResourceNode(ctx, networkAddr)
+	if !found {
+		return types.ErrNoResourceNodeFound
+	}
+	if !node.OwnerAddress.Equals(ownerAddr) {
+		return types.ErrInvalidOwnerAddr
+	}
+	if !node.IsSuspended() {
+		return types.ErrNodeNotSuspended
+	}
+	if ctx.BlockTime().Before(node.SuspendedUntil) {
+		return types.ErrSuspensionNotOver
+	}
+
+	node.Suspend = false
+	k.SetResourceNode(ctx, node)
+	return nil
+}
+
+// UnsuspendIndexingNode lifts the suspension of an indexing node
+func (k Keeper) UnsuspendIndexingNode(ctx sdk.Context, networkAddr sdk.AccAddress, ownerAddr sdk.AccAddress) error {
+	node, found := k.GetIndexingNode(ctx, networkAddr)
+	if !found {
+		return types.ErrNoIndexingNodeFound
+	}
+	if !node.OwnerAddress.Equals(ownerAddr) {
+		return types.ErrInvalidOwnerAddr
+	}
+	if !node.IsSuspended() {
+		return types.ErrNodeNotSuspended
+	}
+	if ctx.BlockTime().Before(node.SuspendedUntil) {
+		return types.ErrSuspensionNotOver
+	}
+
+	node.Suspend = false
+	k.SetIndexingNode(ctx, node)
+	return nil
+}
+
+// GetSlashReport returns the slash report of an evidence reference against a node
+func (k Keeper) GetSlashReport(ctx sdk.Context, nodeAddr sdk.AccAddress, reference string) (report types.SlashReport, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	bz := store.Get(types.GetSlashReportKey(nodeAddr, reference))
+	if bz == nil {
+		return report, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &report)
+	return report, true
+}
+
+// SetSlashReport stores the slash report under its reported node and evidence reference
+func (k Keeper) SetSlashReport(ctx sdk.Context, report types.SlashReport) {
+	store := ctx.KVStore(k.storeKey)
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(report)
+	store.Set(types.GetSlashReportKey(report.NodeAddress, report.Evidence.Reference), bz)
+}
+
+// IterateSlashReports iterates through the slash reports, pending or executed
+func (k Keeper) IterateSlashReports(ctx sdk.Context, handler func(report types.SlashReport) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, types.SlashReportKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var report types.SlashReport
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &report)
+		if handler(report) {
+			break
+		}
+	}
+}
+
+// ReportSlashEvidence adds the report of an indexing node to the evidence against a node. The reports of a node are
+// collected by evidence reference, and only count when they carry the same evidence as the first one. It returns true
+// once the reports reach the quorum of the valid indexing nodes, the node must then be slashed and the evidence can't be reused.
+func (k Keeper) ReportSlashEvidence(ctx sdk.Context, nodeAddr sdk.AccAddress, isIndexingNode bool,
+	evidence types.SlashEvidence, reporterAddr sdk.AccAddress) (report types.SlashReport, quorumReached bool, err error) {
+
+	report, found := k.GetSlashReport(ctx, nodeAddr, evidence.Reference)
+	if !found {
+		report = types.NewSlashReport(nodeAddr, isIndexingNode, evidence)
+	}
+	if report.Executed {
+		return report, false, types.ErrDuplicateSlashEvidence
+	}
+	if !report.Matches(nodeAddr, isIndexingNode, evidence) {
+		return report, false, types.ErrSlashEvidenceMismatch
+	}
+	if report.HasReported(reporterAddr) {
+		return report, false, types.ErrDuplicateSlashReport
+	}
+
+	report.Reporters = append(report.Reporters, reporterAddr)
+	quorumReached = len(report.Reporters) >= k.GetVoteCountRequiredToPass(ctx)
+	report.Executed = quorumReached
+	k.SetSlashReport(ctx, report)
+	return report, quorumReached, nil
+}
@@ -0,0 +1,144 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stratosnet/stratos-chain/x/register/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+var (
+	resNodeOwnerSlash   = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	resNodePubKeySlash  = ed25519.GenPrivKey().PubKey()
+	resNodeAddrSlash    = sdk.AccAddress(resNodePubKeySlash.Address())
+	resNodeStakeSlash   = sdk.NewInt(100000000)
+	initialOzoneLimit   = sdk.NewInt(1000000000)
+	initialGenesisStake = sdk.NewInt(1000000000)
+)
+
+func TestSlashResourceNode(t *testing.T) {
+	ctx, _, bankKeeper, k, _ := CreateTestInput(t, false)
+
+	k.SetInitialGenesisStakeTotal(ctx, initialGenesisStake)
+	k.SetRemainingOzoneLimit(ctx, initialOzoneLimit)
+
+	createAccount(t, ctx, k, resNodeOwnerSlash, sdk.NewCoins(sdk.NewCoin("ustos", resNodeStakeSlash)))
+	_, err := k.RegisterResourceNode(ctx, "sds://resourceNodeSlash", resNodePubKeySlash, resNodeOwnerSlash,
+		types.NewDescription("sds://resourceNodeSlash", "", "", "", ""), "4", sdk.ZeroInt(), sdk.NewCoin("ustos", resNodeStakeSlash))
+	require.NoError(t, err)
+
+	node, found := k.GetResourceNode(ctx, resNodeAddrSlash)
+	require.True(t, found)
+	require.Equal(t, sdk.Bonded, node.GetStatus())
+	ozoneLimitBefore := k.GetRemainingOzoneLimit(ctx)
+
+	slashed, ozoneLimitChange, err := k.SlashResourceNode(ctx, node, types.SlashTypeMisbehavior)
+	require.NoError(t, err)
+
+	expectedSlashed := resNodeStakeSlash.ToDec().Mul(types.DefaultSlashFractionMisbehavior).TruncateInt()
+	require.Equal(t, expectedSlashed, slashed.Amount)
+	require.True(t, ozoneLimitChange.IsPositive())
+	require.Equal(t, ozoneLimitBefore.Sub(ozoneLimitChange), k.GetRemainingOzoneLimit(ctx))
+
+	node, found = k.GetResourceNode(ctx, resNodeAddrSlash)
+	require.True(t, found)
+	require.True(t, node.IsSuspended())
+	require.Equal(t, resNodeStakeSlash.Sub(expectedSlashed), node.GetTokens())
+	require.Equal(t, resNodeStakeSlash.Sub(expectedSlashed), k.GetResourceNodeBondedToken(ctx).Amount)
+	require.True(t, k.GetResourceNodeNotBondedToken(ctx).IsZero())
+	require.Equal(t, resNodeStakeSlash.Sub(expectedSlashed), k.GetLastResourceNodeStake(ctx, resNodeAddrSlash))
+	requireInvariants(t, ctx, k)
+
+	// slashed coins are burnt, not returned to the owner
+	require.True(t, bankKeeper.GetCoins(ctx, resNodeOwnerSlash).IsZero())
+	require.Equal(t, resNodeStakeSlash.Sub(expectedSlashed), k.supplyKeeper.GetSupply(ctx).GetTotal().AmountOf(k.BondDenom(ctx)))
+
+	// the owner can lift the suspension once the suspend duration is over
+	require.Error(t, k.UnsuspendResourceNode(ctx, resNodeAddrSlash, spNodeOwner1))
+	require.Equal(t, types.ErrSuspensionNotOver, k.UnsuspendResourceNode(ctx, resNodeAddrSlash, resNodeOwnerSlash))
+	ctx = ctx.WithBlockTime(ctx.BlockTime().Add(k.SuspendDuration(ctx)))
+	require.NoError(t, k.UnsuspendResourceNode(ctx, resNodeAddrSlash, resNodeOwnerSlash))
+	node, _ = k.GetResourceNode(ctx, resNodeAddrSlash)
+	require.False(t, node.IsSuspended())
+	require.Equal(t, types.ErrNodeNotSuspended, k.UnsuspendResourceNode(ctx, resNodeAddrSlash, resNodeOwnerSlash))
+}
+
+func TestSlashIndexingNodeNotBonded(t *testing.T) {
+	ctx, _, _, k, _ := CreateTestInput(t, false)
+	creationTime, _ := time.Parse(time.RubyDate, "Fri Sep 24 10:37:13 -0400 2021")
+
+	node := types.NewIndexingNode("sds://indexingNode1", spNodePubKey1, spNodeOwner1, types.NewDescription("sds://indexingNode1", "", "", "", ""), creationTime)
+	node.Tokens = initialStake1
+	k.SetIndexingNode(ctx, node)
+	setTokenPool(t, ctx, k, types.IndexingNodeNotBondedPoolName, initialStake1)
+
+	_, _, err := k.SlashIndexingNode(ctx, node, types.SlashTypeDowntime)
+	require.Equal(t, types.ErrNodeNotBonded, err)
+
+	node, found := k.GetIndexingNode(ctx, spNodeAddr1)
+	require.True(t, found)
+	require.False(t, node.IsSuspended())
+	require.Equal(t, initialStake1, node.GetTokens())
+}
+
+func TestReportSlashEvidence(t *testing.T) {
+	ctx, _, _, k, _ := CreateTestInput(t, false)
+	creationTime, _ := time.Parse(time.RubyDate, "Fri Sep 24 10:37:13 -0400 2021")
+
+	reporters := []sdk.AccAddress{spNodeAddr1, spNodeAddr2, spNodeAddr3}
+	for i, pubKey := range []crypto.PubKey{spNodePubKey1, spNodePubKey2, spNodePubKey3} {
+		node := types.NewIndexingNode("sds://indexingNode", pubKey, spNodeOwner1, types.NewDescription("sds://indexingNode", "", "", "", ""), creationTime)
+		node.Status = sdk.Bonded
+		k.SetIndexingNode(ctx, node)
+		require.Equal(t, reporters[i], node.GetNetworkAddr())
+	}
+	// 2/3+1 of the 3 valid indexing nodes
+	require.Equal(t, 3, k.GetVoteCountRequiredToPass(ctx))
+
+	evidence := types.NewSlashEvidence(types.SlashTypeDowntime, 1, "task-1")
+	for i, reporter := range reporters {
+		report, quorumReached, err := k.ReportSlashEvidence(ctx, resNodeAddrSlash, false, evidence, reporter)
+		require.NoError(t, err)
+		require.Len(t, report.Reporters, i+1)
+		require.Equal(t, i == len(reporters)-1, quorumReached)
+
+		// a reporter can't report the same evidence twice
+		if !quorumReached {
+			_, _, err = k.ReportSlashEvidence(ctx, resNodeAddrSlash, false, evidence, reporter)
+			require.Equal(t, types.ErrDuplicateSlashReport, err)
+		}
+	}
+
+	// the evidence can't be replayed once the node has been slashed
+	_, _, err := k.ReportSlashEvidence(ctx, resNodeAddrSlash, false, evidence, spNodeAddr1)
+	require.Equal(t, types.ErrDuplicateSlashEvidence, err)
+
+	// the reports of a reference only count when they carry the same evidence against the node
+	taskEvidence := types.NewSlashEvidence(types.SlashTypeMisbehavior, 1, "task-2")
+	_, _, err = k.ReportSlashEvidence(ctx, resNodeAddrSlash, false, taskEvidence, spNodeAddr1)
+	require.NoError(t, err)
+	_, _, err = k.ReportSlashEvidence(ctx, resNodeAddrSlash, false, types.NewSlashEvidence(types.SlashTypeMisbehavior, 2, "task-2"), spNodeAddr2)
+	require.Equal(t, types.ErrSlashEvidenceMismatch, err)
+	_, _, err = k.ReportSlashEvidence(ctx, resNodeAddrSlash, false, types.NewSlashEvidence(types.SlashTypeDowntime, 1, "task-2"), spNodeAddr2)
+	require.Equal(t, types.ErrSlashEvidenceMismatch, err)
+	_, _, err = k.ReportSlashEvidence(ctx, resNodeAddrSlash, true, taskEvidence, spNodeAddr2)
+	require.Equal(t, types.ErrSlashEvidenceMismatch, err)
+
+	// the reference of a report against a node doesn't block the reports against another node
+	report, _, err := k.ReportSlashEvidence(ctx, spNodeAddr3, true, taskEvidence, spNodeAddr2)
+	require.NoError(t, err)
+	require.Equal(t, []sdk.AccAddress{spNodeAddr2}, report.Reporters)
+	report, found := k.GetSlashReport(ctx, resNodeAddrSlash, "task-2")
+	require.True(t, found)
+	require.Equal(t, []sdk.AccAddress{spNodeAddr1}, report.Reporters)
+
+	report, found = k.GetSlashReport(ctx, resNodeAddrSlash, "task-1")
+	require.True(t, found)
+	require.True(t, report.Executed)
+	_, found = k.GetSlashReport(ctx, spNodeAddr3, "task-1")
+	require.False(t, found)
+}
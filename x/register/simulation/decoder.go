@@ -0,0 +1,62 @@
+package simulation
+
+import (
+	"bytes"
+	"fmt"
+
+	tmkv "github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stratosnet/stratos-chain/x/register/types"
+)
+
+// DecodeStore unmarshals the KVPair's Value to the corresponding register type
+func DecodeStore(cdc *codec.Codec, kvA, kvB tmkv.Pair) string {
+	switch {
+	case bytes.Equal(kvA.Key[:1], types.UpperBoundOfTotalOzoneKey),
+		bytes.Equal(kvA.Key[:1], types.TotalOzoneSupplyKey),
+		bytes.Equal(kvA.Key[:1], types.LastResourceNodeStakeKey),
+		bytes.Equal(kvA.Key[:1], types.LastIndexingNodeStakeKey),
+		bytes.Equal(kvA.Key[:1], types.InitialGenesisStakeTotalKey):
+		var valueA, valueB sdk.Int
+		cdc.MustUnmarshalBinaryLengthPrefixed(kvA.Value, &valueA)
+		cdc.MustUnmarshalBinaryLengthPrefixed(kvB.Value, &valueB)
+		return fmt.Sprintf("%v\n%v", valueA, valueB)
+
+	case bytes.Equal(kvA.Key[:1], types.ResourceNodeKey):
+		nodeA := types.MustUnmarshalResourceNode(cdc, kvA.Value)
+		nodeB := types.MustUnmarshalResourceNode(cdc, kvB.Value)
+		return fmt.Sprintf("%v\n%v", nodeA, nodeB)
+
+	case bytes.Equal(kvA.Key[:1], types.IndexingNodeKey):
+		nodeA := types.MustUnmarshalIndexingNode(cdc, kvA.Value)
+		nodeB := types.MustUnmarshalIndexingNode(cdc, kvB.Value)
+		return fmt.Sprintf("%v\n%v", nodeA, nodeB)
+
+	case bytes.Equal(kvA.Key[:1], types.IndexingNodeRegistrationVotesKey):
+		var votePoolA, votePoolB types.IndexingNodeRegistrationVotePool
+		cdc.MustUnmarshalBinaryLengthPrefixed(kvA.Value, &votePoolA)
+		cdc.MustUnmarshalBinaryLengthPrefixed(kvB.Value, &votePoolB)
+		return fmt.Sprintf("%v\n%v", votePoolA, votePoolB)
+
+	case bytes.Equal(kvA.Key[:1], types.DelegationKey):
+		delegationA := types.MustUnmarshalDelegation(cdc, kvA.Value)
+		delegationB := types.MustUnmarshalDelegation(cdc, kvB.Value)
+		return fmt.Sprintf("%v\n%v", delegationA, delegationB)
+
+	case bytes.Equal(kvA.Key[:1], types.UBDNodeKey):
+		ubdA := types.MustUnmarshalUnbondingNode(cdc, kvA.Value)
+		ubdB := types.MustUnmarshalUnbondingNode(cdc, kvB.Value)
+		return fmt.Sprintf("%v\n%v", ubdA, ubdB)
+
+	case bytes.Equal(kvA.Key[:1], types.UBDNodeQueueKey):
+		var addrsA, addrsB []sdk.AccAddress
+		cdc.MustUnmarshalBinaryLengthPrefixed(kvA.Value, &addrsA)
+		cdc.MustUnmarshalBinaryLengthPrefixed(kvB.Value, &addrsB)
+		return fmt.Sprintf("%v\n%v", addrsA, addrsB)
+
+	default:
+		panic(fmt.Sprintf("invalid register key prefix %X", kvA.Key[:1]))
+	}
+}
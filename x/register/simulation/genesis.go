@@ -0,0 +1,178 @@
+package simulation
+
+// DONTCOVER
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+	"github.com/stratosnet/stratos-chain/x/register/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+// Simulation parameter constants
+const (
+	UnbondingThreasholdTime  = "unbonding_threashold_time"
+	UnbondingCompletionTime  = "unbonding_completion_time"
+	MaxEntries               = "max_entries"
+	SlashFractionDowntime    = "slash_fraction_downtime"
+	SlashFractionMisbehavior = "slash_fraction_misbehavior"
+	SuspendDuration          = "suspend_duration"
+	MaxCapacity              = "max_capacity"
+)
+
+// GenUnbondingThreasholdTime randomized UnbondingThreasholdTime
+func GenUnbondingThreasholdTime(r *rand.Rand) time.Duration {
+	return time.Duration(simulation.RandIntBetween(r, 60, 60*60*24*3)) * time.Second
+}
+
+// GenUnbondingCompletionTime randomized UnbondingCompletionTime
+func GenUnbondingCompletionTime(r *rand.Rand) time.Duration {
+	return time.Duration(simulation.RandIntBetween(r, 60, 60*60*24)) * time.Second
+}
+
+// GenMaxEntries randomized MaxEntries
+func GenMaxEntries(r *rand.Rand) uint16 {
+	return uint16(r.Intn(16) + 1)
+}
+
+// GenSlashFraction randomized SlashFractionDowntime and SlashFractionMisbehavior
+func GenSlashFraction(r *rand.Rand) sdk.Dec {
+	return sdk.NewDecWithPrec(int64(r.Intn(10)+1), 2)
+}
+
+// GenSuspendDuration randomized SuspendDuration
+func GenSuspendDuration(r *rand.Rand) time.Duration {
+	return time.Duration(simulation.RandIntBetween(r, 60, 60*60)) * time.Second
+}
+
+// GenMaxCapacity randomized MaxCapacity
+func GenMaxCapacity(r *rand.Rand) sdk.Int {
+	return sdk.NewInt(int64(simulation.RandIntBetween(r, 1e6, 1e7)))
+}
+
+// RandomNodePubKey returns the public key of a new node, derived from the simulation randomness
+func RandomNodePubKey(r *rand.Rand) crypto.PubKey {
+	return secp256k1.GenPrivKeySecp256k1([]byte(simulation.RandStringOfLength(r, 32))).PubKey()
+}
+
+// RandomNodeType returns a random combination of the resource node types, formatted the way the CLI does
+func RandomNodeType(r *rand.Rand) string {
+	nodeType := types.NodeType(r.Intn(7) + 1)
+	return fmt.Sprintf("%d: %s", nodeType, nodeType.Type())
+}
+
+// RandomCapacity returns a random resource node capacity in uoz up to the max capacity, left at 0 half of the time
+func RandomCapacity(r *rand.Rand, maxCapacity sdk.Int) sdk.Int {
+	if r.Intn(2) == 0 {
+		return sdk.ZeroInt()
+	}
+	return sdk.MinInt(sdk.NewInt(int64(simulation.RandIntBetween(r, 1e5, 1e6))), maxCapacity)
+}
+
+// RandomizedGenState generates a random GenesisState for register
+func RandomizedGenState(simState *module.SimulationState) {
+	var threasholdTime time.Duration
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, UnbondingThreasholdTime, &threasholdTime, simState.Rand,
+		func(r *rand.Rand) { threasholdTime = GenUnbondingThreasholdTime(r) },
+	)
+
+	var completionTime time.Duration
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, UnbondingCompletionTime, &completionTime, simState.Rand,
+		func(r *rand.Rand) { completionTime = GenUnbondingCompletionTime(r) },
+	)
+
+	var maxEntries uint16
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, MaxEntries, &maxEntries, simState.Rand,
+		func(r *rand.Rand) { maxEntries = GenMaxEntries(r) },
+	)
+
+	var slashFractionDowntime sdk.Dec
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, SlashFractionDowntime, &slashFractionDowntime, simState.Rand,
+		func(r *rand.Rand) { slashFractionDowntime = GenSlashFraction(r) },
+	)
+
+	var slashFractionMisbehavior sdk.Dec
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, SlashFractionMisbehavior, &slashFractionMisbehavior, simState.Rand,
+		func(r *rand.Rand) { slashFractionMisbehavior = GenSlashFraction(r) },
+	)
+
+	var suspendDuration time.Duration
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, SuspendDuration, &suspendDuration, simState.Rand,
+		func(r *rand.Rand) { suspendDuration = GenSuspendDuration(r) },
+	)
+
+	var maxCapacity sdk.Int
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, MaxCapacity, &maxCapacity, simState.Rand,
+		func(r *rand.Rand) { maxCapacity = GenMaxCapacity(r) },
+	)
+
+	// the staking module of the simulation bonds the same denomination
+	params := types.NewParams(sdk.DefaultBondDenom, threasholdTime, completionTime, maxEntries,
+		slashFractionDowntime, slashFractionMisbehavior, suspendDuration, maxCapacity)
+
+	// a few bonded accounts own the genesis indexing nodes, so that volume reports can reach the quorum,
+	// the other accounts may own a genesis resource node
+	numIndexingNodes := int64(simulation.RandIntBetween(simState.Rand, 3, 8))
+	if numIndexingNodes > simState.NumBonded {
+		numIndexingNodes = simState.NumBonded
+	}
+	var (
+		indexingNodes          types.IndexingNodes
+		lastIndexingNodeStakes []types.LastIndexingNodeStake
+		resourceNodes          types.ResourceNodes
+		lastResourceNodeStakes []types.LastResourceNodeStake
+	)
+	stake := sdk.NewInt(simState.InitialStake)
+	for i, acc := range simState.Accounts {
+		pubKey := RandomNodePubKey(simState.Rand)
+		networkAddr := sdk.AccAddress(pubKey.Address())
+		description := types.NewDescription(simulation.RandStringOfLength(simState.Rand, 10), "", "", "", "")
+
+		if int64(i) < numIndexingNodes {
+			node := types.NewIndexingNode(fmt.Sprintf("sds://%s", networkAddr), pubKey, acc.Address, description, simState.GenTimestamp)
+			node.Status = sdk.Bonded
+			node.Tokens = stake
+			indexingNodes = append(indexingNodes, node)
+			lastIndexingNodeStakes = append(lastIndexingNodeStakes, types.LastIndexingNodeStake{Address: networkAddr, Stake: stake})
+			continue
+		}
+		if simState.Rand.Intn(2) == 0 {
+			node := types.NewResourceNode(fmt.Sprintf("sds://%s", networkAddr), pubKey, acc.Address, description,
+				RandomNodeType(simState.Rand), simState.GenTimestamp)
+			node.Capacity = RandomCapacity(simState.Rand, maxCapacity)
+			node.Status = sdk.Bonded
+			node.Tokens = stake
+			resourceNodes = append(resourceNodes, node)
+			lastResourceNodeStakes = append(lastResourceNodeStakes, types.LastResourceNodeStake{Address: networkAddr, Stake: stake})
+		}
+	}
+
+	registerGenesis := types.NewGenesisState(params, lastResourceNodeStakes, resourceNodes, lastIndexingNodeStakes, indexingNodes)
+
+	// the node stakes are credited to the token pools at genesis, they are part of the total supply
+	if bz, ok := simState.GenState[supply.ModuleName]; ok {
+		var supplyGenesis supply.GenesisState
+		simState.Cdc.MustUnmarshalJSON(bz, &supplyGenesis)
+		nodeStakes := stake.MulRaw(int64(len(indexingNodes) + len(resourceNodes)))
+		supplyGenesis.Supply = supplyGenesis.Supply.Add(sdk.NewCoin(params.BondDenom, nodeStakes))
+		simState.GenState[supply.ModuleName] = simState.Cdc.MustMarshalJSON(supplyGenesis)
+	}
+
+	fmt.Printf("Selected randomly generated register parameters:\n%s\n", codec.MustMarshalJSONIndent(simState.Cdc, registerGenesis.Params))
+	simState.GenState[types.ModuleName] = simState.Cdc.MustMarshalJSON(registerGenesis)
+}
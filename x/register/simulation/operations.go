@@ -0,0 +1,312 @@
+package simulation
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/simapp/helpers"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+	"github.com/stratosnet/stratos-chain/x/register/keeper"
+	"github.com/stratosnet/stratos-chain/x/register/types"
+)
+
+// Simulation operation weights constants
+const (
+	OpWeightMsgCreateResourceNode           = "op_weight_msg_create_resource_node"
+	OpWeightMsgCreateIndexingNode           = "op_weight_msg_create_indexing_node"
+	OpWeightMsgIndexingNodeRegistrationVote = "op_weight_msg_indexing_node_registration_vote"
+	OpWeightMsgRemoveResourceNode           = "op_weight_msg_remove_resource_node"
+	OpWeightMsgRemoveIndexingNode           = "op_weight_msg_remove_indexing_node"
+)
+
+// Default simulation operation weights
+const (
+	DefaultWeightMsgCreateResourceNode           = 40
+	DefaultWeightMsgCreateIndexingNode           = 20
+	DefaultWeightMsgIndexingNodeRegistrationVote = 60
+	DefaultWeightMsgRemoveResourceNode           = 10
+	DefaultWeightMsgRemoveIndexingNode           = 5
+)
+
+// WeightedOperations returns all the operations from the module with their respective weights
+func WeightedOperations(
+	appParams simulation.AppParams, cdc *codec.Codec, ak types.AccountKeeper, k keeper.Keeper,
+) simulation.WeightedOperations {
+
+	var (
+		weightMsgCreateResourceNode           int
+		weightMsgCreateIndexingNode           int
+		weightMsgIndexingNodeRegistrationVote int
+		weightMsgRemoveResourceNode           int
+		weightMsgRemoveIndexingNode           int
+	)
+
+	appParams.GetOrGenerate(cdc, OpWeightMsgCreateResourceNode, &weightMsgCreateResourceNode, nil,
+		func(_ *rand.Rand) {
+			weightMsgCreateResourceNode = DefaultWeightMsgCreateResourceNode
+		},
+	)
+
+	appParams.GetOrGenerate(cdc, OpWeightMsgCreateIndexingNode, &weightMsgCreateIndexingNode, nil,
+		func(_ *rand.Rand) {
+			weightMsgCreateIndexingNode = DefaultWeightMsgCreateIndexingNode
+		},
+	)
+
+	appParams.GetOrGenerate(cdc, OpWeightMsgIndexingNodeRegistrationVote, &weightMsgIndexingNodeRegistrationVote, nil,
+		func(_ *rand.Rand) {
+			weightMsgIndexingNodeRegistrationVote = DefaultWeightMsgIndexingNodeRegistrationVote
+		},
+	)
+
+	appParams.GetOrGenerate(cdc, OpWeightMsgRemoveResourceNode, &weightMsgRemoveResourceNode, nil,
+		func(_ *rand.Rand) {
+			weightMsgRemoveResourceNode = DefaultWeightMsgRemoveResourceNode
+		},
+	)
+
+	appParams.GetOrGenerate(cdc, OpWeightMsgRemoveIndexingNode, &weightMsgRemoveIndexingNode, nil,
+		func(_ *rand.Rand) {
+			weightMsgRemoveIndexingNode = DefaultWeightMsgRemoveIndexingNode
+		},
+	)
+
+	return simulation.WeightedOperations{
+		simulation.NewWeightedOperation(
+			weightMsgCreateResourceNode,
+			SimulateMsgCreateResourceNode(ak, k),
+		),
+		simulation.NewWeightedOperation(
+			weightMsgCreateIndexingNode,
+			SimulateMsgCreateIndexingNode(ak, k),
+		),
+		simulation.NewWeightedOperation(
+			weightMsgIndexingNodeRegistrationVote,
+			SimulateMsgIndexingNodeRegistrationVote(ak, k),
+		),
+		simulation.NewWeightedOperation(
+			weightMsgRemoveResourceNode,
+			SimulateMsgRemoveResourceNode(ak, k),
+		),
+		simulation.NewWeightedOperation(
+			weightMsgRemoveIndexingNode,
+			SimulateMsgRemoveIndexingNode(ak, k),
+		),
+	}
+}
+
+// SimulateMsgCreateResourceNode generates a MsgCreateResourceNode with random values
+func SimulateMsgCreateResourceNode(ak types.AccountKeeper, k keeper.Keeper) simulation.Operation {
+	return func(
+		r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, chainID string,
+	) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+
+		simAccount, _ := simulation.RandomAcc(r, accs)
+		stake, ok := randomStake(r, ctx, ak, k, simAccount.Address)
+		if !ok {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		pubKey := RandomNodePubKey(r)
+		description := types.NewDescription(simulation.RandStringOfLength(r, 10), "", "", "", "")
+		msg := types.NewMsgCreateResourceNode(fmt.Sprintf("sds://%s", sdk.AccAddress(pubKey.Address())), pubKey, stake,
+			simAccount.Address, description, RandomNodeType(r), RandomCapacity(r, k.MaxCapacity(ctx)))
+
+		return deliverTx(r, app, ctx, ak, chainID, simAccount, msg, sdk.NewCoins(stake))
+	}
+}
+
+// SimulateMsgCreateIndexingNode generates a MsgCreateIndexingNode with random values
+func SimulateMsgCreateIndexingNode(ak types.AccountKeeper, k keeper.Keeper) simulation.Operation {
+	return func(
+		r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, chainID string,
+	) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+
+		simAccount, _ := simulation.RandomAcc(r, accs)
+		stake, ok := randomStake(r, ctx, ak, k, simAccount.Address)
+		if !ok {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		pubKey := RandomNodePubKey(r)
+		description := types.NewDescription(simulation.RandStringOfLength(r, 10), "", "", "", "")
+		msg := types.NewMsgCreateIndexingNode(fmt.Sprintf("sds://%s", sdk.AccAddress(pubKey.Address())), pubKey, stake,
+			simAccount.Address, description)
+
+		return deliverTx(r, app, ctx, ak, chainID, simAccount, msg, sdk.NewCoins(stake))
+	}
+}
+
+// SimulateMsgIndexingNodeRegistrationVote generates a MsgIndexingNodeRegistrationVote of a valid indexing node
+// on a pending indexing node registration
+func SimulateMsgIndexingNodeRegistrationVote(ak types.AccountKeeper, k keeper.Keeper) simulation.Operation {
+	return func(
+		r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, chainID string,
+	) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+
+		var votePools []types.IndexingNodeRegistrationVotePool
+		k.IterateIndexingNodeRegistrationVotePools(ctx, func(votePool types.IndexingNodeRegistrationVotePool) (stop bool) {
+			if !votePool.ExpireTime.Before(ctx.BlockHeader().Time) {
+				votePools = append(votePools, votePool)
+			}
+			return false
+		})
+		if len(votePools) == 0 {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+		votePool := votePools[r.Intn(len(votePools))]
+
+		candidate, found := k.GetIndexingNode(ctx, votePool.NodeAddress)
+		if !found || candidate.GetStatus() != sdk.Unbonded {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		// only the valid indexing nodes which did not vote yet can vote
+		var voters []types.IndexingNode
+		for _, node := range k.GetAllValidIndexingNodes(ctx) {
+			if node.GetNetworkAddr().Equals(candidate.GetNetworkAddr()) ||
+				hasAddr(votePool.ApproveList, node.GetNetworkAddr()) || hasAddr(votePool.RejectList, node.GetNetworkAddr()) {
+				continue
+			}
+			voters = append(voters, node)
+		}
+		if len(voters) == 0 {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+		voter := voters[r.Intn(len(voters))]
+
+		simAccount, found := simulation.FindAccount(accs, voter.OwnerAddress)
+		if !found {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		// most of the registrations are approved
+		opinion := types.VoteOpinionFromBool(r.Intn(10) != 0)
+		msg := types.NewMsgIndexingNodeRegistrationVote(candidate.GetNetworkAddr(), candidate.OwnerAddress, opinion,
+			voter.GetNetworkAddr(), voter.OwnerAddress)
+
+		return deliverTx(r, app, ctx, ak, chainID, simAccount, msg, nil)
+	}
+}
+
+// SimulateMsgRemoveResourceNode generates a MsgRemoveResourceNode of a random resource node
+func SimulateMsgRemoveResourceNode(ak types.AccountKeeper, k keeper.Keeper) simulation.Operation {
+	return func(
+		r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, chainID string,
+	) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+
+		nodes := k.GetAllResourceNodes(ctx)
+		if len(nodes) == 0 {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+		node := nodes[r.Intn(len(nodes))]
+		if !canRemoveNode(ctx, k, node.GetNetworkAddr(), node.GetStatus(), node.GetTokens()) {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		simAccount, found := simulation.FindAccount(accs, node.OwnerAddress)
+		if !found {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		msg := types.NewMsgRemoveResourceNode(node.GetNetworkAddr(), node.OwnerAddress)
+		return deliverTx(r, app, ctx, ak, chainID, simAccount, msg, nil)
+	}
+}
+
+// SimulateMsgRemoveIndexingNode generates a MsgRemoveIndexingNode of a random indexing node
+func SimulateMsgRemoveIndexingNode(ak types.AccountKeeper, k keeper.Keeper) simulation.Operation {
+	return func(
+		r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, chainID string,
+	) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+
+		nodes := k.GetAllIndexingNodes(ctx)
+		if len(nodes) == 0 {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+		node := nodes[r.Intn(len(nodes))]
+		if !canRemoveNode(ctx, k, node.GetNetworkAddr(), node.GetStatus(), node.GetTokens()) {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+		// the last valid indexing node is kept, nothing could be voted on without it
+		if node.GetStatus() == sdk.Bonded && !node.IsSuspended() && len(k.GetAllValidIndexingNodes(ctx)) == 1 {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		simAccount, found := simulation.FindAccount(accs, node.OwnerAddress)
+		if !found {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		msg := types.NewMsgRemoveIndexingNode(node.GetNetworkAddr(), node.OwnerAddress)
+		return deliverTx(r, app, ctx, ak, chainID, simAccount, msg, nil)
+	}
+}
+
+// randomStake returns a random amount of the spendable bond denom of the account, leaving some for the fees
+func randomStake(r *rand.Rand, ctx sdk.Context, ak types.AccountKeeper, k keeper.Keeper, addr sdk.AccAddress) (sdk.Coin, bool) {
+	account := ak.GetAccount(ctx, addr)
+	if account == nil {
+		return sdk.Coin{}, false
+	}
+	denom := k.BondDenom(ctx)
+	spendable := account.SpendableCoins(ctx.BlockTime()).AmountOf(denom)
+	amount, err := simulation.RandPositiveInt(r, spendable.QuoRaw(2))
+	if err != nil {
+		return sdk.Coin{}, false
+	}
+	return sdk.NewCoin(denom, amount), true
+}
+
+// canRemoveNode returns true if the owner of the node can unbond its stake
+func canRemoveNode(ctx sdk.Context, k keeper.Keeper, networkAddr sdk.AccAddress, status sdk.BondStatus, tokens sdk.Int) bool {
+	if status == sdk.Unbonding || k.HasMaxUnbondingNodeEntries(ctx, networkAddr) {
+		return false
+	}
+	return k.GetOwnerAvailableStake(ctx, networkAddr, tokens).IsPositive()
+}
+
+func hasAddr(addrs []sdk.AccAddress, addr sdk.AccAddress) bool {
+	for _, a := range addrs {
+		if a.Equals(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// deliverTx signs the msg with the simulation account, paying random fees out of the coins left once spent is deducted
+func deliverTx(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, ak types.AccountKeeper, chainID string,
+	simAccount simulation.Account, msg sdk.Msg, spent sdk.Coins,
+) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+
+	account := ak.GetAccount(ctx, simAccount.Address)
+	coins, hasNeg := account.SpendableCoins(ctx.BlockTime()).SafeSub(spent)
+	if hasNeg {
+		return simulation.NoOpMsg(types.ModuleName), nil, nil
+	}
+	fees, err := simulation.RandomFees(r, ctx, coins)
+	if err != nil {
+		return simulation.NoOpMsg(types.ModuleName), nil, err
+	}
+
+	tx := helpers.GenTx(
+		[]sdk.Msg{msg},
+		fees,
+		helpers.DefaultGenTxGas,
+		chainID,
+		[]uint64{account.GetAccountNumber()},
+		[]uint64{account.GetSequence()},
+		simAccount.PrivKey,
+	)
+
+	_, _, err = app.Deliver(tx)
+	if err != nil {
+		return simulation.NoOpMsg(types.ModuleName), nil, err
+	}
+
+	return simulation.NewOperationMsg(msg, true, ""), nil, nil
+}
@@ -0,0 +1,48 @@
+package simulation
+
+// DONTCOVER
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+	"github.com/stratosnet/stratos-chain/x/register/types"
+)
+
+// ParamChanges defines the parameters that can be modified by param change proposals
+// on the simulation
+func ParamChanges(r *rand.Rand) []simulation.ParamChange {
+	return []simulation.ParamChange{
+		simulation.NewSimParamChange(types.ModuleName, string(types.KeyUnbondingThreasholdTime),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%d\"", GenUnbondingThreasholdTime(r))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.KeyUnbondingCompletionTime),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%d\"", GenUnbondingCompletionTime(r))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.KeyMaxEntries),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("%d", GenMaxEntries(r))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.KeySlashFractionDowntime),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%s\"", GenSlashFraction(r))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.KeySuspendDuration),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%d\"", GenSuspendDuration(r))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.KeyMaxCapacity),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%s\"", GenMaxCapacity(r))
+			},
+		),
+	}
+}
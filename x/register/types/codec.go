@@ -15,6 +15,25 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgUpdateIndexingNode{}, "register/MsgUpdateIndexingNode", nil)
 
 	cdc.RegisterConcrete(MsgIndexingNodeRegistrationVote{}, "register/MsgIndexingNodeRegistrationVote", nil)
+
+	cdc.RegisterConcrete(MsgSlashResourceNode{}, "register/MsgSlashResourceNode", nil)
+	cdc.RegisterConcrete(MsgSlashIndexingNode{}, "register/MsgSlashIndexingNode", nil)
+	cdc.RegisterConcrete(MsgUnsuspendResourceNode{}, "register/MsgUnsuspendResourceNode", nil)
+	cdc.RegisterConcrete(MsgUnsuspendIndexingNode{}, "register/MsgUnsuspendIndexingNode", nil)
+
+	cdc.RegisterConcrete(MsgUnbondResourceNodeStake{}, "register/MsgUnbondResourceNodeStake", nil)
+	cdc.RegisterConcrete(MsgUnbondIndexingNodeStake{}, "register/MsgUnbondIndexingNodeStake", nil)
+	cdc.RegisterConcrete(MsgAddResourceNodeStake{}, "register/MsgAddResourceNodeStake", nil)
+	cdc.RegisterConcrete(MsgAddIndexingNodeStake{}, "register/MsgAddIndexingNodeStake", nil)
+
+	cdc.RegisterConcrete(MsgDelegateResourceNode{}, "register/MsgDelegateResourceNode", nil)
+	cdc.RegisterConcrete(MsgDelegateIndexingNode{}, "register/MsgDelegateIndexingNode", nil)
+	cdc.RegisterConcrete(MsgUndelegateResourceNode{}, "register/MsgUndelegateResourceNode", nil)
+	cdc.RegisterConcrete(MsgUndelegateIndexingNode{}, "register/MsgUndelegateIndexingNode", nil)
+	cdc.RegisterConcrete(MsgUpdateResourceNodeCommission{}, "register/MsgUpdateResourceNodeCommission", nil)
+	cdc.RegisterConcrete(MsgUpdateIndexingNodeCommission{}, "register/MsgUpdateIndexingNodeCommission", nil)
+
+	cdc.RegisterConcrete(UpdateParamsProposal{}, "register/UpdateParamsProposal", nil)
 }
 
 // ModuleCdc defines the module codec
@@ -0,0 +1,51 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Commission defines the share of the delegators' stake reward kept by the node owner
+type Commission struct {
+	Rate       sdk.Dec   `json:"rate" yaml:"rate"`               // the commission rate charged to delegators, as a fraction
+	UpdateTime time.Time `json:"update_time" yaml:"update_time"` // the last time the commission rate was changed
+}
+
+// NewCommission - initialize a new commission
+func NewCommission(rate sdk.Dec, updateTime time.Time) Commission {
+	return Commission{
+		Rate:       rate,
+		UpdateTime: updateTime,
+	}
+}
+
+// GetRate returns the commission rate, nodes registered before commissions existed charge nothing
+func (c Commission) GetRate() sdk.Dec {
+	if c.Rate.IsNil() {
+		return sdk.ZeroDec()
+	}
+	return c.Rate
+}
+
+// Validate performs basic sanity checks on the commission
+func (c Commission) Validate() error {
+	return ValidateCommissionRate(c.GetRate())
+}
+
+// ValidateCommissionRate checks that the commission rate is within [0, 1]
+func ValidateCommissionRate(rate sdk.Dec) error {
+	if rate.IsNil() || rate.IsNegative() || rate.GT(sdk.OneDec()) {
+		return ErrInvalidCommissionRate
+	}
+	return nil
+}
+
+// String returns a human readable string representation of the commission.
+func (c Commission) String() string {
+	return fmt.Sprintf(`Commission:{
+		Rate:				%s
+		UpdateTime:			%s
+	}`, c.GetRate(), c.UpdateTime)
+}
@@ -0,0 +1,99 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Delegation represents the stake a third party has bonded to a resource node or an indexing node.
+// The delegated tokens are part of the Tokens of the node.
+type Delegation struct {
+	DelegatorAddress sdk.AccAddress `json:"delegator_address" yaml:"delegator_address"` // address of the delegator
+	NetworkAddr      sdk.AccAddress `json:"network_addr" yaml:"network_addr"`           // network address of the node
+	IsIndexingNode   bool           `json:"is_indexing_node" yaml:"is_indexing_node"`   // whether the node is an indexing node
+	Amount           sdk.Int        `json:"amount" yaml:"amount"`                       // delegated tokens
+}
+
+// NewDelegation - initialize a new delegation
+func NewDelegation(delegatorAddr sdk.AccAddress, networkAddr sdk.AccAddress, isIndexingNode bool, amount sdk.Int) Delegation {
+	return Delegation{
+		DelegatorAddress: delegatorAddr,
+		NetworkAddr:      networkAddr,
+		IsIndexingNode:   isIndexingNode,
+		Amount:           amount,
+	}
+}
+
+// Validate performs basic sanity checks on the delegation
+func (d Delegation) Validate() error {
+	if d.DelegatorAddress.Empty() {
+		return ErrEmptyDelegatorAddr
+	}
+	if d.NetworkAddr.Empty() {
+		return ErrEmptyNetworkAddr
+	}
+	if d.Amount.IsNil() || !d.Amount.IsPositive() {
+		return ErrValueNegative
+	}
+	return nil
+}
+
+// String returns a human readable string representation of a delegation.
+func (d Delegation) String() string {
+	return fmt.Sprintf(`Delegation:{
+		DelegatorAddress:	%s
+		NetworkAddr:		%s
+		IsIndexingNode:		%t
+		Amount:				%s
+	}`, d.DelegatorAddress, d.NetworkAddr, d.IsIndexingNode, d.Amount)
+}
+
+// Equal - inefficient but only used in testing
+func (d Delegation) Equal(d2 Delegation) bool {
+	bz1 := ModuleCdc.MustMarshalBinaryLengthPrefixed(&d)
+	bz2 := ModuleCdc.MustMarshalBinaryLengthPrefixed(&d2)
+	return bytes.Equal(bz1, bz2)
+}
+
+// MustMarshalDelegation returns the delegation bytes. Panics if fails
+func MustMarshalDelegation(cdc *codec.Codec, delegation Delegation) []byte {
+	return cdc.MustMarshalBinaryLengthPrefixed(delegation)
+}
+
+// MustUnmarshalDelegation unmarshal a delegation from a store value. Panics if fails
+func MustUnmarshalDelegation(cdc *codec.Codec, value []byte) Delegation {
+	delegation, err := UnmarshalDelegation(cdc, value)
+	if err != nil {
+		panic(err)
+	}
+	return delegation
+}
+
+// UnmarshalDelegation unmarshal a delegation from a store value
+func UnmarshalDelegation(cdc *codec.Codec, value []byte) (delegation Delegation, err error) {
+	err = cdc.UnmarshalBinaryLengthPrefixed(value, &delegation)
+	return delegation, err
+}
+
+// Delegations is a collection of delegations
+type Delegations []Delegation
+
+func (d Delegations) String() (out string) {
+	for _, del := range d {
+		out += del.String() + "\n"
+	}
+	return strings.TrimSpace(out)
+}
+
+func (d Delegations) Validate() error {
+	for _, del := range d {
+		if err := del.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
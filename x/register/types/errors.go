@@ -44,4 +44,29 @@ var (
 	ErrNoNodeForAddress                   = sdkerrors.Register(ModuleName, 37, "registered node does not contain address")
 	ErrUnbondingNode                      = sdkerrors.Register(ModuleName, 38, "changes cannot be made to an unbonding node")
 	ErrInvalidNodeStatBonded              = sdkerrors.Register(ModuleName, 39, "invalid node status: bonded")
+	ErrInvalidSlashType                   = sdkerrors.Register(ModuleName, 40, "invalid slash type")
+	ErrInvalidEvidenceHeight              = sdkerrors.Register(ModuleName, 41, "evidence height must be positive and not in the future")
+	ErrEmptyEvidenceReference             = sdkerrors.Register(ModuleName, 42, "missing evidence reference")
+	ErrEmptyReporterAddr                  = sdkerrors.Register(ModuleName, 43, "missing reporter address")
+	ErrEmptyReporterOwnerAddr             = sdkerrors.Register(ModuleName, 44, "missing reporter owner address")
+	ErrInvalidReporter                    = sdkerrors.Register(ModuleName, 45, "reporter is not a bonded and unsuspended indexing node")
+	ErrSlashSelf                          = sdkerrors.Register(ModuleName, 46, "node can not report itself")
+	ErrNodeSuspended                      = sdkerrors.Register(ModuleName, 47, "node is suspended")
+	ErrNodeNotSuspended                   = sdkerrors.Register(ModuleName, 48, "node is not suspended")
+	ErrNodeNotBonded                      = sdkerrors.Register(ModuleName, 49, "node is not bonded")
+	ErrInsufficientStake                  = sdkerrors.Register(ModuleName, 50, "insufficient stake available for unbonding")
+	ErrEmptyDelegatorAddr                 = sdkerrors.Register(ModuleName, 51, "missing delegator address")
+	ErrNoDelegationFound                  = sdkerrors.Register(ModuleName, 52, "delegation does not exist")
+	ErrInsufficientDelegation             = sdkerrors.Register(ModuleName, 53, "insufficient delegated tokens")
+	ErrInvalidCommissionRate              = sdkerrors.Register(ModuleName, 54, "commission rate must be between 0 and 1")
+	ErrSelfDelegation                     = sdkerrors.Register(ModuleName, 55, "node owner can not delegate to its own node")
+	ErrBondDenomChange                    = sdkerrors.Register(ModuleName, 56, "bond denom cannot be changed by a params update proposal")
+	ErrInvalidGenesisPool                 = sdkerrors.Register(ModuleName, 57, "genesis token pools do not match the node stakes")
+	ErrInvalidGenesisUnbondingQueue       = sdkerrors.Register(ModuleName, 58, "genesis unbonding queue does not match the unbonding nodes")
+	ErrInvalidCapacity                    = sdkerrors.Register(ModuleName, 59, "capacity must not be negative")
+	ErrSuspensionNotOver                  = sdkerrors.Register(ModuleName, 60, "node can not be unsuspended before its suspend duration is over")
+	ErrDuplicateSlashEvidence             = sdkerrors.Register(ModuleName, 61, "node has already been slashed for this evidence")
+	ErrSlashEvidenceMismatch              = sdkerrors.Register(ModuleName, 62, "evidence differs from the evidence already reported against the node under this reference")
+	ErrDuplicateSlashReport               = sdkerrors.Register(ModuleName, 63, "reporter has already reported this evidence")
+	ErrCapacityTooLarge                   = sdkerrors.Register(ModuleName, 64, "capacity exceeds the max capacity")
 )
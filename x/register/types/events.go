@@ -14,6 +14,16 @@ const (
 	EventTypeUnbondingIndexingNode        = "unbonding_indexing_node"
 	EventTypeUpdateIndexingNode           = "update_indexing_node"
 	EventTypeIndexingNodeRegistrationVote = "indexing_node_reg_vote"
+	EventTypeSlashReport                  = "slash_report"
+	EventTypeSlashResourceNode            = "slash_resource_node"
+	EventTypeSlashIndexingNode            = "slash_indexing_node"
+	EventTypeUnsuspendResourceNode        = "unsuspend_resource_node"
+	EventTypeUnsuspendIndexingNode        = "unsuspend_indexing_node"
+	EventTypeAddResourceNodeStake         = "add_resource_node_stake"
+	EventTypeAddIndexingNodeStake         = "add_indexing_node_stake"
+	EventTypeDelegate                     = "delegate"
+	EventTypeUndelegate                   = "undelegate"
+	EventTypeUpdateCommission             = "update_commission"
 
 	AttributeKeyResourceNode            = "resource_node"
 	AttributeKeyIndexingNode            = "indexing_node"
@@ -23,6 +33,14 @@ const (
 	AttributeKeyCandidateStatus         = "candidate_status"
 	AttributeKeyNetworkAddr             = "network_addr"
 	AttributeKeyIsIndexingNode          = "is_indexing_node"
+	AttributeKeyReporter                = "reporter"
+	AttributeKeySlashType               = "slash_type"
+	AttributeKeySlashedAmount           = "slashed_amount"
+	AttributeKeyEvidenceReference       = "evidence_reference"
+	AttributeKeyReportCount             = "report_count"
+	AttributeKeyStakeAmount             = "stake_amount"
+	AttributeKeyDelegator               = "delegator"
+	AttributeKeyCommissionRate          = "commission_rate"
 
 	AttributeKeyUnbondingMatureTime = "unbonding_mature_time"
 
@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	stratos "github.com/stratosnet/stratos-chain/types"
+	"time"
 )
 
 // GenesisState - all register state that must be provided at genesis
@@ -14,6 +16,22 @@ type GenesisState struct {
 	ResourceNodes          ResourceNodes           `json:"resource_nodes" yaml:"resource_nodes"`
 	LastIndexingNodeStakes []LastIndexingNodeStake `json:"last_indexing_node_stakes" yaml:"last_indexing_node_stakes"`
 	IndexingNodes          IndexingNodes           `json:"indexing_nodes" yaml:"indexing_nodes"`
+	Delegations            Delegations             `json:"delegations" yaml:"delegations"`
+
+	UnbondingNodes                    []UnbondingNode                    `json:"unbonding_nodes" yaml:"unbonding_nodes"`
+	UnbondingNodeQueue                []UnbondingNodeQueueTimeSlice      `json:"unbonding_node_queue" yaml:"unbonding_node_queue"`
+	IndexingNodeRegistrationVotePools []IndexingNodeRegistrationVotePool `json:"indexing_node_registration_vote_pools" yaml:"indexing_node_registration_vote_pools"`
+	SlashReports                      []SlashReport                      `json:"slash_reports" yaml:"slash_reports"`
+
+	// computed from the bonded node stakes when left out of the genesis file
+	InitialGenesisStakeTotal sdk.Int `json:"initial_genesis_stake_total,omitempty" yaml:"initial_genesis_stake_total,omitempty"`
+	RemainingOzoneLimit      sdk.Int `json:"remaining_ozone_limit,omitempty" yaml:"remaining_ozone_limit,omitempty"`
+}
+
+// UnbondingNodeQueueTimeSlice is the list of nodes with an unbonding entry completing at a given time
+type UnbondingNodeQueueTimeSlice struct {
+	Time         time.Time        `json:"time" yaml:"time"`
+	NetworkAddrs []sdk.AccAddress `json:"network_addrs" yaml:"network_addrs"`
 }
 
 // LastResourceNodeStake required for resource node set update logic
@@ -71,6 +89,9 @@ func ValidateGenesis(data GenesisState) error {
 	if err := data.IndexingNodes.Validate(); err != nil {
 		return err
 	}
+	if err := data.Delegations.Validate(); err != nil {
+		return err
+	}
 
 	if data.LastResourceNodeStakes != nil {
 		for _, nodeStake := range data.LastResourceNodeStakes {
@@ -93,9 +114,140 @@ func ValidateGenesis(data GenesisState) error {
 			}
 		}
 	}
+
+	for _, value := range []sdk.Int{data.InitialGenesisStakeTotal, data.RemainingOzoneLimit} {
+		if !value.IsNil() && value.IsNegative() {
+			return ErrValueNegative
+		}
+	}
+	if err := validateRegistrationVotePools(data); err != nil {
+		return err
+	}
+	if err := validateSlashReports(data); err != nil {
+		return err
+	}
+	return validateUnbondingNodes(data)
+}
+
+// validateRegistrationVotePools checks that every vote pool belongs to a single known indexing node
+func validateRegistrationVotePools(data GenesisState) error {
+	indexingNodes := make(map[string]bool)
+	for _, node := range data.IndexingNodes {
+		indexingNodes[node.GetNetworkAddr().String()] = true
+	}
+
+	seen := make(map[string]bool)
+	for _, votePool := range data.IndexingNodeRegistrationVotePools {
+		if votePool.NodeAddress.Empty() {
+			return ErrEmptyIndexingNodeAddr
+		}
+		addr := votePool.NodeAddress.String()
+		if !indexingNodes[addr] {
+			return sdkerrors.Wrapf(ErrNoIndexingNodeFound, "registration vote pool of %s", addr)
+		}
+		if seen[addr] {
+			return sdkerrors.Wrapf(ErrInvalid, "duplicate registration vote pool of %s", addr)
+		}
+		seen[addr] = true
+
+		for _, voter := range append(votePool.ApproveList, votePool.RejectList...) {
+			if voter.Empty() {
+				return ErrEmptyVoterNetworkAddr
+			}
+		}
+	}
 	return nil
 }
 
+// validateSlashReports checks that every slash report holds a valid evidence with a reference unique to its node
+func validateSlashReports(data GenesisState) error {
+	seen := make(map[string]bool)
+	for _, report := range data.SlashReports {
+		if report.NodeAddress.Empty() {
+			return ErrEmptyNetworkAddr
+		}
+		if err := report.Evidence.ValidateBasic(); err != nil {
+			return err
+		}
+		key := report.NodeAddress.String() + "/" + report.Evidence.Reference
+		if seen[key] {
+			return sdkerrors.Wrapf(ErrInvalid, "duplicate slash report of evidence %s against node %s",
+				report.Evidence.Reference, report.NodeAddress)
+		}
+		seen[key] = true
+
+		for _, reporter := range report.Reporters {
+			if reporter.Empty() {
+				return ErrEmptyReporterAddr
+			}
+		}
+	}
+	return nil
+}
+
+// validateUnbondingNodes checks that every unbonding node belongs to a known node
+// and that each of its entries is scheduled in the unbonding queue
+func validateUnbondingNodes(data GenesisState) error {
+	isIndexingNode := make(map[string]bool)
+	for _, node := range data.ResourceNodes {
+		isIndexingNode[node.GetNetworkAddr().String()] = false
+	}
+	for _, node := range data.IndexingNodes {
+		isIndexingNode[node.GetNetworkAddr().String()] = true
+	}
+
+	queued := make(map[string]bool)
+	for _, timeSlice := range data.UnbondingNodeQueue {
+		for _, networkAddr := range timeSlice.NetworkAddrs {
+			queued[unbondingQueueKey(networkAddr, timeSlice.Time)] = true
+		}
+	}
+
+	unbonding := make(map[string]bool)
+	for _, ubd := range data.UnbondingNodes {
+		if ubd.NetworkAddr.Empty() {
+			return ErrEmptyNetworkAddr
+		}
+		addr := ubd.NetworkAddr.String()
+		indexing, found := isIndexingNode[addr]
+		if !found || indexing != ubd.IsIndexingNode {
+			if ubd.IsIndexingNode {
+				return sdkerrors.Wrapf(ErrNoIndexingNodeFound, "unbonding node %s", addr)
+			}
+			return sdkerrors.Wrapf(ErrNoResourceNodeFound, "unbonding node %s", addr)
+		}
+		if unbonding[addr] {
+			return sdkerrors.Wrapf(ErrInvalid, "duplicate unbonding node %s", addr)
+		}
+		unbonding[addr] = true
+
+		for _, entry := range ubd.Entries {
+			if entry.Balance.IsNil() || entry.Balance.IsNegative() ||
+				entry.InitialBalance.IsNil() || entry.InitialBalance.IsNegative() {
+				return ErrValueNegative
+			}
+			if !queued[unbondingQueueKey(ubd.NetworkAddr, entry.CompletionTime)] {
+				return sdkerrors.Wrapf(ErrInvalidGenesisUnbondingQueue, "entry of %s completing at %s is not queued",
+					addr, entry.CompletionTime)
+			}
+		}
+	}
+
+	for _, timeSlice := range data.UnbondingNodeQueue {
+		for _, networkAddr := range timeSlice.NetworkAddrs {
+			if !unbonding[networkAddr.String()] {
+				return sdkerrors.Wrapf(ErrInvalidGenesisUnbondingQueue, "%s is queued without unbonding entries",
+					networkAddr)
+			}
+		}
+	}
+	return nil
+}
+
+func unbondingQueueKey(networkAddr sdk.AccAddress, completionTime time.Time) string {
+	return networkAddr.String() + "/" + string(sdk.FormatTimeBytes(completionTime))
+}
+
 type GenesisIndexingNode struct {
 	NetworkID    string         `json:"network_id" yaml:"network_id"`       // network address of the indexing node
 	PubKey       string         `json:"pubkey" yaml:"pubkey"`               // the consensus public key of the indexing node; bech encoded in JSON
@@ -130,5 +282,6 @@ func (v GenesisIndexingNode) ToIndexingNode() IndexingNode {
 		Tokens:       tokens,
 		OwnerAddress: ownerAddress,
 		Description:  v.Description,
+		Commission:   NewCommission(sdk.ZeroDec(), time.Time{}),
 	}
 }
@@ -54,14 +54,16 @@ func (v IndexingNodes) Validate() error {
 }
 
 type IndexingNode struct {
-	NetworkID    string         `json:"network_id" yaml:"network_id"`       // network id of the indexing node
-	PubKey       crypto.PubKey  `json:"pubkey" yaml:"pubkey"`               // the consensus public key of the indexing node; bech encoded in JSON
-	Suspend      bool           `json:"suspend" yaml:"suspend"`             // has the indexing node been suspended from bonded status?
-	Status       sdk.BondStatus `json:"status" yaml:"status"`               // indexing node status (bonded/unbonding/unbonded)
-	Tokens       sdk.Int        `json:"tokens" yaml:"tokens"`               // delegated tokens
-	OwnerAddress sdk.AccAddress `json:"owner_address" yaml:"owner_address"` // owner address of the indexing node
-	Description  Description    `json:"description" yaml:"description"`     // description terms for the indexing node
-	CreationTime time.Time      `json:"creation_time" yaml:"creation_time"`
+	NetworkID      string         `json:"network_id" yaml:"network_id"`       // network id of the indexing node
+	PubKey         crypto.PubKey  `json:"pubkey" yaml:"pubkey"`               // the consensus public key of the indexing node; bech encoded in JSON
+	Suspend        bool           `json:"suspend" yaml:"suspend"`             // has the indexing node been suspended from bonded status?
+	Status         sdk.BondStatus `json:"status" yaml:"status"`               // indexing node status (bonded/unbonding/unbonded)
+	Tokens         sdk.Int        `json:"tokens" yaml:"tokens"`               // delegated tokens
+	OwnerAddress   sdk.AccAddress `json:"owner_address" yaml:"owner_address"` // owner address of the indexing node
+	Description    Description    `json:"description" yaml:"description"`     // description terms for the indexing node
+	CreationTime   time.Time      `json:"creation_time" yaml:"creation_time"`
+	Commission     Commission     `json:"commission" yaml:"commission"`           // commission charged on the stake reward of delegators
+	SuspendedUntil time.Time      `json:"suspended_until" yaml:"suspended_until"` // time before which the suspension can't be lifted
 }
 
 // NewIndexingNode - initialize a new indexing node
@@ -75,6 +77,7 @@ func NewIndexingNode(networkID string, pubKey crypto.PubKey, ownerAddr sdk.AccAd
 		OwnerAddress: ownerAddr,
 		Description:  description,
 		CreationTime: creationTime,
+		Commission:   NewCommission(sdk.ZeroDec(), creationTime),
 	}
 }
 
@@ -93,7 +96,9 @@ func (v IndexingNode) String() string {
 		Owner Address: 		%s
   		Description:		%s
 		CreationTime:		%s
-	}`, v.NetworkID, pubKey, v.Suspend, v.Status, v.Tokens, v.OwnerAddress, v.Description, v.CreationTime)
+		Commission:			%s
+		SuspendedUntil:		%s
+	}`, v.NetworkID, pubKey, v.Suspend, v.Status, v.Tokens, v.OwnerAddress, v.Description, v.CreationTime, v.Commission, v.SuspendedUntil)
 }
 
 // AddToken adds tokens to a indexing node
@@ -130,6 +135,9 @@ func (v IndexingNode) Validate() error {
 	if v.Description.Moniker == "" {
 		return ErrEmptyMoniker
 	}
+	if err := v.Commission.Validate(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -142,6 +150,7 @@ func (v IndexingNode) GetNetworkAddr() sdk.AccAddress { return sdk.AccAddress(v.
 func (v IndexingNode) GetTokens() sdk.Int             { return v.Tokens }
 func (v IndexingNode) GetOwnerAddr() sdk.AccAddress   { return v.OwnerAddress }
 func (v IndexingNode) GetCreationTime() time.Time     { return v.CreationTime }
+func (v IndexingNode) GetCommissionRate() sdk.Dec     { return v.Commission.GetRate() }
 
 // MustMarshalIndexingNode returns the indexingNode bytes. Panics if fails
 func MustMarshalIndexingNode(cdc *codec.Codec, indexingNode IndexingNode) []byte {
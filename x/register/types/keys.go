@@ -18,14 +18,18 @@ const (
 	RouterKey = ModuleName
 	// QuerierRoute to be used for querier msgs
 	QuerierRoute = ModuleName
+
+	// module accounts holding the node stakes
+	ResourceNodeBondedPoolName    = "resource_node_bonded_pool"
+	ResourceNodeNotBondedPoolName = "resource_node_not_bonded_pool"
+	IndexingNodeBondedPoolName    = "indexing_node_bonded_pool"
+	IndexingNodeNotBondedPoolName = "indexing_node_not_bonded_pool"
 )
 
 var (
-	ResourceNodeNotBondedTokenKey = []byte{0x01}
-	ResourceNodeBondedTokenKey    = []byte{0x02}
-	IndexingNodeNotBondedTokenKey = []byte{0x03}
-	IndexingNodeBondedTokenKey    = []byte{0x04}
-	UpperBoundOfTotalOzoneKey     = []byte{0x05}
+	// 0x01 to 0x04 held the token pools before they were moved to module accounts
+	UpperBoundOfTotalOzoneKey = []byte{0x05}
+	TotalOzoneSupplyKey       = []byte{0x06} // key of the uoz created by node stakes, purchased or not
 
 	LastResourceNodeStakeKey    = []byte{0x11} // prefix for each key to a resource node index, for bonded resource nodes
 	LastIndexingNodeStakeKey    = []byte{0x12} // prefix for each key to a indexing node index, for bonded indexing nodes
@@ -34,6 +38,8 @@ var (
 	ResourceNodeKey                  = []byte{0x21} // prefix for each key to a resource node
 	IndexingNodeKey                  = []byte{0x22} // prefix for each key to a indexing node
 	IndexingNodeRegistrationVotesKey = []byte{0x23} // prefix for each key to the vote for Indexing node registration
+	DelegationKey                    = []byte{0x24} // prefix for each key to a delegation, indexed by node address then delegator address
+	SlashReportKey                   = []byte{0x25} // prefix for each key to a slash report, indexed by reported node and evidence reference
 
 	UBDNodeKey = []byte{0x31} // prefix for each key to an unbonding node
 
@@ -67,6 +73,23 @@ func GetIndexingNodeRegistrationVotesKey(nodeAddr sdk.AccAddress) []byte {
 	return append(IndexingNodeRegistrationVotesKey, nodeAddr.Bytes()...)
 }
 
+// GetSlashReportKey gets the key for the slash report of an evidence reference against a node
+// VALUE: SlashReport
+func GetSlashReportKey(nodeAddr sdk.AccAddress, reference string) []byte {
+	return append(append(SlashReportKey, nodeAddr.Bytes()...), []byte(reference)...)
+}
+
+// GetDelegationKey gets the key for the delegation of a delegator to a node
+// VALUE: Delegation
+func GetDelegationKey(nodeAddr sdk.AccAddress, delegatorAddr sdk.AccAddress) []byte {
+	return append(GetNodeDelegationsKey(nodeAddr), delegatorAddr.Bytes()...)
+}
+
+// GetNodeDelegationsKey gets the prefix for all the delegations to a node
+func GetNodeDelegationsKey(nodeAddr sdk.AccAddress) []byte {
+	return append(DelegationKey, nodeAddr.Bytes()...)
+}
+
 // GetURNKey gets the key for the unbonding Node with address
 func GetUBDNodeKey(nodeAddr sdk.AccAddress) []byte {
 	return append(UBDNodeKey, nodeAddr.Bytes()...)
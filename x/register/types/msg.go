@@ -12,6 +12,20 @@ var (
 	_ sdk.Msg = &MsgCreateIndexingNode{}
 	_ sdk.Msg = &MsgRemoveIndexingNode{}
 	_ sdk.Msg = &MsgIndexingNodeRegistrationVote{}
+	_ sdk.Msg = &MsgSlashResourceNode{}
+	_ sdk.Msg = &MsgSlashIndexingNode{}
+	_ sdk.Msg = &MsgUnsuspendResourceNode{}
+	_ sdk.Msg = &MsgUnsuspendIndexingNode{}
+	_ sdk.Msg = &MsgUnbondResourceNodeStake{}
+	_ sdk.Msg = &MsgUnbondIndexingNodeStake{}
+	_ sdk.Msg = &MsgAddResourceNodeStake{}
+	_ sdk.Msg = &MsgAddIndexingNodeStake{}
+	_ sdk.Msg = &MsgDelegateResourceNode{}
+	_ sdk.Msg = &MsgDelegateIndexingNode{}
+	_ sdk.Msg = &MsgUndelegateResourceNode{}
+	_ sdk.Msg = &MsgUndelegateIndexingNode{}
+	_ sdk.Msg = &MsgUpdateResourceNodeCommission{}
+	_ sdk.Msg = &MsgUpdateIndexingNodeCommission{}
 )
 
 type MsgCreateResourceNode struct {
@@ -21,11 +35,12 @@ type MsgCreateResourceNode struct {
 	OwnerAddress sdk.AccAddress `json:"owner_address" yaml:"owner_address"`
 	Description  Description    `json:"description" yaml:"description"`
 	NodeType     string         `json:"node_type" yaml:"node_type"`
+	Capacity     sdk.Int        `json:"capacity" yaml:"capacity"` // optional, max volume credited to the node per epoch, defaults to the max capacity when 0
 }
 
 // NewMsgCreateResourceNode NewMsg<Action> creates a new Msg<Action> instance
 func NewMsgCreateResourceNode(networkID string, pubKey crypto.PubKey, value sdk.Coin,
-	ownerAddr sdk.AccAddress, description Description, nodeType string,
+	ownerAddr sdk.AccAddress, description Description, nodeType string, capacity sdk.Int,
 ) MsgCreateResourceNode {
 	return MsgCreateResourceNode{
 		NetworkID:    networkID,
@@ -34,6 +49,7 @@ func NewMsgCreateResourceNode(networkID string, pubKey crypto.PubKey, value sdk.
 		OwnerAddress: ownerAddr,
 		Description:  description,
 		NodeType:     nodeType,
+		Capacity:     capacity,
 	}
 }
 
@@ -63,6 +79,9 @@ func (msg MsgCreateResourceNode) ValidateBasic() error {
 	if msg.Description.Moniker == "" {
 		return ErrEmptyMoniker
 	}
+	if !msg.Capacity.IsNil() && msg.Capacity.IsNegative() {
+		return ErrInvalidCapacity
+	}
 	return nil
 }
 
@@ -225,17 +244,19 @@ type MsgUpdateResourceNode struct {
 	NetworkID      string         `json:"network_id" yaml:"network_id"`
 	Description    Description    `json:"description" yaml:"description"`
 	NodeType       string         `json:"node_type" yaml:"node_type"`
+	Capacity       sdk.Int        `json:"capacity" yaml:"capacity"` // optional, keeps the current capacity when 0
 	NetworkAddress sdk.AccAddress `json:"network_address" yaml:"network_address"`
 	OwnerAddress   sdk.AccAddress `json:"owner_address" yaml:"owner_address"`
 }
 
-func NewMsgUpdateResourceNode(networkID string, description Description, nodeType string,
+func NewMsgUpdateResourceNode(networkID string, description Description, nodeType string, capacity sdk.Int,
 	networkAddress sdk.AccAddress, ownerAddress sdk.AccAddress) MsgUpdateResourceNode {
 
 	return MsgUpdateResourceNode{
 		NetworkID:      networkID,
 		Description:    description,
 		NodeType:       nodeType,
+		Capacity:       capacity,
 		NetworkAddress: networkAddress,
 		OwnerAddress:   ownerAddress,
 	}
@@ -269,6 +290,9 @@ func (msg MsgUpdateResourceNode) ValidateBasic() error {
 	if msg.Description.Moniker == "" {
 		return ErrEmptyMoniker
 	}
+	if !msg.Capacity.IsNil() && msg.Capacity.IsNegative() {
+		return ErrInvalidCapacity
+	}
 	return nil
 }
 
@@ -380,3 +404,657 @@ func (m MsgIndexingNodeRegistrationVote) GetSigners() []sdk.AccAddress {
 	addrs = append(addrs, m.VoterOwnerAddress)
 	return addrs
 }
+
+// MsgSlashResourceNode - struct for reporting a faulty resource node
+type MsgSlashResourceNode struct {
+	NodeAddress     sdk.AccAddress `json:"node_address" yaml:"node_address"`         // network address of the resource node to be slashed
+	Evidence        SlashEvidence  `json:"evidence" yaml:"evidence"`                 // evidence of the fault
+	ReporterAddress sdk.AccAddress `json:"reporter_address" yaml:"reporter_address"` // network address of the reporting indexing node
+	ReporterOwner   sdk.AccAddress `json:"reporter_owner" yaml:"reporter_owner"`     // owner address of the reporting indexing node
+}
+
+// NewMsgSlashResourceNode creates a new MsgSlashResourceNode instance.
+func NewMsgSlashResourceNode(nodeAddress sdk.AccAddress, evidence SlashEvidence, reporterAddress, reporterOwner sdk.AccAddress,
+) MsgSlashResourceNode {
+	return MsgSlashResourceNode{
+		NodeAddress:     nodeAddress,
+		Evidence:        evidence,
+		ReporterAddress: reporterAddress,
+		ReporterOwner:   reporterOwner,
+	}
+}
+
+// Route implements the sdk.Msg interface.
+func (msg MsgSlashResourceNode) Route() string { return RouterKey }
+
+// Type implements the sdk.Msg interface.
+func (msg MsgSlashResourceNode) Type() string { return "slash_resource_node" }
+
+// GetSigners implements the sdk.Msg interface.
+func (msg MsgSlashResourceNode) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.ReporterOwner}
+}
+
+// GetSignBytes implements the sdk.Msg interface.
+func (msg MsgSlashResourceNode) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic implements the sdk.Msg interface.
+func (msg MsgSlashResourceNode) ValidateBasic() error {
+	if msg.NodeAddress.Empty() {
+		return ErrEmptyResourceNodeAddr
+	}
+	if msg.ReporterAddress.Empty() {
+		return ErrEmptyReporterAddr
+	}
+	if msg.ReporterOwner.Empty() {
+		return ErrEmptyReporterOwnerAddr
+	}
+	if msg.NodeAddress.Equals(msg.ReporterAddress) {
+		return ErrSlashSelf
+	}
+	return msg.Evidence.ValidateBasic()
+}
+
+// MsgSlashIndexingNode - struct for reporting a faulty indexing node
+type MsgSlashIndexingNode struct {
+	NodeAddress     sdk.AccAddress `json:"node_address" yaml:"node_address"`         // network address of the indexing node to be slashed
+	Evidence        SlashEvidence  `json:"evidence" yaml:"evidence"`                 // evidence of the fault
+	ReporterAddress sdk.AccAddress `json:"reporter_address" yaml:"reporter_address"` // network address of the reporting indexing node
+	ReporterOwner   sdk.AccAddress `json:"reporter_owner" yaml:"reporter_owner"`     // owner address of the reporting indexing node
+}
+
+// NewMsgSlashIndexingNode creates a new MsgSlashIndexingNode instance.
+func NewMsgSlashIndexingNode(nodeAddress sdk.AccAddress, evidence SlashEvidence, reporterAddress, reporterOwner sdk.AccAddress,
+) MsgSlashIndexingNode {
+	return MsgSlashIndexingNode{
+		NodeAddress:     nodeAddress,
+		Evidence:        evidence,
+		ReporterAddress: reporterAddress,
+		ReporterOwner:   reporterOwner,
+	}
+}
+
+// Route implements the sdk.Msg interface.
+func (msg MsgSlashIndexingNode) Route() string { return RouterKey }
+
+// Type implements the sdk.Msg interface.
+func (msg MsgSlashIndexingNode) Type() string { return "slash_indexing_node" }
+
+// GetSigners implements the sdk.Msg interface.
+func (msg MsgSlashIndexingNode) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.ReporterOwner}
+}
+
+// GetSignBytes implements the sdk.Msg interface.
+func (msg MsgSlashIndexingNode) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic implements the sdk.Msg interface.
+func (msg MsgSlashIndexingNode) ValidateBasic() error {
+	if msg.NodeAddress.Empty() {
+		return ErrEmptyIndexingNodeAddr
+	}
+	if msg.ReporterAddress.Empty() {
+		return ErrEmptyReporterAddr
+	}
+	if msg.ReporterOwner.Empty() {
+		return ErrEmptyReporterOwnerAddr
+	}
+	if msg.NodeAddress.Equals(msg.ReporterAddress) {
+		return ErrSlashSelf
+	}
+	return msg.Evidence.ValidateBasic()
+}
+
+// MsgUnsuspendResourceNode - struct for lifting the suspension of a resource node
+type MsgUnsuspendResourceNode struct {
+	NetworkAddress sdk.AccAddress `json:"network_address" yaml:"network_address"`
+	OwnerAddress   sdk.AccAddress `json:"owner_address" yaml:"owner_address"`
+}
+
+// NewMsgUnsuspendResourceNode creates a new MsgUnsuspendResourceNode instance.
+func NewMsgUnsuspendResourceNode(networkAddress, ownerAddress sdk.AccAddress) MsgUnsuspendResourceNode {
+	return MsgUnsuspendResourceNode{
+		NetworkAddress: networkAddress,
+		OwnerAddress:   ownerAddress,
+	}
+}
+
+// Route implements the sdk.Msg interface.
+func (msg MsgUnsuspendResourceNode) Route() string { return RouterKey }
+
+// Type implements the sdk.Msg interface.
+func (msg MsgUnsuspendResourceNode) Type() string { return "unsuspend_resource_node" }
+
+// GetSigners implements the sdk.Msg interface.
+func (msg MsgUnsuspendResourceNode) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.OwnerAddress}
+}
+
+// GetSignBytes implements the sdk.Msg interface.
+func (msg MsgUnsuspendResourceNode) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic implements the sdk.Msg interface.
+func (msg MsgUnsuspendResourceNode) ValidateBasic() error {
+	if msg.NetworkAddress.Empty() {
+		return ErrEmptyResourceNodeAddr
+	}
+	if msg.OwnerAddress.Empty() {
+		return ErrEmptyOwnerAddr
+	}
+	return nil
+}
+
+// MsgUnsuspendIndexingNode - struct for lifting the suspension of an indexing node
+type MsgUnsuspendIndexingNode struct {
+	NetworkAddress sdk.AccAddress `json:"network_address" yaml:"network_address"`
+	OwnerAddress   sdk.AccAddress `json:"owner_address" yaml:"owner_address"`
+}
+
+// NewMsgUnsuspendIndexingNode creates a new MsgUnsuspendIndexingNode instance.
+func NewMsgUnsuspendIndexingNode(networkAddress, ownerAddress sdk.AccAddress) MsgUnsuspendIndexingNode {
+	return MsgUnsuspendIndexingNode{
+		NetworkAddress: networkAddress,
+		OwnerAddress:   ownerAddress,
+	}
+}
+
+// Route implements the sdk.Msg interface.
+func (msg MsgUnsuspendIndexingNode) Route() string { return RouterKey }
+
+// Type implements the sdk.Msg interface.
+func (msg MsgUnsuspendIndexingNode) Type() string { return "unsuspend_indexing_node" }
+
+// GetSigners implements the sdk.Msg interface.
+func (msg MsgUnsuspendIndexingNode) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.OwnerAddress}
+}
+
+// GetSignBytes implements the sdk.Msg interface.
+func (msg MsgUnsuspendIndexingNode) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic implements the sdk.Msg interface.
+func (msg MsgUnsuspendIndexingNode) ValidateBasic() error {
+	if msg.NetworkAddress.Empty() {
+		return ErrEmptyIndexingNodeAddr
+	}
+	if msg.OwnerAddress.Empty() {
+		return ErrEmptyOwnerAddr
+	}
+	return nil
+}
+
+// MsgUnbondResourceNodeStake - struct for unbonding part of the stake of a resource node
+type MsgUnbondResourceNodeStake struct {
+	NetworkAddress sdk.AccAddress `json:"network_address" yaml:"network_address"`
+	OwnerAddress   sdk.AccAddress `json:"owner_address" yaml:"owner_address"`
+	Amount         sdk.Coin       `json:"amount" yaml:"amount"`
+}
+
+// NewMsgUnbondResourceNodeStake creates a new MsgUnbondResourceNodeStake instance.
+func NewMsgUnbondResourceNodeStake(networkAddress, ownerAddress sdk.AccAddress, amount sdk.Coin) MsgUnbondResourceNodeStake {
+	return MsgUnbondResourceNodeStake{
+		NetworkAddress: networkAddress,
+		OwnerAddress:   ownerAddress,
+		Amount:         amount,
+	}
+}
+
+// Route implements the sdk.Msg interface.
+func (msg MsgUnbondResourceNodeStake) Route() string { return RouterKey }
+
+// Type implements the sdk.Msg interface.
+func (msg MsgUnbondResourceNodeStake) Type() string { return "unbond_resource_node_stake" }
+
+// GetSigners implements the sdk.Msg interface.
+func (msg MsgUnbondResourceNodeStake) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.OwnerAddress}
+}
+
+// GetSignBytes implements the sdk.Msg interface.
+func (msg MsgUnbondResourceNodeStake) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic implements the sdk.Msg interface.
+func (msg MsgUnbondResourceNodeStake) ValidateBasic() error {
+	if msg.NetworkAddress.Empty() {
+		return ErrEmptyResourceNodeAddr
+	}
+	if msg.OwnerAddress.Empty() {
+		return ErrEmptyOwnerAddr
+	}
+	if !msg.Amount.IsValid() || !msg.Amount.IsPositive() {
+		return ErrValueNegative
+	}
+	return nil
+}
+
+// MsgUnbondIndexingNodeStake - struct for unbonding part of the stake of a indexing node
+type MsgUnbondIndexingNodeStake struct {
+	NetworkAddress sdk.AccAddress `json:"network_address" yaml:"network_address"`
+	OwnerAddress   sdk.AccAddress `json:"owner_address" yaml:"owner_address"`
+	Amount         sdk.Coin       `json:"amount" yaml:"amount"`
+}
+
+// NewMsgUnbondIndexingNodeStake creates a new MsgUnbondIndexingNodeStake instance.
+func NewMsgUnbondIndexingNodeStake(networkAddress, ownerAddress sdk.AccAddress, amount sdk.Coin) MsgUnbondIndexingNodeStake {
+	return MsgUnbondIndexingNodeStake{
+		NetworkAddress: networkAddress,
+		OwnerAddress:   ownerAddress,
+		Amount:         amount,
+	}
+}
+
+// Route implements the sdk.Msg interface.
+func (msg MsgUnbondIndexingNodeStake) Route() string { return RouterKey }
+
+// Type implements the sdk.Msg interface.
+func (msg MsgUnbondIndexingNodeStake) Type() string { return "unbond_indexing_node_stake" }
+
+// GetSigners implements the sdk.Msg interface.
+func (msg MsgUnbondIndexingNodeStake) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.OwnerAddress}
+}
+
+// GetSignBytes implements the sdk.Msg interface.
+func (msg MsgUnbondIndexingNodeStake) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic implements the sdk.Msg interface.
+func (msg MsgUnbondIndexingNodeStake) ValidateBasic() error {
+	if msg.NetworkAddress.Empty() {
+		return ErrEmptyIndexingNodeAddr
+	}
+	if msg.OwnerAddress.Empty() {
+		return ErrEmptyOwnerAddr
+	}
+	if !msg.Amount.IsValid() || !msg.Amount.IsPositive() {
+		return ErrValueNegative
+	}
+	return nil
+}
+
+// MsgAddResourceNodeStake - struct for adding stake to a resource node
+type MsgAddResourceNodeStake struct {
+	NetworkAddress sdk.AccAddress `json:"network_address" yaml:"network_address"`
+	OwnerAddress   sdk.AccAddress `json:"owner_address" yaml:"owner_address"`
+	Amount         sdk.Coin       `json:"amount" yaml:"amount"`
+}
+
+// NewMsgAddResourceNodeStake creates a new MsgAddResourceNodeStake instance.
+func NewMsgAddResourceNodeStake(networkAddress, ownerAddress sdk.AccAddress, amount sdk.Coin) MsgAddResourceNodeStake {
+	return MsgAddResourceNodeStake{
+		NetworkAddress: networkAddress,
+		OwnerAddress:   ownerAddress,
+		Amount:         amount,
+	}
+}
+
+// Route implements the sdk.Msg interface.
+func (msg MsgAddResourceNodeStake) Route() string { return RouterKey }
+
+// Type implements the sdk.Msg interface.
+func (msg MsgAddResourceNodeStake) Type() string { return "add_resource_node_stake" }
+
+// GetSigners implements the sdk.Msg interface.
+func (msg MsgAddResourceNodeStake) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.OwnerAddress}
+}
+
+// GetSignBytes implements the sdk.Msg interface.
+func (msg MsgAddResourceNodeStake) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic implements the sdk.Msg interface.
+func (msg MsgAddResourceNodeStake) ValidateBasic() error {
+	if msg.NetworkAddress.Empty() {
+		return ErrEmptyResourceNodeAddr
+	}
+	if msg.OwnerAddress.Empty() {
+		return ErrEmptyOwnerAddr
+	}
+	if !msg.Amount.IsValid() || !msg.Amount.IsPositive() {
+		return ErrValueNegative
+	}
+	return nil
+}
+
+// MsgAddIndexingNodeStake - struct for adding stake to a indexing node
+type MsgAddIndexingNodeStake struct {
+	NetworkAddress sdk.AccAddress `json:"network_address" yaml:"network_address"`
+	OwnerAddress   sdk.AccAddress `json:"owner_address" yaml:"owner_address"`
+	Amount         sdk.Coin       `json:"amount" yaml:"amount"`
+}
+
+// NewMsgAddIndexingNodeStake creates a new MsgAddIndexingNodeStake instance.
+func NewMsgAddIndexingNodeStake(networkAddress, ownerAddress sdk.AccAddress, amount sdk.Coin) MsgAddIndexingNodeStake {
+	return MsgAddIndexingNodeStake{
+		NetworkAddress: networkAddress,
+		OwnerAddress:   ownerAddress,
+		Amount:         amount,
+	}
+}
+
+// Route implements the sdk.Msg interface.
+func (msg MsgAddIndexingNodeStake) Route() string { return RouterKey }
+
+// Type implements the sdk.Msg interface.
+func (msg MsgAddIndexingNodeStake) Type() string { return "add_indexing_node_stake" }
+
+// GetSigners implements the sdk.Msg interface.
+func (msg MsgAddIndexingNodeStake) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.OwnerAddress}
+}
+
+// GetSignBytes implements the sdk.Msg interface.
+func (msg MsgAddIndexingNodeStake) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic implements the sdk.Msg interface.
+func (msg MsgAddIndexingNodeStake) ValidateBasic() error {
+	if msg.NetworkAddress.Empty() {
+		return ErrEmptyIndexingNodeAddr
+	}
+	if msg.OwnerAddress.Empty() {
+		return ErrEmptyOwnerAddr
+	}
+	if !msg.Amount.IsValid() || !msg.Amount.IsPositive() {
+		return ErrValueNegative
+	}
+	return nil
+}
+
+// MsgDelegateResourceNode - struct for delegating tokens to a resource node
+type MsgDelegateResourceNode struct {
+	NetworkAddress   sdk.AccAddress `json:"network_address" yaml:"network_address"`
+	DelegatorAddress sdk.AccAddress `json:"delegator_address" yaml:"delegator_address"`
+	Amount           sdk.Coin       `json:"amount" yaml:"amount"`
+}
+
+// NewMsgDelegateResourceNode creates a new MsgDelegateResourceNode instance.
+func NewMsgDelegateResourceNode(networkAddress, delegatorAddress sdk.AccAddress, amount sdk.Coin) MsgDelegateResourceNode {
+	return MsgDelegateResourceNode{
+		NetworkAddress:   networkAddress,
+		DelegatorAddress: delegatorAddress,
+		Amount:           amount,
+	}
+}
+
+// Route implements the sdk.Msg interface.
+func (msg MsgDelegateResourceNode) Route() string { return RouterKey }
+
+// Type implements the sdk.Msg interface.
+func (msg MsgDelegateResourceNode) Type() string { return "delegate_resource_node" }
+
+// GetSigners implements the sdk.Msg interface.
+func (msg MsgDelegateResourceNode) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddress}
+}
+
+// GetSignBytes implements the sdk.Msg interface.
+func (msg MsgDelegateResourceNode) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic implements the sdk.Msg interface.
+func (msg MsgDelegateResourceNode) ValidateBasic() error {
+	if msg.NetworkAddress.Empty() {
+		return ErrEmptyResourceNodeAddr
+	}
+	if msg.DelegatorAddress.Empty() {
+		return ErrEmptyDelegatorAddr
+	}
+	if !msg.Amount.IsValid() || !msg.Amount.IsPositive() {
+		return ErrValueNegative
+	}
+	return nil
+}
+
+// MsgDelegateIndexingNode - struct for delegating tokens to a indexing node
+type MsgDelegateIndexingNode struct {
+	NetworkAddress   sdk.AccAddress `json:"network_address" yaml:"network_address"`
+	DelegatorAddress sdk.AccAddress `json:"delegator_address" yaml:"delegator_address"`
+	Amount           sdk.Coin       `json:"amount" yaml:"amount"`
+}
+
+// NewMsgDelegateIndexingNode creates a new MsgDelegateIndexingNode instance.
+func NewMsgDelegateIndexingNode(networkAddress, delegatorAddress sdk.AccAddress, amount sdk.Coin) MsgDelegateIndexingNode {
+	return MsgDelegateIndexingNode{
+		NetworkAddress:   networkAddress,
+		DelegatorAddress: delegatorAddress,
+		Amount:           amount,
+	}
+}
+
+// Route implements the sdk.Msg interface.
+func (msg MsgDelegateIndexingNode) Route() string { return RouterKey }
+
+// Type implements the sdk.Msg interface.
+func (msg MsgDelegateIndexingNode) Type() string { return "delegate_indexing_node" }
+
+// GetSigners implements the sdk.Msg interface.
+func (msg MsgDelegateIndexingNode) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddress}
+}
+
+// GetSignBytes implements the sdk.Msg interface.
+func (msg MsgDelegateIndexingNode) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic implements the sdk.Msg interface.
+func (msg MsgDelegateIndexingNode) ValidateBasic() error {
+	if msg.NetworkAddress.Empty() {
+		return ErrEmptyIndexingNodeAddr
+	}
+	if msg.DelegatorAddress.Empty() {
+		return ErrEmptyDelegatorAddr
+	}
+	if !msg.Amount.IsValid() || !msg.Amount.IsPositive() {
+		return ErrValueNegative
+	}
+	return nil
+}
+
+// MsgUndelegateResourceNode - struct for unbonding tokens delegated to a resource node
+type MsgUndelegateResourceNode struct {
+	NetworkAddress   sdk.AccAddress `json:"network_address" yaml:"network_address"`
+	DelegatorAddress sdk.AccAddress `json:"delegator_address" yaml:"delegator_address"`
+	Amount           sdk.Coin       `json:"amount" yaml:"amount"`
+}
+
+// NewMsgUndelegateResourceNode creates a new MsgUndelegateResourceNode instance.
+func NewMsgUndelegateResourceNode(networkAddress, delegatorAddress sdk.AccAddress, amount sdk.Coin) MsgUndelegateResourceNode {
+	return MsgUndelegateResourceNode{
+		NetworkAddress:   networkAddress,
+		DelegatorAddress: delegatorAddress,
+		Amount:           amount,
+	}
+}
+
+// Route implements the sdk.Msg interface.
+func (msg MsgUndelegateResourceNode) Route() string { return RouterKey }
+
+// Type implements the sdk.Msg interface.
+func (msg MsgUndelegateResourceNode) Type() string { return "undelegate_resource_node" }
+
+// GetSigners implements the sdk.Msg interface.
+func (msg MsgUndelegateResourceNode) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddress}
+}
+
+// GetSignBytes implements the sdk.Msg interface.
+func (msg MsgUndelegateResourceNode) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic implements the sdk.Msg interface.
+func (msg MsgUndelegateResourceNode) ValidateBasic() error {
+	if msg.NetworkAddress.Empty() {
+		return ErrEmptyResourceNodeAddr
+	}
+	if msg.DelegatorAddress.Empty() {
+		return ErrEmptyDelegatorAddr
+	}
+	if !msg.Amount.IsValid() || !msg.Amount.IsPositive() {
+		return ErrValueNegative
+	}
+	return nil
+}
+
+// MsgUndelegateIndexingNode - struct for unbonding tokens delegated to a indexing node
+type MsgUndelegateIndexingNode struct {
+	NetworkAddress   sdk.AccAddress `json:"network_address" yaml:"network_address"`
+	DelegatorAddress sdk.AccAddress `json:"delegator_address" yaml:"delegator_address"`
+	Amount           sdk.Coin       `json:"amount" yaml:"amount"`
+}
+
+// NewMsgUndelegateIndexingNode creates a new MsgUndelegateIndexingNode instance.
+func NewMsgUndelegateIndexingNode(networkAddress, delegatorAddress sdk.AccAddress, amount sdk.Coin) MsgUndelegateIndexingNode {
+	return MsgUndelegateIndexingNode{
+		NetworkAddress:   networkAddress,
+		DelegatorAddress: delegatorAddress,
+		Amount:           amount,
+	}
+}
+
+// Route implements the sdk.Msg interface.
+func (msg MsgUndelegateIndexingNode) Route() string { return RouterKey }
+
+// Type implements the sdk.Msg interface.
+func (msg MsgUndelegateIndexingNode) Type() string { return "undelegate_indexing_node" }
+
+// GetSigners implements the sdk.Msg interface.
+func (msg MsgUndelegateIndexingNode) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddress}
+}
+
+// GetSignBytes implements the sdk.Msg interface.
+func (msg MsgUndelegateIndexingNode) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic implements the sdk.Msg interface.
+func (msg MsgUndelegateIndexingNode) ValidateBasic() error {
+	if msg.NetworkAddress.Empty() {
+		return ErrEmptyIndexingNodeAddr
+	}
+	if msg.DelegatorAddress.Empty() {
+		return ErrEmptyDelegatorAddr
+	}
+	if !msg.Amount.IsValid() || !msg.Amount.IsPositive() {
+		return ErrValueNegative
+	}
+	return nil
+}
+
+// MsgUpdateResourceNodeCommission - struct for updating the commission rate of a resource node
+type MsgUpdateResourceNodeCommission struct {
+	NetworkAddress sdk.AccAddress `json:"network_address" yaml:"network_address"`
+	OwnerAddress   sdk.AccAddress `json:"owner_address" yaml:"owner_address"`
+	CommissionRate sdk.Dec        `json:"commission_rate" yaml:"commission_rate"`
+}
+
+// NewMsgUpdateResourceNodeCommission creates a new MsgUpdateResourceNodeCommission instance.
+func NewMsgUpdateResourceNodeCommission(networkAddress, ownerAddress sdk.AccAddress, commissionRate sdk.Dec) MsgUpdateResourceNodeCommission {
+	return MsgUpdateResourceNodeCommission{
+		NetworkAddress: networkAddress,
+		OwnerAddress:   ownerAddress,
+		CommissionRate: commissionRate,
+	}
+}
+
+// Route implements the sdk.Msg interface.
+func (msg MsgUpdateResourceNodeCommission) Route() string { return RouterKey }
+
+// Type implements the sdk.Msg interface.
+func (msg MsgUpdateResourceNodeCommission) Type() string { return "update_resource_node_commission" }
+
+// GetSigners implements the sdk.Msg interface.
+func (msg MsgUpdateResourceNodeCommission) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.OwnerAddress}
+}
+
+// GetSignBytes implements the sdk.Msg interface.
+func (msg MsgUpdateResourceNodeCommission) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic implements the sdk.Msg interface.
+func (msg MsgUpdateResourceNodeCommission) ValidateBasic() error {
+	if msg.NetworkAddress.Empty() {
+		return ErrEmptyResourceNodeAddr
+	}
+	if msg.OwnerAddress.Empty() {
+		return ErrEmptyOwnerAddr
+	}
+	return ValidateCommissionRate(msg.CommissionRate)
+}
+
+// MsgUpdateIndexingNodeCommission - struct for updating the commission rate of a indexing node
+type MsgUpdateIndexingNodeCommission struct {
+	NetworkAddress sdk.AccAddress `json:"network_address" yaml:"network_address"`
+	OwnerAddress   sdk.AccAddress `json:"owner_address" yaml:"owner_address"`
+	CommissionRate sdk.Dec        `json:"commission_rate" yaml:"commission_rate"`
+}
+
+// NewMsgUpdateIndexingNodeCommission creates a new MsgUpdateIndexingNodeCommission instance.
+func NewMsgUpdateIndexingNodeCommission(networkAddress, ownerAddress sdk.AccAddress, commissionRate sdk.Dec) MsgUpdateIndexingNodeCommission {
+	return MsgUpdateIndexingNodeCommission{
+		NetworkAddress: networkAddress,
+		OwnerAddress:   ownerAddress,
+		CommissionRate: commissionRate,
+	}
+}
+
+// Route implements the sdk.Msg interface.
+func (msg MsgUpdateIndexingNodeCommission) Route() string { return RouterKey }
+
+// Type implements the sdk.Msg interface.
+func (msg MsgUpdateIndexingNodeCommission) Type() string { return "update_indexing_node_commission" }
+
+// GetSigners implements the sdk.Msg interface.
+func (msg MsgUpdateIndexingNodeCommission) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.OwnerAddress}
+}
+
+// GetSignBytes implements the sdk.Msg interface.
+func (msg MsgUpdateIndexingNodeCommission) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic implements the sdk.Msg interface.
+func (msg MsgUpdateIndexingNodeCommission) ValidateBasic() error {
+	if msg.NetworkAddress.Empty() {
+		return ErrEmptyIndexingNodeAddr
+	}
+	if msg.OwnerAddress.Empty() {
+		return ErrEmptyOwnerAddr
+	}
+	return ValidateCommissionRate(msg.CommissionRate)
+}
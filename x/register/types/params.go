@@ -17,14 +17,25 @@ const (
 	DefaultUnbondingThreasholdTime time.Duration = 180 * 24 * time.Hour // threashold for unbonding - by default 180 days
 	DefaultUnbondingCompletionTime time.Duration = 14 * 24 * time.Hour  // lead time to complete unbonding - by default 14 days
 	DefaultMaxEntries                            = uint16(16)
+	DefaultSuspendDuration         time.Duration = time.Hour // min time a node stays suspended - by default 1 hour
+)
+
+var (
+	DefaultSlashFractionDowntime    = sdk.NewDecWithPrec(1, 2)     // 1% of the bonded stake by default
+	DefaultSlashFractionMisbehavior = sdk.NewDecWithPrec(5, 2)     // 5% of the bonded stake by default
+	DefaultMaxCapacity              = sdk.NewInt(1000000000000000) // 1,000,000 oz per epoch by default
 )
 
 // Parameter store keys
 var (
-	KeyBondDenom               = []byte("BondDenom")
-	KeyUnbondingThreasholdTime = []byte("UnbondingThreasholdTime")
-	KeyUnbondingCompletionTime = []byte("UnbondingCompletionTime")
-	KeyMaxEntries              = []byte("KeyMaxEntries")
+	KeyBondDenom                = []byte("BondDenom")
+	KeyUnbondingThreasholdTime  = []byte("UnbondingThreasholdTime")
+	KeyUnbondingCompletionTime  = []byte("UnbondingCompletionTime")
+	KeyMaxEntries               = []byte("KeyMaxEntries")
+	KeySlashFractionDowntime    = []byte("SlashFractionDowntime")
+	KeySlashFractionMisbehavior = []byte("SlashFractionMisbehavior")
+	KeySuspendDuration          = []byte("SuspendDuration")
+	KeyMaxCapacity              = []byte("MaxCapacity")
 )
 
 var _ subspace.ParamSet = &Params{}
@@ -36,19 +47,28 @@ func ParamKeyTable() params.KeyTable {
 
 // Params - used for initializing default parameter for register at genesis
 type Params struct {
-	BondDenom               string        `json:"bond_denom" yaml:"bond_denom"`                               // bondable coin denomination
-	UnbondingThreasholdTime time.Duration `json:"unbonding_threashold_time" yaml:"unbonding_threashold_time"` // threashold for unbonding - by default 180 days
-	UnbondingCompletionTime time.Duration `json:"unbonding_completion_time" yaml:"unbonding_completion_time"` // lead time to complete unbonding - by default 14 days
-	MaxEntries              uint16        `json:"max_entries" yaml:"max_entries"`                             // max entries for either unbonding delegation or redelegation (per pair/trio)
+	BondDenom                string        `json:"bond_denom" yaml:"bond_denom"`                                 // bondable coin denomination
+	UnbondingThreasholdTime  time.Duration `json:"unbonding_threashold_time" yaml:"unbonding_threashold_time"`   // threashold for unbonding - by default 180 days
+	UnbondingCompletionTime  time.Duration `json:"unbonding_completion_time" yaml:"unbonding_completion_time"`   // lead time to complete unbonding - by default 14 days
+	MaxEntries               uint16        `json:"max_entries" yaml:"max_entries"`                               // max entries for either unbonding delegation or redelegation (per pair/trio)
+	SlashFractionDowntime    sdk.Dec       `json:"slash_fraction_downtime" yaml:"slash_fraction_downtime"`       // fraction of bonded stake slashed for downtime
+	SlashFractionMisbehavior sdk.Dec       `json:"slash_fraction_misbehavior" yaml:"slash_fraction_misbehavior"` // fraction of bonded stake slashed for misbehavior
+	SuspendDuration          time.Duration `json:"suspend_duration" yaml:"suspend_duration"`                     // min time a node stays suspended before its owner can lift the suspension
+	MaxCapacity              sdk.Int       `json:"max_capacity" yaml:"max_capacity"`                             // max volume in uoz a resource node can declare per epoch
 }
 
 // NewParams creates a new Params object
-func NewParams(bondDenom string, threashold, completion time.Duration, maxEntries uint16) Params {
+func NewParams(bondDenom string, threashold, completion time.Duration, maxEntries uint16,
+	slashFractionDowntime, slashFractionMisbehavior sdk.Dec, suspendDuration time.Duration, maxCapacity sdk.Int) Params {
 	return Params{
-		BondDenom:               bondDenom,
-		UnbondingThreasholdTime: threashold,
-		UnbondingCompletionTime: completion,
-		MaxEntries:              maxEntries,
+		BondDenom:                bondDenom,
+		UnbondingThreasholdTime:  threashold,
+		UnbondingCompletionTime:  completion,
+		MaxEntries:               maxEntries,
+		SlashFractionDowntime:    slashFractionDowntime,
+		SlashFractionMisbehavior: slashFractionMisbehavior,
+		SuspendDuration:          suspendDuration,
+		MaxCapacity:              maxCapacity,
 	}
 }
 
@@ -59,8 +79,13 @@ func (p Params) String() string {
 	  Unbonding Threashold Time:  	%s
 	  Unbonding Completion Time:  	%s
 	  Max Entries:        			%d
+	  Slash Fraction Downtime:  	%s
+	  Slash Fraction Misbehavior:	%s
+	  Suspend Duration:				%s
+	  Max Capacity:					%s
 `,
 		p.BondDenom, p.UnbondingThreasholdTime, p.UnbondingCompletionTime, p.MaxEntries,
+		p.SlashFractionDowntime, p.SlashFractionMisbehavior, p.SuspendDuration, p.MaxCapacity,
 	)
 }
 
@@ -71,6 +96,10 @@ func (p *Params) ParamSetPairs() params.ParamSetPairs {
 		params.NewParamSetPair(KeyUnbondingThreasholdTime, &p.UnbondingThreasholdTime, validateUnbondingThreasholdTime),
 		params.NewParamSetPair(KeyUnbondingCompletionTime, &p.UnbondingCompletionTime, validateUnbondingCompletionTime),
 		params.NewParamSetPair(KeyMaxEntries, &p.MaxEntries, validateMaxEntries),
+		params.NewParamSetPair(KeySlashFractionDowntime, &p.SlashFractionDowntime, validateSlashFraction),
+		params.NewParamSetPair(KeySlashFractionMisbehavior, &p.SlashFractionMisbehavior, validateSlashFraction),
+		params.NewParamSetPair(KeySuspendDuration, &p.SuspendDuration, validateSuspendDuration),
+		params.NewParamSetPair(KeyMaxCapacity, &p.MaxCapacity, validateMaxCapacity),
 	}
 }
 
@@ -87,12 +116,25 @@ func (p Params) Validate() error {
 	if err := validateMaxEntries(p.MaxEntries); err != nil {
 		return err
 	}
+	if err := validateSlashFraction(p.SlashFractionDowntime); err != nil {
+		return err
+	}
+	if err := validateSlashFraction(p.SlashFractionMisbehavior); err != nil {
+		return err
+	}
+	if err := validateSuspendDuration(p.SuspendDuration); err != nil {
+		return err
+	}
+	if err := validateMaxCapacity(p.MaxCapacity); err != nil {
+		return err
+	}
 	return nil
 }
 
 // DefaultParams defines the parameters for this module
 func DefaultParams() Params {
-	return NewParams(DefaultBondDenom, DefaultUnbondingThreasholdTime, DefaultUnbondingCompletionTime, DefaultMaxEntries)
+	return NewParams(DefaultBondDenom, DefaultUnbondingThreasholdTime, DefaultUnbondingCompletionTime, DefaultMaxEntries,
+		DefaultSlashFractionDowntime, DefaultSlashFractionMisbehavior, DefaultSuspendDuration, DefaultMaxCapacity)
 }
 
 func validateBondDenom(i interface{}) error {
@@ -149,3 +191,45 @@ func validateMaxEntries(i interface{}) error {
 
 	return nil
 }
+
+func validateSlashFraction(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsNil() || v.IsNegative() {
+		return fmt.Errorf("slash fraction cannot be negative: %s", v)
+	}
+	if v.GT(sdk.OneDec()) {
+		return fmt.Errorf("slash fraction too large: %s", v)
+	}
+
+	return nil
+}
+
+func validateSuspendDuration(i interface{}) error {
+	v, ok := i.(time.Duration)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v <= 0 {
+		return fmt.Errorf("suspend duration must be positive: %d", v)
+	}
+
+	return nil
+}
+
+func validateMaxCapacity(i interface{}) error {
+	v, ok := i.(sdk.Int)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsNil() || !v.IsPositive() {
+		return fmt.Errorf("max capacity must be positive: %s", v)
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"fmt"
+
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+)
+
+const (
+	// ProposalTypeUpdateRegisterParams defines the type for an UpdateParamsProposal of the register module
+	ProposalTypeUpdateRegisterParams = "UpdateRegisterParams"
+)
+
+// Assert UpdateParamsProposal implements govtypes.Content at compile-time
+var _ govtypes.Content = UpdateParamsProposal{}
+
+func init() {
+	govtypes.RegisterProposalType(ProposalTypeUpdateRegisterParams)
+	govtypes.RegisterProposalTypeCodec(UpdateParamsProposal{}, "register/UpdateParamsProposal")
+}
+
+// UpdateParamsProposal replaces the register params once the proposal passes
+type UpdateParamsProposal struct {
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description" yaml:"description"`
+	Params      Params `json:"params" yaml:"params"`
+}
+
+// NewUpdateParamsProposal creates a new register params update proposal.
+func NewUpdateParamsProposal(title, description string, params Params) UpdateParamsProposal {
+	return UpdateParamsProposal{title, description, params}
+}
+
+// GetTitle returns the title of a register params update proposal.
+func (p UpdateParamsProposal) GetTitle() string { return p.Title }
+
+// GetDescription returns the description of a register params update proposal.
+func (p UpdateParamsProposal) GetDescription() string { return p.Description }
+
+// ProposalRoute returns the routing key of a register params update proposal.
+func (p UpdateParamsProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of a register params update proposal.
+func (p UpdateParamsProposal) ProposalType() string { return ProposalTypeUpdateRegisterParams }
+
+// ValidateBasic runs basic stateless validity checks
+func (p UpdateParamsProposal) ValidateBasic() error {
+	err := govtypes.ValidateAbstract(p)
+	if err != nil {
+		return err
+	}
+	return p.Params.Validate()
+}
+
+// String implements the Stringer interface.
+func (p UpdateParamsProposal) String() string {
+	return fmt.Sprintf(`Update Register Params Proposal:
+  Title:       %s
+  Description: %s
+  %s
+`, p.Title, p.Description, p.Params)
+}
@@ -82,15 +82,18 @@ func (v ResourceNodes) Validate() error {
 }
 
 type ResourceNode struct {
-	NetworkID    string         `json:"network_id" yaml:"network_id"`       // network id of the resource node
-	PubKey       crypto.PubKey  `json:"pubkey" yaml:"pubkey"`               // the public key of the resource node; bech encoded in JSON
-	Suspend      bool           `json:"suspend" yaml:"suspend"`             // has the resource node been suspended from bonded status?
-	Status       sdk.BondStatus `json:"status" yaml:"status"`               // resource node bond status (bonded/unbonding/unbonded)
-	Tokens       sdk.Int        `json:"tokens" yaml:"tokens"`               // delegated tokens
-	OwnerAddress sdk.AccAddress `json:"owner_address" yaml:"owner_address"` // owner address of the resource node
-	Description  Description    `json:"description" yaml:"description"`     // description terms for the resource node
-	NodeType     string         `json:"node_type" yaml:"node_type"`
-	CreationTime time.Time      `json:"creation_time" yaml:"creation_time"`
+	NetworkID      string         `json:"network_id" yaml:"network_id"`       // network id of the resource node
+	PubKey         crypto.PubKey  `json:"pubkey" yaml:"pubkey"`               // the public key of the resource node; bech encoded in JSON
+	Suspend        bool           `json:"suspend" yaml:"suspend"`             // has the resource node been suspended from bonded status?
+	Status         sdk.BondStatus `json:"status" yaml:"status"`               // resource node bond status (bonded/unbonding/unbonded)
+	Tokens         sdk.Int        `json:"tokens" yaml:"tokens"`               // delegated tokens
+	OwnerAddress   sdk.AccAddress `json:"owner_address" yaml:"owner_address"` // owner address of the resource node
+	Description    Description    `json:"description" yaml:"description"`     // description terms for the resource node
+	NodeType       string         `json:"node_type" yaml:"node_type"`
+	CreationTime   time.Time      `json:"creation_time" yaml:"creation_time"`
+	Commission     Commission     `json:"commission" yaml:"commission"`           // commission charged on the stake reward of delegators
+	Capacity       sdk.Int        `json:"capacity" yaml:"capacity"`               // max volume credited to the resource node per epoch
+	SuspendedUntil time.Time      `json:"suspended_until" yaml:"suspended_until"` // time before which the suspension can't be lifted
 }
 
 // NewResourceNode - initialize a new resource node
@@ -106,6 +109,7 @@ func NewResourceNode(networkID string, pubKey crypto.PubKey, ownerAddr sdk.AccAd
 		Description:  description,
 		NodeType:     nodeType,
 		CreationTime: creationTime,
+		Commission:   NewCommission(sdk.ZeroDec(), creationTime),
 	}
 }
 
@@ -124,7 +128,10 @@ func (v ResourceNode) String() string {
 		Owner Address: 		%s
   		Description:		%s
   		CreationTime:		%s
-	}`, v.NetworkID, pubKey, v.Suspend, v.Status, v.Tokens, v.OwnerAddress, v.Description, v.CreationTime)
+  		Commission:			%s
+  		Capacity:			%s
+  		SuspendedUntil:		%s
+	}`, v.NetworkID, pubKey, v.Suspend, v.Status, v.Tokens, v.OwnerAddress, v.Description, v.CreationTime, v.Commission, v.Capacity, v.SuspendedUntil)
 }
 
 // AddToken adds tokens to a resource node
@@ -161,9 +168,21 @@ func (v ResourceNode) Validate() error {
 	if v.Description.Moniker == "" {
 		return ErrEmptyMoniker
 	}
+	if err := v.Commission.Validate(); err != nil {
+		return err
+	}
+	// a capacity left at 0 defaults to the max capacity at genesis
+	if !v.Capacity.IsNil() && v.Capacity.IsNegative() {
+		return ErrInvalidCapacity
+	}
 	return nil
 }
 
+// ExceedsCapacity returns whether the volume is above the declared capacity of the resource node
+func (v ResourceNode) ExceedsCapacity(volume sdk.Int) bool {
+	return v.Capacity.IsNil() || volume.GT(v.Capacity)
+}
+
 func (v ResourceNode) IsSuspended() bool              { return v.Suspend }
 func (v ResourceNode) GetMoniker() string             { return v.Description.Moniker }
 func (v ResourceNode) GetStatus() sdk.BondStatus      { return v.Status }
@@ -174,6 +193,7 @@ func (v ResourceNode) GetTokens() sdk.Int             { return v.Tokens }
 func (v ResourceNode) GetOwnerAddr() sdk.AccAddress   { return v.OwnerAddress }
 func (v ResourceNode) GetNodeType() string            { return v.NodeType }
 func (v ResourceNode) GetCreationTime() time.Time     { return v.CreationTime }
+func (v ResourceNode) GetCommissionRate() sdk.Dec     { return v.Commission.GetRate() }
 
 // MustMarshalResourceNode returns the resourceNode bytes. Panics if fails
 func MustMarshalResourceNode(cdc *codec.Codec, resourceNode ResourceNode) []byte {
@@ -0,0 +1,132 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SlashType defines the kind of fault an indexing node can report against a registered node
+type SlashType byte
+
+const (
+	SlashTypeDowntime    SlashType = 0x01 // node dropped offline / stopped serving requests
+	SlashTypeMisbehavior SlashType = 0x02 // node served bad data or otherwise misbehaved
+
+	SlashTypeDowntimeStr    = "Downtime"
+	SlashTypeMisbehaviorStr = "Misbehavior"
+)
+
+// SlashTypeFromString converts the string representation of a SlashType
+func SlashTypeFromString(str string) (SlashType, error) {
+	switch strings.ToLower(str) {
+	case strings.ToLower(SlashTypeDowntimeStr):
+		return SlashTypeDowntime, nil
+	case strings.ToLower(SlashTypeMisbehaviorStr):
+		return SlashTypeMisbehavior, nil
+	default:
+		return SlashType(0xff), fmt.Errorf("'%s' is not a valid slash type", str)
+	}
+}
+
+// Valid returns true if the slash type is one of the supported types
+func (st SlashType) Valid() bool {
+	return st == SlashTypeDowntime || st == SlashTypeMisbehavior
+}
+
+// String implements the Stringer interface for SlashType.
+func (st SlashType) String() string {
+	switch st {
+	case SlashTypeDowntime:
+		return SlashTypeDowntimeStr
+	case SlashTypeMisbehavior:
+		return SlashTypeMisbehaviorStr
+	default:
+		return ""
+	}
+}
+
+// SlashEvidence is the proof attached by an indexing node when it reports a faulty node
+type SlashEvidence struct {
+	SlashType SlashType `json:"slash_type" yaml:"slash_type"` // kind of fault being reported
+	Height    int64     `json:"height" yaml:"height"`         // block height at which the fault was observed
+	Reference string    `json:"reference" yaml:"reference"`   // off-chain reference backing the report (e.g. task id or record hash)
+}
+
+// NewSlashEvidence - initialize a new slash evidence
+func NewSlashEvidence(slashType SlashType, height int64, reference string) SlashEvidence {
+	return SlashEvidence{
+		SlashType: slashType,
+		Height:    height,
+		Reference: reference,
+	}
+}
+
+// ValidateBasic performs stateless checks on the evidence
+func (e SlashEvidence) ValidateBasic() error {
+	if !e.SlashType.Valid() {
+		return ErrInvalidSlashType
+	}
+	if e.Height <= 0 {
+		return ErrInvalidEvidenceHeight
+	}
+	if strings.TrimSpace(e.Reference) == "" {
+		return ErrEmptyEvidenceReference
+	}
+	return nil
+}
+
+// String returns a human readable string representation of the evidence.
+func (e SlashEvidence) String() string {
+	return fmt.Sprintf(`SlashEvidence:{
+		SlashType:			%s
+		Height:				%d
+		Reference:			%s
+	}`, e.SlashType, e.Height, e.Reference)
+}
+
+// SlashReport collects the indexing nodes reporting the same evidence against a node.
+// The node is slashed once the reporters reach the quorum, the report is kept afterwards to reject a replay of the evidence.
+type SlashReport struct {
+	NodeAddress    sdk.AccAddress   `json:"node_address" yaml:"node_address"`         // network address of the reported node
+	IsIndexingNode bool             `json:"is_indexing_node" yaml:"is_indexing_node"` // true if the reported node is an indexing node
+	Evidence       SlashEvidence    `json:"evidence" yaml:"evidence"`                 // evidence backing the report
+	Reporters      []sdk.AccAddress `json:"reporters" yaml:"reporters"`               // network addresses of the reporting indexing nodes
+	Executed       bool             `json:"executed" yaml:"executed"`                 // has the node been slashed for this evidence?
+}
+
+// NewSlashReport - initialize a new slash report without reporters
+func NewSlashReport(nodeAddress sdk.AccAddress, isIndexingNode bool, evidence SlashEvidence) SlashReport {
+	return SlashReport{
+		NodeAddress:    nodeAddress,
+		IsIndexingNode: isIndexingNode,
+		Evidence:       evidence,
+	}
+}
+
+// Matches returns true if the report is about the given node and evidence
+func (r SlashReport) Matches(nodeAddress sdk.AccAddress, isIndexingNode bool, evidence SlashEvidence) bool {
+	return r.NodeAddress.Equals(nodeAddress) && r.IsIndexingNode == isIndexingNode && r.Evidence == evidence
+}
+
+// HasReported returns true if the indexing node already reported the evidence
+func (r SlashReport) HasReported(reporter sdk.AccAddress) bool {
+	for _, addr := range r.Reporters {
+		if addr.Equals(reporter) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns a human readable string representation of the report.
+func (r SlashReport) String() string {
+	return fmt.Sprintf(`SlashReport:{
+		NodeAddress:		%s
+		IsIndexingNode:		%v
+		Evidence:			%s
+		Reporters:			%v
+		Executed:			%v
+	}`, r.NodeAddress, r.IsIndexingNode, r.Evidence, r.Reporters, r.Executed)
+}
@@ -15,16 +15,17 @@ import (
 // for a single unbonding node in an time-ordered list
 type UnbondingNode struct {
 	NetworkAddr    sdk.AccAddress       `json:"network_addr" yaml:"network_addr"`
-	IsIndexingNode bool                 `json:"is_indexing_node yaml:"is_indexing_node`
+	IsIndexingNode bool                 `json:"is_indexing_node" yaml:"is_indexing_node"`
 	Entries        []UnbondingNodeEntry `json:"entries" yaml:"entries"` // unbonding node entries
 }
 
 // UnbondingNodeEntry - entry to an UnbondingNode
 type UnbondingNodeEntry struct {
-	CreationHeight int64     `json:"creation_height" yaml:"creation_height"` // height which the unbonding took place
-	CompletionTime time.Time `json:"completion_time" yaml:"completion_time"` // time at which the unbonding delegation will complete
-	InitialBalance sdk.Int   `json:"initial_balance" yaml:"initial_balance"` // ustos initially scheduled to receive at completion
-	Balance        sdk.Int   `json:"balance" yaml:"balance"`                 // ustos to receive at completion
+	CreationHeight   int64          `json:"creation_height" yaml:"creation_height"`                         // height which the unbonding took place
+	CompletionTime   time.Time      `json:"completion_time" yaml:"completion_time"`                         // time at which the unbonding delegation will complete
+	InitialBalance   sdk.Int        `json:"initial_balance" yaml:"initial_balance"`                         // ustos initially scheduled to receive at completion
+	Balance          sdk.Int        `json:"balance" yaml:"balance"`                                         // ustos to receive at completion
+	DelegatorAddress sdk.AccAddress `json:"delegator_address,omitempty" yaml:"delegator_address,omitempty"` // delegator refunded at completion, empty for the stake of the node owner
 }
 
 // IsMature - is the current entry mature
@@ -56,6 +57,30 @@ func NewUnbondingNodeEntry(creationHeight int64, completionTime time.Time,
 	}
 }
 
+// NewUnbondingDelegatorEntry - create a new unbonding entry refunding a delegator of the node
+func NewUnbondingDelegatorEntry(delegatorAddr sdk.AccAddress, creationHeight int64, completionTime time.Time,
+	balance sdk.Int) UnbondingNodeEntry {
+
+	entry := NewUnbondingNodeEntry(creationHeight, completionTime, balance)
+	entry.DelegatorAddress = delegatorAddr
+	return entry
+}
+
+// IsDelegatorEntry - does the entry refund a delegator rather than the node owner
+func (e UnbondingNodeEntry) IsDelegatorEntry() bool {
+	return !e.DelegatorAddress.Empty()
+}
+
+// CountEntries - number of entries refunding the delegator, or the node owner when delegatorAddr is empty
+func (un UnbondingNode) CountEntries(delegatorAddr sdk.AccAddress) (count int) {
+	for _, entry := range un.Entries {
+		if entry.DelegatorAddress.Equals(delegatorAddr) {
+			count++
+		}
+	}
+	return count
+}
+
 // AddEntry - append entry to the unbonding Node
 func (un *UnbondingNode) AddEntry(creationHeight int64,
 	minTime time.Time, balance sdk.Int) {
@@ -64,6 +89,14 @@ func (un *UnbondingNode) AddEntry(creationHeight int64,
 	un.Entries = append(un.Entries, entry)
 }
 
+// AddDelegatorEntry - append an entry refunding a delegator to the unbonding Node
+func (un *UnbondingNode) AddDelegatorEntry(delegatorAddr sdk.AccAddress, creationHeight int64,
+	minTime time.Time, balance sdk.Int) {
+
+	entry := NewUnbondingDelegatorEntry(delegatorAddr, creationHeight, minTime, balance)
+	un.Entries = append(un.Entries, entry)
+}
+
 // RemoveEntry - remove entry at index i to the unbonding Node
 func (un *UnbondingNode) RemoveEntry(i int64) {
 	un.Entries = append(un.Entries[:i], un.Entries[i+1:]...)
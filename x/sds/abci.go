@@ -15,5 +15,7 @@ func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k keeper.Keeper)
 
 // EndBlocker called every block, process inflation, update validator set.
 func EndBlocker(ctx sdk.Context, k keeper.Keeper) {
-	// 	TODO: fill out if your application requires endblock, if not you can delete this function
+	k.RemoveExpiredFiles(ctx)
+	k.ProcessExpiredStorageChallenges(ctx)
+	k.RecordOzonePrice(ctx)
 }
@@ -15,6 +15,8 @@ const (
 var (
 	NewKeeper     = keeper.NewKeeper
 	RegisterCodec = types.RegisterCodec
+
+	OzonePriceHistoryPrefix = types.OzonePriceHistoryPrefix
 )
 
 type (
@@ -12,11 +12,14 @@ import (
 	"github.com/stratosnet/stratos-chain/x/pot"
 	pottypes "github.com/stratosnet/stratos-chain/x/pot/types"
 	"github.com/stratosnet/stratos-chain/x/register"
+	"github.com/stratosnet/stratos-chain/x/sds/keeper"
 	"github.com/stratosnet/stratos-chain/x/sds/types"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
 	"log"
 	"testing"
+	"time"
 )
 
 var (
@@ -28,7 +31,7 @@ func TestSdsMsgs(t *testing.T) {
 
 	/********************* initialize mock app *********************/
 	SetConfig()
-	mApp, _, _, _, _ := getMockApp(t)
+	mApp, k, _, _, _ := getMockApp(t)
 	accs := setupAccounts(mApp)
 	mock.SetGenesis(mApp, accs)
 	//mock.CheckBalance(t, mApp, foundationAccAddr, foundationDeposit)
@@ -36,7 +39,7 @@ func TestSdsMsgs(t *testing.T) {
 	///********************* create fileUpload msg *********************/
 	log.Print("====== Testing MsgFileUpload ======")
 	fileHash, _ := hex.DecodeString(testFileHashHex)
-	fileUploadMsg := types.NewMsgUpload(fileHash, spNodeAddrIdx1, sdsAccAddr2)
+	fileUploadMsg := types.NewMsgUpload(fileHash, spNodeAddrIdx1, sdsAccAddr2, 1024, 2, []sdk.AccAddress{addrRes1}, 0, nil, 0)
 	headerUpload := abci.Header{Height: mApp.LastBlockHeight() + 1}
 	mock.SignCheckDeliver(t, mApp.Cdc, mApp.BaseApp, headerUpload, []sdk.Msg{fileUploadMsg}, []uint64{17}, []uint64{0}, true, true, spNodePrivKeyIdx1)
 	coin := sdk.NewCoin(DefaultDenom, spNodeInitialStakeIdx1)
@@ -44,12 +47,463 @@ func TestSdsMsgs(t *testing.T) {
 	///********************* create prepay msg *********************/
 	log.Print("====== Testing MsgPrepay ======")
 	coinToPrepay := sdk.NewCoin(DefaultDenom, prepayAmt)
-	prepayMsg := types.NewMsgPrepay(sdsAccAddr3, sdk.NewCoins(coinToPrepay))
+	prepayMsg := types.NewMsgPrepay(sdsAccAddr3, sdk.NewCoins(coinToPrepay), sdk.ZeroInt())
 	headerPrepay := abci.Header{Height: mApp.LastBlockHeight() + 1}
 	mock.SignCheckDeliver(t, mApp.Cdc, mApp.BaseApp, headerPrepay, []sdk.Msg{prepayMsg}, []uint64{20}, []uint64{0}, true, true, sdsAccPrivKey3)
 	newBalanceInt := sdsAccBal3.Sub(prepayAmt)
 	newBalanceCoin := sdk.NewCoin(DefaultDenom, newBalanceInt)
 	mock.CheckBalance(t, mApp, sdsAccAddr3, sdk.NewCoins(newBalanceCoin))
+
+	// a prepay purchasing less uoz than the min ozone out fails without moving any coins
+	headerPrepay = abci.Header{Height: mApp.LastBlockHeight() + 1}
+	ctxPrepay := mApp.BaseApp.NewContext(true, headerPrepay)
+	minOzoneOut := k.GetOzoneBalance(ctxPrepay, sdsAccAddr3)
+	prepayMsg = types.NewMsgPrepay(sdsAccAddr3, sdk.NewCoins(coinToPrepay), minOzoneOut)
+	mock.SignCheckDeliver(t, mApp.Cdc, mApp.BaseApp, headerPrepay, []sdk.Msg{prepayMsg}, []uint64{20}, []uint64{1}, false, false, sdsAccPrivKey3)
+	mock.CheckBalance(t, mApp, sdsAccAddr3, sdk.NewCoins(newBalanceCoin))
+	cacheCtx, _ := ctxPrepay.CacheContext()
+	_, err := NewHandler(k)(cacheCtx, prepayMsg)
+	require.True(t, types.ErrMinOzoneOut.Is(err))
+	require.Error(t, types.NewMsgPrepay(sdsAccAddr3, sdk.NewCoins(coinToPrepay), sdk.NewInt(-1)).ValidateBasic())
+
+	///********************* check ozone balance *********************/
+	log.Print("====== Testing ozone balance ======")
+	ctx := mApp.BaseApp.NewContext(true, abci.Header{Height: mApp.LastBlockHeight() + 1})
+	ozoneBalance := k.GetOzoneBalance(ctx, sdsAccAddr3)
+	require.True(t, ozoneBalance.IsPositive())
+	require.True(t, k.GetOzoneBalance(ctx, sdsAccAddr2).IsZero())
+	require.Equal(t, remainingOzoneLimit, k.RegisterKeeper.GetTotalOzoneSupply(ctx))
+	require.Equal(t, sdk.NewCoins(coinToPrepay), k.GetTotalPrepay(ctx))
+	_, broken := keeper.AllInvariants(k)(ctx)
+	require.False(t, broken)
+
+	// traffic is debited once the volume report is settled, up to the balance of each user
+	consumed := ozoneBalance.QuoRaw(2)
+	usersVolume := []pot.SingleUserVolume{
+		pot.NewSingleUserVolume(sdsAccAddr3, consumed),
+		pot.NewSingleUserVolume(sdsAccAddr2, consumed),
+	}
+	remaining := k.RegisterKeeper.GetRemainingOzoneLimit(ctx)
+	ctx = ctx.WithEventManager(sdk.NewEventManager())
+	require.NoError(t, k.Hooks().AfterVolumeReportSettled(ctx, sdk.OneInt(), usersVolume))
+	require.Equal(t, ozoneBalance.Sub(consumed), k.GetOzoneBalance(ctx, sdsAccAddr3))
+	require.True(t, k.GetOzoneBalance(ctx, sdsAccAddr2).IsZero())
+	// the shortfall of a user is reported without failing the report
+	var shortfalls []sdk.Event
+	for _, event := range ctx.EventManager().Events() {
+		if event.Type == types.EventTypeUozShortfall {
+			shortfalls = append(shortfalls, event)
+		}
+	}
+	require.Len(t, shortfalls, 1)
+	attributes := make(map[string]string)
+	for _, attr := range shortfalls[0].Attributes {
+		attributes[string(attr.Key)] = string(attr.Value)
+	}
+	require.Equal(t, sdsAccAddr2.String(), attributes[types.AttributeKeyUser])
+	require.Equal(t, consumed.String(), attributes[types.AttributeKeyShortfallUoz])
+	// the consumed uoz leave the total supply, the remaining ozone limit is left as is
+	require.Equal(t, remaining, k.RegisterKeeper.GetRemainingOzoneLimit(ctx))
+	require.Equal(t, remainingOzoneLimit.Sub(consumed), k.RegisterKeeper.GetTotalOzoneSupply(ctx))
+	_, broken = keeper.TotalOzoneSupplyInvariant(k)(ctx)
+	require.False(t, broken)
+
+	_, err = k.SubtractOzoneBalance(ctx, sdsAccAddr3, ozoneBalance)
+	require.Error(t, err)
+
+	///********************* file lifecycle *********************/
+	log.Print("====== Testing file lifecycle ======")
+	require.Len(t, k.GetFilesByUploader(ctx, sdsAccAddr2, 1, 10), 1)
+	require.Len(t, k.GetFilesByResourceNode(ctx, addrRes1, 1, 10), 1)
+
+	// an existing file hash is never overwritten
+	header := abci.Header{Height: mApp.LastBlockHeight() + 1}
+	spNodeAcc := mApp.AccountKeeper.GetAccount(ctx, spNodeAddrIdx1)
+	mock.SignCheckDeliver(t, mApp.Cdc, mApp.BaseApp, header, []sdk.Msg{fileUploadMsg}, []uint64{spNodeAcc.GetAccountNumber()}, []uint64{spNodeAcc.GetSequence()}, false, false, spNodePrivKeyIdx1)
+
+	// only the reporter or the uploader can update the replicas
+	updateReplicasMsg := types.NewMsgFileUpdateReplicas(fileHash, sdsAccAddr3, 3, []sdk.AccAddress{addrRes2, addrRes3})
+	header = abci.Header{Height: mApp.LastBlockHeight() + 1}
+	ctx = mApp.BaseApp.NewContext(true, header)
+	sdsAcc3 := mApp.AccountKeeper.GetAccount(ctx, sdsAccAddr3)
+	mock.SignCheckDeliver(t, mApp.Cdc, mApp.BaseApp, header, []sdk.Msg{updateReplicasMsg}, []uint64{sdsAcc3.GetAccountNumber()}, []uint64{sdsAcc3.GetSequence()}, false, false, sdsAccPrivKey3)
+
+	updateReplicasMsg = types.NewMsgFileUpdateReplicas(fileHash, sdsAccAddr2, 3, []sdk.AccAddress{addrRes2, addrRes3})
+	header = abci.Header{Height: mApp.LastBlockHeight() + 1}
+	ctx = mApp.BaseApp.NewContext(true, header)
+	sdsAcc2 := mApp.AccountKeeper.GetAccount(ctx, sdsAccAddr2)
+	mock.SignCheckDeliver(t, mApp.Cdc, mApp.BaseApp, header, []sdk.Msg{updateReplicasMsg}, []uint64{sdsAcc2.GetAccountNumber()}, []uint64{sdsAcc2.GetSequence()}, true, true, sdsAccPrivKey2)
+
+	ctx = mApp.BaseApp.NewContext(true, abci.Header{Height: mApp.LastBlockHeight() + 1})
+	fileInfo, found := k.GetFileInfo(ctx, fileHash)
+	require.True(t, found)
+	require.Equal(t, uint64(3), fileInfo.Replicas)
+	require.Empty(t, k.GetFilesByResourceNode(ctx, addrRes1, 1, 10))
+	require.Len(t, k.GetFilesByResourceNode(ctx, addrRes3, 1, 10), 1)
+
+	// the reporter deletes the file
+	fileDeleteMsg := types.NewMsgFileDelete(fileHash, spNodeAddrIdx1)
+	header = abci.Header{Height: mApp.LastBlockHeight() + 1}
+	spNodeAcc = mApp.AccountKeeper.GetAccount(ctx, spNodeAddrIdx1)
+	mock.SignCheckDeliver(t, mApp.Cdc, mApp.BaseApp, header, []sdk.Msg{fileDeleteMsg}, []uint64{spNodeAcc.GetAccountNumber()}, []uint64{spNodeAcc.GetSequence()}, true, true, spNodePrivKeyIdx1)
+
+	ctx = mApp.BaseApp.NewContext(true, abci.Header{Height: mApp.LastBlockHeight() + 1})
+	_, found = k.GetFileInfo(ctx, fileHash)
+	require.False(t, found)
+	require.Empty(t, k.GetFilesByUploader(ctx, sdsAccAddr2, 1, 10))
+	require.Empty(t, k.GetFilesByResourceNode(ctx, addrRes3, 1, 10))
+
+	// a file is removed once its expire height is reached
+	ctx = ctx.WithBlockHeight(10)
+	_, err = k.UploadFile(ctx, fileHash, spNodeAddrIdx1, sdsAccAddr2, 1024, 1, nil, 10, nil, 0)
+	require.Equal(t, types.ErrInvalidExpireHeight, err)
+	_, err = k.UploadFile(ctx, fileHash, spNodeAddrIdx1, sdsAccAddr2, 1024, 1, nil, 11, nil, 0)
+	require.NoError(t, err)
+	k.RemoveExpiredFiles(ctx)
+	_, found = k.GetFileInfo(ctx, fileHash)
+	require.True(t, found)
+	k.RemoveExpiredFiles(ctx.WithBlockHeight(11))
+	_, found = k.GetFileInfo(ctx, fileHash)
+	require.False(t, found)
+}
+
+func TestStorageChallenge(t *testing.T) {
+	mApp, k, _, registerKeeper, potKeeper := getMockApp(t)
+	accs := setupAccounts(mApp)
+	mock.SetGenesis(mApp, accs)
+
+	ctx := mApp.BaseApp.NewContext(true, abci.Header{Height: mApp.LastBlockHeight() + 1})
+	chunks := [][]byte{[]byte("chunk0"), []byte("chunk1"), []byte("chunk2"), []byte("chunk3"), []byte("chunk4")}
+	merkleRoot, proofs := merkle.SimpleProofsFromByteSlices(chunks)
+	fileHash, _ := hex.DecodeString("c03661732294feb49caf6dc16c7cbb2534986d73")
+
+	// files without a merkle root can't be challenged
+	_, err := k.UploadFile(ctx, fileHash, spNodeAddrIdx1, sdsAccAddr2, 1024, 2, []sdk.AccAddress{addrRes1}, 0, nil, 0)
+	require.NoError(t, err)
+	_, err = k.IssueStorageChallenge(ctx, fileHash, addrRes1, spNodeAddrIdx1)
+	require.Equal(t, types.ErrFileNotChallengeable, err)
+	_, err = k.DeleteFile(ctx, fileHash, spNodeAddrIdx1)
+	require.NoError(t, err)
+
+	_, err = k.UploadFile(ctx, fileHash, spNodeAddrIdx1, sdsAccAddr2, 1024, 2, []sdk.AccAddress{addrRes1}, 0,
+		merkleRoot, uint64(len(chunks)))
+	require.NoError(t, err)
+
+	_, err = k.IssueStorageChallenge(ctx, fileHash, addrRes1, sdsAccAddr2)
+	require.True(t, types.ErrNotIndexingNode.Is(err))
+	_, err = k.IssueStorageChallenge(ctx, fileHash, addrRes2, spNodeAddrIdx1)
+	require.Equal(t, types.ErrNotFileHolder, err)
+
+	challenge, err := k.IssueStorageChallenge(ctx, fileHash, addrRes1, spNodeAddrIdx1)
+	require.NoError(t, err)
+	require.True(t, challenge.ChunkIndex < uint64(len(chunks)))
+	require.Equal(t, ctx.BlockHeight()+k.StorageChallengeWindow(ctx), challenge.Deadline)
+	_, err = k.IssueStorageChallenge(ctx, fileHash, addrRes1, spNodeAddrIdx1)
+	require.Equal(t, types.ErrChallengeAlreadyOpen, err)
+	require.Len(t, k.GetStorageChallengesByResourceNode(ctx, addrRes1), 1)
+
+	// a proof of another chunk is rejected
+	wrongIndex := (challenge.ChunkIndex + 1) % uint64(len(chunks))
+	_, err = k.SubmitStorageProof(ctx, fileHash, addrRes1, chunks[wrongIndex], proofs[wrongIndex].Aunts)
+	require.True(t, types.ErrInvalidStorageProof.Is(err))
+
+	// a valid proof closes the challenge and resumes the withheld rewards
+	potKeeper.SetRewardWithheld(ctx, addrRes1)
+	_, err = k.SubmitStorageProof(ctx, fileHash, addrRes1, chunks[challenge.ChunkIndex], proofs[challenge.ChunkIndex].Aunts)
+	require.NoError(t, err)
+	require.Empty(t, k.GetStorageChallengesByResourceNode(ctx, addrRes1))
+	require.False(t, potKeeper.IsRewardWithheld(ctx, addrRes1))
+	_, err = k.SubmitStorageProof(ctx, fileHash, addrRes1, chunks[challenge.ChunkIndex], proofs[challenge.ChunkIndex].Aunts)
+	require.Equal(t, types.ErrChallengeNotFound, err)
+
+	// with a quorum of one SP node, an unanswered challenge suspends the node and withholds its rewards
+	// once the deadline is reached
+	params := k.GetParams(ctx)
+	params.StorageChallengeQuorum = 1
+	k.SetParams(ctx, params)
+	challenge, err = k.IssueStorageChallenge(ctx, fileHash, addrRes1, spNodeAddrIdx1)
+	require.NoError(t, err)
+	k.ProcessExpiredStorageChallenges(ctx.WithBlockHeight(challenge.Deadline - 1))
+	require.Len(t, k.GetStorageChallengesByResourceNode(ctx, addrRes1), 1)
+	k.ProcessExpiredStorageChallenges(ctx.WithBlockHeight(challenge.Deadline))
+	require.Empty(t, k.GetStorageChallengesByResourceNode(ctx, addrRes1))
+	resourceNode, found := registerKeeper.GetResourceNode(ctx, addrRes1)
+	require.True(t, found)
+	require.True(t, resourceNode.IsSuspended())
+	require.True(t, potKeeper.IsRewardWithheld(ctx, addrRes1))
+
+	// the suspended node can still be challenged and resumes its rewards by passing the challenge
+	challenge, err = k.IssueStorageChallenge(ctx, fileHash, addrRes1, spNodeAddrIdx1)
+	require.NoError(t, err)
+	_, err = k.SubmitStorageProof(ctx, fileHash, addrRes1, chunks[challenge.ChunkIndex], proofs[challenge.ChunkIndex].Aunts)
+	require.NoError(t, err)
+	require.False(t, potKeeper.IsRewardWithheld(ctx, addrRes1))
+	resourceNode, found = registerKeeper.GetResourceNode(ctx, addrRes1)
+	require.True(t, found)
+	require.True(t, resourceNode.IsSuspended())
+}
+
+func TestRedeemOzone(t *testing.T) {
+	mApp, k, _, registerKeeper, potKeeper := getMockApp(t)
+	accs := setupAccounts(mApp)
+	mock.SetGenesis(mApp, accs)
+
+	prepayTime := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	ctx := mApp.BaseApp.NewContext(true, abci.Header{Height: mApp.LastBlockHeight() + 1, Time: prepayTime})
+	k.SetParams(ctx, types.NewParams(time.Hour, sdk.ZeroDec(), []string{DefaultDenom}, 0, types.DefaultStorageChallengeWindow,
+		types.DefaultStorageChallengeQuorum))
+
+	purchased, err := k.Prepay(ctx, sdsAccAddr3, sdk.NewCoins(sdk.NewCoin(DefaultDenom, prepayAmt)))
+	require.NoError(t, err)
+	remainingOzoneLimit := registerKeeper.GetRemainingOzoneLimit(ctx)
+	totalOzoneSupply := registerKeeper.GetTotalOzoneSupply(ctx)
+	unissuedPrepay := potKeeper.GetTotalUnissuedPrepay(ctx)
+
+	// ozone can't be redeemed during the cooldown
+	_, _, err = k.RedeemOzone(ctx, sdsAccAddr3, purchased)
+	require.True(t, types.ErrRedeemCooldown.Is(err))
+	ctx = ctx.WithBlockTime(prepayTime.Add(time.Hour))
+	_, _, err = k.RedeemOzone(ctx, sdsAccAddr3, purchased.AddRaw(1))
+	require.True(t, types.ErrInsufficientOzoneBalance.Is(err))
+	_, _, err = k.RedeemOzone(ctx, sdsAccAddr2, purchased)
+	require.True(t, types.ErrInsufficientOzoneBalance.Is(err))
+
+	// the uoz that open volume reports will consume can't be redeemed, the competing reports of an epoch
+	// reserve the most any of them consumes and expired reports reserve nothing
+	epoch := sdk.NewInt(1)
+	pending := purchased.QuoRaw(4)
+	expireTime := ctx.BlockHeader().Time.Add(time.Hour)
+	potKeeper.SetVolumeReportProposal(ctx, pottypes.NewVolumeReportProposal(epoch, nil,
+		[]pottypes.SingleUserVolume{pottypes.NewSingleUserVolume(sdsAccAddr3, pending)}, spNodeAddrIdx1, "ref1", "", expireTime))
+	potKeeper.SetVolumeReportProposal(ctx, pottypes.NewVolumeReportProposal(epoch, nil,
+		[]pottypes.SingleUserVolume{pottypes.NewSingleUserVolume(sdsAccAddr3, pending.QuoRaw(2))}, spNodeAddrIdx1, "ref2", "", expireTime))
+	potKeeper.SetVolumeReportProposal(ctx, pottypes.NewVolumeReportProposal(epoch.AddRaw(1), nil,
+		[]pottypes.SingleUserVolume{pottypes.NewSingleUserVolume(sdsAccAddr3, pending)}, spNodeAddrIdx1, "ref3", "",
+		ctx.BlockHeader().Time.Add(-time.Second)))
+	require.Equal(t, pending, k.GetPendingOzoneConsumption(ctx, sdsAccAddr3))
+	require.True(t, k.GetPendingOzoneConsumption(ctx, sdsAccAddr2).IsZero())
+	_, _, err = k.RedeemOzone(ctx, sdsAccAddr3, purchased.Sub(pending).AddRaw(1))
+	require.True(t, types.ErrOzonePendingConsumption.Is(err))
+	_, _, err = k.CheckRedeemOzone(ctx, sdsAccAddr3, purchased.Sub(pending))
+	require.NoError(t, err)
+	potKeeper.DeleteVolumeReportProposal(ctx, epoch, "ref1")
+	potKeeper.DeleteVolumeReportProposal(ctx, epoch, "ref2")
+	potKeeper.DeleteVolumeReportProposal(ctx, epoch.AddRaw(1), "ref3")
+
+	// redeeming all the uoz right after the purchase pays back at most the prepaid ustos
+	bankBalance := k.BankKeeper.GetCoins(ctx, sdsAccAddr3).AmountOf(DefaultDenom)
+	payout, fee, err := k.RedeemOzone(ctx, sdsAccAddr3, purchased)
+	require.NoError(t, err)
+	require.True(t, fee.IsZero())
+	require.True(t, payout.IsPositive())
+	require.True(t, payout.LTE(prepayAmt))
+	require.True(t, prepayAmt.Sub(payout).LT(prepayAmt.QuoRaw(1000)))
+
+	require.True(t, k.GetOzoneBalance(ctx, sdsAccAddr3).IsZero())
+	require.Equal(t, remainingOzoneLimit.Add(purchased), registerKeeper.GetRemainingOzoneLimit(ctx))
+	require.Equal(t, totalOzoneSupply, registerKeeper.GetTotalOzoneSupply(ctx))
+	require.Equal(t, unissuedPrepay.Sub(payout), potKeeper.GetTotalUnissuedPrepay(ctx))
+	require.Equal(t, bankBalance.Add(payout), k.BankKeeper.GetCoins(ctx, sdsAccAddr3).AmountOf(DefaultDenom))
+	require.Equal(t, prepayAmt.Sub(payout), k.GetPrepayBalance(ctx, sdsAccAddr3, DefaultDenom))
+	_, broken := keeper.AllInvariants(k)(ctx)
+	require.False(t, broken)
+
+	// the fee stays in the unissued prepay pool
+	k.SetParams(ctx, types.NewParams(0, sdk.NewDecWithPrec(1, 1), []string{DefaultDenom}, 0, types.DefaultStorageChallengeWindow,
+		types.DefaultStorageChallengeQuorum))
+	purchased, err = k.Prepay(ctx, sdsAccAddr3, sdk.NewCoins(sdk.NewCoin(DefaultDenom, prepayAmt)))
+	require.NoError(t, err)
+	unissuedPrepay = potKeeper.GetTotalUnissuedPrepay(ctx)
+	payout, fee, err = k.RedeemOzone(ctx, sdsAccAddr3, purchased)
+	require.NoError(t, err)
+	require.True(t, fee.IsPositive())
+	require.True(t, payout.Add(fee).LTE(prepayAmt))
+	require.Equal(t, unissuedPrepay.Sub(payout), potKeeper.GetTotalUnissuedPrepay(ctx))
+	_, broken = keeper.AllInvariants(k)(ctx)
+	require.False(t, broken)
+
+	// a redemption never pays out more than the sender prepaid
+	k.AddOzoneBalance(ctx, sdsAccAddr2, purchased)
+	registerKeeper.SetRemainingOzoneLimit(ctx, registerKeeper.GetRemainingOzoneLimit(ctx).Sub(purchased))
+	_, _, err = k.RedeemOzone(ctx, sdsAccAddr2, purchased)
+	require.True(t, types.ErrInsufficientPrepay.Is(err))
+}
+
+func TestPrepayDenoms(t *testing.T) {
+	mApp, k, _, _, _ := getMockApp(t)
+	accs := setupAccounts(mApp)
+	mock.SetGenesis(mApp, accs)
+
+	ctx := mApp.BaseApp.NewContext(true, abci.Header{Height: mApp.LastBlockHeight() + 1})
+	otherCoin := sdk.NewCoin("uother", prepayAmt)
+	_, err := k.BankKeeper.AddCoins(ctx, sdsAccAddr3, sdk.NewCoins(otherCoin.Add(otherCoin)))
+	require.NoError(t, err)
+
+	// only the prepay denoms of the params are accepted
+	require.Error(t, types.NewMsgPrepay(sdsAccAddr3, sdk.Coins{sdk.NewCoin(DefaultDenom, sdk.ZeroInt())}, sdk.ZeroInt()).ValidateBasic())
+	_, err = k.Prepay(ctx, sdsAccAddr3, sdk.NewCoins(otherCoin))
+	require.True(t, types.ErrUnsupportedPrepayDenom.Is(err))
+	require.True(t, k.GetPrepay(ctx, sdsAccAddr3).Empty())
+
+	// only the bond denom has a uoz price, a prepay holding any other denom is rejected before any transfer
+	k.SetParams(ctx, types.NewParams(0, sdk.ZeroDec(), []string{DefaultDenom, otherCoin.Denom}, 0, types.DefaultStorageChallengeWindow,
+		types.DefaultStorageChallengeQuorum))
+	bankCoins := k.BankKeeper.GetCoins(ctx, sdsAccAddr3)
+	coins := sdk.NewCoins(sdk.NewCoin(DefaultDenom, prepayAmt), otherCoin)
+	_, err = k.Prepay(ctx, sdsAccAddr3, coins)
+	require.True(t, types.ErrUnpricedPrepayDenom.Is(err))
+	_, err = k.Prepay(ctx, sdsAccAddr3, sdk.NewCoins(otherCoin))
+	require.True(t, types.ErrUnpricedPrepayDenom.Is(err))
+	require.Equal(t, bankCoins, k.BankKeeper.GetCoins(ctx, sdsAccAddr3))
+	require.True(t, k.GetPrepay(ctx, sdsAccAddr3).Empty())
+	require.True(t, k.GetTotalPrepay(ctx).Empty())
+
+	// the balances are kept per denom
+	bondCoins := sdk.NewCoins(sdk.NewCoin(DefaultDenom, prepayAmt))
+	purchased, err := k.Prepay(ctx, sdsAccAddr3, bondCoins)
+	require.NoError(t, err)
+	require.True(t, purchased.IsPositive())
+	require.Equal(t, bondCoins, k.GetPrepay(ctx, sdsAccAddr3))
+	require.Equal(t, bondCoins, k.GetTotalPrepay(ctx))
+	_, broken := keeper.AllInvariants(k)(ctx)
+	require.False(t, broken)
+
+	// the prepay denoms must include the bond denom
+	require.NoError(t, k.ValidatePrepayDenoms(ctx, []string{DefaultDenom, otherCoin.Denom}))
+	require.True(t, types.ErrMissingBondPrepayDenom.Is(k.ValidatePrepayDenoms(ctx, []string{otherCoin.Denom})))
+
+	// a genesis can't hold a balance of a denom that is no longer accepted
+	k.SetPrepayBalance(ctx, sdsAccAddr3, otherCoin.Denom, prepayAmt)
+	genesis := ExportGenesis(ctx, k)
+	require.NoError(t, types.ValidateGenesis(genesis))
+	genesis.Params.PrepayDenoms = []string{DefaultDenom}
+	require.Error(t, types.ValidateGenesis(genesis))
+}
+
+func TestUozPriceHistory(t *testing.T) {
+	mApp, k, _, _, _ := getMockApp(t)
+	accs := setupAccounts(mApp)
+	mock.SetGenesis(mApp, accs)
+
+	ctx := mApp.BaseApp.NewContext(true, abci.Header{Height: 10})
+	k.SetParams(ctx, types.NewParams(0, sdk.ZeroDec(), []string{DefaultDenom}, 20, types.DefaultStorageChallengeWindow,
+		types.DefaultStorageChallengeQuorum))
+	prepay := func(height int64) sdk.Dec {
+		ctx = ctx.WithBlockHeight(height)
+		_, err := k.Prepay(ctx, sdsAccAddr3, sdk.NewCoins(sdk.NewCoin(DefaultDenom, prepayAmt)))
+		require.NoError(t, err)
+		k.RecordOzonePrice(ctx)
+		price, found := k.GetOzonePrice(ctx, height)
+		require.True(t, found)
+		require.Equal(t, height, price.Height)
+		return price.Price
+	}
+
+	// a price is only recorded at the heights it changed at
+	k.RecordOzonePrice(ctx)
+	price10, found := k.GetOzonePrice(ctx, 10)
+	require.True(t, found)
+	require.True(t, price10.Price.IsPositive())
+	ctx = ctx.WithBlockHeight(11)
+	k.RecordOzonePrice(ctx)
+	price12 := prepay(12)
+	price15 := prepay(15)
+	require.True(t, price12.LT(price10.Price))
+	require.True(t, price15.LT(price12))
+
+	history := k.GetOzonePriceHistory(ctx, types.NewQueryUozPriceHistoryParams(1, 0, 0, 0))
+	require.Equal(t, []types.OzonePrice{price10, types.NewOzonePrice(12, price12), types.NewOzonePrice(15, price15)}, history)
+	history = k.GetOzonePriceHistory(ctx, types.NewQueryUozPriceHistoryParams(2, 2, 0, 0))
+	require.Equal(t, []types.OzonePrice{types.NewOzonePrice(15, price15)}, history)
+	history = k.GetOzonePriceHistory(ctx, types.NewQueryUozPriceHistoryParams(1, 0, 11, 14))
+	require.Equal(t, []types.OzonePrice{types.NewOzonePrice(12, price12)}, history)
+
+	// each block weighs the price in effect at its end
+	twap, err := k.GetOzonePriceTwap(ctx, 10, 15)
+	require.NoError(t, err)
+	require.Equal(t, price10.Price.MulInt64(2).Add(price12.MulInt64(3)).Add(price15).QuoInt64(6), twap)
+	twap, err = k.GetOzonePriceTwap(ctx, 13, 14)
+	require.NoError(t, err)
+	require.Equal(t, price12, twap)
+	_, err = k.GetOzonePriceTwap(ctx, 9, 15)
+	require.True(t, types.ErrOzonePriceNotFound.Is(err))
+	_, err = k.GetOzonePriceTwap(ctx, 15, 16)
+	require.True(t, types.ErrInvalidHeightRange.Is(err))
+	_, err = k.GetOzonePriceTwap(ctx, 12, 11)
+	require.True(t, types.ErrInvalidHeightRange.Is(err))
+
+	// the records older than the history are pruned, except the one still in effect at its start
+	ctx = ctx.WithBlockHeight(35)
+	k.RecordOzonePrice(ctx)
+	history = k.GetOzonePriceHistory(ctx, types.NewQueryUozPriceHistoryParams(1, 0, 0, 0))
+	require.Equal(t, []types.OzonePrice{types.NewOzonePrice(15, price15)}, history)
+	twap, err = k.GetOzonePriceTwap(ctx, 15, 35)
+	require.NoError(t, err)
+	require.Equal(t, price15, twap)
+	_, err = k.GetOzonePriceTwap(ctx, 14, 35)
+	require.True(t, types.ErrOzonePriceNotFound.Is(err))
+}
+
+func TestExportImportGenesis(t *testing.T) {
+	mApp, k, _, _, potKeeper := getMockApp(t)
+	accs := setupAccounts(mApp)
+	mock.SetGenesis(mApp, accs)
+
+	ctx := mApp.BaseApp.NewContext(true, abci.Header{Height: mApp.LastBlockHeight() + 1})
+	chunks := [][]byte{[]byte("chunk0"), []byte("chunk1"), []byte("chunk2")}
+	merkleRoot, _ := merkle.SimpleProofsFromByteSlices(chunks)
+	fileHash, _ := hex.DecodeString(testFileHashHex)
+
+	_, err := k.Prepay(ctx, sdsAccAddr3, sdk.NewCoins(sdk.NewCoin(DefaultDenom, prepayAmt)))
+	require.NoError(t, err)
+	_, err = k.UploadFile(ctx, fileHash, spNodeAddrIdx1, sdsAccAddr2, 1024, 2, []sdk.AccAddress{addrRes1}, ctx.BlockHeight()+1000,
+		merkleRoot, uint64(len(chunks)))
+	require.NoError(t, err)
+	_, err = k.IssueStorageChallenge(ctx, fileHash, addrRes1, spNodeAddrIdx1)
+	require.NoError(t, err)
+	potKeeper.SetRewardWithheld(ctx, addrRes2)
+	k.SetStorageChallengeFailure(ctx, types.NewStorageChallengeFailure(addrRes2, spNodeAddrIdx1, fileHash))
+
+	sdsGenesis := ExportGenesis(ctx, k)
+	potGenesis := pot.ExportGenesis(ctx, potKeeper)
+	require.NoError(t, types.ValidateGenesis(sdsGenesis))
+	require.NoError(t, pottypes.ValidateGenesis(potGenesis))
+	require.Len(t, sdsGenesis.Prepays, 1)
+	require.Len(t, sdsGenesis.OzoneBalances, 1)
+	require.Len(t, sdsGenesis.Files, 1)
+	require.Len(t, sdsGenesis.StorageChallenges, 1)
+	require.Len(t, sdsGenesis.ChallengeFailures, 1)
+	require.Equal(t, []sdk.AccAddress{addrRes2}, potGenesis.RewardsWithheld)
+
+	// a challenge on a file left out of the genesis is rejected
+	invalidGenesis := types.NewGenesisState(sdsGenesis.Params, sdsGenesis.Prepays, sdsGenesis.OzoneBalances, nil, sdsGenesis.StorageChallenges,
+		sdsGenesis.ChallengeFailures)
+	require.True(t, types.ErrFileNotFound.Is(types.ValidateGenesis(invalidGenesis)))
+	invalidGenesis = types.NewGenesisState(types.NewParams(0, sdk.OneDec(), []string{DefaultDenom}, 0, types.DefaultStorageChallengeWindow,
+		types.DefaultStorageChallengeQuorum), sdsGenesis.Prepays, sdsGenesis.OzoneBalances,
+		sdsGenesis.Files, sdsGenesis.StorageChallenges, sdsGenesis.ChallengeFailures)
+	require.Error(t, types.ValidateGenesis(invalidGenesis))
+
+	// import into a new chain and export it again
+	newApp, newK, _, _, newPotKeeper := getMockApp(t)
+	mock.SetGenesis(newApp, accs)
+	newCtx := newApp.BaseApp.NewContext(true, abci.Header{Height: ctx.BlockHeight()})
+	// the pot token pools are imported with the genesis accounts
+	for _, poolName := range []string{pot.TotalRewardPoolName, pot.TotalUnissuedPrepayPoolName} {
+		pool := newPotKeeper.GetTokenPool(newCtx, poolName)
+		require.NoError(t, pool.SetCoins(potKeeper.GetTokenPool(ctx, poolName).GetCoins()))
+		newPotKeeper.SetTokenPool(newCtx, pool)
+	}
+	pot.InitGenesis(newCtx, newPotKeeper, potGenesis)
+	InitGenesis(newCtx, newK, sdsGenesis)
+
+	require.Equal(t, sdsGenesis, ExportGenesis(newCtx, newK))
+	require.Equal(t, potGenesis, pot.ExportGenesis(newCtx, newPotKeeper))
+	require.Len(t, newK.GetFilesByResourceNode(newCtx, addrRes1, 1, 0), 1)
+	require.Len(t, newK.GetStorageChallengesByResourceNode(newCtx, addrRes1), 1)
+	require.Len(t, newK.GetStorageChallengeFailures(newCtx, addrRes2), 1)
+	_, broken := keeper.AllInvariants(newK)(newCtx)
+	require.False(t, broken)
 }
 
 func getMockApp(t *testing.T) (*mock.App, Keeper, bank.Keeper, register.Keeper, pot.Keeper) {
@@ -77,15 +531,21 @@ func getMockApp(t *testing.T) (*mock.App, Keeper, bank.Keeper, register.Keeper,
 
 	bankKeeper := bank.NewBaseKeeper(mApp.AccountKeeper, mApp.ParamsKeeper.Subspace(bank.DefaultParamspace), blacklistedAddrs)
 	maccPerms := map[string][]string{
-		auth.FeeCollectorName:     {"fee_collector"},
-		staking.NotBondedPoolName: {supply.Burner, supply.Staking},
-		staking.BondedPoolName:    {supply.Burner, supply.Staking},
+		auth.FeeCollectorName:                  {"fee_collector"},
+		staking.NotBondedPoolName:              {supply.Burner, supply.Staking},
+		staking.BondedPoolName:                 {supply.Burner, supply.Staking},
+		pot.TotalRewardPoolName:                nil,
+		pot.TotalUnissuedPrepayPoolName:        nil,
+		register.ResourceNodeBondedPoolName:    nil,
+		register.ResourceNodeNotBondedPoolName: {supply.Burner},
+		register.IndexingNodeBondedPoolName:    nil,
+		register.IndexingNodeNotBondedPoolName: {supply.Burner},
 	}
 	supplyKeeper := supply.NewKeeper(mApp.Cdc, keySupply, mApp.AccountKeeper, bankKeeper, maccPerms)
 	stakingKeeper := staking.NewKeeper(mApp.Cdc, keyStaking, supplyKeeper, mApp.ParamsKeeper.Subspace(staking.DefaultParamspace))
-	registerKeeper := register.NewKeeper(mApp.Cdc, keyRegister, mApp.ParamsKeeper.Subspace(register.DefaultParamSpace), mApp.AccountKeeper, bankKeeper)
-	potKeeper := pot.NewKeeper(mApp.Cdc, keyPot, mApp.ParamsKeeper.Subspace(DefaultParamSpace), auth.FeeCollectorName, bankKeeper, supplyKeeper, mApp.AccountKeeper, stakingKeeper, registerKeeper)
-	keeper := NewKeeper(mApp.Cdc, keySds, bankKeeper, registerKeeper, potKeeper)
+	registerKeeper := register.NewKeeper(mApp.Cdc, keyRegister, mApp.ParamsKeeper.Subspace(register.DefaultParamSpace), mApp.AccountKeeper, bankKeeper, supplyKeeper)
+	potKeeper := pot.NewKeeper(mApp.Cdc, keyPot, mApp.ParamsKeeper.Subspace(pot.DefaultParamSpace), auth.FeeCollectorName, bankKeeper, supplyKeeper, mApp.AccountKeeper, stakingKeeper, registerKeeper)
+	keeper := NewKeeper(mApp.Cdc, keySds, mApp.ParamsKeeper.Subspace(DefaultParamSpace), bankKeeper, supplyKeeper, registerKeeper, potKeeper)
 
 	mApp.Router().AddRoute(staking.RouterKey, staking.NewHandler(stakingKeeper))
 	mApp.Router().AddRoute(RouterKey, NewHandler(keeper))
@@ -150,11 +610,15 @@ func getInitChainer(mapp *mock.App, keeper Keeper, accountKeeper auth.AccountKee
 
 		//preset
 		registerKeeper.SetRemainingOzoneLimit(ctx, remainingOzoneLimit)
+		registerKeeper.SetTotalOzoneSupply(ctx, remainingOzoneLimit)
 		potKeeper.SetTotalUnissuedPrepay(ctx, totalUnissuedPrepay)
 
 		//pot genesis data load
 		pot.InitGenesis(ctx, potKeeper, pot.NewGenesisState(pottypes.DefaultParams(), initialOzonePrice))
 
+		//sds genesis data load
+		InitGenesis(ctx, keeper, types.DefaultGenesisState())
+
 		// init bank genesis
 		keeper.BankKeeper.SetSendEnabled(ctx, true)
 
@@ -1,6 +1,16 @@
 package cli
 
 const (
-	FlagFileHash = "file-hash"
-	FlagUploader = "uploader"
+	FlagFileHash      = "file-hash"
+	FlagUploader      = "uploader"
+	FlagFileSize      = "file-size"
+	FlagReplicas      = "replicas"
+	FlagResourceNodes = "resource-nodes"
+	FlagExpireHeight  = "expire-height"
+	FlagMerkleRoot    = "merkle-root"
+	FlagChunkCount    = "chunk-count"
+	FlagAunts         = "aunts"
+	FlagStartHeight   = "start-height"
+	FlagEndHeight     = "end-height"
+	FlagMinOzoneOut   = "min-ozone-out"
 )
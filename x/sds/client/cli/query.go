@@ -5,11 +5,13 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/version"
 	"github.com/stratosnet/stratos-chain/x/sds/client/common"
+	"strconv"
 	"strings"
 
 	// "strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
@@ -31,14 +33,41 @@ func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
 
 	sdsQueryCmd.AddCommand(
 		flags.GetCommands(
+			GetCmdQueryParams(queryRoute, cdc),
 			GetCmdQueryUploadedFile(queryRoute, cdc),
 			GetCmdQueryPrepayBalance(queryRoute, cdc),
+			GetCmdQueryOzoneBalance(queryRoute, cdc),
+			GetCmdQueryUozPriceHistory(queryRoute, cdc),
+			GetCmdQueryUozPriceTwap(queryRoute, cdc),
+			GetCmdQueryFilesByUploader(queryRoute, cdc),
+			GetCmdQueryFilesByResourceNode(queryRoute, cdc),
+			GetCmdQueryStorageChallenges(queryRoute, cdc),
 		)...,
 	)
 
 	return sdsQueryCmd
 }
 
+// GetCmdQueryParams implements the query params command.
+func GetCmdQueryParams(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "params",
+		Args:  cobra.NoArgs,
+		Short: "Query the current sds parameters",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			resp, _, err := common.QueryParams(cliCtx, queryRoute)
+			if err != nil {
+				return err
+			}
+			var params types.Params
+			cdc.MustUnmarshalJSON(resp, &params)
+			return cliCtx.PrintOutput(params)
+		},
+	}
+}
+
 // GetCmdQueryUploadedFile implements the query uploaded file command.
 func GetCmdQueryUploadedFile(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
@@ -91,12 +120,212 @@ $ %s query sds prepay st1yx3kkx9jnqeck59j744nc5qgtv4lt4dc45jcwz
 			if err != nil {
 				return err
 			}
-			var prepaidBalance sdk.Int
-			error := prepaidBalance.UnmarshalJSON(resp)
-			if error != nil {
-				return error
+			var prepaidBalance sdk.Coins
+			cdc.MustUnmarshalJSON(resp, &prepaidBalance)
+			return cliCtx.PrintOutput(prepaidBalance)
+		},
+	}
+}
+
+// GetCmdQueryOzoneBalance implements the query ozone balance command.
+func GetCmdQueryOzoneBalance(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "ozone-balance [acct_addr]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Query uoz owned by an account",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query uoz purchased by an account and not consumed yet.
+
+Example:
+$ %s query sds ozone-balance st1yx3kkx9jnqeck59j744nc5qgtv4lt4dc45jcwz
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			// query ozone balance
+			resp, _, err := common.QueryOzoneBalance(cliCtx, queryRoute, args[0])
+			if err != nil {
+				return err
+			}
+			var ozoneBalance sdk.Int
+			err = ozoneBalance.UnmarshalJSON(resp)
+			if err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(ozoneBalance.String())
+		},
+	}
+}
+
+// GetCmdQueryUozPriceHistory implements the query uoz price history command.
+func GetCmdQueryUozPriceHistory(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "uoz-price-history",
+		Args:  cobra.NoArgs,
+		Short: "Query the uoz prices recorded over a height range",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the uoz prices recorded over a height range, with pagination.
+A price is recorded at the end of the blocks it changed in and holds until the next record.
+
+Example:
+$ %s query sds uoz-price-history --start-height=1000 --end-height=2000 --page=1 --limit=20
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			resp, _, err := common.QueryUozPriceHistory(cliCtx, queryRoute, viper.GetInt(flags.FlagPage), viper.GetInt(flags.FlagLimit),
+				viper.GetInt64(FlagStartHeight), viper.GetInt64(FlagEndHeight))
+			if err != nil {
+				return err
+			}
+			var prices []types.OzonePrice
+			cdc.MustUnmarshalJSON(resp, &prices)
+			return cliCtx.PrintOutput(prices)
+		},
+	}
+	cmd.Flags().Int64(FlagStartHeight, 0, "the first height of the range, from the first recorded price if 0")
+	cmd.Flags().Int64(FlagEndHeight, 0, "the last height of the range, to the latest block if 0")
+	cmd.Flags().Int(flags.FlagPage, 1, "pagination page of prices to query for")
+	cmd.Flags().Int(flags.FlagLimit, 100, "pagination limit of prices to query for")
+	return cmd
+}
+
+// GetCmdQueryUozPriceTwap implements the query time weighted average uoz price command.
+func GetCmdQueryUozPriceTwap(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "uoz-price-twap [start_height] [end_height]",
+		Args:  cobra.ExactArgs(2),
+		Short: "Query the time weighted average uoz price over a height range",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the uoz price averaged over the blocks from start_height to end_height, both included.
+
+Example:
+$ %s query sds uoz-price-twap 1000 2000
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			startHeight, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+			endHeight, err := strconv.ParseInt(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+			resp, _, err := common.QueryUozPriceTwap(cliCtx, queryRoute, startHeight, endHeight)
+			if err != nil {
+				return err
+			}
+			var twap sdk.Dec
+			if err := twap.UnmarshalJSON(resp); err != nil {
+				return err
+			}
+			return cliCtx.PrintOutput(twap.String())
+		},
+	}
+}
+
+// GetCmdQueryFilesByUploader implements the query files by uploader command.
+func GetCmdQueryFilesByUploader(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "files-by-uploader [uploader_addr]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Query files uploaded by an account",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query files uploaded by an account, with pagination.
+
+Example:
+$ %s query sds files-by-uploader st1yx3kkx9jnqeck59j744nc5qgtv4lt4dc45jcwz --page=1 --limit=20
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			resp, _, err := common.QueryFiles(cliCtx, queryRoute, types.QueryFilesByUploader, args[0],
+				viper.GetInt(flags.FlagPage), viper.GetInt(flags.FlagLimit))
+			if err != nil {
+				return err
+			}
+			var files []types.FileRecord
+			cdc.MustUnmarshalJSON(resp, &files)
+			return cliCtx.PrintOutput(files)
+		},
+	}
+	cmd.Flags().Int(flags.FlagPage, 1, "pagination page of files to query for")
+	cmd.Flags().Int(flags.FlagLimit, 100, "pagination limit of files to query for")
+	return cmd
+}
+
+// GetCmdQueryFilesByResourceNode implements the query files by resource node command.
+func GetCmdQueryFilesByResourceNode(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "files-by-resource-node [node_addr]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Query files held by a resource node",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query files held by a resource node, with pagination.
+
+Example:
+$ %s query sds files-by-resource-node st1yx3kkx9jnqeck59j744nc5qgtv4lt4dc45jcwz --page=1 --limit=20
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			resp, _, err := common.QueryFiles(cliCtx, queryRoute, types.QueryFilesByResourceNode, args[0],
+				viper.GetInt(flags.FlagPage), viper.GetInt(flags.FlagLimit))
+			if err != nil {
+				return err
+			}
+			var files []types.FileRecord
+			cdc.MustUnmarshalJSON(resp, &files)
+			return cliCtx.PrintOutput(files)
+		},
+	}
+	cmd.Flags().Int(flags.FlagPage, 1, "pagination page of files to query for")
+	cmd.Flags().Int(flags.FlagLimit, 100, "pagination limit of files to query for")
+	return cmd
+}
+
+// GetCmdQueryStorageChallenges implements the query storage challenges command.
+func GetCmdQueryStorageChallenges(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "storage-challenges [node_addr]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Query the open storage challenges of a resource node",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the storage challenges a resource node has to answer before their deadline.
+
+Example:
+$ %s query sds storage-challenges st1yx3kkx9jnqeck59j744nc5qgtv4lt4dc45jcwz
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			resp, _, err := common.QueryStorageChallenges(cliCtx, queryRoute, args[0])
+			if err != nil {
+				return err
 			}
-			return cliCtx.PrintOutput(prepaidBalance.String())
+			var challenges []types.StorageChallenge
+			cdc.MustUnmarshalJSON(resp, &challenges)
+			return cliCtx.PrintOutput(challenges)
 		},
 	}
 }
@@ -9,6 +9,9 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"io/ioutil"
+	"strconv"
+	"strings"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
@@ -29,7 +32,12 @@ func GetTxCmd(cdc *codec.Codec) *cobra.Command {
 
 	sdsTxCmd.AddCommand(
 		FileUploadTxCmd(cdc),
+		FileDeleteTxCmd(cdc),
+		FileUpdateReplicasTxCmd(cdc),
+		StorageChallengeTxCmd(cdc),
+		StorageProofTxCmd(cdc),
 		PrepayTxCmd(cdc),
+		RedeemOzoneTxCmd(cdc),
 	)
 	return sdsTxCmd
 }
@@ -55,8 +63,20 @@ func FileUploadTxCmd(cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
+			resourceNodes, err := parseResourceNodes(viper.GetString(FlagResourceNodes))
+			if err != nil {
+				return err
+			}
+
+			merkleRoot, err := hex.DecodeString(viper.GetString(FlagMerkleRoot))
+			if err != nil {
+				return err
+			}
+
 			// build and sign the transaction, then broadcast to Tendermint
-			msg := types.NewMsgUpload(fileHash, cliCtx.GetFromAddress(), uploader)
+			msg := types.NewMsgUpload(fileHash, cliCtx.GetFromAddress(), uploader, viper.GetUint64(FlagFileSize),
+				viper.GetUint64(FlagReplicas), resourceNodes, viper.GetInt64(FlagExpireHeight), merkleRoot,
+				viper.GetUint64(FlagChunkCount))
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
@@ -64,14 +84,193 @@ func FileUploadTxCmd(cdc *codec.Codec) *cobra.Command {
 	//cmd.Flags().String(flags.FlagFrom, "", "from address")
 	cmd.Flags().String(FlagFileHash, "", "Hash of uploaded file")
 	cmd.Flags().String(FlagUploader, "", "Uploader of file")
+	cmd.Flags().Uint64(FlagFileSize, 0, "Size of file in bytes")
+	cmd.Flags().Uint64(FlagReplicas, 0, "Number of replicas to keep")
+	cmd.Flags().String(FlagResourceNodes, "", "Comma separated addresses of the resource nodes holding a replica")
+	cmd.Flags().Int64(FlagExpireHeight, 0, "Height the file is removed at, 0 if it never expires")
+	cmd.Flags().String(FlagMerkleRoot, "", "Hex encoded merkle root of the file chunks, required to challenge the resource nodes")
+	cmd.Flags().Uint64(FlagChunkCount, 0, "Number of chunks the merkle root is built over")
 
 	cmd.MarkFlagRequired(flags.FlagFrom)
 	cmd.MarkFlagRequired(FlagFileHash)
 	cmd.MarkFlagRequired(FlagUploader)
+	cmd.MarkFlagRequired(FlagFileSize)
+	cmd.MarkFlagRequired(FlagReplicas)
+
+	return cmd
+}
+
+// FileDeleteTxCmd will create a file delete tx and sign it with the given key.
+func FileDeleteTxCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete [file_hash]",
+		Short: "Create and sign a file delete tx, sent by the reporter or the uploader of the file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			fileHash, err := hex.DecodeString(args[0])
+			if err != nil {
+				return err
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			msg := types.NewMsgFileDelete(fileHash, cliCtx.GetFromAddress())
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd = flags.PostCommands(cmd)[0]
+	cmd.MarkFlagRequired(flags.FlagFrom)
+
+	return cmd
+}
+
+// FileUpdateReplicasTxCmd will create a tx updating the replicas of a file and sign it with the given key.
+func FileUpdateReplicasTxCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update-replicas [file_hash] [replicas]",
+		Short: "Create and sign a tx updating the replicas of a file, sent by the reporter or the uploader of the file",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			fileHash, err := hex.DecodeString(args[0])
+			if err != nil {
+				return err
+			}
+
+			replicas, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			resourceNodes, err := parseResourceNodes(viper.GetString(FlagResourceNodes))
+			if err != nil {
+				return err
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			msg := types.NewMsgFileUpdateReplicas(fileHash, cliCtx.GetFromAddress(), replicas, resourceNodes)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd = flags.PostCommands(cmd)[0]
+	cmd.Flags().String(FlagResourceNodes, "", "Comma separated addresses of the resource nodes holding a replica")
+
+	cmd.MarkFlagRequired(flags.FlagFrom)
+
+	return cmd
+}
+
+// StorageChallengeTxCmd will create a storage challenge tx and sign it with the given key.
+func StorageChallengeTxCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "storage-challenge [file_hash] [node_addr]",
+		Short: "Create and sign a tx challenging a resource node to prove it stores a file, sent by an SP node",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			fileHash, err := hex.DecodeString(args[0])
+			if err != nil {
+				return err
+			}
+
+			resourceNode, err := sdk.AccAddressFromBech32(args[1])
+			if err != nil {
+				return err
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			msg := types.NewMsgStorageChallenge(fileHash, resourceNode, cliCtx.GetFromAddress())
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd = flags.PostCommands(cmd)[0]
+	cmd.MarkFlagRequired(flags.FlagFrom)
 
 	return cmd
 }
 
+// StorageProofTxCmd will create a storage proof tx and sign it with the given key.
+func StorageProofTxCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "storage-proof [file_hash] [chunk_file]",
+		Short: "Create and sign a tx answering a storage challenge with the challenged chunk and its merkle path, sent by the resource node",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			fileHash, err := hex.DecodeString(args[0])
+			if err != nil {
+				return err
+			}
+
+			chunk, err := ioutil.ReadFile(args[1])
+			if err != nil {
+				return err
+			}
+
+			aunts, err := parseAunts(viper.GetString(FlagAunts))
+			if err != nil {
+				return err
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			msg := types.NewMsgStorageProof(fileHash, cliCtx.GetFromAddress(), chunk, aunts)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+	cmd = flags.PostCommands(cmd)[0]
+	cmd.Flags().String(FlagAunts, "", "Comma separated hex encoded hashes of the merkle path, from the sibling of the chunk up")
+
+	cmd.MarkFlagRequired(flags.FlagFrom)
+
+	return cmd
+}
+
+// parseAunts parses a comma separated list of hex encoded merkle path hashes
+func parseAunts(auntsStr string) ([][]byte, error) {
+	aunts := make([][]byte, 0)
+	for _, auntStr := range strings.Split(auntsStr, ",") {
+		auntStr = strings.TrimSpace(auntStr)
+		if len(auntStr) == 0 {
+			continue
+		}
+		aunt, err := hex.DecodeString(auntStr)
+		if err != nil {
+			return nil, err
+		}
+		aunts = append(aunts, aunt)
+	}
+	return aunts, nil
+}
+
+// parseResourceNodes parses a comma separated list of resource node addresses
+func parseResourceNodes(resourceNodesStr string) ([]sdk.AccAddress, error) {
+	resourceNodes := make([]sdk.AccAddress, 0)
+	for _, addrStr := range strings.Split(resourceNodesStr, ",") {
+		addrStr = strings.TrimSpace(addrStr)
+		if len(addrStr) == 0 {
+			continue
+		}
+		addr, err := sdk.AccAddressFromBech32(addrStr)
+		if err != nil {
+			return nil, err
+		}
+		resourceNodes = append(resourceNodes, addr)
+	}
+	return resourceNodes, nil
+}
+
 // PrepayTxCmd will create a prepay tx and sign it with the given key.
 func PrepayTxCmd(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -87,14 +286,46 @@ func PrepayTxCmd(cdc *codec.Codec) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			minOzoneOut, ok := sdk.NewIntFromString(viper.GetString(FlagMinOzoneOut))
+			if !ok {
+				return fmt.Errorf("invalid min ozone out %s", viper.GetString(FlagMinOzoneOut))
+			}
 
 			// build and sign the transaction, then broadcast to Tendermint
-			msg := types.NewMsgPrepay(cliCtx.GetFromAddress(), coins)
+			msg := types.NewMsgPrepay(cliCtx.GetFromAddress(), coins, minOzoneOut)
 			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
 		},
 	}
 
+	cmd.Flags().String(FlagMinOzoneOut, "0", "least uoz the prepay has to purchase, the tx fails otherwise")
+	cmd = flags.PostCommands(cmd)[0]
+
+	return cmd
+}
+
+// RedeemOzoneTxCmd will create a tx redeeming uoz for ustos and sign it with the given key.
+func RedeemOzoneTxCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "redeem-ozone [amount]",
+		Short: "Create and sign a tx burning unused uoz back into the remaining ozone limit, paid out in ustos",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			amount, ok := sdk.NewIntFromString(args[0])
+			if !ok {
+				return fmt.Errorf("invalid uoz amount %s", args[0])
+			}
+
+			// build and sign the transaction, then broadcast to Tendermint
+			msg := types.NewMsgRedeemOzone(cliCtx.GetFromAddress(), amount)
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
 	cmd = flags.PostCommands(cmd)[0]
+	cmd.MarkFlagRequired(flags.FlagFrom)
 
 	return cmd
 }
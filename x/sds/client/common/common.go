@@ -29,6 +29,40 @@ func QueryPrepayBalance(cliCtx context.CLIContext, queryRoute, sender string) ([
 	return cliCtx.QueryWithData(route, accAddr)
 }
 
+// QueryOzoneBalance queries the uoz owned by an account
+func QueryOzoneBalance(cliCtx context.CLIContext, queryRoute, owner string) ([]byte, int64, error) {
+	accAddr, err := sdk.AccAddressFromBech32(owner)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid owner, please specify an owner in Bech32 format %w", err)
+	}
+	route := fmt.Sprintf("custom/%s/%s", queryRoute, sds.QueryOzoneBalance)
+	return cliCtx.QueryWithData(route, accAddr)
+}
+
+// QueryStorageChallenges queries the open storage challenges of a resource node
+func QueryStorageChallenges(cliCtx context.CLIContext, queryRoute, nodeAddr string) ([]byte, int64, error) {
+	accAddr, err := sdk.AccAddressFromBech32(nodeAddr)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid resource node, please specify an address in Bech32 format %w", err)
+	}
+	route := fmt.Sprintf("custom/%s/%s", queryRoute, sds.QueryStorageChallenges)
+	return cliCtx.QueryWithData(route, accAddr)
+}
+
+// QueryFiles queries a page of the files indexed by an address, either by uploader or by resource node
+func QueryFiles(cliCtx context.CLIContext, queryRoute, queryPath, address string, page, limit int) ([]byte, int64, error) {
+	accAddr, err := sdk.AccAddressFromBech32(address)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid address, please specify an address in Bech32 format %w", err)
+	}
+	bz, err := cliCtx.Codec.MarshalJSON(sds.NewQueryFilesParams(page, limit, accAddr))
+	if err != nil {
+		return nil, 0, err
+	}
+	route := fmt.Sprintf("custom/%s/%s", queryRoute, queryPath)
+	return cliCtx.QueryWithData(route, bz)
+}
+
 // QuerySimulatePrepay queries the ongoing price for prepay
 func QuerySimulatePrepay(cliCtx context.CLIContext, queryRoute string, amtToPrepay sdk.Int) ([]byte, int64, error) {
 	amtByteArray, err := amtToPrepay.MarshalJSON()
@@ -39,12 +73,48 @@ func QuerySimulatePrepay(cliCtx context.CLIContext, queryRoute string, amtToPrep
 	return cliCtx.QueryWithData(route, amtByteArray)
 }
 
+// QuerySimulateRedeemOzone queries the ustos paid out for redeeming uoz
+func QuerySimulateRedeemOzone(cliCtx context.CLIContext, queryRoute string, amtToRedeem sdk.Int) ([]byte, int64, error) {
+	amtByteArray, err := amtToRedeem.MarshalJSON()
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid amount, please specify a valid amount to simulate redeem %w", err)
+	}
+	route := fmt.Sprintf("custom/%s/%s", queryRoute, sds.QuerySimulateRedeemOzone)
+	return cliCtx.QueryWithData(route, amtByteArray)
+}
+
+// QueryParams queries the sds params
+func QueryParams(cliCtx context.CLIContext, queryRoute string) ([]byte, int64, error) {
+	route := fmt.Sprintf("custom/%s/%s", queryRoute, sds.QueryParams)
+	return cliCtx.QueryWithData(route, nil)
+}
+
 // QueryCurrUozPrice queries the current price for uoz
 func QueryCurrUozPrice(cliCtx context.CLIContext, queryRoute string) ([]byte, int64, error) {
 	route := fmt.Sprintf("custom/%s/%s", queryRoute, sds.QueryCurrUozPrice)
 	return cliCtx.QueryWithData(route, nil)
 }
 
+// QueryUozPriceHistory queries a page of the uoz prices recorded over a height range
+func QueryUozPriceHistory(cliCtx context.CLIContext, queryRoute string, page, limit int, startHeight, endHeight int64) ([]byte, int64, error) {
+	bz, err := cliCtx.Codec.MarshalJSON(sds.NewQueryUozPriceHistoryParams(page, limit, startHeight, endHeight))
+	if err != nil {
+		return nil, 0, err
+	}
+	route := fmt.Sprintf("custom/%s/%s", queryRoute, sds.QueryUozPriceHistory)
+	return cliCtx.QueryWithData(route, bz)
+}
+
+// QueryUozPriceTwap queries the time weighted average uoz price over a height range
+func QueryUozPriceTwap(cliCtx context.CLIContext, queryRoute string, startHeight, endHeight int64) ([]byte, int64, error) {
+	bz, err := cliCtx.Codec.MarshalJSON(sds.NewQueryUozPriceTwapParams(startHeight, endHeight))
+	if err != nil {
+		return nil, 0, err
+	}
+	route := fmt.Sprintf("custom/%s/%s", queryRoute, sds.QueryUozPriceTwap)
+	return cliCtx.QueryWithData(route, bz)
+}
+
 // QueryCurrUozPrice queries the current price for uoz
 func QueryUozSupply(cliCtx context.CLIContext, queryRoute string) ([]byte, int64, error) {
 	route := fmt.Sprintf("custom/%s/%s", queryRoute, sds.QueryUozSupply)
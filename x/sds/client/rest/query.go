@@ -2,8 +2,12 @@ package rest
 
 import (
 	"encoding/json"
+	"fmt"
+	"strconv"
+
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/stratosnet/stratos-chain/x/sds/client/common"
+	"github.com/stratosnet/stratos-chain/x/sds/types"
 	"net/http"
 
 	"github.com/gorilla/mux"
@@ -14,18 +18,50 @@ import (
 )
 
 func registerSdsQueryRoutes(cliCtx context.CLIContext, r *mux.Router, queryRoute string) {
+	r.HandleFunc(
+		"/sds/params",
+		ParamsHandlerFn(cliCtx, queryRoute),
+	).Methods("GET")
 	r.HandleFunc(
 		"/sds/simulatePrepay/{amtToPrepay}",
 		SimulatePrepayHandlerFn(cliCtx, queryRoute),
 	).Methods("GET")
+	r.HandleFunc(
+		"/sds/simulateRedeemOzone/{amtToRedeem}",
+		SimulateRedeemOzoneHandlerFn(cliCtx, queryRoute),
+	).Methods("GET")
 	r.HandleFunc(
 		"/sds/uozPrice",
 		UozPriceHandlerFn(cliCtx, queryRoute),
 	).Methods("GET")
+	r.HandleFunc(
+		"/sds/uozPrice/history",
+		UozPriceHistoryHandlerFn(cliCtx, queryRoute),
+	).Methods("GET")
+	r.HandleFunc(
+		"/sds/uozPrice/twap/{startHeight}/{endHeight}",
+		UozPriceTwapHandlerFn(cliCtx, queryRoute),
+	).Methods("GET")
 	r.HandleFunc(
 		"/sds/uozSupply",
 		UozSupplyHandlerFn(cliCtx, queryRoute),
 	).Methods("GET")
+	r.HandleFunc(
+		"/sds/ozoneBalance/{ownerAddr}",
+		OzoneBalanceHandlerFn(cliCtx, queryRoute),
+	).Methods("GET")
+	r.HandleFunc(
+		"/sds/files/uploader/{address}",
+		FilesHandlerFn(cliCtx, queryRoute, types.QueryFilesByUploader),
+	).Methods("GET")
+	r.HandleFunc(
+		"/sds/files/resourceNode/{address}",
+		FilesHandlerFn(cliCtx, queryRoute, types.QueryFilesByResourceNode),
+	).Methods("GET")
+	r.HandleFunc(
+		"/sds/storage/challenges/{address}",
+		StorageChallengesHandlerFn(cliCtx, queryRoute),
+	).Methods("GET")
 }
 
 // HTTP request handler to query the simulated purchased amt of prepay
@@ -56,6 +92,51 @@ func SimulatePrepayHandlerFn(cliCtx context.CLIContext, queryRoute string) http.
 	}
 }
 
+// HTTP request handler to query the sds params
+func ParamsHandlerFn(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+		resp, height, err := common.QueryParams(cliCtx, queryRoute)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, resp)
+	}
+}
+
+// HTTP request handler to query the simulated ustos paid out for redeeming uoz
+func SimulateRedeemOzoneHandlerFn(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+		amtToRedeem, ok := sdk.NewIntFromString(mux.Vars(r)["amtToRedeem"])
+		if !ok {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, sdkerrors.ErrInvalidRequest.Error())
+			return
+		}
+		resp, height, err := common.QuerySimulateRedeemOzone(cliCtx, queryRoute, amtToRedeem)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		var payout sdk.Int
+		err = payout.UnmarshalJSON(resp)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, payout)
+	}
+}
+
 // HTTP request handler to query ongoing uoz price
 func UozPriceHandlerFn(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -69,7 +150,7 @@ func UozPriceHandlerFn(cliCtx context.CLIContext, queryRoute string) http.Handle
 			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
 		}
-		var uozPrice sdk.Int
+		var uozPrice sdk.Dec
 		err = uozPrice.UnmarshalJSON(resp)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
@@ -80,6 +161,82 @@ func UozPriceHandlerFn(cliCtx context.CLIContext, queryRoute string) http.Handle
 	}
 }
 
+// HTTP request handler to query a page of the uoz prices recorded over a height range
+func UozPriceHistoryHandlerFn(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, page, limit, err := rest.ParseHTTPArgsWithLimit(r, 0)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		var startHeight, endHeight int64
+		if v := r.URL.Query().Get(RestStartHeight); len(v) != 0 {
+			if startHeight, ok = parseHeight(w, v); !ok {
+				return
+			}
+		}
+		if v := r.URL.Query().Get(RestEndHeight); len(v) != 0 {
+			if endHeight, ok = parseHeight(w, v); !ok {
+				return
+			}
+		}
+
+		resp, height, err := common.QueryUozPriceHistory(cliCtx, queryRoute, page, limit, startHeight, endHeight)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, resp)
+	}
+}
+
+// HTTP request handler to query the time weighted average uoz price over a height range
+func UozPriceTwapHandlerFn(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+		startHeight, ok := parseHeight(w, mux.Vars(r)["startHeight"])
+		if !ok {
+			return
+		}
+		endHeight, ok := parseHeight(w, mux.Vars(r)["endHeight"])
+		if !ok {
+			return
+		}
+
+		resp, height, err := common.QueryUozPriceTwap(cliCtx, queryRoute, startHeight, endHeight)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		var twap sdk.Dec
+		err = twap.UnmarshalJSON(resp)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, twap)
+	}
+}
+
+func parseHeight(w http.ResponseWriter, heightStr string) (int64, bool) {
+	height, err := strconv.ParseInt(heightStr, 10, 64)
+	if err != nil || height < 0 {
+		rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid height %s", heightStr))
+		return 0, false
+	}
+	return height, true
+}
+
 // HTTP request handler to query uoz supply details
 func UozSupplyHandlerFn(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -108,6 +265,68 @@ func UozSupplyHandlerFn(cliCtx context.CLIContext, queryRoute string) http.Handl
 	}
 }
 
+// HTTP request handler to query the uoz owned by an account
+func OzoneBalanceHandlerFn(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+		resp, height, err := common.QueryOzoneBalance(cliCtx, queryRoute, mux.Vars(r)["ownerAddr"])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		var ozoneBalance sdk.Int
+		err = ozoneBalance.UnmarshalJSON(resp)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, ozoneBalance)
+	}
+}
+
+// HTTP request handler to query a page of the files indexed by an address, either by uploader or by resource node
+func FilesHandlerFn(cliCtx context.CLIContext, queryRoute, queryPath string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, page, limit, err := rest.ParseHTTPArgsWithLimit(r, 0)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+		resp, height, err := common.QueryFiles(cliCtx, queryRoute, queryPath, mux.Vars(r)["address"], page, limit)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, resp)
+	}
+}
+
+// HTTP request handler to query the open storage challenges of a resource node
+func StorageChallengesHandlerFn(cliCtx context.CLIContext, queryRoute string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+		resp, height, err := common.QueryStorageChallenges(cliCtx, queryRoute, mux.Vars(r)["address"])
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, resp)
+	}
+}
+
 func checkAmtToPrepayVar(w http.ResponseWriter, r *http.Request) (sdk.Int, bool) {
 	prepayAmtStr := mux.Vars(r)["amtToPrepay"]
 	amtToPrepay, ok := sdk.NewIntFromString(prepayAmtStr)
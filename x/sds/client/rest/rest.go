@@ -13,22 +13,75 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/context"
 )
 
+const (
+	RestStartHeight = "start_height"
+	RestEndHeight   = "end_height"
+)
+
 // RegisterRoutes registers sds-related REST handlers to a router
 func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, queryRoute string) {
 	r.HandleFunc("/sds/file/upload", FileUploadRequestHandlerFn(cliCtx)).Methods("POST")
+	r.HandleFunc("/sds/file/delete", FileDeleteRequestHandlerFn(cliCtx)).Methods("POST")
+	r.HandleFunc("/sds/file/updateReplicas", FileUpdateReplicasRequestHandlerFn(cliCtx)).Methods("POST")
+	r.HandleFunc("/sds/storage/challenge", StorageChallengeRequestHandlerFn(cliCtx)).Methods("POST")
+	r.HandleFunc("/sds/storage/proof", StorageProofRequestHandlerFn(cliCtx)).Methods("POST")
 	r.HandleFunc("/sds/prepay", PrepayRequestHandlerFn(cliCtx)).Methods("POST")
+	r.HandleFunc("/sds/redeemOzone", RedeemOzoneRequestHandlerFn(cliCtx)).Methods("POST")
 	registerSdsQueryRoutes(cliCtx, r, queryRoute)
 }
 
 // FileUploadReq defines the properties of a file upload request's body.
 type FileUploadReq struct {
+	BaseReq       rest.BaseReq `json:"base_req" yaml:"base_req"`
+	FileHash      string       `json:"file_hash" yaml:"file_hash"`
+	Uploader      string       `json:"uploader" yaml:"uploader"`
+	FileSize      uint64       `json:"file_size" yaml:"file_size"`
+	Replicas      uint64       `json:"replicas" yaml:"replicas"`
+	ResourceNodes []string     `json:"resource_nodes" yaml:"resource_nodes"`
+	ExpireHeight  int64        `json:"expire_height" yaml:"expire_height"`
+	MerkleRoot    string       `json:"merkle_root" yaml:"merkle_root"`
+	ChunkCount    uint64       `json:"chunk_count" yaml:"chunk_count"`
+}
+
+// FileDeleteReq defines the properties of a file delete request's body.
+type FileDeleteReq struct {
+	BaseReq  rest.BaseReq `json:"base_req" yaml:"base_req"`
+	FileHash string       `json:"file_hash" yaml:"file_hash"`
+}
+
+// FileUpdateReplicasReq defines the properties of a file replicas update request's body.
+type FileUpdateReplicasReq struct {
+	BaseReq       rest.BaseReq `json:"base_req" yaml:"base_req"`
+	FileHash      string       `json:"file_hash" yaml:"file_hash"`
+	Replicas      uint64       `json:"replicas" yaml:"replicas"`
+	ResourceNodes []string     `json:"resource_nodes" yaml:"resource_nodes"`
+}
+
+// StorageChallengeReq defines the properties of a storage challenge request's body.
+type StorageChallengeReq struct {
+	BaseReq      rest.BaseReq `json:"base_req" yaml:"base_req"`
+	FileHash     string       `json:"file_hash" yaml:"file_hash"`
+	ResourceNode string       `json:"resource_node" yaml:"resource_node"`
+}
+
+// StorageProofReq defines the properties of a storage proof request's body.
+type StorageProofReq struct {
 	BaseReq  rest.BaseReq `json:"base_req" yaml:"base_req"`
 	FileHash string       `json:"file_hash" yaml:"file_hash"`
-	Uploader string       `json:"uploader" yaml:"uploader"`
+	Chunk    string       `json:"chunk" yaml:"chunk"`
+	Aunts    []string     `json:"aunts" yaml:"aunts"`
 }
 
 // PrepayReq defines the properties of a prepay request's body.
 type PrepayReq struct {
+	BaseReq     rest.BaseReq `json:"base_req" yaml:"base_req"`
+	Amount      sdk.Int      `json:"amount" yaml:"amount"`
+	Denom       string       `json:"denom" yaml:"denom"`                 // one of the prepay denoms of the params, the bond denom if empty
+	MinOzoneOut sdk.Int      `json:"min_ozone_out" yaml:"min_ozone_out"` // least uoz the prepay has to purchase, optional
+}
+
+// RedeemOzoneReq defines the properties of an ozone redemption request's body.
+type RedeemOzoneReq struct {
 	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
 	Amount  sdk.Int      `json:"amount" yaml:"amount"`
 }
@@ -64,11 +117,205 @@ func FileUploadRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 			return
 		}
 
-		msg := types.NewMsgUpload(fileHash, fromAddr, uploader)
+		resourceNodes, err := parseResourceNodes(req.ResourceNodes)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		merkleRoot, err := hex.DecodeString(req.MerkleRoot)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgUpload(fileHash, fromAddr, uploader, req.FileSize, req.Replicas, resourceNodes, req.ExpireHeight,
+			merkleRoot, req.ChunkCount)
+		if err = msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+// FileDeleteRequestHandlerFn - http request handler for file deletion.
+func FileDeleteRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req FileDeleteReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		fromAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		fileHash, err := hex.DecodeString(req.FileHash)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgFileDelete(fileHash, fromAddr)
+		if err = msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+// FileUpdateReplicasRequestHandlerFn - http request handler for file replicas update.
+func FileUpdateReplicasRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req FileUpdateReplicasReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		fromAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		fileHash, err := hex.DecodeString(req.FileHash)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		resourceNodes, err := parseResourceNodes(req.ResourceNodes)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgFileUpdateReplicas(fileHash, fromAddr, req.Replicas, resourceNodes)
+		if err = msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
 	}
 }
 
+// StorageChallengeRequestHandlerFn - http request handler for storage challenges.
+func StorageChallengeRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req StorageChallengeReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		fromAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		fileHash, err := hex.DecodeString(req.FileHash)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		resourceNode, err := sdk.AccAddressFromBech32(req.ResourceNode)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := types.NewMsgStorageChallenge(fileHash, resourceNode, fromAddr)
+		if err = msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+// StorageProofRequestHandlerFn - http request handler for storage proofs.
+func StorageProofRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req StorageProofReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		fromAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		fileHash, err := hex.DecodeString(req.FileHash)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		chunk, err := hex.DecodeString(req.Chunk)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		aunts := make([][]byte, 0, len(req.Aunts))
+		for _, auntStr := range req.Aunts {
+			aunt, err := hex.DecodeString(auntStr)
+			if err != nil {
+				rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			aunts = append(aunts, aunt)
+		}
+
+		msg := types.NewMsgStorageProof(fileHash, fromAddr, chunk, aunts)
+		if err = msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+// parseResourceNodes parses the Bech32 addresses of resource nodes
+func parseResourceNodes(resourceNodesStr []string) ([]sdk.AccAddress, error) {
+	resourceNodes := make([]sdk.AccAddress, 0, len(resourceNodesStr))
+	for _, addrStr := range resourceNodesStr {
+		addr, err := sdk.AccAddressFromBech32(addrStr)
+		if err != nil {
+			return nil, err
+		}
+		resourceNodes = append(resourceNodes, addr)
+	}
+	return resourceNodes, nil
+}
+
 // PrepayRequestHandlerFn - http request handler for prepay.
 func PrepayRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -87,14 +334,47 @@ func PrepayRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
-		prepayCoin := sdk.Coin{Denom: regTypes.DefaultBondDenom, Amount: req.Amount}
+		denom := req.Denom
+		if denom == "" {
+			denom = regTypes.DefaultBondDenom
+		}
+		prepayCoin := sdk.Coin{Denom: denom, Amount: req.Amount}
 		coins := sdk.Coins{prepayCoin}
+
+		minOzoneOut := req.MinOzoneOut
+		if minOzoneOut.IsNil() {
+			minOzoneOut = sdk.ZeroInt()
+		}
+
+		msg := types.NewMsgPrepay(fromAddr, coins, minOzoneOut)
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+// RedeemOzoneRequestHandlerFn - http request handler for ozone redemption.
+func RedeemOzoneRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req RedeemOzoneReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		fromAddr, err := sdk.AccAddressFromBech32(req.BaseReq.From)
 		if err != nil {
 			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		msg := types.NewMsgPrepay(fromAddr, coins)
+		msg := types.NewMsgRedeemOzone(fromAddr, req.Amount)
+		if err = msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
 	}
 }
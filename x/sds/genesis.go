@@ -1,22 +1,110 @@
 package sds
 
 import (
+	"encoding/hex"
+	"time"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stratosnet/stratos-chain/x/sds/keeper"
 	"github.com/stratosnet/stratos-chain/x/sds/types"
-	// abci "github.com/tendermint/tendermint/abci/types"
 )
 
 // InitGenesis initialize default parameters
 // and the keeper's address to pubkey map
-func InitGenesis(ctx sdk.Context, k keeper.Keeper /* TODO: Define what keepers the module needs */, data types.GenesisState) {
-	// TODO: Define logic for when you would like to initalize a new genesis
+func InitGenesis(ctx sdk.Context, k keeper.Keeper, data types.GenesisState) {
+	// the params validation can't see the bond denom of register
+	if err := k.ValidatePrepayDenoms(ctx, data.Params.PrepayDenoms); err != nil {
+		panic(err)
+	}
+	k.SetParams(ctx, data.Params)
+
+	for _, prepay := range data.Prepays {
+		for _, coin := range prepay.Balance {
+			k.SetPrepayBalance(ctx, prepay.Sender, coin.Denom, coin.Amount)
+		}
+		if !prepay.LastPrepayTime.IsZero() {
+			k.SetLastPrepayTime(ctx, prepay.Sender, prepay.LastPrepayTime)
+		}
+	}
+	// every prepay balance was moved out of bank before genesis
+	k.SetTotalPrepay(ctx, k.SumPrepayBalances(ctx))
+
+	// the uoz owned by users were issued on top of the remaining ozone limit
+	issued := sdk.ZeroInt()
+	for _, balance := range data.OzoneBalances {
+		k.SetOzoneBalance(ctx, balance.User, balance.Balance)
+		issued = issued.Add(balance.Balance)
+	}
+	k.RegisterKeeper.SetTotalOzoneSupply(ctx, k.RegisterKeeper.GetTotalOzoneSupply(ctx).Add(issued))
+
+	for _, file := range data.Files {
+		fileHash, err := hex.DecodeString(file.FileHash)
+		if err != nil {
+			panic(err)
+		}
+		k.SetFileInfo(ctx, fileHash, file.FileInfo)
+	}
+
+	for _, challenge := range data.StorageChallenges {
+		k.SetStorageChallenge(ctx, challenge)
+	}
+
+	for _, failure := range data.ChallengeFailures {
+		k.SetStorageChallengeFailure(ctx, failure)
+	}
 }
 
 // ExportGenesis writes the current store values
 // to a genesis file, which can be imported again
 // with InitGenesis
 func ExportGenesis(ctx sdk.Context, k keeper.Keeper) (data types.GenesisState) {
-	// TODO: Define logic for exporting state
-	return types.NewGenesisState()
+	// the balances of a sender are stored next to each other, one per denom
+	var prepays []types.PrepayBalance
+	k.IteratePrepays(ctx, func(sender sdk.AccAddress, balance sdk.Coin) (stop bool) {
+		if last := len(prepays) - 1; last >= 0 && prepays[last].Sender.Equals(sender) {
+			prepays[last].Balance = prepays[last].Balance.Add(balance)
+			return false
+		}
+		prepays = append(prepays, types.PrepayBalance{Sender: sender, Balance: sdk.NewCoins(balance)})
+		return false
+	})
+	// a sender keeps its last prepay time once its balances are redeemed
+	senders := make(map[string]int)
+	for i, prepay := range prepays {
+		senders[prepay.Sender.String()] = i
+	}
+	k.IterateLastPrepayTimes(ctx, func(sender sdk.AccAddress, lastPrepayTime time.Time) (stop bool) {
+		if i, found := senders[sender.String()]; found {
+			prepays[i].LastPrepayTime = lastPrepayTime
+			return false
+		}
+		prepays = append(prepays, types.PrepayBalance{Sender: sender, Balance: sdk.NewCoins(), LastPrepayTime: lastPrepayTime})
+		return false
+	})
+
+	var ozoneBalances []types.OzoneBalance
+	k.IterateOzoneBalances(ctx, func(user sdk.AccAddress, balance sdk.Int) (stop bool) {
+		ozoneBalances = append(ozoneBalances, types.OzoneBalance{User: user, Balance: balance})
+		return false
+	})
+
+	var files []types.FileRecord
+	k.IterateFileInfos(ctx, func(fileHash []byte, fileInfo types.FileInfo) (stop bool) {
+		files = append(files, types.NewFileRecord(hex.EncodeToString(fileHash), fileInfo))
+		return false
+	})
+
+	var storageChallenges []types.StorageChallenge
+	k.IterateStorageChallenges(ctx, func(challenge types.StorageChallenge) (stop bool) {
+		storageChallenges = append(storageChallenges, challenge)
+		return false
+	})
+
+	var challengeFailures []types.StorageChallengeFailure
+	k.IterateStorageChallengeFailures(ctx, func(failure types.StorageChallengeFailure) (stop bool) {
+		challengeFailures = append(challengeFailures, failure)
+		return false
+	})
+
+	return types.NewGenesisState(k.GetParams(ctx), prepays, ozoneBalances, files, storageChallenges, challengeFailures)
 }
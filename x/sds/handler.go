@@ -7,6 +7,7 @@ import (
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/stratosnet/stratos-chain/x/sds/keeper"
 	"github.com/stratosnet/stratos-chain/x/sds/types"
+	"strconv"
 )
 
 // NewHandler ...
@@ -18,6 +19,16 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 			return handleMsgFileUpload(ctx, k, msg)
 		case types.MsgPrepay:
 			return handleMsgPrepay(ctx, k, msg)
+		case types.MsgRedeemOzone:
+			return handleMsgRedeemOzone(ctx, k, msg)
+		case types.MsgFileDelete:
+			return handleMsgFileDelete(ctx, k, msg)
+		case types.MsgFileUpdateReplicas:
+			return handleMsgFileUpdateReplicas(ctx, k, msg)
+		case types.MsgStorageChallenge:
+			return handleMsgStorageChallenge(ctx, k, msg)
+		case types.MsgStorageProof:
+			return handleMsgStorageProof(ctx, k, msg)
 
 		default:
 			errMsg := fmt.Sprintf("unrecognized %s message type: %T", types.ModuleName, msg)
@@ -28,17 +39,11 @@ func NewHandler(k keeper.Keeper) sdk.Handler {
 
 // Handle MsgFileUpload.
 func handleMsgFileUpload(ctx sdk.Context, k keeper.Keeper, msg types.MsgFileUpload) (*sdk.Result, error) {
-	// check if reporter addr belongs to an registered sp node
-	if _, found := k.RegisterKeeper.GetIndexingNode(ctx, msg.Reporter); found == false {
-		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "Reporter %s isn't an SP node", msg.Reporter.String())
+	_, err := k.UploadFile(ctx, msg.FileHash, msg.Reporter, msg.Uploader, msg.FileSize, msg.Replicas,
+		msg.ResourceNodes, msg.ExpireHeight, msg.MerkleRoot, msg.ChunkCount)
+	if err != nil {
+		return nil, err
 	}
-	height := sdk.NewInt(ctx.BlockHeight())
-	heightByteArr, _ := height.MarshalJSON()
-	var heightReEncoded sdk.Int
-	heightReEncoded.UnmarshalJSON(heightByteArr)
-
-	fileInfo := types.NewFileInfo(heightReEncoded, msg.Reporter, msg.Uploader)
-	k.SetFileHash(ctx, msg.FileHash, fileInfo)
 
 	ctx.EventManager().EmitEvents(sdk.Events{
 		sdk.NewEvent(
@@ -46,10 +51,110 @@ func handleMsgFileUpload(ctx sdk.Context, k keeper.Keeper, msg types.MsgFileUplo
 			sdk.NewAttribute(types.AttributeKeyReporter, msg.Reporter.String()),
 			sdk.NewAttribute(types.AttributeKeyUploader, msg.Uploader.String()),
 			sdk.NewAttribute(types.AttributeKeyFileHash, hex.EncodeToString(msg.FileHash)),
+			sdk.NewAttribute(types.AttributeKeyFileSize, strconv.FormatUint(msg.FileSize, 10)),
+			sdk.NewAttribute(types.AttributeKeyReplicas, strconv.FormatUint(msg.Replicas, 10)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// Handle MsgFileDelete.
+func handleMsgFileDelete(ctx sdk.Context, k keeper.Keeper, msg types.MsgFileDelete) (*sdk.Result, error) {
+	fileInfo, err := k.DeleteFile(ctx, msg.FileHash, msg.Sender)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeFileDelete,
+			sdk.NewAttribute(types.AttributeKeySender, msg.Sender.String()),
+			sdk.NewAttribute(types.AttributeKeyUploader, fileInfo.Uploader.String()),
+			sdk.NewAttribute(types.AttributeKeyFileHash, hex.EncodeToString(msg.FileHash)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// Handle MsgFileUpdateReplicas.
+func handleMsgFileUpdateReplicas(ctx sdk.Context, k keeper.Keeper, msg types.MsgFileUpdateReplicas) (*sdk.Result, error) {
+	_, err := k.UpdateFileReplicas(ctx, msg.FileHash, msg.Sender, msg.Replicas, msg.ResourceNodes)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeFileUpdateReplicas,
+			sdk.NewAttribute(types.AttributeKeySender, msg.Sender.String()),
+			sdk.NewAttribute(types.AttributeKeyFileHash, hex.EncodeToString(msg.FileHash)),
+			sdk.NewAttribute(types.AttributeKeyReplicas, strconv.FormatUint(msg.Replicas, 10)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// Handle MsgStorageChallenge.
+func handleMsgStorageChallenge(ctx sdk.Context, k keeper.Keeper, msg types.MsgStorageChallenge) (*sdk.Result, error) {
+	challenge, err := k.IssueStorageChallenge(ctx, msg.FileHash, msg.ResourceNode, msg.Challenger)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeStorageChallenge,
+			sdk.NewAttribute(types.AttributeKeyChallenger, msg.Challenger.String()),
+			sdk.NewAttribute(types.AttributeKeyResourceNode, msg.ResourceNode.String()),
+			sdk.NewAttribute(types.AttributeKeyFileHash, hex.EncodeToString(msg.FileHash)),
+			sdk.NewAttribute(types.AttributeKeyChunkIndex, strconv.FormatUint(challenge.ChunkIndex, 10)),
+			sdk.NewAttribute(types.AttributeKeyDeadline, strconv.FormatInt(challenge.Deadline, 10)),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Challenger.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// Handle MsgStorageProof.
+func handleMsgStorageProof(ctx sdk.Context, k keeper.Keeper, msg types.MsgStorageProof) (*sdk.Result, error) {
+	challenge, err := k.SubmitStorageProof(ctx, msg.FileHash, msg.ResourceNode, msg.Chunk, msg.Aunts)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeStorageProof,
+			sdk.NewAttribute(types.AttributeKeyResourceNode, msg.ResourceNode.String()),
+			sdk.NewAttribute(types.AttributeKeyFileHash, hex.EncodeToString(msg.FileHash)),
+			sdk.NewAttribute(types.AttributeKeyChunkIndex, strconv.FormatUint(challenge.ChunkIndex, 10)),
 		),
 		sdk.NewEvent(
 			sdk.EventTypeMessage,
 			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.ResourceNode.String()),
 		),
 	})
 
@@ -65,6 +170,10 @@ func handleMsgPrepay(ctx sdk.Context, k keeper.Keeper, msg types.MsgPrepay) (*sd
 	if err != nil {
 		return nil, err
 	}
+	// the price may have moved since the prepay was simulated, the failed msg reverts the prepay
+	if !msg.MinOzoneOut.IsNil() && purchased.LT(msg.MinOzoneOut) {
+		return nil, sdkerrors.Wrapf(types.ErrMinOzoneOut, "purchased %s uoz, min ozone out is %s", purchased, msg.MinOzoneOut)
+	}
 
 	ctx.EventManager().EmitEvents(sdk.Events{
 		sdk.NewEvent(
@@ -81,3 +190,28 @@ func handleMsgPrepay(ctx sdk.Context, k keeper.Keeper, msg types.MsgPrepay) (*sd
 
 	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
 }
+
+// Handle MsgRedeemOzone.
+func handleMsgRedeemOzone(ctx sdk.Context, k keeper.Keeper, msg types.MsgRedeemOzone) (*sdk.Result, error) {
+	payout, fee, err := k.RedeemOzone(ctx, msg.Sender, msg.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeRedeemOzone,
+			sdk.NewAttribute(types.AttributeKeySender, msg.Sender.String()),
+			sdk.NewAttribute(types.AttributeKeyRedeemedUoz, msg.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyPayout, payout.String()),
+			sdk.NewAttribute(types.AttributeKeyFee, fee.String()),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
@@ -0,0 +1,219 @@
+package keeper
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stratosnet/stratos-chain/x/sds/types"
+)
+
+// GetFileInfo returns the info of an uploaded file
+func (fk Keeper) GetFileInfo(ctx sdk.Context, fileHash []byte) (fileInfo types.FileInfo, found bool) {
+	store := ctx.KVStore(fk.key)
+	bz := store.Get(types.FileStoreKey(fileHash))
+	if bz == nil {
+		return fileInfo, false
+	}
+	return types.MustUnmarshalFileInfo(fk.cdc, bz), true
+}
+
+// setFileIndexes indexes the file by uploader, by resource node and by expire height
+func (fk Keeper) setFileIndexes(ctx sdk.Context, fileHash []byte, fileInfo types.FileInfo) {
+	store := ctx.KVStore(fk.key)
+	store.Set(types.UploaderFileIndexKey(fileInfo.Uploader, fileHash), fileHash)
+	for _, node := range fileInfo.ResourceNodes {
+		store.Set(types.ResourceNodeFileIndexKey(node, fileHash), fileHash)
+	}
+	if fileInfo.HasExpiry() {
+		store.Set(types.FileExpiryQueueKey(fileInfo.ExpireHeight, fileHash), fileHash)
+	}
+}
+
+// deleteFileIndexes removes the file from the indexes set by setFileIndexes
+func (fk Keeper) deleteFileIndexes(ctx sdk.Context, fileHash []byte, fileInfo types.FileInfo) {
+	store := ctx.KVStore(fk.key)
+	store.Delete(types.UploaderFileIndexKey(fileInfo.Uploader, fileHash))
+	for _, node := range fileInfo.ResourceNodes {
+		store.Delete(types.ResourceNodeFileIndexKey(node, fileHash))
+	}
+	if fileInfo.HasExpiry() {
+		store.Delete(types.FileExpiryQueueKey(fileInfo.ExpireHeight, fileHash))
+	}
+}
+
+// SetFileInfo stores the file info along with its indexes
+func (fk Keeper) SetFileInfo(ctx sdk.Context, fileHash []byte, fileInfo types.FileInfo) {
+	if oldFileInfo, found := fk.GetFileInfo(ctx, fileHash); found {
+		fk.deleteFileIndexes(ctx, fileHash, oldFileInfo)
+	}
+	fk.SetFileHash(ctx, fileHash, fileInfo)
+	fk.setFileIndexes(ctx, fileHash, fileInfo)
+}
+
+// RemoveFileInfo removes the file info along with its indexes
+func (fk Keeper) RemoveFileInfo(ctx sdk.Context, fileHash []byte, fileInfo types.FileInfo) {
+	store := ctx.KVStore(fk.key)
+	store.Delete(types.FileStoreKey(fileHash))
+	fk.deleteFileIndexes(ctx, fileHash, fileInfo)
+}
+
+// IterateFileInfos iterates over all the uploaded files
+func (fk Keeper) IterateFileInfos(ctx sdk.Context, handler func(fileHash []byte, fileInfo types.FileInfo) (stop bool)) {
+	store := ctx.KVStore(fk.key)
+	iter := sdk.KVStorePrefixIterator(store, types.FileStoreKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		fileHash := iter.Key()[len(types.FileStoreKeyPrefix):]
+		if handler(fileHash, types.MustUnmarshalFileInfo(fk.cdc, iter.Value())) {
+			break
+		}
+	}
+}
+
+// checkValidIndexingNode ensures the reporter of a file is a bonded, unsuspended indexing node
+func (fk Keeper) checkValidIndexingNode(ctx sdk.Context, reporter sdk.AccAddress) error {
+	node, found := fk.RegisterKeeper.GetIndexingNode(ctx, reporter)
+	if !found || node.IsSuspended() || !node.GetStatus().Equal(sdk.Bonded) {
+		return sdkerrors.Wrapf(types.ErrNotIndexingNode, "reporter %s", reporter.String())
+	}
+	return nil
+}
+
+// checkResourceNodes ensures all the nodes holding a file are bonded, unsuspended resource nodes
+func (fk Keeper) checkResourceNodes(ctx sdk.Context, resourceNodes []sdk.AccAddress) error {
+	for _, nodeAddr := range resourceNodes {
+		node, found := fk.RegisterKeeper.GetResourceNode(ctx, nodeAddr)
+		if !found || node.IsSuspended() || !node.GetStatus().Equal(sdk.Bonded) {
+			return sdkerrors.Wrap(types.ErrNotResourceNode, nodeAddr.String())
+		}
+	}
+	return nil
+}
+
+// UploadFile records a file reported by an SP node, an existing file hash is never overwritten
+func (fk Keeper) UploadFile(ctx sdk.Context, fileHash []byte, reporter, uploader sdk.AccAddress, fileSize, replicas uint64,
+	resourceNodes []sdk.AccAddress, expireHeight int64, merkleRoot []byte, chunkCount uint64) (types.FileInfo, error) {
+
+	if err := fk.checkValidIndexingNode(ctx, reporter); err != nil {
+		return types.FileInfo{}, err
+	}
+	if _, found := fk.GetFileInfo(ctx, fileHash); found {
+		return types.FileInfo{}, sdkerrors.Wrap(types.ErrFileAlreadyExists, hex.EncodeToString(fileHash))
+	}
+	if expireHeight > 0 && expireHeight <= ctx.BlockHeight() {
+		return types.FileInfo{}, types.ErrInvalidExpireHeight
+	}
+	if err := fk.checkResourceNodes(ctx, resourceNodes); err != nil {
+		return types.FileInfo{}, err
+	}
+
+	fileInfo := types.NewFileInfo(sdk.NewInt(ctx.BlockHeight()), reporter, uploader, fileSize, replicas, resourceNodes,
+		expireHeight, merkleRoot, chunkCount)
+	fk.SetFileInfo(ctx, fileHash, fileInfo)
+	return fileInfo, nil
+}
+
+// DeleteFile removes a file on behalf of its reporter or its uploader
+func (fk Keeper) DeleteFile(ctx sdk.Context, fileHash []byte, sender sdk.AccAddress) (types.FileInfo, error) {
+	fileInfo, found := fk.GetFileInfo(ctx, fileHash)
+	if !found {
+		return fileInfo, sdkerrors.Wrap(types.ErrFileNotFound, hex.EncodeToString(fileHash))
+	}
+	if !fileInfo.IsManagedBy(sender) {
+		return fileInfo, types.ErrNotFileManager
+	}
+
+	fk.RemoveFileInfo(ctx, fileHash, fileInfo)
+	return fileInfo, nil
+}
+
+// UpdateFileReplicas updates the replica count of a file and the resource nodes holding it
+// on behalf of its reporter or its uploader
+func (fk Keeper) UpdateFileReplicas(ctx sdk.Context, fileHash []byte, sender sdk.AccAddress, replicas uint64,
+	resourceNodes []sdk.AccAddress) (types.FileInfo, error) {
+
+	fileInfo, found := fk.GetFileInfo(ctx, fileHash)
+	if !found {
+		return fileInfo, sdkerrors.Wrap(types.ErrFileNotFound, hex.EncodeToString(fileHash))
+	}
+	if !fileInfo.IsManagedBy(sender) {
+		return fileInfo, types.ErrNotFileManager
+	}
+	if err := fk.checkResourceNodes(ctx, resourceNodes); err != nil {
+		return fileInfo, err
+	}
+
+	fileInfo.Replicas = replicas
+	fileInfo.ResourceNodes = resourceNodes
+	fk.SetFileInfo(ctx, fileHash, fileInfo)
+	return fileInfo, nil
+}
+
+// getFileRecordsByIndex returns a page of the files found under an index prefix
+func (fk Keeper) getFileRecordsByIndex(ctx sdk.Context, indexPrefix []byte, page, limit int) []types.FileRecord {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = QueryDefaultLimit
+	}
+	skip := (page - 1) * limit
+
+	store := ctx.KVStore(fk.key)
+	iter := sdk.KVStorePrefixIterator(store, indexPrefix)
+	defer iter.Close()
+
+	records := make([]types.FileRecord, 0)
+	for i := 0; iter.Valid() && len(records) < limit; iter.Next() {
+		if i++; i <= skip {
+			continue
+		}
+		fileHash := iter.Value()
+		fileInfo, found := fk.GetFileInfo(ctx, fileHash)
+		if !found {
+			continue
+		}
+		records = append(records, types.NewFileRecord(hex.EncodeToString(fileHash), fileInfo))
+	}
+	return records
+}
+
+// GetFilesByUploader returns a page of the files uploaded by an address
+func (fk Keeper) GetFilesByUploader(ctx sdk.Context, uploader sdk.AccAddress, page, limit int) []types.FileRecord {
+	return fk.getFileRecordsByIndex(ctx, types.UploaderFilesKey(uploader), page, limit)
+}
+
+// GetFilesByResourceNode returns a page of the files held by a resource node
+func (fk Keeper) GetFilesByResourceNode(ctx sdk.Context, nodeAddr sdk.AccAddress, page, limit int) []types.FileRecord {
+	return fk.getFileRecordsByIndex(ctx, types.ResourceNodeFilesKey(nodeAddr), page, limit)
+}
+
+// RemoveExpiredFiles removes the files whose expire height has been reached
+func (fk Keeper) RemoveExpiredFiles(ctx sdk.Context) {
+	store := ctx.KVStore(fk.key)
+	iter := store.Iterator(types.FileExpiryQueuePrefix, sdk.PrefixEndBytes(types.FileExpiryQueueHeightKey(ctx.BlockHeight())))
+	defer iter.Close()
+
+	var expiredHashes [][]byte
+	for ; iter.Valid(); iter.Next() {
+		expiredHashes = append(expiredHashes, iter.Value())
+	}
+
+	for _, fileHash := range expiredHashes {
+		fileInfo, found := fk.GetFileInfo(ctx, fileHash)
+		if !found {
+			continue
+		}
+		fk.RemoveFileInfo(ctx, fileHash, fileInfo)
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeFileExpired,
+				sdk.NewAttribute(types.AttributeKeyFileHash, hex.EncodeToString(fileHash)),
+				sdk.NewAttribute(types.AttributeKeyUploader, fileInfo.Uploader.String()),
+			),
+		)
+		fk.Logger(ctx).Info(fmt.Sprintf("file %s expired at height %d", hex.EncodeToString(fileHash), fileInfo.ExpireHeight))
+	}
+}
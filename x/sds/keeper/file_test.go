@@ -0,0 +1,48 @@
+package keeper
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stratosnet/stratos-chain/x/sds/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadFileRequiresActiveNodes(t *testing.T) {
+	ctx, k, _ := setupStorageChallenge(t)
+	fileHash := []byte("d03661732294feb49caf6dc16c7cbb2534986d73")
+	resourceNodes := []sdk.AccAddress{challengeResAddr}
+
+	// a suspended or unbonded reporter is rejected
+	indexingNode, _ := k.RegisterKeeper.GetIndexingNode(ctx, challengeIdxAddr)
+	suspended := indexingNode
+	suspended.Suspend = true
+	k.RegisterKeeper.SetIndexingNode(ctx, suspended)
+	_, err := k.UploadFile(ctx, fileHash, challengeIdxAddr, challengeOwner, 1024, 1, resourceNodes, 0, nil, 0)
+	require.True(t, types.ErrNotIndexingNode.Is(err))
+	unbonded := indexingNode
+	unbonded.Status = sdk.Unbonded
+	k.RegisterKeeper.SetIndexingNode(ctx, unbonded)
+	_, err = k.UploadFile(ctx, fileHash, challengeIdxAddr, challengeOwner, 1024, 1, resourceNodes, 0, nil, 0)
+	require.True(t, types.ErrNotIndexingNode.Is(err))
+	k.RegisterKeeper.SetIndexingNode(ctx, indexingNode)
+
+	// so is a suspended or unbonding resource node, both on upload and on replicas update
+	resourceNode, _ := k.RegisterKeeper.GetResourceNode(ctx, challengeResAddr)
+	suspendedRes := resourceNode
+	suspendedRes.Suspend = true
+	k.RegisterKeeper.SetResourceNode(ctx, suspendedRes)
+	_, err = k.UploadFile(ctx, fileHash, challengeIdxAddr, challengeOwner, 1024, 1, resourceNodes, 0, nil, 0)
+	require.True(t, types.ErrNotResourceNode.Is(err))
+	unbondingRes := resourceNode
+	unbondingRes.Status = sdk.Unbonding
+	k.RegisterKeeper.SetResourceNode(ctx, unbondingRes)
+	_, err = k.UploadFile(ctx, fileHash, challengeIdxAddr, challengeOwner, 1024, 1, resourceNodes, 0, nil, 0)
+	require.True(t, types.ErrNotResourceNode.Is(err))
+	_, err = k.UpdateFileReplicas(ctx, challengeFileHash, challengeOwner, 1, resourceNodes)
+	require.True(t, types.ErrNotResourceNode.Is(err))
+
+	k.RegisterKeeper.SetResourceNode(ctx, resourceNode)
+	_, err = k.UploadFile(ctx, fileHash, challengeIdxAddr, challengeOwner, 1024, 1, resourceNodes, 0, nil, 0)
+	require.NoError(t, err)
+}
@@ -0,0 +1,52 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stratosnet/stratos-chain/x/pot"
+	"github.com/stratosnet/stratos-chain/x/sds/types"
+)
+
+// Hooks wrapper struct for sds keeper
+type Hooks struct {
+	k Keeper
+}
+
+var _ pot.PotHooks = Hooks{}
+
+// Hooks returns the sds hooks to be registered in the pot keeper
+func (fk Keeper) Hooks() Hooks {
+	return Hooks{fk}
+}
+
+// AfterVolumeReportSettled debits the ozone consumed by each user from its balance. A user that does not own
+// enough uoz is debited its whole balance, the shortfall is logged and reported in an event without failing the report.
+func (h Hooks) AfterVolumeReportSettled(ctx sdk.Context, epoch sdk.Int, usersVolume []pot.SingleUserVolume) error {
+	for _, userVolume := range usersVolume {
+		consumed := h.k.consumeOzone(ctx, userVolume.UserAddress, userVolume.Volume)
+
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				types.EventTypeConsumeUoz,
+				sdk.NewAttribute(types.AttributeKeyUser, userVolume.UserAddress.String()),
+				sdk.NewAttribute(types.AttributeKeyConsumedUoz, consumed.String()),
+				sdk.NewAttribute(types.AttributeKeyEpoch, epoch.String()),
+			),
+		)
+
+		if shortfall := userVolume.Volume.Sub(consumed); shortfall.IsPositive() {
+			h.k.Logger(ctx).Error(fmt.Sprintf("user %s consumed %s uoz at epoch %s but owned only %s",
+				userVolume.UserAddress, userVolume.Volume, epoch, consumed))
+			ctx.EventManager().EmitEvent(
+				sdk.NewEvent(
+					types.EventTypeUozShortfall,
+					sdk.NewAttribute(types.AttributeKeyUser, userVolume.UserAddress.String()),
+					sdk.NewAttribute(types.AttributeKeyShortfallUoz, shortfall.String()),
+					sdk.NewAttribute(types.AttributeKeyEpoch, epoch.String()),
+				),
+			)
+		}
+	}
+	return nil
+}
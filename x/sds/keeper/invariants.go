@@ -0,0 +1,60 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stratosnet/stratos-chain/x/sds/types"
+)
+
+// RegisterInvariants registers all sds invariants
+func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
+	ir.RegisterRoute(types.ModuleName, "total-ozone-supply", TotalOzoneSupplyInvariant(k))
+	ir.RegisterRoute(types.ModuleName, "prepay-balances", PrepayBalancesInvariant(k))
+}
+
+// AllInvariants runs all invariants of the sds module.
+func AllInvariants(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		res, stop := TotalOzoneSupplyInvariant(k)(ctx)
+		if stop {
+			return res, stop
+		}
+		return PrepayBalancesInvariant(k)(ctx)
+	}
+}
+
+// TotalOzoneSupplyInvariant checks that the total uoz supply equals
+// the remaining ozone limit plus the uoz issued to users and not consumed yet
+func TotalOzoneSupplyInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		issued := sdk.ZeroInt()
+		k.IterateOzoneBalances(ctx, func(_ sdk.AccAddress, balance sdk.Int) (stop bool) {
+			issued = issued.Add(balance)
+			return false
+		})
+
+		remaining, total := k.uozSupply(ctx)
+		broken := !total.Equal(remaining.Add(issued))
+
+		return sdk.FormatInvariant(types.ModuleName, "total ozone supply", fmt.Sprintf(
+			"\tsum of remaining ozone limit and issued ozone: %v\n"+
+				"\ttotal ozone supply: %v\n",
+			remaining.Add(issued), total)), broken
+	}
+}
+
+// PrepayBalancesInvariant checks that the prepay balances of all senders
+// sum to the total coins moved out of bank by prepays, denom by denom
+func PrepayBalancesInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		sum := k.SumPrepayBalances(ctx)
+		total := k.GetTotalPrepay(ctx)
+		broken := !sum.IsAllGTE(total) || !total.IsAllGTE(sum)
+
+		return sdk.FormatInvariant(types.ModuleName, "prepay balances", fmt.Sprintf(
+			"\tsum of prepay balances: %v\n"+
+				"\ttotal prepaid: %v\n",
+			sum, total)), broken
+	}
+}
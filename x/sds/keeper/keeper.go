@@ -7,10 +7,13 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/supply"
 	"github.com/stratosnet/stratos-chain/x/pot"
 	"github.com/stratosnet/stratos-chain/x/register"
 	"github.com/stratosnet/stratos-chain/x/sds/types"
 	"github.com/tendermint/tendermint/libs/log"
+	"time"
 )
 
 // Keeper encodes/decodes files using the go-amino (binary)
@@ -18,7 +21,9 @@ import (
 type Keeper struct {
 	key            sdk.StoreKey
 	cdc            *codec.Codec
+	paramSpace     params.Subspace
 	BankKeeper     bank.Keeper
+	SupplyKeeper   supply.Keeper
 	RegisterKeeper register.Keeper
 	PotKeeper      pot.Keeper
 }
@@ -29,14 +34,18 @@ type Keeper struct {
 func NewKeeper(
 	cdc *codec.Codec,
 	key sdk.StoreKey,
+	paramSpace params.Subspace,
 	bankKeeper bank.Keeper,
+	supplyKeeper supply.Keeper,
 	registerKeeper register.Keeper,
 	potKeeper pot.Keeper,
 ) Keeper {
 	return Keeper{
 		key:            key,
 		cdc:            cdc,
+		paramSpace:     paramSpace.WithKeyTable(types.ParamKeyTable()),
 		BankKeeper:     bankKeeper,
+		SupplyKeeper:   supplyKeeper,
 		RegisterKeeper: registerKeeper,
 		PotKeeper:      potKeeper,
 	}
@@ -106,126 +115,283 @@ func (fk Keeper) simulatePurchaseUoz(ctx sdk.Context, amount sdk.Int) sdk.Int {
 	return purchased
 }
 
+// [U] is the amount of uoz redeemed by a user, the redemption is the inverse of the purchase:
+// the ustos paid out [X] would purchase the same U uoz if it were prepaid right after, (Lt + U) * X / (S + (Pt - X) + X) = U
+// the total amount of ustos the user gets = U * (S + Pt) / (Lt + U)
+func (fk Keeper) simulateRedeemUoz(ctx sdk.Context, amount sdk.Int) sdk.Int {
+	S := fk.RegisterKeeper.GetInitialGenesisStakeTotal(ctx)
+	Pt := fk.PotKeeper.GetTotalUnissuedPrepay(ctx)
+	Lt := fk.RegisterKeeper.GetRemainingOzoneLimit(ctx)
+	redeemed := amount.ToDec().
+		Mul((S.
+			Add(Pt)).ToDec()).
+		Quo((Lt.
+			Add(amount)).ToDec()).
+		TruncateInt()
+	return redeemed
+}
+
+// simulateRedeemPayout splits the ustos redeemed for the uoz into the payout and the fee kept in the unissued prepay pool
+func (fk Keeper) simulateRedeemPayout(ctx sdk.Context, amount sdk.Int) (payout, fee sdk.Int) {
+	redeemed := fk.simulateRedeemUoz(ctx, amount)
+	payout = redeemed.ToDec().Mul(sdk.OneDec().Sub(fk.RedeemFeeRate(ctx))).TruncateInt()
+	return payout, redeemed.Sub(payout)
+}
+
 // calc current uoz price
-func (fk Keeper) currUozPrice(ctx sdk.Context) sdk.Int {
+func (fk Keeper) currUozPrice(ctx sdk.Context) sdk.Dec {
 	S := fk.RegisterKeeper.GetInitialGenesisStakeTotal(ctx)
 	Pt := fk.PotKeeper.GetTotalUnissuedPrepay(ctx)
 	Lt := fk.RegisterKeeper.GetRemainingOzoneLimit(ctx)
+	if !S.Add(Pt).IsPositive() {
+		// nothing was staked nor prepaid yet
+		return sdk.ZeroDec()
+	}
 	currUozPrice := Lt.ToDec().
 		Quo((S.
-			Add(Pt)).ToDec()).
-		TruncateInt()
+			Add(Pt)).ToDec())
 	return currUozPrice
 }
 
 // calc remaining/total supply for uoz
 func (fk Keeper) uozSupply(ctx sdk.Context) (remaining, total sdk.Int) {
 	remaining = fk.RegisterKeeper.GetRemainingOzoneLimit(ctx)
-	// TODO create a dedicated storeKey in pot module for total ozone supply and keep updating it along with related operations (like AddResourceNodeStake)
-	// fake return of total
-	total, _ = sdk.NewIntFromString("-1")
+	total = fk.RegisterKeeper.GetTotalOzoneSupply(ctx)
 	return remaining, total
 }
 
-// Prepay transfers coins from bank to sds (volumn) pool
+// Prepay transfers coins from bank to sds (volumn) pool, the uoz are purchased with the bond denom.
+// The bond denom is the only denom with a uoz price, coins of any other denom are rejected before any transfer.
 func (fk Keeper) Prepay(ctx sdk.Context, sender sdk.AccAddress, coins sdk.Coins) (sdk.Int, error) {
-	// src - hasCoins?
-	if !fk.BankKeeper.HasCoins(ctx, sender, coins) {
-		return sdk.ZeroInt(), sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "No valid coins to be deducted from acc %s", hex.EncodeToString(types.PrepayBalanceKey(sender)))
+	prepayDenoms := fk.PrepayDenoms(ctx)
+	bondDenom := fk.RegisterKeeper.BondDenom(ctx)
+	for _, coin := range coins {
+		if !types.IsPrepayDenom(prepayDenoms, coin.Denom) {
+			return sdk.ZeroInt(), sdkerrors.Wrapf(types.ErrUnsupportedPrepayDenom, "%s, accepted denoms are %v", coin.Denom, prepayDenoms)
+		}
+		if coin.Denom != bondDenom {
+			return sdk.ZeroInt(), sdkerrors.Wrapf(types.ErrUnpricedPrepayDenom, "%s, uoz are purchased with %s", coin.Denom, bondDenom)
+		}
 	}
 
-	err := fk.doPrepay(ctx, sender, coins)
-	if err != nil {
-		return sdk.ZeroInt(), sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "Failed prepay from acc %s", hex.EncodeToString(types.PrepayBalanceKey(sender)))
+	// src - hasCoins?
+	if !fk.BankKeeper.HasCoins(ctx, sender, coins) {
+		return sdk.ZeroInt(), sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "No valid coins to be deducted from acc %s", sender)
 	}
 
-	_, err = fk.BankKeeper.SubtractCoins(ctx, sender, coins)
+	// the prepaid tokens are held in the unissued prepay pool until they are issued as traffic rewards
+	err := fk.SupplyKeeper.SendCoinsFromAccountToModule(ctx, sender, pot.TotalUnissuedPrepayPoolName, coins)
 	if err != nil {
 		return sdk.ZeroInt(), err
 	}
 
-	//TODO: move the definition of default denomination to params.go
-	prepay := coins.AmountOf("ustos")
+	fk.addPrepay(ctx, sender, coins)
+	fk.SetTotalPrepay(ctx, fk.GetTotalPrepay(ctx).Add(coins...))
+	fk.SetLastPrepayTime(ctx, sender, ctx.BlockTime())
+
+	prepay := coins.AmountOf(bondDenom)
 	purchased := fk.purchaseUoz(ctx, prepay)
+	fk.AddOzoneBalance(ctx, sender, purchased)
 
 	return purchased, nil
 }
 
-// HasPrepay Returns bool indicating if the sender did prepay before
-func (fk Keeper) hasPrepay(ctx sdk.Context, sender sdk.AccAddress) bool {
-	store := ctx.KVStore(fk.key)
-	return store.Has(types.PrepayBalanceKey(sender))
+// ValidatePrepayDenoms ensures the prepay denoms include the bond denom the uoz are purchased with
+func (fk Keeper) ValidatePrepayDenoms(ctx sdk.Context, prepayDenoms []string) error {
+	if bondDenom := fk.RegisterKeeper.BondDenom(ctx); !types.IsPrepayDenom(prepayDenoms, bondDenom) {
+		return sdkerrors.Wrapf(types.ErrMissingBondPrepayDenom, "%s is not in %v", bondDenom, prepayDenoms)
+	}
+	return nil
 }
 
-// GetPrepay Returns the existing prepay coins
-func (fk Keeper) GetPrepay(ctx sdk.Context, sender sdk.AccAddress) (sdk.Int, error) {
-	store := ctx.KVStore(fk.key)
-	storeValue := store.Get(types.PrepayBalanceKey(sender))
-	if storeValue == nil {
-		return sdk.Int{}, sdkerrors.Wrapf(sdkerrors.ErrUnknownAddress, "No prepayment info for acc %s", hex.EncodeToString(types.PrepayBalanceKey(sender)))
+// CheckRedeemOzone returns the ustos paid out to the sender and the fee kept for redeeming uoz, without redeeming them
+func (fk Keeper) CheckRedeemOzone(ctx sdk.Context, sender sdk.AccAddress, amount sdk.Int) (payout, fee sdk.Int, err error) {
+	cooldown := fk.RedeemCooldown(ctx)
+	if lastPrepayTime, found := fk.GetLastPrepayTime(ctx, sender); found && ctx.BlockTime().Before(lastPrepayTime.Add(cooldown)) {
+		return sdk.ZeroInt(), sdk.ZeroInt(), sdkerrors.Wrapf(types.ErrRedeemCooldown, "%s can redeem ozone from %s", sender, lastPrepayTime.Add(cooldown))
+	}
+
+	balance := fk.GetOzoneBalance(ctx, sender)
+	if balance.LT(amount) {
+		return sdk.ZeroInt(), sdk.ZeroInt(), sdkerrors.Wrapf(types.ErrInsufficientOzoneBalance, "%s has %s uoz, %s required", sender, balance, amount)
+	}
+	if pending := fk.GetPendingOzoneConsumption(ctx, sender); balance.Sub(pending).LT(amount) {
+		return sdk.ZeroInt(), sdk.ZeroInt(), sdkerrors.Wrapf(types.ErrOzonePendingConsumption,
+			"%s has %s uoz, %s of them pending consumption, %s required", sender, balance, pending, amount)
+	}
+
+	payout, fee = fk.simulateRedeemPayout(ctx, amount)
+	if !payout.IsPositive() {
+		return sdk.ZeroInt(), sdk.ZeroInt(), sdkerrors.Wrapf(types.ErrRedeemTooSmall, "%s uoz", amount)
+	}
+
+	prepaid := fk.GetPrepayBalance(ctx, sender, fk.RegisterKeeper.BondDenom(ctx))
+	if prepaid.LT(payout) {
+		return sdk.ZeroInt(), sdk.ZeroInt(), sdkerrors.Wrapf(types.ErrInsufficientPrepay, "%s prepaid %s ustos, %s paid out", sender, prepaid, payout)
+	}
+
+	unissued := fk.PotKeeper.GetTotalUnissuedPrepay(ctx)
+	if unissued.LT(payout) {
+		return sdk.ZeroInt(), sdk.ZeroInt(), sdkerrors.Wrapf(types.ErrInsufficientUnissued, "%s ustos unissued, %s paid out", unissued, payout)
 	}
-	var prepaidBalance sdk.Int
-	err := prepaidBalance.UnmarshalJSON(storeValue)
+	return payout, fee, nil
+}
+
+// RedeemOzone burns uoz of the sender back into the remaining ozone limit and pays out the matching ustos
+// from the unissued prepay pool, the fee stays in the pool
+func (fk Keeper) RedeemOzone(ctx sdk.Context, sender sdk.AccAddress, amount sdk.Int) (payout, fee sdk.Int, err error) {
+	payout, fee, err = fk.CheckRedeemOzone(ctx, sender, amount)
 	if err != nil {
-		return sdk.Int{}, sdkerrors.Wrapf(sdkerrors.ErrUnknownAddress, "Unmarshal failed for acc %s", hex.EncodeToString(types.PrepayBalanceKey(sender)))
+		return sdk.ZeroInt(), sdk.ZeroInt(), err
 	}
-	return prepaidBalance, nil
+
+	bondDenom := fk.RegisterKeeper.BondDenom(ctx)
+	coins := sdk.NewCoins(sdk.NewCoin(bondDenom, payout))
+	err = fk.SupplyKeeper.SendCoinsFromModuleToAccount(ctx, pot.TotalUnissuedPrepayPoolName, sender, coins)
+	if err != nil {
+		return sdk.ZeroInt(), sdk.ZeroInt(), err
+	}
+
+	if _, err = fk.SubtractOzoneBalance(ctx, sender, amount); err != nil {
+		return sdk.ZeroInt(), sdk.ZeroInt(), err
+	}
+	// the redeemed uoz can be purchased again
+	fk.RegisterKeeper.SetRemainingOzoneLimit(ctx, fk.RegisterKeeper.GetRemainingOzoneLimit(ctx).Add(amount))
+	fk.PotKeeper.SetTotalUnissuedPrepay(ctx, fk.PotKeeper.GetTotalUnissuedPrepay(ctx).Sub(payout))
+
+	// the payout is moved back to bank
+	fk.SetPrepayBalance(ctx, sender, bondDenom, fk.GetPrepayBalance(ctx, sender, bondDenom).Sub(payout))
+	fk.SetTotalPrepay(ctx, fk.GetTotalPrepay(ctx).Sub(coins))
+
+	return payout, fee, nil
 }
 
-// GetPrepay Returns bytearr of the existing prepay coins
-func (fk Keeper) GetPrepayBytes(ctx sdk.Context, sender sdk.AccAddress) ([]byte, error) {
+// GetLastPrepayTime returns the time of the last prepay of the sender
+func (fk Keeper) GetLastPrepayTime(ctx sdk.Context, sender sdk.AccAddress) (lastPrepayTime time.Time, found bool) {
 	store := ctx.KVStore(fk.key)
-	storeValue := store.Get(types.PrepayBalanceKey(sender))
-	if storeValue == nil {
-		return []byte{}, sdkerrors.Wrapf(sdkerrors.ErrUnknownAddress, "No prepayment info for acc %s", hex.EncodeToString(types.PrepayBalanceKey(sender)))
+	bz := store.Get(types.LastPrepayTimeKey(sender))
+	if bz == nil {
+		return time.Time{}, false
 	}
-	return storeValue, nil
+	fk.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &lastPrepayTime)
+	return lastPrepayTime, true
 }
 
-// SetPrepay Sets init coins
-func (fk Keeper) setPrepay(ctx sdk.Context, sender sdk.AccAddress, coins sdk.Coins) error {
+// SetLastPrepayTime sets the time of the last prepay of the sender
+func (fk Keeper) SetLastPrepayTime(ctx sdk.Context, sender sdk.AccAddress, lastPrepayTime time.Time) {
 	store := ctx.KVStore(fk.key)
-	storeKey := types.PrepayBalanceKey(sender)
-	balance := sdk.NewInt(0)
-	for _, coin := range coins {
-		balance = balance.Add(coin.Amount)
+	bz := fk.cdc.MustMarshalBinaryLengthPrefixed(lastPrepayTime)
+	store.Set(types.LastPrepayTimeKey(sender), bz)
+}
+
+// IterateLastPrepayTimes iterates over the time of the last prepay of each sender
+func (fk Keeper) IterateLastPrepayTimes(ctx sdk.Context, handler func(sender sdk.AccAddress, lastPrepayTime time.Time) (stop bool)) {
+	store := ctx.KVStore(fk.key)
+	iter := sdk.KVStorePrefixIterator(store, types.LastPrepayTimePrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		sender := sdk.AccAddress(iter.Key()[len(types.LastPrepayTimePrefix):])
+		var lastPrepayTime time.Time
+		fk.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &lastPrepayTime)
+		if handler(sender, lastPrepayTime) {
+			break
+		}
 	}
-	storeValue, err := balance.MarshalJSON()
-	if err != nil {
-		return sdkerrors.Wrapf(sdkerrors.ErrUnknownAddress, "Marshalling failed for acc %s", hex.EncodeToString(types.PrepayBalanceKey(sender)))
+}
+
+// GetPrepayBalance returns the amount of a denom prepaid by the sender, zero if the sender never prepaid any
+func (fk Keeper) GetPrepayBalance(ctx sdk.Context, sender sdk.AccAddress, denom string) sdk.Int {
+	store := ctx.KVStore(fk.key)
+	bz := store.Get(types.PrepayBalanceKey(sender, denom))
+	if bz == nil {
+		return sdk.ZeroInt()
 	}
-	store.Set(storeKey, storeValue)
-	return nil
+	var balance sdk.Int
+	if err := balance.UnmarshalJSON(bz); err != nil {
+		panic(err)
+	}
+	return balance
 }
 
-// AppendPrepay adds more coins to existing coins
-func (fk Keeper) appendPrepay(ctx sdk.Context, sender sdk.AccAddress, coins sdk.Coins) error {
+// SetPrepayBalance sets the amount of a denom prepaid by the sender, the entry is removed once the balance is zero
+func (fk Keeper) SetPrepayBalance(ctx sdk.Context, sender sdk.AccAddress, denom string, balance sdk.Int) {
 	store := ctx.KVStore(fk.key)
-	storeKey := types.PrepayBalanceKey(sender)
-	storeValue := store.Get(storeKey)
-	var prepaidBalance sdk.Int
-	err := prepaidBalance.UnmarshalJSON(storeValue)
+	if balance.IsZero() {
+		store.Delete(types.PrepayBalanceKey(sender, denom))
+		return
+	}
+	bz, err := balance.MarshalJSON()
 	if err != nil {
-		//prepaidBalance = sdk.NewInt(0)
-		return sdkerrors.Wrapf(sdkerrors.ErrUnknownAddress, "Unmarshal failed for acc %s", hex.EncodeToString(types.PrepayBalanceKey(sender)))
+		panic(err)
 	}
+	store.Set(types.PrepayBalanceKey(sender, denom), bz)
+}
+
+// GetPrepay returns the coins prepaid by the sender, one per denom
+func (fk Keeper) GetPrepay(ctx sdk.Context, sender sdk.AccAddress) sdk.Coins {
+	store := ctx.KVStore(fk.key)
+	prefix := types.SenderPrepayBalancesKey(sender)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+	coins := sdk.NewCoins()
+	for ; iter.Valid(); iter.Next() {
+		var balance sdk.Int
+		if err := balance.UnmarshalJSON(iter.Value()); err != nil {
+			panic(err)
+		}
+		coins = coins.Add(sdk.NewCoin(string(iter.Key()[len(prefix):]), balance))
+	}
+	return coins
+}
+
+// addPrepay adds the prepaid coins to the balances of the sender, denom by denom
+func (fk Keeper) addPrepay(ctx sdk.Context, sender sdk.AccAddress, coins sdk.Coins) {
 	for _, coin := range coins {
-		prepaidBalance = prepaidBalance.Add(coin.Amount)
+		fk.SetPrepayBalance(ctx, sender, coin.Denom, fk.GetPrepayBalance(ctx, sender, coin.Denom).Add(coin.Amount))
 	}
-	newStoreValue, err := prepaidBalance.MarshalJSON()
-	if err != nil {
-		return sdkerrors.Wrapf(sdkerrors.ErrUnknownAddress, "Marshal failed for acc %s", hex.EncodeToString(types.PrepayBalanceKey(sender)))
+}
+
+// IteratePrepays iterates over the amount of each denom prepaid by each sender
+func (fk Keeper) IteratePrepays(ctx sdk.Context, handler func(sender sdk.AccAddress, balance sdk.Coin) (stop bool)) {
+	store := ctx.KVStore(fk.key)
+	iter := sdk.KVStorePrefixIterator(store, types.PrepayBalancePrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		sender, denom := types.SplitPrepayBalanceKey(iter.Key())
+		var balance sdk.Int
+		if err := balance.UnmarshalJSON(iter.Value()); err != nil {
+			panic(err)
+		}
+		if handler(sender, sdk.NewCoin(denom, balance)) {
+			break
+		}
 	}
-	store.Set(storeKey, newStoreValue)
-	return nil
 }
 
-func (fk Keeper) doPrepay(ctx sdk.Context, sender sdk.AccAddress, coins sdk.Coins) error {
-	// tar - has key?
-	if fk.hasPrepay(ctx, sender) {
-		// has key - append coins
-		return fk.appendPrepay(ctx, sender, coins)
+// GetTotalPrepay returns the total coins moved out of bank by all prepays
+func (fk Keeper) GetTotalPrepay(ctx sdk.Context) (total sdk.Coins) {
+	store := ctx.KVStore(fk.key)
+	bz := store.Get(types.TotalPrepayKey)
+	if bz == nil {
+		return sdk.NewCoins()
 	}
-	// doesn't have key - create new
-	return fk.setPrepay(ctx, sender, coins)
+	fk.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &total)
+	return total
+}
+
+// SetTotalPrepay sets the total coins moved out of bank by all prepays
+func (fk Keeper) SetTotalPrepay(ctx sdk.Context, total sdk.Coins) {
+	store := ctx.KVStore(fk.key)
+	bz := fk.cdc.MustMarshalBinaryLengthPrefixed(total)
+	store.Set(types.TotalPrepayKey, bz)
+}
+
+// SumPrepayBalances returns the sum of the coins prepaid by each sender
+func (fk Keeper) SumPrepayBalances(ctx sdk.Context) sdk.Coins {
+	sum := sdk.NewCoins()
+	fk.IteratePrepays(ctx, func(_ sdk.AccAddress, balance sdk.Coin) (stop bool) {
+		sum = sum.Add(balance)
+		return false
+	})
+	return sum
 }
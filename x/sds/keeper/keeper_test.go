@@ -0,0 +1,124 @@
+package keeper
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authexported "github.com/cosmos/cosmos-sdk/x/auth/exported"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/staking"
+	"github.com/cosmos/cosmos-sdk/x/supply"
+	stratos "github.com/stratosnet/stratos-chain/types"
+	"github.com/stratosnet/stratos-chain/x/pot"
+	pottypes "github.com/stratosnet/stratos-chain/x/pot/types"
+	"github.com/stratosnet/stratos-chain/x/register"
+	"github.com/stratosnet/stratos-chain/x/sds/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+)
+
+const (
+	StratosBech32Prefix = "st"
+)
+
+var (
+	AccountPubKeyPrefix    = StratosBech32Prefix + "pub"
+	ValidatorAddressPrefix = StratosBech32Prefix + "valoper"
+	ValidatorPubKeyPrefix  = StratosBech32Prefix + "valoperpub"
+	ConsNodeAddressPrefix  = StratosBech32Prefix + "valcons"
+	ConsNodePubKeyPrefix   = StratosBech32Prefix + "valconspub"
+	SdsNodeP2PKeyPrefix    = StratosBech32Prefix + "sdsp2p"
+)
+
+func CreateTestInput(t testing.TB, isCheckTx bool) (sdk.Context, Keeper) {
+
+	SetConfig()
+
+	keyParams := sdk.NewKVStoreKey(params.StoreKey)
+	tkeyParams := sdk.NewTransientStoreKey(params.TStoreKey)
+	keySupply := sdk.NewKVStoreKey(supply.StoreKey)
+	keyAcc := sdk.NewKVStoreKey(auth.StoreKey)
+	keyStaking := sdk.NewKVStoreKey(staking.StoreKey)
+	keyRegister := sdk.NewKVStoreKey(register.StoreKey)
+	keyPot := sdk.NewKVStoreKey(pot.StoreKey)
+	keySds := sdk.NewKVStoreKey(types.StoreKey)
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	ms.MountStoreWithDB(keySupply, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyStaking, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyRegister, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyPot, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keySds, sdk.StoreTypeIAVL, db)
+	err := ms.LoadLatestVersion()
+	require.Nil(t, err)
+
+	cdc := MakeTestCodec()
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "foochainid", Height: 1}, isCheckTx, log.NewNopLogger())
+
+	accountKeeper := auth.NewAccountKeeper(cdc, keyAcc, pk.Subspace(auth.DefaultParamspace), auth.ProtoBaseAccount)
+	bankKeeper := bank.NewBaseKeeper(accountKeeper, pk.Subspace(bank.DefaultParamspace), make(map[string]bool))
+	maccPerms := map[string][]string{
+		auth.FeeCollectorName:                  nil,
+		staking.NotBondedPoolName:              {supply.Burner, supply.Staking},
+		staking.BondedPoolName:                 {supply.Burner, supply.Staking},
+		pot.FoundationAccount:                  nil,
+		pot.TotalRewardPoolName:                nil,
+		pot.TotalUnissuedPrepayPoolName:        nil,
+		register.ResourceNodeBondedPoolName:    nil,
+		register.ResourceNodeNotBondedPoolName: {supply.Burner},
+		register.IndexingNodeBondedPoolName:    nil,
+		register.IndexingNodeNotBondedPoolName: {supply.Burner},
+	}
+	supplyKeeper := supply.NewKeeper(cdc, keySupply, accountKeeper, bankKeeper, maccPerms)
+	stakingKeeper := staking.NewKeeper(cdc, keyStaking, supplyKeeper, pk.Subspace(staking.DefaultParamspace))
+	stakingKeeper.SetParams(ctx, staking.NewParams(staking.DefaultUnbondingTime, staking.DefaultMaxValidators, staking.DefaultMaxEntries, 0, "ustos"))
+	registerKeeper := register.NewKeeper(cdc, keyRegister, pk.Subspace(register.DefaultParamSpace), accountKeeper, bankKeeper, supplyKeeper)
+	registerKeeper.SetParams(ctx, register.DefaultParams())
+	potKeeper := pot.NewKeeper(cdc, keyPot, pk.Subspace(pot.DefaultParamSpace), auth.FeeCollectorName, bankKeeper, supplyKeeper, accountKeeper, stakingKeeper, registerKeeper)
+	potKeeper.SetParams(ctx, pottypes.DefaultParams())
+
+	keeper := NewKeeper(cdc, keySds, pk.Subspace(types.DefaultParamSpace), bankKeeper, supplyKeeper, registerKeeper, potKeeper)
+	keeper.SetParams(ctx, types.DefaultParams())
+
+	return ctx, keeper
+}
+
+// setConfigOnce lets CreateTestInput run more than once per test binary once the config is sealed
+var setConfigOnce sync.Once
+
+func SetConfig() {
+	setConfigOnce.Do(func() {
+		config := stratos.GetConfig()
+		config.SetBech32PrefixForAccount(StratosBech32Prefix, AccountPubKeyPrefix)
+		config.SetBech32PrefixForValidator(ValidatorAddressPrefix, ValidatorPubKeyPrefix)
+		config.SetBech32PrefixForConsensusNode(ConsNodeAddressPrefix, ConsNodePubKeyPrefix)
+		config.SetBech32PrefixForSdsNodeP2P(SdsNodeP2PKeyPrefix)
+		config.Seal()
+	})
+}
+
+// create a codec used only for testing
+func MakeTestCodec() *codec.Codec {
+	var cdc = codec.New()
+
+	// Register AppAccount
+	cdc.RegisterInterface((*authexported.Account)(nil), nil)
+	cdc.RegisterConcrete(&auth.BaseAccount{}, "test/sds/BaseAccount", nil)
+	supply.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+
+	return cdc
+}
@@ -0,0 +1,62 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stratosnet/stratos-chain/x/sds/types"
+)
+
+// MigrateParams sets the sds params added since the chain started to their default value
+func (fk Keeper) MigrateParams(ctx sdk.Context) {
+	if !fk.paramSpace.Has(ctx, types.KeyRedeemCooldown) {
+		fk.paramSpace.Set(ctx, types.KeyRedeemCooldown, types.DefaultRedeemCooldown)
+	}
+	if !fk.paramSpace.Has(ctx, types.KeyRedeemFeeRate) {
+		fk.paramSpace.Set(ctx, types.KeyRedeemFeeRate, types.DefaultRedeemFeeRate)
+	}
+	if !fk.paramSpace.Has(ctx, types.KeyPrepayDenoms) {
+		// only the bond denom was prepaid so far
+		fk.paramSpace.Set(ctx, types.KeyPrepayDenoms, []string{fk.RegisterKeeper.BondDenom(ctx)})
+	}
+	if !fk.paramSpace.Has(ctx, types.KeyPriceHistoryBlocks) {
+		fk.paramSpace.Set(ctx, types.KeyPriceHistoryBlocks, types.DefaultPriceHistoryBlocks)
+	}
+	if !fk.paramSpace.Has(ctx, types.KeyStorageChallengeWindow) {
+		fk.paramSpace.Set(ctx, types.KeyStorageChallengeWindow, types.DefaultStorageChallengeWindow)
+	}
+	if !fk.paramSpace.Has(ctx, types.KeyStorageChallengeQuorum) {
+		fk.paramSpace.Set(ctx, types.KeyStorageChallengeQuorum, types.DefaultStorageChallengeQuorum)
+	}
+}
+
+// MigratePrepayBalances moves the prepay balances stored under the address of the sender alone
+// to the key of the bond denom, the only denom prepaid so far
+func (fk Keeper) MigratePrepayBalances(ctx sdk.Context) (migrated int) {
+	store := ctx.KVStore(fk.key)
+	legacyKeyLen := len(types.PrepayBalancePrefix) + sdk.AddrLen
+
+	iter := sdk.KVStorePrefixIterator(store, types.PrepayBalancePrefix)
+	var legacyKeys, values [][]byte
+	for ; iter.Valid(); iter.Next() {
+		if len(iter.Key()) == legacyKeyLen {
+			legacyKeys = append(legacyKeys, iter.Key())
+			values = append(values, iter.Value())
+		}
+	}
+	iter.Close()
+
+	bondDenom := fk.RegisterKeeper.BondDenom(ctx)
+	for i, key := range legacyKeys {
+		store.Delete(key)
+		var balance sdk.Int
+		if err := balance.UnmarshalJSON(values[i]); err != nil {
+			panic(err)
+		}
+		fk.SetPrepayBalance(ctx, key[len(types.PrepayBalancePrefix):], bondDenom, balance)
+		migrated++
+	}
+
+	fk.Logger(ctx).Info(fmt.Sprintf("moved %d prepay balances to the key of the %s denom", migrated, bondDenom))
+	return migrated
+}
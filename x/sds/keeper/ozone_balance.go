@@ -0,0 +1,101 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stratosnet/stratos-chain/x/pot"
+	"github.com/stratosnet/stratos-chain/x/sds/types"
+)
+
+// GetOzoneBalance returns the uoz owned by the user, zero if the user never purchased any
+func (fk Keeper) GetOzoneBalance(ctx sdk.Context, user sdk.AccAddress) (balance sdk.Int) {
+	store := ctx.KVStore(fk.key)
+	bz := store.Get(types.OzoneBalanceKey(user))
+	if bz == nil {
+		return sdk.ZeroInt()
+	}
+	fk.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &balance)
+	return balance
+}
+
+// SetOzoneBalance sets the uoz owned by the user, the entry is removed once the balance is zero
+func (fk Keeper) SetOzoneBalance(ctx sdk.Context, user sdk.AccAddress, balance sdk.Int) {
+	store := ctx.KVStore(fk.key)
+	if balance.IsZero() {
+		store.Delete(types.OzoneBalanceKey(user))
+		return
+	}
+	bz := fk.cdc.MustMarshalBinaryLengthPrefixed(balance)
+	store.Set(types.OzoneBalanceKey(user), bz)
+}
+
+// IterateOzoneBalances iterates over the ozone balances of all users
+func (fk Keeper) IterateOzoneBalances(ctx sdk.Context, handler func(user sdk.AccAddress, balance sdk.Int) (stop bool)) {
+	store := ctx.KVStore(fk.key)
+	iter := sdk.KVStorePrefixIterator(store, types.OzoneBalancePrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		user := sdk.AccAddress(iter.Key()[len(types.OzoneBalancePrefix):])
+		var balance sdk.Int
+		fk.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &balance)
+		if handler(user, balance) {
+			break
+		}
+	}
+}
+
+// AddOzoneBalance credits the user with purchased uoz
+func (fk Keeper) AddOzoneBalance(ctx sdk.Context, user sdk.AccAddress, amount sdk.Int) sdk.Int {
+	balance := fk.GetOzoneBalance(ctx, user).Add(amount)
+	fk.SetOzoneBalance(ctx, user, balance)
+	return balance
+}
+
+// SubtractOzoneBalance debits uoz from the user, failing if the balance is not enough
+func (fk Keeper) SubtractOzoneBalance(ctx sdk.Context, user sdk.AccAddress, amount sdk.Int) (sdk.Int, error) {
+	balance := fk.GetOzoneBalance(ctx, user)
+	if balance.LT(amount) {
+		return balance, sdkerrors.Wrapf(types.ErrInsufficientOzoneBalance, "%s has %s uoz, %s required", user.String(), balance, amount)
+	}
+	balance = balance.Sub(amount)
+	fk.SetOzoneBalance(ctx, user, balance)
+	return balance, nil
+}
+
+// GetPendingOzoneConsumption returns the uoz of the user that the volume reports still open for votes will consume
+// once settled. Only one of the competing reports of an epoch settles, so an epoch holds the most any of them consumes.
+func (fk Keeper) GetPendingOzoneConsumption(ctx sdk.Context, user sdk.AccAddress) sdk.Int {
+	epochConsumption := make(map[string]sdk.Int)
+	fk.PotKeeper.IterateVolumeReportProposals(ctx, func(proposal pot.VolumeReportProposal) (stop bool) {
+		if proposal.ExpireTime.Before(ctx.BlockHeader().Time) {
+			return false
+		}
+		volume := sdk.ZeroInt()
+		for _, userVolume := range proposal.UsersVolume {
+			if userVolume.UserAddress.Equals(user) {
+				volume = volume.Add(userVolume.Volume)
+			}
+		}
+		epoch := proposal.Epoch.String()
+		if consumption, ok := epochConsumption[epoch]; !ok || consumption.LT(volume) {
+			epochConsumption[epoch] = volume
+		}
+		return false
+	})
+
+	pending := sdk.ZeroInt()
+	for _, consumption := range epochConsumption {
+		pending = pending.Add(consumption)
+	}
+	return pending
+}
+
+// consumeOzone debits the traffic consumed by the user from the uoz the user owns, up to its balance, and returns
+// the uoz actually debited, which leave the total uoz supply
+func (fk Keeper) consumeOzone(ctx sdk.Context, user sdk.AccAddress, volume sdk.Int) (consumed sdk.Int) {
+	balance := fk.GetOzoneBalance(ctx, user)
+	consumed = sdk.MinInt(balance, volume)
+	fk.SetOzoneBalance(ctx, user, balance.Sub(consumed))
+	fk.RegisterKeeper.DecreaseTotalOzoneSupply(ctx, consumed)
+	return consumed
+}
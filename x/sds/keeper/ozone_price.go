@@ -0,0 +1,128 @@
+package keeper
+
+import (
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stratosnet/stratos-chain/x/sds/types"
+)
+
+// setOzonePrice records the uoz price of a height
+func (fk Keeper) setOzonePrice(ctx sdk.Context, height int64, price sdk.Dec) {
+	store := ctx.KVStore(fk.key)
+	store.Set(types.OzonePriceKey(height), fk.cdc.MustMarshalBinaryLengthPrefixed(price))
+}
+
+// GetOzonePrice returns the uoz price in effect at the end of a block, which is the last one recorded up to its height
+func (fk Keeper) GetOzonePrice(ctx sdk.Context, height int64) (price types.OzonePrice, found bool) {
+	store := ctx.KVStore(fk.key)
+	iter := store.ReverseIterator(types.OzonePriceHistoryPrefix, types.OzonePriceKey(height+1))
+	defer iter.Close()
+
+	if !iter.Valid() {
+		return price, false
+	}
+	return fk.unmarshalOzonePrice(iter.Key(), iter.Value()), true
+}
+
+func (fk Keeper) unmarshalOzonePrice(key, value []byte) types.OzonePrice {
+	var price sdk.Dec
+	fk.cdc.MustUnmarshalBinaryLengthPrefixed(value, &price)
+	return types.NewOzonePrice(int64(binary.BigEndian.Uint64(key[len(types.OzonePriceHistoryPrefix):])), price)
+}
+
+// IterateOzonePrices iterates over the uoz prices recorded from startHeight to endHeight, both included, in height order
+func (fk Keeper) IterateOzonePrices(ctx sdk.Context, startHeight, endHeight int64, handler func(price types.OzonePrice) (stop bool)) {
+	store := ctx.KVStore(fk.key)
+	iter := store.Iterator(types.OzonePriceKey(startHeight), types.OzonePriceKey(endHeight+1))
+	defer iter.Close()
+
+	for ; iter.Valid(); iter.Next() {
+		if handler(fk.unmarshalOzonePrice(iter.Key(), iter.Value())) {
+			break
+		}
+	}
+}
+
+// RecordOzonePrice records the uoz price at the end of the block if it changed since the last record,
+// and prunes the records older than the price history blocks param
+func (fk Keeper) RecordOzonePrice(ctx sdk.Context) {
+	height := ctx.BlockHeight()
+	price := fk.currUozPrice(ctx)
+	if last, found := fk.GetOzonePrice(ctx, height); !found || !last.Price.Equal(price) {
+		fk.setOzonePrice(ctx, height, price)
+	}
+
+	historyBlocks := fk.PriceHistoryBlocks(ctx)
+	if historyBlocks == 0 || height <= historyBlocks {
+		return
+	}
+	// the last record up to the start of the history holds the price of its first blocks, it is kept
+	store := ctx.KVStore(fk.key)
+	iter := store.ReverseIterator(types.OzonePriceHistoryPrefix, types.OzonePriceKey(height-historyBlocks+1))
+	var prunedKeys [][]byte
+	for i := 0; iter.Valid(); iter.Next() {
+		if i++; i > 1 {
+			prunedKeys = append(prunedKeys, iter.Key())
+		}
+	}
+	iter.Close()
+
+	for _, key := range prunedKeys {
+		store.Delete(key)
+	}
+}
+
+// GetOzonePriceHistory returns a page of the uoz prices recorded over a height range, in height order
+func (fk Keeper) GetOzonePriceHistory(ctx sdk.Context, params types.QueryUozPriceHistoryParams) []types.OzonePrice {
+	page, limit := params.Page, params.Limit
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = QueryDefaultLimit
+	}
+	skip := (page - 1) * limit
+
+	startHeight, endHeight := params.StartHeight, params.EndHeight
+	if startHeight < 1 {
+		startHeight = 1
+	}
+	if endHeight < 1 || endHeight > ctx.BlockHeight() {
+		endHeight = ctx.BlockHeight()
+	}
+
+	prices := make([]types.OzonePrice, 0)
+	i := 0
+	fk.IterateOzonePrices(ctx, startHeight, endHeight, func(price types.OzonePrice) bool {
+		if i++; i <= skip {
+			return false
+		}
+		prices = append(prices, price)
+		return len(prices) >= limit
+	})
+	return prices
+}
+
+// GetOzonePriceTwap returns the uoz price averaged over the blocks from startHeight to endHeight, both included,
+// each block weighing the price in effect at its end
+func (fk Keeper) GetOzonePriceTwap(ctx sdk.Context, startHeight, endHeight int64) (sdk.Dec, error) {
+	if startHeight < 1 || endHeight < startHeight || endHeight > ctx.BlockHeight() {
+		return sdk.ZeroDec(), sdkerrors.Wrapf(types.ErrInvalidHeightRange, "[%d, %d] at height %d", startHeight, endHeight, ctx.BlockHeight())
+	}
+	current, found := fk.GetOzonePrice(ctx, startHeight)
+	if !found {
+		return sdk.ZeroDec(), sdkerrors.Wrapf(types.ErrOzonePriceNotFound, "%d", startHeight)
+	}
+
+	sum := sdk.ZeroDec()
+	from := startHeight
+	fk.IterateOzonePrices(ctx, startHeight+1, endHeight, func(price types.OzonePrice) bool {
+		sum = sum.Add(current.Price.MulInt64(price.Height - from))
+		current, from = price, price.Height
+		return false
+	})
+	sum = sum.Add(current.Price.MulInt64(endHeight + 1 - from))
+	return sum.QuoInt64(endHeight + 1 - startHeight), nil
+}
@@ -1,8 +1,5 @@
 package keeper
 
-/*
-// TODO: Define if your module needs Parameters, if not this can be deleted
-
 import (
 	"time"
 
@@ -11,13 +8,48 @@ import (
 )
 
 // GetParams returns the total set of sds parameters.
-func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
-	k.paramspace.GetParamSet(ctx, &params)
+func (fk Keeper) GetParams(ctx sdk.Context) (params types.Params) {
+	fk.paramSpace.GetParamSet(ctx, &params)
 	return params
 }
 
 // SetParams sets the sds parameters to the param space.
-func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
-	k.paramspace.SetParamSet(ctx, &params)
+func (fk Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	fk.paramSpace.SetParamSet(ctx, &params)
+}
+
+// RedeemCooldown - time after the last prepay of a sender before it can redeem ozone
+func (fk Keeper) RedeemCooldown(ctx sdk.Context) (res time.Duration) {
+	fk.paramSpace.Get(ctx, types.KeyRedeemCooldown, &res)
+	return
+}
+
+// RedeemFeeRate - share of the redeemed ustos kept in the unissued prepay pool
+func (fk Keeper) RedeemFeeRate(ctx sdk.Context) (res sdk.Dec) {
+	fk.paramSpace.Get(ctx, types.KeyRedeemFeeRate, &res)
+	return
+}
+
+// PrepayDenoms - denoms accepted by prepay
+func (fk Keeper) PrepayDenoms(ctx sdk.Context) (res []string) {
+	fk.paramSpace.Get(ctx, types.KeyPrepayDenoms, &res)
+	return
+}
+
+// PriceHistoryBlocks - number of blocks the uoz price history is kept for, 0 to keep it all
+func (fk Keeper) PriceHistoryBlocks(ctx sdk.Context) (res int64) {
+	fk.paramSpace.Get(ctx, types.KeyPriceHistoryBlocks, &res)
+	return
+}
+
+// StorageChallengeWindow - number of blocks a resource node has to answer a storage challenge
+func (fk Keeper) StorageChallengeWindow(ctx sdk.Context) (res int64) {
+	fk.paramSpace.Get(ctx, types.KeyStorageChallengeWindow, &res)
+	return
+}
+
+// StorageChallengeQuorum - number of SP nodes whose storage challenges a resource node has to fail before it is suspended
+func (fk Keeper) StorageChallengeQuorum(ctx sdk.Context) (res int64) {
+	fk.paramSpace.Get(ctx, types.KeyStorageChallengeQuorum, &res)
+	return
 }
-*/
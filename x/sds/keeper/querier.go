@@ -7,38 +7,75 @@ import (
 	// this line is used by starport scaffolding # 1
 	abci "github.com/tendermint/tendermint/abci/types"
 
+	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stratosnet/stratos-chain/x/sds/types"
 )
 
 const (
-	QueryFileHash       = "uploaded_file"
-	QueryPrepay         = "prepay"
-	QuerySimulatePrepay = "simulate_prepay"
-	QueryCurrUozPrice   = "curr_uoz_price"
-	QueryUozSupply      = "uoz_supply"
+	QueryParams              = "params"
+	QueryFileHash            = "uploaded_file"
+	QueryPrepay              = "prepay"
+	QuerySimulatePrepay      = "simulate_prepay"
+	QuerySimulateRedeemOzone = "simulate_redeem_ozone"
+	QueryCurrUozPrice        = "curr_uoz_price"
+	QueryUozPriceHistory     = "uoz_price_history"
+	QueryUozPriceTwap        = "uoz_price_twap"
+	QueryUozSupply           = "uoz_supply"
+	QueryOzoneBalance        = "ozone_balance"
+	QueryFilesByUploader     = "files_by_uploader"
+	QueryFilesByResourceNode = "files_by_resource_node"
+	QueryStorageChallenges   = "storage_challenges"
+	QueryDefaultLimit        = 100
 )
 
 // NewQuerier creates a new querier for sds clients.
 func NewQuerier(k Keeper) sdk.Querier {
 	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
 		switch path[0] {
+		case QueryParams:
+			return queryParams(ctx, req, k)
 		case QueryFileHash:
 			return queryFileHash(ctx, req, k)
 		case QueryPrepay:
 			return queryPrepay(ctx, req, k)
 		case QuerySimulatePrepay:
 			return querySimulatePrepay(ctx, req, k)
+		case QuerySimulateRedeemOzone:
+			return querySimulateRedeemOzone(ctx, req, k)
 		case QueryCurrUozPrice:
 			return queryCurrUozPrice(ctx, req, k)
+		case QueryUozPriceHistory:
+			return queryUozPriceHistory(ctx, req, k)
+		case QueryUozPriceTwap:
+			return queryUozPriceTwap(ctx, req, k)
 		case QueryUozSupply:
 			return queryUozSupply(ctx, req, k)
+		case QueryOzoneBalance:
+			return queryOzoneBalance(ctx, req, k)
+		case QueryFilesByUploader:
+			return queryFilesByUploader(ctx, req, k)
+		case QueryFilesByResourceNode:
+			return queryFilesByResourceNode(ctx, req, k)
+		case QueryStorageChallenges:
+			return queryStorageChallenges(ctx, req, k)
 		default:
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown sds query endpoint "+req.String()+hex.EncodeToString(req.Data))
 		}
 	}
 }
 
+// queryParams fetch the sds params.
+func queryParams(ctx sdk.Context, _ abci.RequestQuery, k Keeper) ([]byte, error) {
+	params := k.GetParams(ctx)
+	res, err := codec.MarshalJSONIndent(k.cdc, params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return res, nil
+}
+
 // queryFileHash fetch an file's hash for the supplied height.
 func queryFileHash(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
 	fileHash, err := k.GetFileInfoBytesByFileHash(ctx, req.Data)
@@ -49,13 +86,14 @@ func queryFileHash(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, er
 	return fileHash, nil
 }
 
-// queryPrepay fetch prepaid balance of an account.
+// queryPrepay fetch the coins prepaid by an account.
 func queryPrepay(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
-	balance, err := k.GetPrepayBytes(ctx, req.Data)
+	prepaid := k.GetPrepay(ctx, req.Data)
+	res, err := codec.MarshalJSONIndent(k.cdc, prepaid)
 	if err != nil {
-		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
 	}
-	return balance, nil
+	return res, nil
 }
 
 // querySimulatePrepay fetch amt of uoz with a simulated prepay of X ustos.
@@ -70,6 +108,21 @@ func querySimulatePrepay(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]by
 	return uozAmtByte, nil
 }
 
+// querySimulateRedeemOzone fetch amt of ustos paid out with a simulated redemption of X uoz, the fee excluded.
+func querySimulateRedeemOzone(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var amtToRedeem sdk.Int
+	err := amtToRedeem.UnmarshalJSON(req.Data)
+	if err != nil {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	if !amtToRedeem.IsPositive() {
+		return []byte{}, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "uoz to redeem should be positive")
+	}
+	payout, _ := k.simulateRedeemPayout(ctx, amtToRedeem)
+	payoutByte, _ := payout.MarshalJSON()
+	return payoutByte, nil
+}
+
 // queryCurrUozPrice fetch current uoz price.
 func queryCurrUozPrice(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
 	uozPrice := k.currUozPrice(ctx)
@@ -77,6 +130,36 @@ func queryCurrUozPrice(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte
 	return uozPriceByte, nil
 }
 
+// queryUozPriceHistory fetch a page of the uoz prices recorded over a height range.
+func queryUozPriceHistory(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryUozPriceHistoryParams
+	err := k.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+	prices := k.GetOzonePriceHistory(ctx, params)
+	bz, err := codec.MarshalJSONIndent(k.cdc, prices)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+// queryUozPriceTwap fetch the time weighted average uoz price over a height range.
+func queryUozPriceTwap(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryUozPriceTwapParams
+	err := k.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+	twap, err := k.GetOzonePriceTwap(ctx, params.StartHeight, params.EndHeight)
+	if err != nil {
+		return nil, err
+	}
+	twapByte, _ := twap.MarshalJSON()
+	return twapByte, nil
+}
+
 // queryUozSupply fetch remaining/total uoz supply.
 func queryUozSupply(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
 	type Supply struct {
@@ -88,3 +171,50 @@ func queryUozSupply(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, e
 	uozSupplyByte, _ := json.Marshal(uozSupply)
 	return uozSupplyByte, nil
 }
+
+// queryOzoneBalance fetch the uoz owned by an account.
+func queryOzoneBalance(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	balance := k.GetOzoneBalance(ctx, req.Data)
+	balanceByte, _ := balance.MarshalJSON()
+	return balanceByte, nil
+}
+
+// queryFilesByUploader fetch a page of the files uploaded by an account.
+func queryFilesByUploader(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryFilesParams
+	err := k.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	files := k.GetFilesByUploader(ctx, params.Address, params.Page, params.Limit)
+	bz, err := codec.MarshalJSONIndent(k.cdc, files)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+// queryFilesByResourceNode fetch a page of the files held by a resource node.
+func queryFilesByResourceNode(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryFilesParams
+	err := k.cdc.UnmarshalJSON(req.Data, &params)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	files := k.GetFilesByResourceNode(ctx, params.Address, params.Page, params.Limit)
+	bz, err := codec.MarshalJSONIndent(k.cdc, files)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+// queryStorageChallenges fetch the open storage challenges of a resource node.
+func queryStorageChallenges(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	challenges := k.GetStorageChallengesByResourceNode(ctx, req.Data)
+	bz, err := codec.MarshalJSONIndent(k.cdc, challenges)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
@@ -0,0 +1,256 @@
+package keeper
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stratosnet/stratos-chain/x/sds/types"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+// GetStorageChallenge returns the open storage challenge of a resource node on a file
+func (fk Keeper) GetStorageChallenge(ctx sdk.Context, nodeAddr sdk.AccAddress, fileHash []byte) (challenge types.StorageChallenge, found bool) {
+	store := ctx.KVStore(fk.key)
+	bz := store.Get(types.StorageChallengeKey(nodeAddr, fileHash))
+	if bz == nil {
+		return challenge, false
+	}
+	return types.MustUnmarshalStorageChallenge(fk.cdc, bz), true
+}
+
+// SetStorageChallenge stores an open storage challenge and queues it by deadline
+func (fk Keeper) SetStorageChallenge(ctx sdk.Context, challenge types.StorageChallenge) {
+	store := ctx.KVStore(fk.key)
+	challengeKey := types.StorageChallengeKey(challenge.ResourceNode, challenge.FileHash)
+	store.Set(challengeKey, types.MustMarshalStorageChallenge(fk.cdc, challenge))
+	store.Set(types.StorageChallengeQueueKey(challenge.Deadline, challenge.ResourceNode, challenge.FileHash), challengeKey)
+}
+
+// removeStorageChallenge removes a storage challenge along with its deadline queue entry
+func (fk Keeper) removeStorageChallenge(ctx sdk.Context, challenge types.StorageChallenge) {
+	store := ctx.KVStore(fk.key)
+	store.Delete(types.StorageChallengeKey(challenge.ResourceNode, challenge.FileHash))
+	store.Delete(types.StorageChallengeQueueKey(challenge.Deadline, challenge.ResourceNode, challenge.FileHash))
+}
+
+// GetStorageChallengesByResourceNode returns the open storage challenges of a resource node
+func (fk Keeper) GetStorageChallengesByResourceNode(ctx sdk.Context, nodeAddr sdk.AccAddress) []types.StorageChallenge {
+	store := ctx.KVStore(fk.key)
+	iter := sdk.KVStorePrefixIterator(store, types.ResourceNodeChallengesKey(nodeAddr))
+	defer iter.Close()
+
+	challenges := make([]types.StorageChallenge, 0)
+	for ; iter.Valid(); iter.Next() {
+		challenges = append(challenges, types.MustUnmarshalStorageChallenge(fk.cdc, iter.Value()))
+	}
+	return challenges
+}
+
+// drawChunkIndex picks the chunk to prove from the hash of the last block, so that neither
+// the challenger nor the resource node can choose it
+func (fk Keeper) drawChunkIndex(ctx sdk.Context, nodeAddr sdk.AccAddress, fileHash []byte, chunkCount uint64) uint64 {
+	seed := append([]byte{}, ctx.BlockHeader().LastBlockId.Hash...)
+	seed = append(seed, nodeAddr.Bytes()...)
+	seed = append(seed, fileHash...)
+	digest := sha256.Sum256(seed)
+	return binary.BigEndian.Uint64(digest[:8]) % chunkCount
+}
+
+// IssueStorageChallenge asks a resource node holding a file to prove it stores one of the file chunks
+func (fk Keeper) IssueStorageChallenge(ctx sdk.Context, fileHash []byte, nodeAddr, challenger sdk.AccAddress,
+) (types.StorageChallenge, error) {
+
+	indexingNode, found := fk.RegisterKeeper.GetIndexingNode(ctx, challenger)
+	if !found || indexingNode.IsSuspended() || !indexingNode.GetStatus().Equal(sdk.Bonded) {
+		return types.StorageChallenge{}, sdkerrors.Wrapf(types.ErrNotIndexingNode, "challenger %s", challenger.String())
+	}
+	resourceNode, found := fk.RegisterKeeper.GetResourceNode(ctx, nodeAddr)
+	if !found {
+		return types.StorageChallenge{}, sdkerrors.Wrap(types.ErrNotResourceNode, nodeAddr.String())
+	}
+	// a suspended node is still challenged, passing a challenge is the only way to resume its withheld rewards
+	if !resourceNode.GetStatus().Equal(sdk.Bonded) {
+		return types.StorageChallenge{}, sdkerrors.Wrap(types.ErrNodeNotChallengeable, nodeAddr.String())
+	}
+
+	fileInfo, found := fk.GetFileInfo(ctx, fileHash)
+	if !found {
+		return types.StorageChallenge{}, sdkerrors.Wrap(types.ErrFileNotFound, hex.EncodeToString(fileHash))
+	}
+	if !fileInfo.IsChallengeable() {
+		return types.StorageChallenge{}, types.ErrFileNotChallengeable
+	}
+	if !fileInfo.IsHeldBy(nodeAddr) {
+		return types.StorageChallenge{}, types.ErrNotFileHolder
+	}
+	if _, found := fk.GetStorageChallenge(ctx, nodeAddr, fileHash); found {
+		return types.StorageChallenge{}, types.ErrChallengeAlreadyOpen
+	}
+
+	chunkIndex := fk.drawChunkIndex(ctx, nodeAddr, fileHash, fileInfo.ChunkCount)
+	challenge := types.NewStorageChallenge(fileHash, nodeAddr, challenger, chunkIndex, ctx.BlockHeight(),
+		ctx.BlockHeight()+fk.StorageChallengeWindow(ctx))
+	fk.SetStorageChallenge(ctx, challenge)
+	return challenge, nil
+}
+
+// SubmitStorageProof checks the merkle path of the challenged chunk against the merkle root of the file.
+// A valid proof closes the challenge, clears the failed challenges of the resource node
+// and resumes its rewards if they were withheld.
+func (fk Keeper) SubmitStorageProof(ctx sdk.Context, fileHash []byte, nodeAddr sdk.AccAddress, chunk []byte, aunts [][]byte,
+) (types.StorageChallenge, error) {
+
+	challenge, found := fk.GetStorageChallenge(ctx, nodeAddr, fileHash)
+	if !found {
+		return challenge, types.ErrChallengeNotFound
+	}
+	fileInfo, found := fk.GetFileInfo(ctx, fileHash)
+	if !found {
+		return challenge, sdkerrors.Wrap(types.ErrFileNotFound, hex.EncodeToString(fileHash))
+	}
+
+	proof := merkle.SimpleProof{
+		Total:    int(fileInfo.ChunkCount),
+		Index:    int(challenge.ChunkIndex),
+		LeafHash: tmhash.Sum(append([]byte{0}, chunk...)), // leaf prefix of the tendermint simple merkle tree
+		Aunts:    aunts,
+	}
+	if err := proof.Verify(fileInfo.MerkleRoot, chunk); err != nil {
+		return challenge, sdkerrors.Wrap(types.ErrInvalidStorageProof, err.Error())
+	}
+
+	fk.removeStorageChallenge(ctx, challenge)
+	fk.removeStorageChallengeFailures(ctx, nodeAddr)
+	fk.PotKeeper.RemoveRewardWithheld(ctx, nodeAddr)
+	return challenge, nil
+}
+
+// ProcessExpiredStorageChallenges fails the storage challenges left unanswered at their deadline.
+// Once a resource node failed the challenges of a quorum of SP nodes, it is suspended
+// and its rewards are withheld until it passes a later challenge.
+func (fk Keeper) ProcessExpiredStorageChallenges(ctx sdk.Context) {
+	store := ctx.KVStore(fk.key)
+	iter := store.Iterator(types.StorageChallengeQueuePrefix, sdk.PrefixEndBytes(types.StorageChallengeQueueHeightKey(ctx.BlockHeight())))
+	defer iter.Close()
+
+	var challengeKeys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		challengeKeys = append(challengeKeys, iter.Value())
+	}
+
+	for _, challengeKey := range challengeKeys {
+		bz := store.Get(challengeKey)
+		if bz == nil {
+			continue
+		}
+		challenge := types.MustUnmarshalStorageChallenge(fk.cdc, bz)
+		fk.removeStorageChallenge(ctx, challenge)
+
+		// the file was removed or moved away from the node in the meantime, nothing left to prove
+		fileInfo, found := fk.GetFileInfo(ctx, challenge.FileHash)
+		if !found || !fileInfo.IsHeldBy(challenge.ResourceNode) {
+			continue
+		}
+		fk.failStorageChallenge(ctx, challenge)
+	}
+}
+
+// failStorageChallenge records the failure of a storage challenge. The resource node is suspended and its rewards
+// are withheld once it failed the challenges of a quorum of SP nodes, so that no single SP node can get it suspended.
+func (fk Keeper) failStorageChallenge(ctx sdk.Context, challenge types.StorageChallenge) {
+	fk.SetStorageChallengeFailure(ctx, types.NewStorageChallengeFailure(challenge.ResourceNode, challenge.Challenger, challenge.FileHash))
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeStorageChallengeFailed,
+			sdk.NewAttribute(types.AttributeKeyResourceNode, challenge.ResourceNode.String()),
+			sdk.NewAttribute(types.AttributeKeyFileHash, hex.EncodeToString(challenge.FileHash)),
+			sdk.NewAttribute(types.AttributeKeyChallenger, challenge.Challenger.String()),
+		),
+	)
+	fk.Logger(ctx).Info(fmt.Sprintf("resource node %s failed the storage challenge on file %s",
+		challenge.ResourceNode.String(), hex.EncodeToString(challenge.FileHash)))
+
+	failures := int64(len(fk.GetStorageChallengeFailures(ctx, challenge.ResourceNode)))
+	if failures < fk.StorageChallengeQuorum(ctx) {
+		return
+	}
+	fk.removeStorageChallengeFailures(ctx, challenge.ResourceNode)
+	if err := fk.RegisterKeeper.SuspendResourceNode(ctx, challenge.ResourceNode); err != nil {
+		fk.Logger(ctx).Error(fmt.Sprintf("failed to suspend resource node %s: %s", challenge.ResourceNode.String(), err.Error()))
+	}
+	fk.PotKeeper.SetRewardWithheld(ctx, challenge.ResourceNode)
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeStorageChallengeSuspend,
+			sdk.NewAttribute(types.AttributeKeyResourceNode, challenge.ResourceNode.String()),
+			sdk.NewAttribute(types.AttributeKeyFailures, fmt.Sprintf("%d", failures)),
+		),
+	)
+	fk.Logger(ctx).Info(fmt.Sprintf("resource node %s suspended after failing the storage challenges of %d SP nodes",
+		challenge.ResourceNode.String(), failures))
+}
+
+// SetStorageChallengeFailure records the failed storage challenge of a resource node, one per challenger
+func (fk Keeper) SetStorageChallengeFailure(ctx sdk.Context, failure types.StorageChallengeFailure) {
+	store := ctx.KVStore(fk.key)
+	store.Set(types.StorageChallengeFailureKey(failure.ResourceNode, failure.Challenger), failure.FileHash)
+}
+
+// GetStorageChallengeFailures returns the failed storage challenges of a resource node since it last passed one
+func (fk Keeper) GetStorageChallengeFailures(ctx sdk.Context, nodeAddr sdk.AccAddress) []types.StorageChallengeFailure {
+	failures := make([]types.StorageChallengeFailure, 0)
+	fk.iterateStorageChallengeFailures(ctx, types.ResourceNodeChallengeFailuresKey(nodeAddr),
+		func(failure types.StorageChallengeFailure) (stop bool) {
+			failures = append(failures, failure)
+			return false
+		},
+	)
+	return failures
+}
+
+// removeStorageChallengeFailures clears the failed storage challenges of a resource node
+func (fk Keeper) removeStorageChallengeFailures(ctx sdk.Context, nodeAddr sdk.AccAddress) {
+	store := ctx.KVStore(fk.key)
+	for _, failure := range fk.GetStorageChallengeFailures(ctx, nodeAddr) {
+		store.Delete(types.StorageChallengeFailureKey(failure.ResourceNode, failure.Challenger))
+	}
+}
+
+// IterateStorageChallengeFailures iterates over the failed storage challenges of all resource nodes
+func (fk Keeper) IterateStorageChallengeFailures(ctx sdk.Context, handler func(failure types.StorageChallengeFailure) (stop bool)) {
+	fk.iterateStorageChallengeFailures(ctx, types.StorageChallengeFailurePrefix, handler)
+}
+
+func (fk Keeper) iterateStorageChallengeFailures(ctx sdk.Context, prefix []byte,
+	handler func(failure types.StorageChallengeFailure) (stop bool)) {
+
+	store := ctx.KVStore(fk.key)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		key := iter.Key()[len(types.StorageChallengeFailurePrefix):]
+		failure := types.NewStorageChallengeFailure(sdk.AccAddress(key[:sdk.AddrLen]), sdk.AccAddress(key[sdk.AddrLen:]), iter.Value())
+		if handler(failure) {
+			break
+		}
+	}
+}
+
+// IterateStorageChallenges iterates over the open storage challenges of all resource nodes
+func (fk Keeper) IterateStorageChallenges(ctx sdk.Context, handler func(challenge types.StorageChallenge) (stop bool)) {
+	store := ctx.KVStore(fk.key)
+	iter := sdk.KVStorePrefixIterator(store, types.StorageChallengePrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if handler(types.MustUnmarshalStorageChallenge(fk.cdc, iter.Value())) {
+			break
+		}
+	}
+}
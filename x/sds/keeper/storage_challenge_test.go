@@ -0,0 +1,160 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stratosnet/stratos-chain/x/register"
+	"github.com/stratosnet/stratos-chain/x/sds/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/merkle"
+)
+
+var (
+	challengeResPubKey  = ed25519.GenPrivKey().PubKey()
+	challengeResAddr    = sdk.AccAddress(challengeResPubKey.Address())
+	challengeIdxPubKey  = ed25519.GenPrivKey().PubKey()
+	challengeIdxAddr    = sdk.AccAddress(challengeIdxPubKey.Address())
+	challengeIdxPubKey2 = ed25519.GenPrivKey().PubKey()
+	challengeIdxAddr2   = sdk.AccAddress(challengeIdxPubKey2.Address())
+	challengeOwner      = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	challengeFileHash   = []byte("c03661732294feb49caf6dc16c7cbb2534986d73")
+	challengeChunks     = [][]byte{[]byte("chunk0"), []byte("chunk1"), []byte("chunk2"), []byte("chunk3")}
+)
+
+// setupStorageChallenge bonds a resource node and two indexing nodes and uploads a file held by the resource node
+func setupStorageChallenge(t *testing.T) (sdk.Context, Keeper, []*merkle.SimpleProof) {
+	ctx, k := CreateTestInput(t, false)
+
+	description := register.NewDescription("sds://challenge", "", "", "", "")
+	resourceNode := register.NewResourceNode("sds://resourceNode", challengeResPubKey, challengeOwner, description, "4", ctx.BlockTime())
+	resourceNode.Status = sdk.Bonded
+	k.RegisterKeeper.SetResourceNode(ctx, resourceNode)
+	indexingNode := register.NewIndexingNode("sds://indexingNode", challengeIdxPubKey, challengeOwner, description, ctx.BlockTime())
+	indexingNode.Status = sdk.Bonded
+	k.RegisterKeeper.SetIndexingNode(ctx, indexingNode)
+	indexingNode2 := register.NewIndexingNode("sds://indexingNode2", challengeIdxPubKey2, challengeOwner, description, ctx.BlockTime())
+	indexingNode2.Status = sdk.Bonded
+	k.RegisterKeeper.SetIndexingNode(ctx, indexingNode2)
+
+	merkleRoot, proofs := merkle.SimpleProofsFromByteSlices(challengeChunks)
+	_, err := k.UploadFile(ctx, challengeFileHash, challengeIdxAddr, challengeOwner, 1024, 1,
+		[]sdk.AccAddress{challengeResAddr}, 0, merkleRoot, uint64(len(challengeChunks)))
+	require.NoError(t, err)
+	return ctx, k, proofs
+}
+
+func TestStorageChallengeAnsweredInWindow(t *testing.T) {
+	ctx, k, proofs := setupStorageChallenge(t)
+	window := int64(10)
+	params := k.GetParams(ctx)
+	params.StorageChallengeWindow = window
+	k.SetParams(ctx, params)
+	k.SetStorageChallengeFailure(ctx, types.NewStorageChallengeFailure(challengeResAddr, challengeIdxAddr2, challengeFileHash))
+
+	challenge, err := k.IssueStorageChallenge(ctx, challengeFileHash, challengeResAddr, challengeIdxAddr)
+	require.NoError(t, err)
+	require.Equal(t, ctx.BlockHeight()+window, challenge.Deadline)
+
+	// a proof submitted at the deadline is still accepted
+	ctx = ctx.WithBlockHeight(challenge.Deadline)
+	_, err = k.SubmitStorageProof(ctx, challengeFileHash, challengeResAddr, challengeChunks[challenge.ChunkIndex],
+		proofs[challenge.ChunkIndex].Aunts)
+	require.NoError(t, err)
+	_, found := k.GetStorageChallenge(ctx, challengeResAddr, challengeFileHash)
+	require.False(t, found)
+	require.Empty(t, k.GetStorageChallengeFailures(ctx, challengeResAddr))
+
+	// the answered challenge is no longer queued for expiry
+	k.ProcessExpiredStorageChallenges(ctx)
+	node, _ := k.RegisterKeeper.GetResourceNode(ctx, challengeResAddr)
+	require.False(t, node.IsSuspended())
+	require.False(t, k.PotKeeper.IsRewardWithheld(ctx, challengeResAddr))
+}
+
+func TestStorageChallengeExpiry(t *testing.T) {
+	ctx, k, _ := setupStorageChallenge(t)
+	require.Equal(t, int64(2), k.StorageChallengeQuorum(ctx))
+
+	challenge, err := k.IssueStorageChallenge(ctx, challengeFileHash, challengeResAddr, challengeIdxAddr)
+	require.NoError(t, err)
+
+	// the challenge stays open until its deadline
+	k.ProcessExpiredStorageChallenges(ctx.WithBlockHeight(challenge.Deadline - 1))
+	_, found := k.GetStorageChallenge(ctx, challengeResAddr, challengeFileHash)
+	require.True(t, found)
+
+	// failing the challenges of a single SP node, however many, does not suspend the node
+	for i := 0; i < 2; i++ {
+		ctx = ctx.WithBlockHeight(challenge.Deadline)
+		k.ProcessExpiredStorageChallenges(ctx)
+		_, found = k.GetStorageChallenge(ctx, challengeResAddr, challengeFileHash)
+		require.False(t, found)
+		require.Len(t, k.GetStorageChallengeFailures(ctx, challengeResAddr), 1)
+		node, _ := k.RegisterKeeper.GetResourceNode(ctx, challengeResAddr)
+		require.False(t, node.IsSuspended())
+		require.False(t, k.PotKeeper.IsRewardWithheld(ctx, challengeResAddr))
+
+		challenge, err = k.IssueStorageChallenge(ctx, challengeFileHash, challengeResAddr, challengeIdxAddr)
+		require.NoError(t, err)
+	}
+	k.removeStorageChallenge(ctx, challenge)
+
+	// failing the challenge of a second SP node reaches the quorum
+	challenge, err = k.IssueStorageChallenge(ctx, challengeFileHash, challengeResAddr, challengeIdxAddr2)
+	require.NoError(t, err)
+	ctx = ctx.WithBlockHeight(challenge.Deadline).WithBlockTime(time.Unix(1000, 0))
+	k.ProcessExpiredStorageChallenges(ctx)
+	node, _ := k.RegisterKeeper.GetResourceNode(ctx, challengeResAddr)
+	require.True(t, node.IsSuspended())
+	require.Equal(t, ctx.BlockTime().Add(k.RegisterKeeper.SuspendDuration(ctx)), node.SuspendedUntil)
+	require.True(t, k.PotKeeper.IsRewardWithheld(ctx, challengeResAddr))
+	require.Empty(t, k.GetStorageChallengeFailures(ctx, challengeResAddr))
+
+	// a challenge on a file the node no longer holds expires without a failure
+	challenge, err = k.IssueStorageChallenge(ctx, challengeFileHash, challengeResAddr, challengeIdxAddr)
+	require.NoError(t, err)
+	_, err = k.DeleteFile(ctx, challengeFileHash, challengeOwner)
+	require.NoError(t, err)
+	k.ProcessExpiredStorageChallenges(ctx.WithBlockHeight(challenge.Deadline))
+	require.Empty(t, k.GetStorageChallengeFailures(ctx, challengeResAddr))
+}
+
+func TestStorageChallengeWithholding(t *testing.T) {
+	ctx, k, proofs := setupStorageChallenge(t)
+	params := k.GetParams(ctx)
+	params.StorageChallengeQuorum = 1
+	k.SetParams(ctx, params)
+
+	challenge, err := k.IssueStorageChallenge(ctx, challengeFileHash, challengeResAddr, challengeIdxAddr)
+	require.NoError(t, err)
+	k.ProcessExpiredStorageChallenges(ctx.WithBlockHeight(challenge.Deadline))
+	require.True(t, k.PotKeeper.IsRewardWithheld(ctx, challengeResAddr))
+
+	// lifting the suspension does not resume the rewards
+	ctx = ctx.WithBlockHeight(challenge.Deadline + 1).WithBlockTime(ctx.BlockTime().Add(k.RegisterKeeper.SuspendDuration(ctx)))
+	node, _ := k.RegisterKeeper.GetResourceNode(ctx, challengeResAddr)
+	require.NoError(t, k.RegisterKeeper.UnsuspendResourceNode(ctx, challengeResAddr, node.OwnerAddress))
+	require.True(t, k.PotKeeper.IsRewardWithheld(ctx, challengeResAddr))
+
+	// a wrong proof keeps them withheld, the right one resumes them
+	challenge, err = k.IssueStorageChallenge(ctx, challengeFileHash, challengeResAddr, challengeIdxAddr)
+	require.NoError(t, err)
+	wrongIndex := (challenge.ChunkIndex + 1) % uint64(len(challengeChunks))
+	_, err = k.SubmitStorageProof(ctx, challengeFileHash, challengeResAddr, challengeChunks[wrongIndex], proofs[wrongIndex].Aunts)
+	require.True(t, types.ErrInvalidStorageProof.Is(err))
+	require.True(t, k.PotKeeper.IsRewardWithheld(ctx, challengeResAddr))
+	_, err = k.SubmitStorageProof(ctx, challengeFileHash, challengeResAddr, challengeChunks[challenge.ChunkIndex],
+		proofs[challenge.ChunkIndex].Aunts)
+	require.NoError(t, err)
+	require.False(t, k.PotKeeper.IsRewardWithheld(ctx, challengeResAddr))
+
+	// an unbonded node can't be challenged
+	node, _ = k.RegisterKeeper.GetResourceNode(ctx, challengeResAddr)
+	node.Status = sdk.Unbonded
+	k.RegisterKeeper.SetResourceNode(ctx, node)
+	_, err = k.IssueStorageChallenge(ctx, challengeFileHash, challengeResAddr, challengeIdxAddr)
+	require.True(t, types.ErrNodeNotChallengeable.Is(err))
+}
@@ -278,6 +278,12 @@ func setupAllResourceNodes() []register.ResourceNode {
 	resourceNode4 = resourceNode4.AddToken(initialStakeRes4)
 	resourceNode5 = resourceNode5.AddToken(initialStakeRes5)
 
+	resourceNode1.Status = sdk.Bonded
+	resourceNode2.Status = sdk.Bonded
+	resourceNode3.Status = sdk.Bonded
+	resourceNode4.Status = sdk.Bonded
+	resourceNode5.Status = sdk.Bonded
+
 	var resourceNodes []register.ResourceNode
 	resourceNodes = append(resourceNodes, resourceNode1)
 	resourceNodes = append(resourceNodes, resourceNode2)
@@ -0,0 +1,73 @@
+package simulation
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	tmkv "github.com/tendermint/tendermint/libs/kv"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stratosnet/stratos-chain/x/sds/types"
+)
+
+// DecodeStore unmarshals the KVPair's Value to the corresponding sds type
+func DecodeStore(cdc *codec.Codec, kvA, kvB tmkv.Pair) string {
+	switch {
+	case bytes.Equal(kvA.Key[:1], types.PrepayBalancePrefix):
+		var prepayA, prepayB sdk.Int
+		if err := prepayA.UnmarshalJSON(kvA.Value); err != nil {
+			panic(err)
+		}
+		if err := prepayB.UnmarshalJSON(kvB.Value); err != nil {
+			panic(err)
+		}
+		return fmt.Sprintf("%v\n%v", prepayA, prepayB)
+
+	case bytes.Equal(kvA.Key[:1], types.FileStoreKeyPrefix):
+		fileA := types.MustUnmarshalFileInfo(cdc, kvA.Value)
+		fileB := types.MustUnmarshalFileInfo(cdc, kvB.Value)
+		return fmt.Sprintf("%v\n%v", fileA, fileB)
+
+	case bytes.Equal(kvA.Key[:1], types.TotalPrepayKey):
+		var totalA, totalB sdk.Coins
+		cdc.MustUnmarshalBinaryLengthPrefixed(kvA.Value, &totalA)
+		cdc.MustUnmarshalBinaryLengthPrefixed(kvB.Value, &totalB)
+		return fmt.Sprintf("%v\n%v", totalA, totalB)
+
+	case bytes.Equal(kvA.Key[:1], types.OzoneBalancePrefix):
+		var valueA, valueB sdk.Int
+		cdc.MustUnmarshalBinaryLengthPrefixed(kvA.Value, &valueA)
+		cdc.MustUnmarshalBinaryLengthPrefixed(kvB.Value, &valueB)
+		return fmt.Sprintf("%v\n%v", valueA, valueB)
+
+	case bytes.Equal(kvA.Key[:1], types.LastPrepayTimePrefix):
+		var timeA, timeB time.Time
+		cdc.MustUnmarshalBinaryLengthPrefixed(kvA.Value, &timeA)
+		cdc.MustUnmarshalBinaryLengthPrefixed(kvB.Value, &timeB)
+		return fmt.Sprintf("%v\n%v", timeA, timeB)
+
+	case bytes.Equal(kvA.Key[:1], types.OzonePriceHistoryPrefix):
+		var priceA, priceB sdk.Dec
+		cdc.MustUnmarshalBinaryLengthPrefixed(kvA.Value, &priceA)
+		cdc.MustUnmarshalBinaryLengthPrefixed(kvB.Value, &priceB)
+		return fmt.Sprintf("%v\n%v", priceA, priceB)
+
+	case bytes.Equal(kvA.Key[:1], types.UploaderFileIndexPrefix),
+		bytes.Equal(kvA.Key[:1], types.ResourceNodeFileIndexPrefix),
+		bytes.Equal(kvA.Key[:1], types.FileExpiryQueuePrefix),
+		bytes.Equal(kvA.Key[:1], types.StorageChallengeQueuePrefix),
+		bytes.Equal(kvA.Key[:1], types.StorageChallengeFailurePrefix):
+		return fmt.Sprintf("%s\n%s", hex.EncodeToString(kvA.Value), hex.EncodeToString(kvB.Value))
+
+	case bytes.Equal(kvA.Key[:1], types.StorageChallengePrefix):
+		challengeA := types.MustUnmarshalStorageChallenge(cdc, kvA.Value)
+		challengeB := types.MustUnmarshalStorageChallenge(cdc, kvB.Value)
+		return fmt.Sprintf("%v\n%v", challengeA, challengeB)
+
+	default:
+		panic(fmt.Sprintf("invalid sds key prefix %X", kvA.Key[:1]))
+	}
+}
@@ -0,0 +1,90 @@
+package simulation
+
+// DONTCOVER
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	"github.com/stratosnet/stratos-chain/x/sds/types"
+)
+
+// Simulation parameter constants
+const (
+	RedeemCooldown         = "redeem_cooldown"
+	RedeemFeeRate          = "redeem_fee_rate"
+	PriceHistoryBlocks     = "price_history_blocks"
+	StorageChallengeWindow = "storage_challenge_window"
+	StorageChallengeQuorum = "storage_challenge_quorum"
+)
+
+// GenRedeemCooldown randomized RedeemCooldown, short enough for ozone to be redeemed during the simulation
+func GenRedeemCooldown(r *rand.Rand) time.Duration {
+	return time.Duration(r.Intn(60*60)) * time.Second
+}
+
+// GenRedeemFeeRate randomized RedeemFeeRate
+func GenRedeemFeeRate(r *rand.Rand) sdk.Dec {
+	return sdk.NewDecWithPrec(int64(r.Intn(10)), 2)
+}
+
+// GenPriceHistoryBlocks randomized PriceHistoryBlocks, short enough for the uoz price history to be pruned during the simulation
+func GenPriceHistoryBlocks(r *rand.Rand) int64 {
+	return r.Int63n(50)
+}
+
+// GenStorageChallengeWindow randomized StorageChallengeWindow, short enough for storage challenges to expire during the simulation
+func GenStorageChallengeWindow(r *rand.Rand) int64 {
+	return r.Int63n(50) + 1
+}
+
+// GenStorageChallengeQuorum randomized StorageChallengeQuorum
+func GenStorageChallengeQuorum(r *rand.Rand) int64 {
+	return r.Int63n(3) + 1
+}
+
+// RandomizedGenState generates a GenesisState for sds. The prepays, ozone balances and files are
+// all created by the simulated transactions, so the chain starts with no other state than the params.
+func RandomizedGenState(simState *module.SimulationState) {
+	var redeemCooldown time.Duration
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, RedeemCooldown, &redeemCooldown, simState.Rand,
+		func(r *rand.Rand) { redeemCooldown = GenRedeemCooldown(r) },
+	)
+
+	var redeemFeeRate sdk.Dec
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, RedeemFeeRate, &redeemFeeRate, simState.Rand,
+		func(r *rand.Rand) { redeemFeeRate = GenRedeemFeeRate(r) },
+	)
+
+	var priceHistoryBlocks int64
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, PriceHistoryBlocks, &priceHistoryBlocks, simState.Rand,
+		func(r *rand.Rand) { priceHistoryBlocks = GenPriceHistoryBlocks(r) },
+	)
+
+	var storageChallengeWindow int64
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, StorageChallengeWindow, &storageChallengeWindow, simState.Rand,
+		func(r *rand.Rand) { storageChallengeWindow = GenStorageChallengeWindow(r) },
+	)
+
+	var storageChallengeQuorum int64
+	simState.AppParams.GetOrGenerate(
+		simState.Cdc, StorageChallengeQuorum, &storageChallengeQuorum, simState.Rand,
+		func(r *rand.Rand) { storageChallengeQuorum = GenStorageChallengeQuorum(r) },
+	)
+
+	// the staking module of the simulation bonds the same denomination
+	sdsGenesis := types.DefaultGenesisState()
+	sdsGenesis.Params = types.NewParams(redeemCooldown, redeemFeeRate, []string{sdk.DefaultBondDenom}, priceHistoryBlocks,
+		storageChallengeWindow, storageChallengeQuorum)
+
+	fmt.Printf("Selected sds genesis state:\n%s\n", codec.MustMarshalJSONIndent(simState.Cdc, sdsGenesis))
+	simState.GenState[types.ModuleName] = simState.Cdc.MustMarshalJSON(sdsGenesis)
+}
@@ -0,0 +1,155 @@
+package simulation
+
+import (
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/simapp/helpers"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+	"github.com/stratosnet/stratos-chain/x/sds/keeper"
+	"github.com/stratosnet/stratos-chain/x/sds/types"
+)
+
+// Simulation operation weights constants
+const (
+	OpWeightMsgPrepay      = "op_weight_msg_prepay"
+	OpWeightMsgRedeemOzone = "op_weight_msg_redeem_ozone"
+)
+
+// Default simulation operation weights
+const (
+	DefaultWeightMsgPrepay      = 50
+	DefaultWeightMsgRedeemOzone = 20
+)
+
+// WeightedOperations returns all the operations from the module with their respective weights
+func WeightedOperations(
+	appParams simulation.AppParams, cdc *codec.Codec, ak types.AccountKeeper, k keeper.Keeper,
+) simulation.WeightedOperations {
+
+	var weightMsgPrepay int
+	appParams.GetOrGenerate(cdc, OpWeightMsgPrepay, &weightMsgPrepay, nil,
+		func(_ *rand.Rand) {
+			weightMsgPrepay = DefaultWeightMsgPrepay
+		},
+	)
+
+	var weightMsgRedeemOzone int
+	appParams.GetOrGenerate(cdc, OpWeightMsgRedeemOzone, &weightMsgRedeemOzone, nil,
+		func(_ *rand.Rand) {
+			weightMsgRedeemOzone = DefaultWeightMsgRedeemOzone
+		},
+	)
+
+	return simulation.WeightedOperations{
+		simulation.NewWeightedOperation(
+			weightMsgPrepay,
+			SimulateMsgPrepay(ak, k),
+		),
+		simulation.NewWeightedOperation(
+			weightMsgRedeemOzone,
+			SimulateMsgRedeemOzone(ak, k),
+		),
+	}
+}
+
+// SimulateMsgPrepay generates a MsgPrepay of a random amount of the spendable bond denom
+func SimulateMsgPrepay(ak types.AccountKeeper, k keeper.Keeper) simulation.Operation {
+	return func(
+		r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, chainID string,
+	) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+
+		// the prepaid coins are sent through the bank module
+		if !k.BankKeeper.GetSendEnabled(ctx) {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		simAccount, _ := simulation.RandomAcc(r, accs)
+		account := ak.GetAccount(ctx, simAccount.Address)
+		if account == nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		denom := k.RegisterKeeper.BondDenom(ctx)
+		spendable := account.SpendableCoins(ctx.BlockTime()).AmountOf(denom)
+		amount, err := simulation.RandPositiveInt(r, spendable.QuoRaw(2))
+		if err != nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		coins := sdk.NewCoins(sdk.NewCoin(denom, amount))
+		msg := types.NewMsgPrepay(simAccount.Address, coins, sdk.ZeroInt())
+
+		fees, err := simulation.RandomFees(r, ctx, account.SpendableCoins(ctx.BlockTime()).Sub(coins))
+		if err != nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, err
+		}
+
+		tx := helpers.GenTx(
+			[]sdk.Msg{msg},
+			fees,
+			helpers.DefaultGenTxGas,
+			chainID,
+			[]uint64{account.GetAccountNumber()},
+			[]uint64{account.GetSequence()},
+			simAccount.PrivKey,
+		)
+
+		_, _, err = app.Deliver(tx)
+		if err != nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, err
+		}
+
+		return simulation.NewOperationMsg(msg, true, ""), nil, nil
+	}
+}
+
+// SimulateMsgRedeemOzone generates a MsgRedeemOzone of a random part of the uoz owned by the account
+func SimulateMsgRedeemOzone(ak types.AccountKeeper, k keeper.Keeper) simulation.Operation {
+	return func(
+		r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, chainID string,
+	) (simulation.OperationMsg, []simulation.FutureOperation, error) {
+
+		simAccount, _ := simulation.RandomAcc(r, accs)
+		account := ak.GetAccount(ctx, simAccount.Address)
+		if account == nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		amount, err := simulation.RandPositiveInt(r, k.GetOzoneBalance(ctx, simAccount.Address))
+		if err != nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		// skip the redemptions that would be refused, e.g. during the cooldown or above the prepaid ustos
+		if _, _, err = k.CheckRedeemOzone(ctx, simAccount.Address, amount); err != nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, nil
+		}
+
+		msg := types.NewMsgRedeemOzone(simAccount.Address, amount)
+
+		fees, err := simulation.RandomFees(r, ctx, account.SpendableCoins(ctx.BlockTime()))
+		if err != nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, err
+		}
+
+		tx := helpers.GenTx(
+			[]sdk.Msg{msg},
+			fees,
+			helpers.DefaultGenTxGas,
+			chainID,
+			[]uint64{account.GetAccountNumber()},
+			[]uint64{account.GetSequence()},
+			simAccount.PrivKey,
+		)
+
+		_, _, err = app.Deliver(tx)
+		if err != nil {
+			return simulation.NoOpMsg(types.ModuleName), nil, err
+		}
+
+		return simulation.NewOperationMsg(msg, true, ""), nil, nil
+	}
+}
@@ -0,0 +1,43 @@
+package simulation
+
+// DONTCOVER
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+	"github.com/stratosnet/stratos-chain/x/sds/types"
+)
+
+// ParamChanges defines the parameters that can be modified by param change proposals
+// on the simulation
+func ParamChanges(r *rand.Rand) []simulation.ParamChange {
+	return []simulation.ParamChange{
+		simulation.NewSimParamChange(types.ModuleName, string(types.KeyRedeemCooldown),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%d\"", GenRedeemCooldown(r))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.KeyRedeemFeeRate),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%s\"", GenRedeemFeeRate(r))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.KeyPriceHistoryBlocks),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%d\"", GenPriceHistoryBlocks(r))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.KeyStorageChallengeWindow),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%d\"", GenStorageChallengeWindow(r))
+			},
+		),
+		simulation.NewSimParamChange(types.ModuleName, string(types.KeyStorageChallengeQuorum),
+			func(r *rand.Rand) string {
+				return fmt.Sprintf("\"%d\"", GenStorageChallengeQuorum(r))
+			},
+		),
+	}
+}
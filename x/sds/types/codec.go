@@ -9,6 +9,11 @@ func RegisterCodec(cdc *codec.Codec) {
 	// this line is used by starport scaffolding # 1
 	cdc.RegisterConcrete(MsgFileUpload{}, "sds/MsgFileUpload", nil)
 	cdc.RegisterConcrete(MsgPrepay{}, "sds/MsgPrepay", nil)
+	cdc.RegisterConcrete(MsgRedeemOzone{}, "sds/MsgRedeemOzone", nil)
+	cdc.RegisterConcrete(MsgFileDelete{}, "sds/MsgFileDelete", nil)
+	cdc.RegisterConcrete(MsgFileUpdateReplicas{}, "sds/MsgFileUpdateReplicas", nil)
+	cdc.RegisterConcrete(MsgStorageChallenge{}, "sds/MsgStorageChallenge", nil)
+	cdc.RegisterConcrete(MsgStorageProof{}, "sds/MsgStorageProof", nil)
 }
 
 // ModuleCdc defines the module codec
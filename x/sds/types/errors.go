@@ -5,5 +5,33 @@ import (
 )
 
 var (
-	ErrInvalid = sdkerrors.Register(ModuleName, 1, "error invalid")
+	ErrInvalid                  = sdkerrors.Register(ModuleName, 1, "error invalid")
+	ErrInsufficientOzoneBalance = sdkerrors.Register(ModuleName, 2, "insufficient ozone balance")
+	ErrFileAlreadyExists        = sdkerrors.Register(ModuleName, 3, "file hash already exists")
+	ErrFileNotFound             = sdkerrors.Register(ModuleName, 4, "file hash does not exist")
+	ErrNotFileManager           = sdkerrors.Register(ModuleName, 5, "sender is neither the reporter nor the uploader of the file")
+	ErrInvalidReplicas          = sdkerrors.Register(ModuleName, 6, "replicas should be positive and not less than the resource nodes holding the file")
+	ErrDuplicateResourceNode    = sdkerrors.Register(ModuleName, 7, "duplicate resource node holding the file")
+	ErrNotResourceNode          = sdkerrors.Register(ModuleName, 8, "not a bonded, unsuspended resource node")
+	ErrInvalidFileSize          = sdkerrors.Register(ModuleName, 9, "file size should be positive")
+	ErrInvalidExpireHeight      = sdkerrors.Register(ModuleName, 10, "expire height should be above the current height")
+	ErrNotIndexingNode          = sdkerrors.Register(ModuleName, 11, "reporter is not a bonded, unsuspended SP node")
+	ErrInvalidMerkleRoot        = sdkerrors.Register(ModuleName, 12, "merkle root and chunk count should be both set or both empty")
+	ErrFileNotChallengeable     = sdkerrors.Register(ModuleName, 13, "file has no merkle root to be challenged against")
+	ErrNotFileHolder            = sdkerrors.Register(ModuleName, 14, "resource node does not hold the file")
+	ErrChallengeAlreadyOpen     = sdkerrors.Register(ModuleName, 15, "storage challenge already open for the file and resource node")
+	ErrChallengeNotFound        = sdkerrors.Register(ModuleName, 16, "no open storage challenge for the file and resource node")
+	ErrInvalidStorageProof      = sdkerrors.Register(ModuleName, 17, "invalid storage proof")
+	ErrNodeNotChallengeable     = sdkerrors.Register(ModuleName, 18, "resource node is not bonded")
+	ErrRedeemCooldown           = sdkerrors.Register(ModuleName, 19, "ozone can not be redeemed before the cooldown since the last prepay is over")
+	ErrRedeemTooSmall           = sdkerrors.Register(ModuleName, 20, "redeemed ozone is not worth any ustos")
+	ErrInsufficientPrepay       = sdkerrors.Register(ModuleName, 21, "redemption pays out more than the sender prepaid")
+	ErrInsufficientUnissued     = sdkerrors.Register(ModuleName, 22, "not enough unissued prepay to pay out the redemption")
+	ErrUnsupportedPrepayDenom   = sdkerrors.Register(ModuleName, 23, "denom is not accepted by prepay")
+	ErrInvalidHeightRange       = sdkerrors.Register(ModuleName, 24, "invalid block height range")
+	ErrOzonePriceNotFound       = sdkerrors.Register(ModuleName, 25, "no uoz price recorded at the height")
+	ErrMinOzoneOut              = sdkerrors.Register(ModuleName, 26, "prepay purchases less uoz than the min ozone out")
+	ErrUnpricedPrepayDenom      = sdkerrors.Register(ModuleName, 27, "prepay denom has no uoz price")
+	ErrMissingBondPrepayDenom   = sdkerrors.Register(ModuleName, 28, "prepay denoms must include the bond denom")
+	ErrOzonePendingConsumption  = sdkerrors.Register(ModuleName, 29, "ozone is still to be consumed by open volume reports")
 )
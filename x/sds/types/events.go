@@ -2,16 +2,44 @@ package types
 
 // sds module event types
 const (
-	EventTypeFileUpload = "FileUpload"
-	EventTypePrepay     = "Prepay"
+	EventTypeFileUpload   = "FileUpload"
+	EventTypePrepay       = "Prepay"
+	EventTypeRedeemOzone  = "RedeemOzone"
+	EventTypeConsumeUoz   = "ConsumeUoz"
+	EventTypeUozShortfall = "UozShortfall"
+
+	EventTypeFileDelete         = "FileDelete"
+	EventTypeFileUpdateReplicas = "FileUpdateReplicas"
+	EventTypeFileExpired        = "FileExpired"
+
+	EventTypeStorageChallenge        = "StorageChallenge"
+	EventTypeStorageProof            = "StorageProof"
+	EventTypeStorageChallengeFailed  = "StorageChallengeFailed"
+	EventTypeStorageChallengeSuspend = "StorageChallengeSuspend"
 
 	AttributeKeyReporter = "reporter"
 	AttributeKeyFileHash = "file_hash"
 	AttributeKeyUploader = "uploader"
+	AttributeKeySender   = "sender"
+	AttributeKeyReplicas = "replicas"
+	AttributeKeyFileSize = "file_size"
+
+	AttributeKeyResourceNode = "resource_node"
+	AttributeKeyChallenger   = "challenger"
+	AttributeKeyChunkIndex   = "chunk_index"
+	AttributeKeyDeadline     = "deadline"
+	AttributeKeyFailures     = "failures"
 
 	AttributeKeyRecipient    = "recipient"
 	AttributeKeyCoins        = "coins"
 	AttributeKeyPurchasedUoz = "purchased"
+	AttributeKeyRedeemedUoz  = "redeemed"
+	AttributeKeyPayout       = "payout"
+	AttributeKeyFee          = "fee"
+	AttributeKeyUser         = "user"
+	AttributeKeyConsumedUoz  = "consumed"
+	AttributeKeyShortfallUoz = "shortfall"
+	AttributeKeyEpoch        = "epoch"
 
 	AttributeValueCategory = ModuleName
 )
@@ -2,6 +2,7 @@ package types
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	authexported "github.com/cosmos/cosmos-sdk/x/auth/exported"
 )
 
 // ParamSubspace defines the expected Subspace interfacace
@@ -9,3 +10,8 @@ type ParamSubspace interface {
 	SetUploadFile(ctx sdk.Context, key []byte, value string)
 	GetUploadFile(ctx sdk.Context, key []byte) MsgFileUpload
 }
+
+// AccountKeeper defines the expected account keeper (noalias)
+type AccountKeeper interface {
+	GetAccount(ctx sdk.Context, addr sdk.AccAddress) authexported.Account // only used for simulation
+}
@@ -1,26 +1,145 @@
 package types
 
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
 // GenesisState - all sds state that must be provided at genesis
 type GenesisState struct {
-	// TODO: Fill out what is needed by the module for genesis
+	Params            Params                    `json:"params" yaml:"params"`
+	Prepays           []PrepayBalance           `json:"prepays" yaml:"prepays"`
+	OzoneBalances     []OzoneBalance            `json:"ozone_balances" yaml:"ozone_balances"`
+	Files             []FileRecord              `json:"files" yaml:"files"`
+	StorageChallenges []StorageChallenge        `json:"storage_challenges" yaml:"storage_challenges"`
+	ChallengeFailures []StorageChallengeFailure `json:"challenge_failures" yaml:"challenge_failures"`
+}
+
+// PrepayBalance is the total amount of each denom prepaid by a sender
+type PrepayBalance struct {
+	Sender         sdk.AccAddress `json:"sender" yaml:"sender"`
+	Balance        sdk.Coins      `json:"balance" yaml:"balance"`
+	LastPrepayTime time.Time      `json:"last_prepay_time" yaml:"last_prepay_time"` // zero if the sender never prepaid on this chain
+}
+
+// OzoneBalance is the uoz owned by a user and not consumed yet
+type OzoneBalance struct {
+	User    sdk.AccAddress `json:"user" yaml:"user"`
+	Balance sdk.Int        `json:"balance" yaml:"balance"`
 }
 
 // NewGenesisState creates a new GenesisState object
-func NewGenesisState( /* TODO: Fill out with what is needed for genesis state */ ) GenesisState {
+func NewGenesisState(params Params, prepays []PrepayBalance, ozoneBalances []OzoneBalance, files []FileRecord,
+	storageChallenges []StorageChallenge, challengeFailures []StorageChallengeFailure) GenesisState {
 	return GenesisState{
-		// TODO: Fill out according to your genesis state
+		Params:            params,
+		Prepays:           prepays,
+		OzoneBalances:     ozoneBalances,
+		Files:             files,
+		StorageChallenges: storageChallenges,
+		ChallengeFailures: challengeFailures,
 	}
 }
 
 // DefaultGenesisState - default GenesisState used by Cosmos Hub
 func DefaultGenesisState() GenesisState {
-	return GenesisState{
-		// TODO: Fill out according to your genesis state, these values will be initialized but empty
-	}
+	return GenesisState{Params: DefaultParams()}
 }
 
 // ValidateGenesis validates the sds genesis parameters
 func ValidateGenesis(data GenesisState) error {
-	// TODO: Create a sanity check to make sure the state conforms to the modules needs
+	if err := data.Params.ValidateBasic(); err != nil {
+		return err
+	}
+
+	prepaySenders := make(map[string]bool)
+	for _, prepay := range data.Prepays {
+		if prepay.Sender.Empty() {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing address of prepay sender")
+		}
+		if !prepay.Balance.IsValid() {
+			return fmt.Errorf("invalid prepay balance of %s: %s", prepay.Sender, prepay.Balance)
+		}
+		for _, coin := range prepay.Balance {
+			if !IsPrepayDenom(data.Params.PrepayDenoms, coin.Denom) {
+				return sdkerrors.Wrapf(ErrUnsupportedPrepayDenom, "prepay balance of %s in %s", prepay.Sender, coin.Denom)
+			}
+		}
+		if prepaySenders[prepay.Sender.String()] {
+			return fmt.Errorf("duplicate prepay balance of %s", prepay.Sender)
+		}
+		prepaySenders[prepay.Sender.String()] = true
+	}
+
+	ozoneUsers := make(map[string]bool)
+	for _, balance := range data.OzoneBalances {
+		if balance.User.Empty() {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing address of ozone owner")
+		}
+		if balance.Balance.IsNil() || !balance.Balance.IsPositive() {
+			return fmt.Errorf("ozone balance of %s should be positive", balance.User)
+		}
+		if ozoneUsers[balance.User.String()] {
+			return fmt.Errorf("duplicate ozone balance of %s", balance.User)
+		}
+		ozoneUsers[balance.User.String()] = true
+	}
+
+	files := make(map[string]FileInfo)
+	for _, file := range data.Files {
+		fileHash, err := hex.DecodeString(file.FileHash)
+		if err != nil || len(fileHash) == 0 {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid file hash %s", file.FileHash)
+		}
+		if _, found := files[file.FileHash]; found {
+			return sdkerrors.Wrap(ErrFileAlreadyExists, file.FileHash)
+		}
+		if err := file.FileInfo.Validate(); err != nil {
+			return sdkerrors.Wrap(err, file.FileHash)
+		}
+		files[file.FileHash] = file.FileInfo
+	}
+
+	challenges := make(map[string]bool)
+	for _, challenge := range data.StorageChallenges {
+		fileHash := hex.EncodeToString(challenge.FileHash)
+		fileInfo, found := files[fileHash]
+		if !found {
+			return sdkerrors.Wrap(ErrFileNotFound, fileHash)
+		}
+		if !fileInfo.IsChallengeable() {
+			return sdkerrors.Wrap(ErrFileNotChallengeable, fileHash)
+		}
+		if !fileInfo.IsHeldBy(challenge.ResourceNode) {
+			return sdkerrors.Wrap(ErrNotFileHolder, challenge.ResourceNode.String())
+		}
+		if challenge.Challenger.Empty() {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing address of challenger")
+		}
+		if challenge.ChunkIndex >= fileInfo.ChunkCount || challenge.Deadline < challenge.Height {
+			return sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid storage challenge on file %s", fileHash)
+		}
+		key := challenge.ResourceNode.String() + "/" + fileHash
+		if challenges[key] {
+			return sdkerrors.Wrap(ErrChallengeAlreadyOpen, key)
+		}
+		challenges[key] = true
+	}
+
+	failures := make(map[string]bool)
+	for _, failure := range data.ChallengeFailures {
+		if failure.ResourceNode.Empty() || failure.Challenger.Empty() {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing address of failed storage challenge")
+		}
+		key := failure.ResourceNode.String() + "/" + failure.Challenger.String()
+		if failures[key] {
+			return fmt.Errorf("duplicate failed storage challenge %s", key)
+		}
+		failures[key] = true
+	}
 	return nil
 }
@@ -1,5 +1,9 @@
 package types
 
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
 const (
 	// ModuleName is the name of the module
 	ModuleName = "sds"
@@ -15,18 +19,124 @@ const (
 )
 
 var (
-	// Acc Balance prefix for sds store
+	// prepaid balance prefix for sds store, one balance per sender and denom
 	PrepayBalancePrefix = []byte{0x01}
 	// FileStorage prefix for sds store
 	FileStoreKeyPrefix = []byte{0x02}
+	// OzoneBalance prefix for sds store
+	OzoneBalancePrefix = []byte{0x03}
+	// file hashes indexed by uploader
+	UploaderFileIndexPrefix = []byte{0x04}
+	// file hashes indexed by the resource nodes holding them
+	ResourceNodeFileIndexPrefix = []byte{0x05}
+	// file hashes indexed by expire height
+	FileExpiryQueuePrefix = []byte{0x06}
+	// open storage challenges by resource node and file hash
+	StorageChallengePrefix = []byte{0x07}
+	// open storage challenges indexed by deadline
+	StorageChallengeQueuePrefix = []byte{0x08}
+	// total coins moved out of bank by all prepays
+	TotalPrepayKey = []byte{0x09}
+	// time of the last prepay of each sender
+	LastPrepayTimePrefix = []byte{0x0a}
+	// uoz prices by the block height they changed at
+	OzonePriceHistoryPrefix = []byte{0x0b}
+	// failed storage challenges of each resource node by challenger, cleared once the node passes a challenge
+	StorageChallengeFailurePrefix = []byte{0x0c}
 )
 
-// PrepayBalanceKey turn an address to key used to get prepaid balance from the sds store
-func PrepayBalanceKey(acc []byte) []byte {
-	return append(PrepayBalancePrefix, acc...)
+// SenderPrepayBalancesKey is the prefix of the balances prepaid by an address
+func SenderPrepayBalancesKey(sender sdk.AccAddress) []byte {
+	return append(PrepayBalancePrefix, sender.Bytes()...)
+}
+
+// PrepayBalanceKey turn an address and a denom to key used to get prepaid balance from the sds store
+func PrepayBalanceKey(sender sdk.AccAddress, denom string) []byte {
+	return append(SenderPrepayBalancesKey(sender), []byte(denom)...)
+}
+
+// SplitPrepayBalanceKey splits a prepaid balance key into the sender and the denom
+func SplitPrepayBalanceKey(key []byte) (sender sdk.AccAddress, denom string) {
+	addrEnd := len(PrepayBalancePrefix) + sdk.AddrLen
+	return sdk.AccAddress(key[len(PrepayBalancePrefix):addrEnd]), string(key[addrEnd:])
+}
+
+// LastPrepayTimeKey turn an address to key used to get the time of its last prepay from the sds store
+func LastPrepayTimeKey(acc []byte) []byte {
+	return append(LastPrepayTimePrefix, acc...)
+}
+
+// OzonePriceKey is the key of the uoz price recorded at a height
+func OzonePriceKey(height int64) []byte {
+	return append(OzonePriceHistoryPrefix, sdk.Uint64ToBigEndian(uint64(height))...)
 }
 
 // FileStoreKey turn an address to key used to get it from the account store
 func FileStoreKey(sender []byte) []byte {
 	return append(FileStoreKeyPrefix, sender...)
 }
+
+// OzoneBalanceKey turn an address to key used to get the ozone balance from the sds store
+func OzoneBalanceKey(acc []byte) []byte {
+	return append(OzoneBalancePrefix, acc...)
+}
+
+// UploaderFilesKey is the prefix of the file hashes uploaded by an address
+func UploaderFilesKey(uploader sdk.AccAddress) []byte {
+	return append(UploaderFileIndexPrefix, uploader.Bytes()...)
+}
+
+// UploaderFileIndexKey is the key of a file hash in the uploader index
+func UploaderFileIndexKey(uploader sdk.AccAddress, fileHash []byte) []byte {
+	return append(UploaderFilesKey(uploader), fileHash...)
+}
+
+// ResourceNodeFilesKey is the prefix of the file hashes held by a resource node
+func ResourceNodeFilesKey(nodeAddr sdk.AccAddress) []byte {
+	return append(ResourceNodeFileIndexPrefix, nodeAddr.Bytes()...)
+}
+
+// ResourceNodeFileIndexKey is the key of a file hash in the resource node index
+func ResourceNodeFileIndexKey(nodeAddr sdk.AccAddress, fileHash []byte) []byte {
+	return append(ResourceNodeFilesKey(nodeAddr), fileHash...)
+}
+
+// FileExpiryQueueHeightKey is the prefix of the file hashes expiring at a height
+func FileExpiryQueueHeightKey(height int64) []byte {
+	return append(FileExpiryQueuePrefix, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// FileExpiryQueueKey is the key of a file hash in the expiry queue
+func FileExpiryQueueKey(height int64, fileHash []byte) []byte {
+	return append(FileExpiryQueueHeightKey(height), fileHash...)
+}
+
+// ResourceNodeChallengesKey is the prefix of the open storage challenges of a resource node
+func ResourceNodeChallengesKey(nodeAddr sdk.AccAddress) []byte {
+	return append(StorageChallengePrefix, nodeAddr.Bytes()...)
+}
+
+// StorageChallengeKey is the key of the open storage challenge of a resource node on a file
+func StorageChallengeKey(nodeAddr sdk.AccAddress, fileHash []byte) []byte {
+	return append(ResourceNodeChallengesKey(nodeAddr), fileHash...)
+}
+
+// StorageChallengeQueueHeightKey is the prefix of the storage challenges whose deadline is a height
+func StorageChallengeQueueHeightKey(height int64) []byte {
+	return append(StorageChallengeQueuePrefix, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// StorageChallengeQueueKey is the key of a storage challenge in the deadline queue
+func StorageChallengeQueueKey(height int64, nodeAddr sdk.AccAddress, fileHash []byte) []byte {
+	return append(StorageChallengeQueueHeightKey(height), StorageChallengeKey(nodeAddr, fileHash)[1:]...)
+}
+
+// ResourceNodeChallengeFailuresKey is the prefix of the failed storage challenges of a resource node
+func ResourceNodeChallengeFailuresKey(nodeAddr sdk.AccAddress) []byte {
+	return append(StorageChallengeFailurePrefix, nodeAddr.Bytes()...)
+}
+
+// StorageChallengeFailureKey is the key of the failed storage challenge of a resource node issued by a challenger
+func StorageChallengeFailureKey(nodeAddr, challenger sdk.AccAddress) []byte {
+	return append(ResourceNodeChallengeFailuresKey(nodeAddr), challenger.Bytes()...)
+}
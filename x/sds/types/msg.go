@@ -3,28 +3,47 @@ package types
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/tendermint/tendermint/crypto/merkle"
 )
 
 const (
-	ConstFileUpload = "FileUploadTx"
-	ConstSdsPrepay  = "SdsPrepayTx"
+	ConstFileUpload         = "FileUploadTx"
+	ConstSdsPrepay          = "SdsPrepayTx"
+	ConstSdsRedeemOzone     = "SdsRedeemOzoneTx"
+	ConstFileDelete         = "FileDeleteTx"
+	ConstFileUpdateReplicas = "FileUpdateReplicasTx"
+	ConstStorageChallenge   = "StorageChallengeTx"
+	ConstStorageProof       = "StorageProofTx"
 )
 
 type MsgFileUpload struct {
-	FileHash []byte         `json:"file_hash" yaml:"file_hash"` // hash of file
-	Reporter sdk.AccAddress `json:"reporter" yaml:"reporter"`   // sp node who reports this tx
-	Uploader sdk.AccAddress `json:"uploader" yaml:"uploader`    // who uploads the file
+	FileHash      []byte           `json:"file_hash" yaml:"file_hash"`           // hash of file
+	Reporter      sdk.AccAddress   `json:"reporter" yaml:"reporter"`             // sp node who reports this tx
+	Uploader      sdk.AccAddress   `json:"uploader" yaml:"uploader"`             // who uploads the file
+	FileSize      uint64           `json:"file_size" yaml:"file_size"`           // size of the file in bytes
+	Replicas      uint64           `json:"replicas" yaml:"replicas"`             // number of replicas to keep
+	ResourceNodes []sdk.AccAddress `json:"resource_nodes" yaml:"resource_nodes"` // resource nodes holding a replica
+	ExpireHeight  int64            `json:"expire_height" yaml:"expire_height"`   // height the file is removed at, 0 if it never expires
+	MerkleRoot    []byte           `json:"merkle_root" yaml:"merkle_root"`       // root of the merkle tree built over the file chunks, optional
+	ChunkCount    uint64           `json:"chunk_count" yaml:"chunk_count"`       // number of leaves of the merkle tree, optional
 }
 
 // verify interface at compile time
 var _ sdk.Msg = &MsgFileUpload{}
 
 // NewMsg<Action> creates a new Msg<Action> instance
-func NewMsgUpload(fileHash []byte, reporter, uploader sdk.AccAddress) MsgFileUpload {
+func NewMsgUpload(fileHash []byte, reporter, uploader sdk.AccAddress, fileSize, replicas uint64,
+	resourceNodes []sdk.AccAddress, expireHeight int64, merkleRoot []byte, chunkCount uint64) MsgFileUpload {
 	return MsgFileUpload{
-		FileHash: fileHash,
-		Reporter: reporter,
-		Uploader: uploader,
+		FileHash:      fileHash,
+		Reporter:      reporter,
+		Uploader:      uploader,
+		FileSize:      fileSize,
+		Replicas:      replicas,
+		ResourceNodes: resourceNodes,
+		ExpireHeight:  expireHeight,
+		MerkleRoot:    merkleRoot,
+		ChunkCount:    chunkCount,
 	}
 }
 
@@ -52,22 +71,51 @@ func (msg MsgFileUpload) ValidateBasic() error {
 	if len(msg.FileHash) == 0 {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "missing file hash")
 	}
+	if msg.FileSize == 0 {
+		return ErrInvalidFileSize
+	}
+	if msg.ExpireHeight < 0 {
+		return ErrInvalidExpireHeight
+	}
+	if (len(msg.MerkleRoot) == 0) != (msg.ChunkCount == 0) {
+		return ErrInvalidMerkleRoot
+	}
+	return validateReplicas(msg.Replicas, msg.ResourceNodes)
+}
+
+// validateReplicas checks the replica count covers a list of distinct resource nodes
+func validateReplicas(replicas uint64, resourceNodes []sdk.AccAddress) error {
+	if replicas == 0 || uint64(len(resourceNodes)) > replicas {
+		return ErrInvalidReplicas
+	}
+	seen := make(map[string]bool)
+	for _, node := range resourceNodes {
+		if node.Empty() {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing address of resource node")
+		}
+		if seen[node.String()] {
+			return ErrDuplicateResourceNode
+		}
+		seen[node.String()] = true
+	}
 	return nil
 }
 
 type MsgPrepay struct {
-	Sender sdk.AccAddress `json:"sender" yaml:"sender"` // sender of tx
-	Coins  sdk.Coins      `json:"coins" yaml:"coins"`   // coins to send
+	Sender      sdk.AccAddress `json:"sender" yaml:"sender"`               // sender of tx
+	Coins       sdk.Coins      `json:"coins" yaml:"coins"`                 // coins to send
+	MinOzoneOut sdk.Int        `json:"min_ozone_out" yaml:"min_ozone_out"` // least uoz the prepay has to purchase, optional
 }
 
 // verify interface at compile time
 var _ sdk.Msg = &MsgPrepay{}
 
 // NewMsg<Action> creates a new Msg<Action> instance
-func NewMsgPrepay(sender sdk.AccAddress, coins sdk.Coins) MsgPrepay {
+func NewMsgPrepay(sender sdk.AccAddress, coins sdk.Coins, minOzoneOut sdk.Int) MsgPrepay {
 	return MsgPrepay{
-		Sender: sender,
-		Coins:  coins,
+		Sender:      sender,
+		Coins:       coins,
+		MinOzoneOut: minOzoneOut,
 	}
 }
 
@@ -92,5 +140,231 @@ func (msg MsgPrepay) ValidateBasic() error {
 	if msg.Coins.Empty() {
 		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, "missing coins to send")
 	}
+	// the keeper checks the denoms against the prepay denoms of the params
+	if !msg.Coins.IsValid() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidCoins, msg.Coins.String())
+	}
+	if !msg.MinOzoneOut.IsNil() && msg.MinOzoneOut.IsNegative() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "min ozone out must not be negative")
+	}
+	return nil
+}
+
+type MsgRedeemOzone struct {
+	Sender sdk.AccAddress `json:"sender" yaml:"sender"` // owner of the redeemed uoz
+	Amount sdk.Int        `json:"amount" yaml:"amount"` // uoz to redeem
+}
+
+// verify interface at compile time
+var _ sdk.Msg = &MsgRedeemOzone{}
+
+// NewMsgRedeemOzone creates a new MsgRedeemOzone instance
+func NewMsgRedeemOzone(sender sdk.AccAddress, amount sdk.Int) MsgRedeemOzone {
+	return MsgRedeemOzone{
+		Sender: sender,
+		Amount: amount,
+	}
+}
+
+// nolint
+func (msg MsgRedeemOzone) Route() string { return RouterKey }
+func (msg MsgRedeemOzone) Type() string  { return ConstSdsRedeemOzone }
+func (msg MsgRedeemOzone) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgRedeemOzone) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic validity check for the AnteHandler
+func (msg MsgRedeemOzone) ValidateBasic() error {
+	if msg.Sender.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing sender address")
+	}
+	if msg.Amount.IsNil() || !msg.Amount.IsPositive() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "uoz to redeem should be positive")
+	}
+	return nil
+}
+
+type MsgFileDelete struct {
+	FileHash []byte         `json:"file_hash" yaml:"file_hash"` // hash of file
+	Sender   sdk.AccAddress `json:"sender" yaml:"sender"`       // reporter or uploader of the file
+}
+
+// verify interface at compile time
+var _ sdk.Msg = &MsgFileDelete{}
+
+// NewMsgFileDelete creates a new MsgFileDelete instance
+func NewMsgFileDelete(fileHash []byte, sender sdk.AccAddress) MsgFileDelete {
+	return MsgFileDelete{
+		FileHash: fileHash,
+		Sender:   sender,
+	}
+}
+
+// nolint
+func (msg MsgFileDelete) Route() string { return RouterKey }
+func (msg MsgFileDelete) Type() string  { return ConstFileDelete }
+func (msg MsgFileDelete) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgFileDelete) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic validity check for the AnteHandler
+func (msg MsgFileDelete) ValidateBasic() error {
+	if msg.Sender.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing sender address")
+	}
+	if len(msg.FileHash) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "missing file hash")
+	}
+	return nil
+}
+
+type MsgFileUpdateReplicas struct {
+	FileHash      []byte           `json:"file_hash" yaml:"file_hash"`           // hash of file
+	Sender        sdk.AccAddress   `json:"sender" yaml:"sender"`                 // reporter or uploader of the file
+	Replicas      uint64           `json:"replicas" yaml:"replicas"`             // number of replicas to keep
+	ResourceNodes []sdk.AccAddress `json:"resource_nodes" yaml:"resource_nodes"` // resource nodes holding a replica
+}
+
+// verify interface at compile time
+var _ sdk.Msg = &MsgFileUpdateReplicas{}
+
+// NewMsgFileUpdateReplicas creates a new MsgFileUpdateReplicas instance
+func NewMsgFileUpdateReplicas(fileHash []byte, sender sdk.AccAddress, replicas uint64, resourceNodes []sdk.AccAddress) MsgFileUpdateReplicas {
+	return MsgFileUpdateReplicas{
+		FileHash:      fileHash,
+		Sender:        sender,
+		Replicas:      replicas,
+		ResourceNodes: resourceNodes,
+	}
+}
+
+// nolint
+func (msg MsgFileUpdateReplicas) Route() string { return RouterKey }
+func (msg MsgFileUpdateReplicas) Type() string  { return ConstFileUpdateReplicas }
+func (msg MsgFileUpdateReplicas) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgFileUpdateReplicas) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic validity check for the AnteHandler
+func (msg MsgFileUpdateReplicas) ValidateBasic() error {
+	if msg.Sender.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing sender address")
+	}
+	if len(msg.FileHash) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "missing file hash")
+	}
+	return validateReplicas(msg.Replicas, msg.ResourceNodes)
+}
+
+type MsgStorageChallenge struct {
+	FileHash     []byte         `json:"file_hash" yaml:"file_hash"`         // hash of the challenged file
+	ResourceNode sdk.AccAddress `json:"resource_node" yaml:"resource_node"` // resource node that has to prove it holds the file
+	Challenger   sdk.AccAddress `json:"challenger" yaml:"challenger"`       // sp node who issues the challenge
+}
+
+// verify interface at compile time
+var _ sdk.Msg = &MsgStorageChallenge{}
+
+// NewMsgStorageChallenge creates a new MsgStorageChallenge instance
+func NewMsgStorageChallenge(fileHash []byte, resourceNode, challenger sdk.AccAddress) MsgStorageChallenge {
+	return MsgStorageChallenge{
+		FileHash:     fileHash,
+		ResourceNode: resourceNode,
+		Challenger:   challenger,
+	}
+}
+
+// nolint
+func (msg MsgStorageChallenge) Route() string { return RouterKey }
+func (msg MsgStorageChallenge) Type() string  { return ConstStorageChallenge }
+func (msg MsgStorageChallenge) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Challenger}
+}
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgStorageChallenge) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic validity check for the AnteHandler
+func (msg MsgStorageChallenge) ValidateBasic() error {
+	if msg.Challenger.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing address of challenger")
+	}
+	if msg.ResourceNode.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing address of resource node")
+	}
+	if len(msg.FileHash) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "missing file hash")
+	}
+	return nil
+}
+
+type MsgStorageProof struct {
+	FileHash     []byte         `json:"file_hash" yaml:"file_hash"`         // hash of the challenged file
+	ResourceNode sdk.AccAddress `json:"resource_node" yaml:"resource_node"` // challenged resource node
+	Chunk        []byte         `json:"chunk" yaml:"chunk"`                 // content of the challenged chunk
+	Aunts        [][]byte       `json:"aunts" yaml:"aunts"`                 // merkle path from the sibling of the chunk up to a child of the root
+}
+
+// verify interface at compile time
+var _ sdk.Msg = &MsgStorageProof{}
+
+// NewMsgStorageProof creates a new MsgStorageProof instance
+func NewMsgStorageProof(fileHash []byte, resourceNode sdk.AccAddress, chunk []byte, aunts [][]byte) MsgStorageProof {
+	return MsgStorageProof{
+		FileHash:     fileHash,
+		ResourceNode: resourceNode,
+		Chunk:        chunk,
+		Aunts:        aunts,
+	}
+}
+
+// nolint
+func (msg MsgStorageProof) Route() string { return RouterKey }
+func (msg MsgStorageProof) Type() string  { return ConstStorageProof }
+func (msg MsgStorageProof) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.ResourceNode}
+}
+
+// GetSignBytes gets the bytes for the message signer to sign on
+func (msg MsgStorageProof) GetSignBytes() []byte {
+	bz := ModuleCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic validity check for the AnteHandler
+func (msg MsgStorageProof) ValidateBasic() error {
+	if msg.ResourceNode.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing address of resource node")
+	}
+	if len(msg.FileHash) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "missing file hash")
+	}
+	if len(msg.Chunk) == 0 {
+		return sdkerrors.Wrap(ErrInvalidStorageProof, "missing chunk")
+	}
+	if len(msg.Aunts) > merkle.MaxAunts {
+		return sdkerrors.Wrap(ErrInvalidStorageProof, "too many aunts")
+	}
 	return nil
 }
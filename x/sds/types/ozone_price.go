@@ -0,0 +1,29 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// OzonePrice is the uoz price recorded at the end of a block, it holds until the next recorded block
+type OzonePrice struct {
+	Height int64   `json:"height" yaml:"height"` // block height the price was recorded at
+	Price  sdk.Dec `json:"price" yaml:"price"`   // uoz purchased per ustos, Lt / (S + Pt)
+}
+
+// NewOzonePrice creates a new OzonePrice instance
+func NewOzonePrice(height int64, price sdk.Dec) OzonePrice {
+	return OzonePrice{
+		Height: height,
+		Price:  price,
+	}
+}
+
+// String returns a human readable string representation of an ozone price.
+func (op OzonePrice) String() string {
+	return fmt.Sprintf(`OzonePrice:{
+		Height:			%d
+		Price:			%s
+	}`, op.Height, op.Price)
+}
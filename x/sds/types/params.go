@@ -1,22 +1,41 @@
 package types
 
 import (
+	"errors"
 	"fmt"
-	"github.com/cosmos/cosmos-sdk/x/params/subspace"
+	"time"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/params/subspace"
 )
 
 // Default parameter namespace
 const (
 	DefaultParamSpace = ModuleName
-	// TODO: Define your default parameters
+	// DefaultPrepayDenom is the bond denom of the chain
+	DefaultPrepayDenom = "ustos"
+	// DefaultRedeemCooldown keeps a sender from redeeming ozone for a day after its last prepay
+	DefaultRedeemCooldown = 24 * time.Hour
+	// DefaultPriceHistoryBlocks keeps about 30 days of uoz prices with 6 second blocks
+	DefaultPriceHistoryBlocks int64 = 432000
+	// DefaultStorageChallengeWindow gives a resource node 100 blocks to answer a storage challenge
+	DefaultStorageChallengeWindow int64 = 100
+	// DefaultStorageChallengeQuorum suspends a resource node once it failed the storage challenges of 2 SP nodes
+	DefaultStorageChallengeQuorum int64 = 2
 )
 
 // Parameter store keys
 var (
-// TODO: Define your keys for the parameter store
-// KeyParamName          = []byte("ParamName")
+	KeyRedeemCooldown         = []byte("RedeemCooldown")
+	KeyRedeemFeeRate          = []byte("RedeemFeeRate")
+	KeyPrepayDenoms           = []byte("PrepayDenoms")
+	KeyPriceHistoryBlocks     = []byte("PriceHistoryBlocks")
+	KeyStorageChallengeWindow = []byte("StorageChallengeWindow")
+	KeyStorageChallengeQuorum = []byte("StorageChallengeQuorum")
+
+	// DefaultRedeemFeeRate keeps 1% of the redeemed ustos in the unissued prepay pool
+	DefaultRedeemFeeRate = sdk.NewDecWithPrec(1, 2)
 )
 
 var _ subspace.ParamSet = &Params{}
@@ -28,33 +47,177 @@ func ParamKeyTable() params.KeyTable {
 
 // Params - used for initializing default parameter for sds at genesis
 type Params struct {
-	// TODO: Add your Paramaters to the Paramter struct
-	// KeyParamName string `json:"key_param_name"`
+	RedeemCooldown         time.Duration `json:"redeem_cooldown" yaml:"redeem_cooldown"`                   // time after the last prepay of a sender before it can redeem ozone, 0 to disable
+	RedeemFeeRate          sdk.Dec       `json:"redeem_fee_rate" yaml:"redeem_fee_rate"`                   // share of the redeemed ustos kept in the unissued prepay pool
+	PrepayDenoms           []string      `json:"prepay_denoms" yaml:"prepay_denoms"`                       // denoms accepted by prepay, must include the bond denom, the only one uoz are priced in
+	PriceHistoryBlocks     int64         `json:"price_history_blocks" yaml:"price_history_blocks"`         // number of blocks the uoz price history is kept for, 0 to keep it all
+	StorageChallengeWindow int64         `json:"storage_challenge_window" yaml:"storage_challenge_window"` // number of blocks a resource node has to answer a storage challenge
+	StorageChallengeQuorum int64         `json:"storage_challenge_quorum" yaml:"storage_challenge_quorum"` // number of SP nodes whose storage challenges a resource node has to fail before it is suspended
 }
 
 // NewParams creates a new Params object
-func NewParams( /* TODO: Pass in the paramters*/ ) Params {
+func NewParams(redeemCooldown time.Duration, redeemFeeRate sdk.Dec, prepayDenoms []string, priceHistoryBlocks int64,
+	storageChallengeWindow, storageChallengeQuorum int64) Params {
 	return Params{
-		// TODO: Create your Params Type
+		RedeemCooldown:         redeemCooldown,
+		RedeemFeeRate:          redeemFeeRate,
+		PrepayDenoms:           prepayDenoms,
+		PriceHistoryBlocks:     priceHistoryBlocks,
+		StorageChallengeWindow: storageChallengeWindow,
+		StorageChallengeQuorum: storageChallengeQuorum,
 	}
 }
 
 // String implements the stringer interface for Params
 func (p Params) String() string {
-	return fmt.Sprintf(`
-	// TODO: Return all the params as a string
-	`)
+	return fmt.Sprintf(`Params:
+	RedeemCooldown:		%s
+	RedeemFeeRate:		%s
+	PrepayDenoms:		%v
+	PriceHistoryBlocks:	%d
+	StorageChallengeWindow:	%d
+	StorageChallengeQuorum:	%d`,
+		p.RedeemCooldown, p.RedeemFeeRate, p.PrepayDenoms, p.PriceHistoryBlocks, p.StorageChallengeWindow,
+		p.StorageChallengeQuorum)
 }
 
 // ParamSetPairs - Implements params.ParamSet
 func (p *Params) ParamSetPairs() params.ParamSetPairs {
 	return params.ParamSetPairs{
-		// TODO: Pair your key with the param
-		// params.NewParamSetPair(KeyParamName, &p.ParamName),
+		params.NewParamSetPair(KeyRedeemCooldown, &p.RedeemCooldown, validateRedeemCooldown),
+		params.NewParamSetPair(KeyRedeemFeeRate, &p.RedeemFeeRate, validateRedeemFeeRate),
+		params.NewParamSetPair(KeyPrepayDenoms, &p.PrepayDenoms, validatePrepayDenoms),
+		params.NewParamSetPair(KeyPriceHistoryBlocks, &p.PriceHistoryBlocks, validatePriceHistoryBlocks),
+		params.NewParamSetPair(KeyStorageChallengeWindow, &p.StorageChallengeWindow, validateStorageChallengeWindow),
+		params.NewParamSetPair(KeyStorageChallengeQuorum, &p.StorageChallengeQuorum, validateStorageChallengeQuorum),
 	}
 }
 
 // DefaultParams defines the parameters for this module
 func DefaultParams() Params {
-	return NewParams( /* TODO: Pass in your default Params */ )
+	return NewParams(DefaultRedeemCooldown, DefaultRedeemFeeRate, []string{DefaultPrepayDenom}, DefaultPriceHistoryBlocks,
+		DefaultStorageChallengeWindow, DefaultStorageChallengeQuorum)
+}
+
+// IsPrepayDenom returns whether the denom is one of the prepay denoms
+func IsPrepayDenom(prepayDenoms []string, denom string) bool {
+	for _, prepayDenom := range prepayDenoms {
+		if prepayDenom == denom {
+			return true
+		}
+	}
+	return false
+}
+
+func validateRedeemCooldown(i interface{}) error {
+	v, ok := i.(time.Duration)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v < 0 {
+		return fmt.Errorf("redeem cooldown must not be negative: %s", v)
+	}
+
+	return nil
+}
+
+func validateRedeemFeeRate(i interface{}) error {
+	v, ok := i.(sdk.Dec)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v.IsNil() || v.IsNegative() {
+		return fmt.Errorf("redeem fee rate must not be negative: %s", v)
+	}
+	if v.GTE(sdk.OneDec()) {
+		return fmt.Errorf("redeem fee rate must be less than 1: %s", v)
+	}
+
+	return nil
+}
+
+func validatePrepayDenoms(i interface{}) error {
+	v, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if len(v) == 0 {
+		return errors.New("prepay denoms cannot be empty")
+	}
+	seen := make(map[string]bool)
+	for _, denom := range v {
+		if err := sdk.ValidateDenom(denom); err != nil {
+			return err
+		}
+		if seen[denom] {
+			return fmt.Errorf("duplicate prepay denom: %s", denom)
+		}
+		seen[denom] = true
+	}
+
+	return nil
+}
+
+func validatePriceHistoryBlocks(i interface{}) error {
+	v, ok := i.(int64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v < 0 {
+		return fmt.Errorf("price history blocks must not be negative: %d", v)
+	}
+
+	return nil
+}
+
+func validateStorageChallengeWindow(i interface{}) error {
+	v, ok := i.(int64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v <= 0 {
+		return fmt.Errorf("storage challenge window must be positive: %d", v)
+	}
+
+	return nil
+}
+
+func validateStorageChallengeQuorum(i interface{}) error {
+	v, ok := i.(int64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if v <= 0 {
+		return fmt.Errorf("storage challenge quorum must be positive: %d", v)
+	}
+
+	return nil
+}
+
+func (p Params) ValidateBasic() error {
+	if err := validateRedeemCooldown(p.RedeemCooldown); err != nil {
+		return err
+	}
+	if err := validateRedeemFeeRate(p.RedeemFeeRate); err != nil {
+		return err
+	}
+	if err := validatePrepayDenoms(p.PrepayDenoms); err != nil {
+		return err
+	}
+	if err := validatePriceHistoryBlocks(p.PriceHistoryBlocks); err != nil {
+		return err
+	}
+	if err := validateStorageChallengeWindow(p.StorageChallengeWindow); err != nil {
+		return err
+	}
+	if err := validateStorageChallengeQuorum(p.StorageChallengeQuorum); err != nil {
+		return err
+	}
+	return nil
 }
@@ -4,12 +4,19 @@ import "github.com/cosmos/cosmos-sdk/types"
 
 // querier keys
 const (
-	QueryParams         = "params"
-	QueryUploadedFile   = "uploaded_file"
-	QueryPrepay         = "prepay"
-	QuerySimulatePrepay = "simulate_prepay"
-	QueryCurrUozPrice   = "curr_uoz_price"
-	QueryUozSupply      = "uoz_supply"
+	QueryParams              = "params"
+	QueryUploadedFile        = "uploaded_file"
+	QueryPrepay              = "prepay"
+	QuerySimulatePrepay      = "simulate_prepay"
+	QuerySimulateRedeemOzone = "simulate_redeem_ozone"
+	QueryCurrUozPrice        = "curr_uoz_price"
+	QueryUozPriceHistory     = "uoz_price_history"
+	QueryUozPriceTwap        = "uoz_price_twap"
+	QueryUozSupply           = "uoz_supply"
+	QueryOzoneBalance        = "ozone_balance"
+	QueryFilesByUploader     = "files_by_uploader"
+	QueryFilesByResourceNode = "files_by_resource_node"
+	QueryStorageChallenges   = "storage_challenges"
 )
 
 // params for query 'custom/distr/validator_outstanding_rewards'
@@ -23,3 +30,52 @@ func NewQueryUploadedFileParams(sender types.AccAddress) QueryUploadedFileParams
 		Sender: sender,
 	}
 }
+
+// QueryFilesParams defines the params for the paginated file list queries by uploader or by resource node
+type QueryFilesParams struct {
+	Page    int
+	Limit   int
+	Address types.AccAddress
+}
+
+// NewQueryFilesParams creates a new instance of QueryFilesParams
+func NewQueryFilesParams(page, limit int, address types.AccAddress) QueryFilesParams {
+	return QueryFilesParams{
+		Page:    page,
+		Limit:   limit,
+		Address: address,
+	}
+}
+
+// QueryUozPriceHistoryParams defines the params for the paginated uoz price history query over a height range,
+// a non positive start or end height leaves that end of the range open
+type QueryUozPriceHistoryParams struct {
+	Page        int
+	Limit       int
+	StartHeight int64
+	EndHeight   int64
+}
+
+// NewQueryUozPriceHistoryParams creates a new instance of QueryUozPriceHistoryParams
+func NewQueryUozPriceHistoryParams(page, limit int, startHeight, endHeight int64) QueryUozPriceHistoryParams {
+	return QueryUozPriceHistoryParams{
+		Page:        page,
+		Limit:       limit,
+		StartHeight: startHeight,
+		EndHeight:   endHeight,
+	}
+}
+
+// QueryUozPriceTwapParams defines the params for the time weighted average uoz price query over a height range
+type QueryUozPriceTwapParams struct {
+	StartHeight int64
+	EndHeight   int64
+}
+
+// NewQueryUozPriceTwapParams creates a new instance of QueryUozPriceTwapParams
+func NewQueryUozPriceTwapParams(startHeight, endHeight int64) QueryUozPriceTwapParams {
+	return QueryUozPriceTwapParams{
+		StartHeight: startHeight,
+		EndHeight:   endHeight,
+	}
+}
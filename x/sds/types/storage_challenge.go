@@ -0,0 +1,71 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// StorageChallenge asks a resource node to prove it holds a chunk of a file before the deadline
+type StorageChallenge struct {
+	FileHash     []byte         `json:"file_hash" yaml:"file_hash"`         // hash of the challenged file
+	ResourceNode sdk.AccAddress `json:"resource_node" yaml:"resource_node"` // resource node that has to answer
+	Challenger   sdk.AccAddress `json:"challenger" yaml:"challenger"`       // sp node who issued the challenge
+	ChunkIndex   uint64         `json:"chunk_index" yaml:"chunk_index"`     // index of the chunk to prove, drawn from the block hash
+	Height       int64          `json:"height" yaml:"height"`               // block height the challenge was issued at
+	Deadline     int64          `json:"deadline" yaml:"deadline"`           // last block height a proof is accepted at
+}
+
+// NewStorageChallenge creates a new StorageChallenge instance
+func NewStorageChallenge(fileHash []byte, resourceNode, challenger sdk.AccAddress, chunkIndex uint64, height, deadline int64,
+) StorageChallenge {
+	return StorageChallenge{
+		FileHash:     fileHash,
+		ResourceNode: resourceNode,
+		Challenger:   challenger,
+		ChunkIndex:   chunkIndex,
+		Height:       height,
+		Deadline:     deadline,
+	}
+}
+
+// StorageChallengeFailure records that a resource node failed the storage challenge of an SP node
+type StorageChallengeFailure struct {
+	ResourceNode sdk.AccAddress `json:"resource_node" yaml:"resource_node"` // resource node that failed the challenge
+	Challenger   sdk.AccAddress `json:"challenger" yaml:"challenger"`       // sp node who issued the challenge
+	FileHash     []byte         `json:"file_hash" yaml:"file_hash"`         // hash of the challenged file
+}
+
+// NewStorageChallengeFailure creates a new StorageChallengeFailure instance
+func NewStorageChallengeFailure(resourceNode, challenger sdk.AccAddress, fileHash []byte) StorageChallengeFailure {
+	return StorageChallengeFailure{
+		ResourceNode: resourceNode,
+		Challenger:   challenger,
+		FileHash:     fileHash,
+	}
+}
+
+// MustMarshalStorageChallenge returns the challenge's bytes. Panics if fails
+func MustMarshalStorageChallenge(cdc *codec.Codec, challenge StorageChallenge) []byte {
+	return cdc.MustMarshalBinaryLengthPrefixed(challenge)
+}
+
+// MustUnmarshalStorageChallenge unmarshal a challenge from a store value. Panics if fails
+func MustUnmarshalStorageChallenge(cdc *codec.Codec, value []byte) StorageChallenge {
+	var challenge StorageChallenge
+	cdc.MustUnmarshalBinaryLengthPrefixed(value, &challenge)
+	return challenge
+}
+
+// String returns a human readable string representation of a storage challenge.
+func (sc StorageChallenge) String() string {
+	return fmt.Sprintf(`StorageChallenge:{
+		FileHash:			%X
+  		ResourceNode:		%s
+  		Challenger:			%s
+  		ChunkIndex:			%d
+  		Height:				%d
+  		Deadline:			%d
+	}`, sc.FileHash, sc.ResourceNode.String(), sc.Challenger.String(), sc.ChunkIndex, sc.Height, sc.Deadline)
+}
@@ -4,23 +4,85 @@ import (
 	"fmt"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
 type FileInfo struct {
-	Height   sdk.Int
-	Reporter sdk.AccAddress
-	Uploader sdk.AccAddress
+	Height        sdk.Int          `json:"height" yaml:"height"`                 // block height of the upload
+	Reporter      sdk.AccAddress   `json:"reporter" yaml:"reporter"`             // sp node who reported the upload
+	Uploader      sdk.AccAddress   `json:"uploader" yaml:"uploader"`             // who uploaded the file
+	FileSize      uint64           `json:"file_size" yaml:"file_size"`           // size of the file in bytes
+	Replicas      uint64           `json:"replicas" yaml:"replicas"`             // number of replicas to keep
+	ResourceNodes []sdk.AccAddress `json:"resource_nodes" yaml:"resource_nodes"` // resource nodes holding a replica
+	ExpireHeight  int64            `json:"expire_height" yaml:"expire_height"`   // height the file is removed at, 0 if it never expires
+	MerkleRoot    []byte           `json:"merkle_root" yaml:"merkle_root"`       // root of the merkle tree built over the file chunks, empty if unknown
+	ChunkCount    uint64           `json:"chunk_count" yaml:"chunk_count"`       // number of leaves of the merkle tree
 }
 
 // constructor
-func NewFileInfo(height sdk.Int, reporter, uploader sdk.AccAddress) FileInfo {
+func NewFileInfo(height sdk.Int, reporter, uploader sdk.AccAddress, fileSize, replicas uint64,
+	resourceNodes []sdk.AccAddress, expireHeight int64, merkleRoot []byte, chunkCount uint64) FileInfo {
 	return FileInfo{
-		Height:   height,
-		Reporter: reporter,
-		Uploader: uploader,
+		Height:        height,
+		Reporter:      reporter,
+		Uploader:      uploader,
+		FileSize:      fileSize,
+		Replicas:      replicas,
+		ResourceNodes: resourceNodes,
+		ExpireHeight:  expireHeight,
+		MerkleRoot:    merkleRoot,
+		ChunkCount:    chunkCount,
 	}
 }
 
+// IsManagedBy returns true if the address is allowed to delete the file or update its replicas
+func (fi FileInfo) IsManagedBy(addr sdk.AccAddress) bool {
+	return fi.Reporter.Equals(addr) || fi.Uploader.Equals(addr)
+}
+
+// HasExpiry returns true if the file is removed once the chain reaches its expire height
+func (fi FileInfo) HasExpiry() bool {
+	return fi.ExpireHeight > 0
+}
+
+// IsChallengeable returns true if the chunks of the file can be challenged against its merkle root
+func (fi FileInfo) IsChallengeable() bool {
+	return len(fi.MerkleRoot) > 0 && fi.ChunkCount > 0
+}
+
+// IsHeldBy returns true if the resource node holds a replica of the file
+func (fi FileInfo) IsHeldBy(nodeAddr sdk.AccAddress) bool {
+	for _, node := range fi.ResourceNodes {
+		if node.Equals(nodeAddr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the file info is consistent on its own, as done for a file upload
+func (fi FileInfo) Validate() error {
+	if fi.Height.IsNil() || fi.Height.IsNegative() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "invalid upload height")
+	}
+	if fi.Reporter.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing address of file reporter")
+	}
+	if fi.Uploader.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing address of file uploader")
+	}
+	if fi.FileSize == 0 {
+		return ErrInvalidFileSize
+	}
+	if fi.ExpireHeight < 0 {
+		return ErrInvalidExpireHeight
+	}
+	if (len(fi.MerkleRoot) == 0) != (fi.ChunkCount == 0) {
+		return ErrInvalidMerkleRoot
+	}
+	return validateReplicas(fi.Replicas, fi.ResourceNodes)
+}
+
 // MustMarshalFileInfo returns the fileInfo's bytes. Panics if fails
 func MustMarshalFileInfo(cdc *codec.Codec, file FileInfo) []byte {
 	return cdc.MustMarshalBinaryLengthPrefixed(file)
@@ -47,5 +109,26 @@ func (fi FileInfo) String() string {
 		Height:				%s
   		Reporter:			%s
   		Uploader:			%s
-	}`, fi.Height.String(), fi.Reporter.String(), fi.Uploader.String())
+  		FileSize:			%d
+  		Replicas:			%d
+  		ResourceNodes:		%s
+  		ExpireHeight:		%d
+  		MerkleRoot:			%X
+  		ChunkCount:			%d
+	}`, fi.Height.String(), fi.Reporter.String(), fi.Uploader.String(), fi.FileSize, fi.Replicas, fi.ResourceNodes, fi.ExpireHeight,
+		fi.MerkleRoot, fi.ChunkCount)
+}
+
+// FileRecord is a file info along with the hash of the file, as returned by the file list queries
+type FileRecord struct {
+	FileHash string   `json:"file_hash" yaml:"file_hash"` // hex encoded hash of the file
+	FileInfo FileInfo `json:"file_info" yaml:"file_info"`
+}
+
+// NewFileRecord creates a new FileRecord instance
+func NewFileRecord(fileHash string, fileInfo FileInfo) FileRecord {
+	return FileRecord{
+		FileHash: fileHash,
+		FileInfo: fileInfo,
+	}
 }